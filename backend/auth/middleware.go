@@ -57,3 +57,37 @@ func OptionalAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// StreamAuthMiddleware requires a valid auth token for long-lived streaming endpoints.
+// Browsers cannot set headers on EventSource connections, so the token may also be
+// passed as the access_token query parameter.
+func StreamAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+		if tokenString == "" {
+			tokenString = c.QueryParam("access_token")
+		}
+
+		if tokenString == "" {
+			return apperror.AuthorizationError("Missing authorization token", nil).
+				WithDetails(&apperror.AuthorizationErrorDetails{
+					Reason: "missing_auth_token",
+					Field:  "access_token",
+				})
+		}
+
+		claims, err := ValidateToken(tokenString)
+		if err != nil {
+			return apperror.AuthorizationError("Invalid or expired token", err).
+				WithDetails(&apperror.AuthorizationErrorDetails{
+					Reason: "token_validation_failed",
+					Field:  "access_token",
+				})
+		}
+
+		// Set authenticated user information in context
+		c.Set("user_id", claims.UserID)
+		c.Set("has_email", claims.HasEmail)
+		return next(c)
+	}
+}
@@ -0,0 +1,494 @@
+// Command catalog brings the creatures table in line with data/creatures.txt, the
+// difficulties in data/creature_difficulties.csv, the bestiary data in
+// data/creature_bestiary.csv and the translated names in data/translations/<lang>.csv.
+// Run it from the backend directory:
+//
+//	go run ./cmd/catalog                              report what differs
+//	go run ./cmd/catalog -migration db/migrations     write the changes as a goose migration
+//	go run ./cmd/catalog -apply -dry-run              apply the changes and roll them back
+//	go run ./cmd/catalog -apply                       apply the changes
+//
+// Creatures missing from the file are matched to new names by similarity and renamed, so
+// their soulcores are kept. Check the renames in the report before applying them.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// foreignKeyViolationCode is returned when a deleted creature is still referenced
+const foreignKeyViolationCode = "23503"
+
+// errDryRun rolls back the transaction of a dry run
+var errDryRun = errors.New("dry run")
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	creaturesPath := flag.String("creatures", "../data/creatures.txt", "file with one creature name per line")
+	difficultiesPath := flag.String("difficulties", "../data/creature_difficulties.csv", "CSV file with name,difficulty rows, empty to leave difficulties alone")
+	bestiaryPath := flag.String("bestiary", "../data/creature_bestiary.csv", "CSV file with a name column and any of class,race,charm_points,locations,sprite, empty to leave bestiary data alone")
+	translationsPath := flag.String("translations", "../data/translations", "directory with a <lang>.csv file of name,translation rows per language, empty to leave translations alone")
+	threshold := flag.Float64("threshold", services.DefaultRenameThreshold, "name similarity from 0 to 1 from which a missing creature counts as renamed")
+	migrationDir := flag.String("migration", "", "write the changes as a goose migration into this directory")
+	apply := flag.Bool("apply", false, "apply the changes to the database")
+	dryRun := flag.Bool("dry-run", false, "with -apply, roll the changes back instead of committing them")
+	flag.Parse()
+
+	if *apply && *migrationDir != "" {
+		logger.Error("-apply and -migration cannot be combined")
+		os.Exit(2)
+	}
+	if *dryRun && !*apply {
+		logger.Error("-dry-run only applies to -apply")
+		os.Exit(2)
+	}
+	if *threshold <= 0 || *threshold > 1 {
+		logger.Error("-threshold must be above 0 and at most 1", "value", *threshold)
+		os.Exit(2)
+	}
+
+	catalog, err := readCatalog(*creaturesPath, *difficultiesPath, *bestiaryPath)
+	if err != nil {
+		logger.Error("Error reading the catalog", "error", err)
+		os.Exit(1)
+	}
+
+	translations, err := readTranslations(*translationsPath, catalog)
+	if err != nil {
+		logger.Error("Error reading the translations", "error", err)
+		os.Exit(1)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		logger.Warn("Warning: .env file not found", "error", err)
+	}
+
+	dbUrl := os.Getenv("DB_URL")
+	if dbUrl == "" {
+		logger.Error("DB_URL environment variable is required")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	connPool, err := pgxpool.New(ctx, dbUrl)
+	if err != nil {
+		logger.Error("Error connecting to the database", "error", err)
+		os.Exit(1)
+	}
+	defer connPool.Close()
+
+	store := db.NewStore(connPool)
+
+	diff, err := diffCatalog(ctx, store, catalog, translations, *threshold)
+	if err != nil {
+		logger.Error("Error comparing the catalog with the database", "error", err)
+		os.Exit(1)
+	}
+
+	printReport(diff)
+	if diff.Empty() {
+		return
+	}
+
+	switch {
+	case *migrationDir != "":
+		name := time.Now().UTC().Format("20060102150405") + "_sync_creatures.sql"
+		path := filepath.Join(*migrationDir, name)
+		if err := os.WriteFile(path, []byte(services.CatalogMigration(diff)), 0o644); err != nil {
+			logger.Error("Error writing the migration", "error", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\nWrote %s\n", path)
+
+	case *apply:
+		err := applyDiff(ctx, store, diff, *dryRun)
+		if err != nil && !errors.Is(err, errDryRun) {
+			logger.Error("Error applying the changes, nothing was changed", "error", err)
+			os.Exit(1)
+		}
+		if *dryRun {
+			fmt.Println("\nDry run: every change applied cleanly and was rolled back")
+		} else {
+			fmt.Println("\nApplied")
+		}
+	}
+}
+
+// readCatalog combines the creature names with their difficulties and bestiary data
+func readCatalog(creaturesPath, difficultiesPath, bestiaryPath string) ([]services.CatalogCreature, error) {
+	f, err := os.Open(creaturesPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	names, err := services.ParseCatalogNames(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", creaturesPath, err)
+	}
+
+	difficulties := map[string]int32{}
+	if difficultiesPath != "" {
+		f, err := os.Open(difficultiesPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		difficulties, err = services.ParseCatalogDifficulties(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", difficultiesPath, err)
+		}
+	}
+
+	bestiary := map[string]services.CatalogBestiary{}
+	if bestiaryPath != "" {
+		f, err := os.Open(bestiaryPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		bestiary, err = services.ParseCatalogBestiary(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", bestiaryPath, err)
+		}
+	}
+
+	catalog := make([]services.CatalogCreature, len(names))
+	for i, name := range names {
+		catalog[i] = services.CatalogCreature{Name: name}
+		if d, ok := difficulties[name]; ok {
+			catalog[i].Difficulty = &d
+		}
+		if b, ok := bestiary[name]; ok {
+			catalog[i].Bestiary = &b
+		}
+		delete(difficulties, name)
+		delete(bestiary, name)
+	}
+
+	// Data for a name missing from the catalog is most likely a typo
+	if err := unknownNames(difficulties, difficultiesPath, creaturesPath); err != nil {
+		return nil, err
+	}
+	if err := unknownNames(bestiary, bestiaryPath, creaturesPath); err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
+
+// unknownNames fails for the names left over in data after taking out the catalog's
+func unknownNames[T any](data map[string]T, path, creaturesPath string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var unknown []string
+	for name := range data {
+		unknown = append(unknown, name)
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("%s: data for creatures not in %s: %s", path, creaturesPath, strings.Join(unknown, ", "))
+}
+
+// readTranslations reads the translation file of every language in dir, keyed by language.
+// A missing directory means there are no translations.
+func readTranslations(dir string, catalog []services.CatalogCreature) (map[string]map[string]string, error) {
+	translations := make(map[string]map[string]string)
+	if dir == "" {
+		return translations, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return translations, nil
+		}
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(catalog))
+	for _, c := range catalog {
+		names[c.Name] = true
+	}
+
+	for _, entry := range entries {
+		lang, ok := strings.CutSuffix(entry.Name(), ".csv")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		// English names are the creature names themselves
+		if lang == services.DefaultLanguage || !services.IsSupportedLanguage(lang) {
+			return nil, fmt.Errorf("%s: language must be one of %s other than %s", path, strings.Join(services.SupportedLanguages, ", "), services.DefaultLanguage)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := services.ParseCatalogTranslations(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		unknown := make(map[string]bool)
+		for name := range parsed {
+			if !names[name] {
+				unknown[name] = true
+			}
+		}
+		if err := unknownNames(unknown, path, "the catalog"); err != nil {
+			return nil, err
+		}
+
+		translations[lang] = parsed
+	}
+	return translations, nil
+}
+
+func diffCatalog(ctx context.Context, store db.Store, catalog []services.CatalogCreature, translations map[string]map[string]string, threshold float64) (services.CatalogDiff, error) {
+	current, err := store.GetCreatures(ctx, db.GetCreaturesParams{})
+	if err != nil {
+		return services.CatalogDiff{}, err
+	}
+	creatures := make([]db.Creature, len(current))
+	for i, r := range current {
+		creatures[i] = db.Creature{
+			ID:            r.ID,
+			Name:          r.Name,
+			Difficulty:    r.Difficulty,
+			BestiaryClass: r.BestiaryClass,
+			Race:          r.Race,
+			CharmPoints:   r.CharmPoints,
+			Locations:     r.Locations,
+			Sprite:        r.Sprite,
+		}
+	}
+
+	rows, err := store.GetAllCreatureAliases(ctx)
+	if err != nil {
+		return services.CatalogDiff{}, err
+	}
+	aliases := make(map[string]string, len(rows))
+	for _, r := range rows {
+		aliases[strings.ToLower(r.Name)] = r.CreatureName
+	}
+
+	diff := services.DiffCatalog(catalog, creatures, aliases, threshold)
+
+	existing, err := store.GetAllCreatureTranslations(ctx)
+	if err != nil {
+		return services.CatalogDiff{}, err
+	}
+	diff.Translations = services.DiffTranslations(translations, existing, diff)
+
+	return diff, nil
+}
+
+// applyDiff makes the changes in a single transaction, in the same order as the
+// generated migration
+func applyDiff(ctx context.Context, store db.Store, diff services.CatalogDiff, dryRun bool) error {
+	return store.ExecTx(ctx, func(q db.Querier) error {
+		for _, r := range diff.Renamed {
+			err := q.RenameCreature(ctx, db.RenameCreatureParams{OldName: r.From, NewName: r.To})
+			if err != nil {
+				return fmt.Errorf("renaming %q to %q: %w", r.From, r.To, err)
+			}
+		}
+
+		for _, c := range diff.Added {
+			err := q.CreateCreature(ctx, db.CreateCreatureParams{Name: c.Name, Difficulty: int4(c.Difficulty)})
+			if err != nil {
+				return fmt.Errorf("adding %q: %w", c.Name, err)
+			}
+		}
+
+		for _, c := range diff.Removed {
+			if _, err := q.DeleteCreature(ctx, c.Name); err != nil {
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+					return fmt.Errorf("%q still has soulcores, merge it into its replacement with merge_creature instead", c.Name)
+				}
+				return fmt.Errorf("removing %q: %w", c.Name, err)
+			}
+		}
+
+		for _, c := range diff.Difficulties {
+			err := q.UpdateCreatureDifficulty(ctx, db.UpdateCreatureDifficultyParams{Name: c.Name, Difficulty: int4(c.To)})
+			if err != nil {
+				return fmt.Errorf("updating the difficulty of %q: %w", c.Name, err)
+			}
+		}
+
+		for _, c := range diff.Bestiary {
+			locations := c.To.Locations
+			if locations == nil {
+				locations = []string{}
+			}
+			err := q.UpdateCreatureBestiary(ctx, db.UpdateCreatureBestiaryParams{
+				Name:          c.Name,
+				BestiaryClass: text(c.To.Class),
+				Race:          text(c.To.Race),
+				CharmPoints:   int4(c.To.CharmPoints),
+				Locations:     locations,
+				Sprite:        text(c.To.Sprite),
+			})
+			if err != nil {
+				return fmt.Errorf("updating the bestiary entry of %q: %w", c.Name, err)
+			}
+		}
+
+		for _, t := range diff.Translations {
+			var err error
+			if t.To == nil {
+				err = q.DeleteCreatureTranslation(ctx, db.DeleteCreatureTranslationParams{Language: t.Language, CreatureName: t.Name})
+			} else {
+				err = q.UpsertCreatureTranslation(ctx, db.UpsertCreatureTranslationParams{Language: t.Language, Name: *t.To, CreatureName: t.Name})
+			}
+			if err != nil {
+				return fmt.Errorf("updating the %s translation of %q: %w", t.Language, t.Name, err)
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+}
+
+func int4(v *int32) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *v, Valid: true}
+}
+
+func text(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *s, Valid: true}
+}
+
+func printReport(diff services.CatalogDiff) {
+	if diff.Empty() {
+		fmt.Println("The creatures table matches the catalog")
+		return
+	}
+
+	if len(diff.Renamed) > 0 {
+		fmt.Printf("Renamed (%d):\n", len(diff.Renamed))
+		for _, r := range diff.Renamed {
+			match := fmt.Sprintf("%.0f%% similar", r.Similarity*100)
+			if r.ByAlias {
+				match = "former name"
+			}
+			fmt.Printf("  %s -> %s (%s)\n", r.From, r.To, match)
+		}
+	}
+
+	if len(diff.Added) > 0 {
+		fmt.Printf("Added (%d):\n", len(diff.Added))
+		for _, c := range diff.Added {
+			fmt.Printf("  %s (difficulty %s)\n", c.Name, formatDifficulty(c.Difficulty))
+		}
+	}
+
+	if len(diff.Removed) > 0 {
+		fmt.Printf("Removed (%d):\n", len(diff.Removed))
+		for _, c := range diff.Removed {
+			fmt.Printf("  %s\n", c.Name)
+		}
+	}
+
+	if len(diff.Difficulties) > 0 {
+		fmt.Printf("Difficulty changed (%d):\n", len(diff.Difficulties))
+		for _, c := range diff.Difficulties {
+			fmt.Printf("  %s: %s -> %s\n", c.Name, formatDifficulty(c.From), formatDifficulty(c.To))
+		}
+	}
+
+	if len(diff.Bestiary) > 0 {
+		fmt.Printf("Bestiary changed (%d):\n", len(diff.Bestiary))
+		for _, c := range diff.Bestiary {
+			fmt.Printf("  %s\n", c.Name)
+			for _, field := range bestiaryFields(c.From, c.To) {
+				fmt.Printf("    %s\n", field)
+			}
+		}
+	}
+
+	if len(diff.Translations) > 0 {
+		fmt.Printf("Translations changed (%d):\n", len(diff.Translations))
+		for _, t := range diff.Translations {
+			fmt.Printf("  [%s] %s: %s -> %s\n", t.Language, t.Name, formatTranslation(t.From), formatTranslation(t.To))
+		}
+	}
+}
+
+// bestiaryFields describes the fields that differ between two bestiary entries
+func bestiaryFields(from, to services.CatalogBestiary) []string {
+	var fields []string
+	add := func(name, from, to string) {
+		if from != to {
+			fields = append(fields, fmt.Sprintf("%s: %s -> %s", name, from, to))
+		}
+	}
+	add("class", formatText(from.Class), formatText(to.Class))
+	add("race", formatText(from.Race), formatText(to.Race))
+	add("charm points", formatDifficulty(from.CharmPoints), formatDifficulty(to.CharmPoints))
+	add("locations", formatLocations(from.Locations), formatLocations(to.Locations))
+	add("sprite", formatText(from.Sprite), formatText(to.Sprite))
+	return fields
+}
+
+func formatText(s *string) string {
+	if s == nil {
+		return "unknown"
+	}
+	return *s
+}
+
+func formatTranslation(s *string) string {
+	if s == nil {
+		return "none"
+	}
+	return *s
+}
+
+func formatLocations(locations []string) string {
+	if len(locations) == 0 {
+		return "none"
+	}
+	return strings.Join(locations, "; ")
+}
+
+func formatDifficulty(d *int32) string {
+	if d == nil {
+		return "unknown"
+	}
+	return fmt.Sprint(*d)
+}
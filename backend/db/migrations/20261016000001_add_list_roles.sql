@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE list_role AS ENUM ('owner', 'moderator', 'member', 'viewer');
+
+ALTER TABLE lists_users ADD COLUMN role list_role NOT NULL DEFAULT 'member';
+
+-- The list author becomes the owner of every character they have in the list
+UPDATE lists_users lu
+SET role = 'owner'
+FROM lists l
+WHERE lu.list_id = l.id AND lu.user_id = l.author_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE lists_users DROP COLUMN IF EXISTS role;
+
+DROP TYPE IF EXISTS list_role;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS list_removed_members (
+    list_id UUID NOT NULL REFERENCES lists(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    removed_by UUID NOT NULL REFERENCES users(id),
+    removed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS list_removed_members;
+-- +goose StatementEnd
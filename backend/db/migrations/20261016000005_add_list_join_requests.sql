@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE lists ADD COLUMN approval_required BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS list_join_requests (
+    list_id UUID NOT NULL REFERENCES lists(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    character_id UUID NOT NULL REFERENCES characters(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS list_join_requests;
+ALTER TABLE lists DROP COLUMN IF EXISTS approval_required;
+-- +goose StatementEnd
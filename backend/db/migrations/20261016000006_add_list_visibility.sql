@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE list_visibility AS ENUM ('private', 'unlisted', 'public');
+
+ALTER TABLE lists ADD COLUMN visibility list_visibility NOT NULL DEFAULT 'private';
+
+CREATE INDEX IF NOT EXISTS idx_lists_public_world ON lists(world) WHERE visibility = 'public' AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_lists_public_world;
+
+ALTER TABLE lists DROP COLUMN IF EXISTS visibility;
+
+DROP TYPE IF EXISTS list_visibility;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE list_activity_action AS ENUM (
+    'soulcore_added',
+    'soulcore_removed',
+    'soulcore_status_changed',
+    'member_joined',
+    'member_left',
+    'member_removed',
+    'chat_message_deleted'
+);
+
+CREATE TABLE IF NOT EXISTS list_activity (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    list_id UUID NOT NULL REFERENCES lists(id),
+    actor_id UUID NOT NULL REFERENCES users(id),
+    character_id UUID REFERENCES characters(id),
+    action list_activity_action NOT NULL,
+    creature_id UUID REFERENCES creatures(id),
+    target_user_id UUID REFERENCES users(id),
+    old_value TEXT,
+    new_value TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_list_activity_list_created ON list_activity(list_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS list_activity;
+
+DROP TYPE IF EXISTS list_activity_action;
+-- +goose StatementEnd
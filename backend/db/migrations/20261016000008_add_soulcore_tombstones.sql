@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE lists_soulcores ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE characters_soulcores ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_lists_soulcores_deleted_at ON lists_soulcores(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_characters_soulcores_deleted_at ON characters_soulcores(deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TYPE list_activity_action ADD VALUE 'soulcore_restored' AFTER 'soulcore_removed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Enum values cannot be dropped, so the type is rebuilt without the new value
+DELETE FROM list_activity WHERE action = 'soulcore_restored';
+ALTER TYPE list_activity_action RENAME TO list_activity_action_old;
+CREATE TYPE list_activity_action AS ENUM (
+    'soulcore_added',
+    'soulcore_removed',
+    'soulcore_status_changed',
+    'member_joined',
+    'member_left',
+    'member_removed',
+    'chat_message_deleted'
+);
+ALTER TABLE list_activity ALTER COLUMN action TYPE list_activity_action USING action::text::list_activity_action;
+DROP TYPE list_activity_action_old;
+
+-- Removed soulcores are gone for good once the columns are dropped
+DELETE FROM lists_soulcores WHERE deleted_at IS NOT NULL;
+DELETE FROM characters_soulcores WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_characters_soulcores_deleted_at;
+DROP INDEX IF EXISTS idx_lists_soulcores_deleted_at;
+
+ALTER TABLE characters_soulcores DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE lists_soulcores DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE soulcore_status ADD VALUE 'wanted' BEFORE 'obtained';
+ALTER TYPE soulcore_status ADD VALUE 'reserved' BEFORE 'obtained';
+ALTER TYPE soulcore_status ADD VALUE 'traded' AFTER 'obtained';
+
+-- The member a reserved core is earmarked for, NULL for every other status
+ALTER TABLE lists_soulcores ADD COLUMN reserved_for_user_id UUID REFERENCES users(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE lists_soulcores DROP COLUMN IF EXISTS reserved_for_user_id;
+
+-- Enum values cannot be dropped, so the type is rebuilt. Cores that were only wanted are
+-- removed, reserved and traded ones fall back to obtained.
+DELETE FROM lists_soulcores WHERE status = 'wanted';
+ALTER TYPE soulcore_status RENAME TO soulcore_status_old;
+CREATE TYPE soulcore_status AS ENUM ('obtained', 'unlocked');
+ALTER TABLE lists_soulcores ALTER COLUMN status TYPE soulcore_status
+    USING (CASE WHEN status::text IN ('reserved', 'traded') THEN 'obtained' ELSE status::text END)::soulcore_status;
+DROP TYPE soulcore_status_old;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE distribution_mode AS ENUM ('need', 'round_robin', 'fewest_cores', 'random');
+
+-- Every computed distribution is kept, so anyone can check who was picked and replay it from the seed
+CREATE TABLE IF NOT EXISTS list_distributions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    list_id UUID NOT NULL REFERENCES lists(id),
+    created_by UUID NOT NULL REFERENCES users(id),
+    mode distribution_mode NOT NULL,
+    seed BIGINT NOT NULL,
+    assignments JSONB NOT NULL,
+    reserved BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_list_distributions_list_created ON list_distributions(list_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS list_distributions;
+DROP TYPE IF EXISTS distribution_mode;
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A list without a scope row tracks every creature
+CREATE TABLE IF NOT EXISTS list_scopes (
+    list_id UUID PRIMARY KEY REFERENCES lists(id),
+    min_difficulty INTEGER,
+    max_difficulty INTEGER,
+    target_date DATE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT list_scopes_difficulty_range CHECK (min_difficulty IS NULL OR max_difficulty IS NULL OR min_difficulty <= max_difficulty)
+);
+
+-- Included creatures narrow the scope down to themselves, excluded ones are always left out
+CREATE TABLE IF NOT EXISTS list_scope_creatures (
+    list_id UUID NOT NULL REFERENCES lists(id),
+    creature_id UUID NOT NULL REFERENCES creatures(id),
+    excluded BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (list_id, creature_id)
+);
+
+-- Creatures of unknown difficulty fall outside any difficulty range
+CREATE OR REPLACE FUNCTION creature_in_list_scope(p_list_id UUID, p_creature_id UUID) RETURNS BOOLEAN AS $$
+    SELECT
+        NOT EXISTS (
+            SELECT 1 FROM list_scope_creatures sc
+            WHERE sc.list_id = p_list_id AND sc.creature_id = p_creature_id AND sc.excluded
+        )
+        AND (
+            NOT EXISTS (
+                SELECT 1 FROM list_scope_creatures sc
+                WHERE sc.list_id = p_list_id AND NOT sc.excluded
+            )
+            OR EXISTS (
+                SELECT 1 FROM list_scope_creatures sc
+                WHERE sc.list_id = p_list_id AND sc.creature_id = p_creature_id AND NOT sc.excluded
+            )
+        )
+        AND COALESCE((
+            SELECT (s.min_difficulty IS NULL OR COALESCE(cr.difficulty >= s.min_difficulty, false))
+                AND (s.max_difficulty IS NULL OR COALESCE(cr.difficulty <= s.max_difficulty, false))
+            FROM list_scopes s
+            JOIN creatures cr ON cr.id = p_creature_id
+            WHERE s.list_id = p_list_id
+        ), true)
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS creature_in_list_scope(UUID, UUID);
+DROP TABLE IF EXISTS list_scope_creatures;
+DROP TABLE IF EXISTS list_scopes;
+-- +goose StatementEnd
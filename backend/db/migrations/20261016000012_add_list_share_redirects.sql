@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Share codes of lists that were merged into another list keep working and lead to that list.
+-- The merged list is purged eventually, so source_list_id is not a foreign key.
+CREATE TABLE IF NOT EXISTS list_share_redirects (
+    share_code UUID PRIMARY KEY,
+    source_list_id UUID NOT NULL,
+    list_id UUID NOT NULL REFERENCES lists(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_list_share_redirects_list_id ON list_share_redirects(list_id);
+CREATE INDEX IF NOT EXISTS idx_list_share_redirects_source_list_id ON list_share_redirects(source_list_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_list_share_redirects_source_list_id;
+DROP INDEX IF EXISTS idx_list_share_redirects_list_id;
+DROP TABLE IF EXISTS list_share_redirects;
+-- +goose StatementEnd
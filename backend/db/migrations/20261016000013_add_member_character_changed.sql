@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE list_activity_action ADD VALUE 'member_character_changed' AFTER 'member_removed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Enum values cannot be dropped, so the type is rebuilt without the new value
+DELETE FROM list_activity WHERE action = 'member_character_changed';
+ALTER TYPE list_activity_action RENAME TO list_activity_action_old;
+CREATE TYPE list_activity_action AS ENUM (
+    'soulcore_added',
+    'soulcore_removed',
+    'soulcore_restored',
+    'soulcore_status_changed',
+    'member_joined',
+    'member_left',
+    'member_removed',
+    'chat_message_deleted'
+);
+ALTER TABLE list_activity ALTER COLUMN action TYPE list_activity_action USING action::text::list_activity_action;
+DROP TYPE list_activity_action_old;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Memberships a claimed character had in lists, offered to its new owner. The previous
+-- owner's row in lists_users stays inactive as history.
+CREATE TABLE IF NOT EXISTS list_reactivations (
+    list_id UUID NOT NULL REFERENCES lists(id),
+    character_id UUID NOT NULL REFERENCES characters(id),
+    previous_user_id UUID NOT NULL REFERENCES users(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, character_id)
+);
+
+CREATE INDEX idx_list_reactivations_user_id ON list_reactivations(user_id);
+CREATE INDEX idx_list_reactivations_character_id ON list_reactivations(character_id);
+
+ALTER TYPE list_activity_action ADD VALUE 'character_claimed' AFTER 'member_character_changed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Enum values cannot be dropped, so the type is rebuilt without the new value
+DELETE FROM list_activity WHERE action = 'character_claimed';
+ALTER TYPE list_activity_action RENAME TO list_activity_action_old;
+CREATE TYPE list_activity_action AS ENUM (
+    'soulcore_added',
+    'soulcore_removed',
+    'soulcore_restored',
+    'soulcore_status_changed',
+    'member_joined',
+    'member_left',
+    'member_removed',
+    'member_character_changed',
+    'chat_message_deleted'
+);
+ALTER TABLE list_activity ALTER COLUMN action TYPE list_activity_action USING action::text::list_activity_action;
+DROP TYPE list_activity_action_old;
+
+DROP TABLE IF EXISTS list_reactivations;
+-- +goose StatementEnd
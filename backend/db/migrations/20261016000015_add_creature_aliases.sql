@@ -0,0 +1,117 @@
+-- +goose Up
+-- +goose StatementBegin
+-- historical: a former name of the creature, merged: the name of a creature merged into it,
+-- community: a name players use for it
+CREATE TYPE creature_alias_kind AS ENUM ('historical', 'merged', 'community');
+
+-- Lookups by name fall back to aliases, so renamed creatures are still found by their old name
+CREATE TABLE IF NOT EXISTS creature_aliases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    creature_id UUID NOT NULL REFERENCES creatures(id),
+    name TEXT NOT NULL,
+    kind creature_alias_kind NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_creature_aliases_lower_name ON creature_aliases(lower(name));
+CREATE INDEX IF NOT EXISTS idx_creature_aliases_creature_id ON creature_aliases(creature_id);
+
+-- Renames a creature and keeps its old name as an alias. Migrations renaming creatures
+-- call this instead of updating creatures directly.
+CREATE OR REPLACE FUNCTION rename_creature(p_old_name TEXT, p_new_name TEXT) RETURNS VOID AS $$
+DECLARE
+    v_id UUID;
+BEGIN
+    SELECT id INTO v_id FROM creatures WHERE name = p_old_name;
+    IF v_id IS NULL THEN
+        RAISE EXCEPTION 'creature % does not exist', p_old_name;
+    END IF;
+
+    -- Renaming back to a former name
+    DELETE FROM creature_aliases WHERE creature_id = v_id AND lower(name) = lower(p_new_name);
+
+    UPDATE creatures SET name = p_new_name WHERE id = v_id;
+
+    INSERT INTO creature_aliases (creature_id, name, kind)
+    VALUES (v_id, p_old_name, 'historical');
+END;
+$$ LANGUAGE plpgsql;
+
+-- Folds a duplicate creature into another one. Soul cores, unlocks, suggestions, scopes and
+-- activity move over; where both creatures have a row, the target's row is kept. The source's
+-- name and aliases stay as aliases of the target.
+CREATE OR REPLACE FUNCTION merge_creature(p_source_name TEXT, p_target_name TEXT) RETURNS VOID AS $$
+DECLARE
+    v_source UUID;
+    v_target UUID;
+BEGIN
+    SELECT id INTO v_source FROM creatures WHERE name = p_source_name;
+    IF v_source IS NULL THEN
+        RAISE EXCEPTION 'creature % does not exist', p_source_name;
+    END IF;
+
+    SELECT id INTO v_target FROM creatures WHERE name = p_target_name;
+    IF v_target IS NULL THEN
+        RAISE EXCEPTION 'creature % does not exist', p_target_name;
+    END IF;
+
+    UPDATE lists_soulcores s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM lists_soulcores t
+            WHERE t.list_id = s.list_id AND t.creature_id = v_target
+        );
+    DELETE FROM lists_soulcores WHERE creature_id = v_source;
+
+    UPDATE characters_soulcores s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM characters_soulcores t
+            WHERE t.character_id = s.character_id AND t.creature_id = v_target
+        );
+    DELETE FROM characters_soulcores WHERE creature_id = v_source;
+
+    UPDATE character_soulcore_suggestions s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM character_soulcore_suggestions t
+            WHERE t.character_id = s.character_id AND t.creature_id = v_target
+        );
+    DELETE FROM character_soulcore_suggestions WHERE creature_id = v_source;
+
+    UPDATE list_scope_creatures s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM list_scope_creatures t
+            WHERE t.list_id = s.list_id AND t.creature_id = v_target
+        );
+    DELETE FROM list_scope_creatures WHERE creature_id = v_source;
+
+    UPDATE list_activity SET creature_id = v_target WHERE creature_id = v_source;
+
+    UPDATE creature_aliases SET creature_id = v_target WHERE creature_id = v_source;
+
+    DELETE FROM creatures WHERE id = v_source;
+
+    INSERT INTO creature_aliases (creature_id, name, kind)
+    VALUES (v_target, p_source_name, 'merged');
+END;
+$$ LANGUAGE plpgsql;
+
+-- Names changed by earlier hand-written migrations
+INSERT INTO creature_aliases (creature_id, name, kind)
+SELECT id, 'Horse (Dark Brown)', 'historical' FROM creatures WHERE name = 'Horse (Taupe)'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO creature_aliases (creature_id, name, kind)
+SELECT id, 'Monk', 'historical' FROM creatures WHERE name = 'Monk (Creature)'
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS merge_creature(TEXT, TEXT);
+DROP FUNCTION IF EXISTS rename_creature(TEXT, TEXT);
+DROP TABLE IF EXISTS creature_aliases;
+DROP TYPE IF EXISTS creature_alias_kind;
+-- +goose StatementEnd
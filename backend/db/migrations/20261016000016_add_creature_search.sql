@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Trigram indexes serve both substring (LIKE) and typo-tolerant (%) matching of names
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_creatures_name_trgm ON creatures USING GIN (lower(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_creature_aliases_name_trgm ON creature_aliases USING GIN (lower(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_creatures_difficulty ON creatures(difficulty);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_creatures_difficulty;
+DROP INDEX IF EXISTS idx_creature_aliases_name_trgm;
+DROP INDEX IF EXISTS idx_creatures_name_trgm;
+DROP EXTENSION IF EXISTS pg_trgm;
+-- +goose StatementEnd
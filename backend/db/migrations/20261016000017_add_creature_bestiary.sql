@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Bestiary data is loaded by cmd/catalog from data/creature_bestiary.csv and stays NULL
+-- until known. sprite is the path of the creature's image in the frontend.
+ALTER TABLE creatures ADD COLUMN bestiary_class TEXT;
+ALTER TABLE creatures ADD COLUMN race TEXT;
+ALTER TABLE creatures ADD COLUMN charm_points INTEGER;
+ALTER TABLE creatures ADD COLUMN locations TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE creatures ADD COLUMN sprite TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_creatures_bestiary_class ON creatures(lower(bestiary_class));
+CREATE INDEX IF NOT EXISTS idx_creatures_race ON creatures(lower(race));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_creatures_race;
+DROP INDEX IF EXISTS idx_creatures_bestiary_class;
+ALTER TABLE creatures DROP COLUMN sprite;
+ALTER TABLE creatures DROP COLUMN locations;
+ALTER TABLE creatures DROP COLUMN charm_points;
+ALTER TABLE creatures DROP COLUMN race;
+ALTER TABLE creatures DROP COLUMN bestiary_class;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Creature names in other languages, loaded by cmd/catalog from data/translations. Creatures
+-- without a translation are shown with their English name.
+CREATE TABLE IF NOT EXISTS creature_translations (
+    creature_id UUID NOT NULL REFERENCES creatures(id) ON DELETE CASCADE,
+    language TEXT NOT NULL CHECK (language ~ '^[a-z]{2}$'),
+    name TEXT NOT NULL,
+    PRIMARY KEY (creature_id, language)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS creature_translations;
+-- +goose StatementEnd
@@ -0,0 +1,156 @@
+-- +goose Up
+-- +goose StatementBegin
+-- How far along the lifecycle a soulcore is. Removed soulcores come before every live one.
+CREATE OR REPLACE FUNCTION soulcore_progress(p_status soulcore_status, p_deleted_at TIMESTAMP WITH TIME ZONE) RETURNS INTEGER AS $$
+    SELECT CASE
+        WHEN p_deleted_at IS NOT NULL THEN 0
+        WHEN p_status = 'wanted' THEN 1
+        WHEN p_status = 'obtained' THEN 2
+        WHEN p_status = 'reserved' THEN 3
+        WHEN p_status = 'traded' THEN 4
+        ELSE 5
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Folds a duplicate creature into another one. Soul cores, unlocks, suggestions, scopes and
+-- activity move over. Where both creatures have a soulcore in the same list, the one further
+-- along the lifecycle is kept along with its adder and reservation; where a character has
+-- unlocked both, a live unlock wins over a removed one. Otherwise the target's row is kept.
+-- The source's name and aliases stay as aliases of the target.
+CREATE OR REPLACE FUNCTION merge_creature(p_source_name TEXT, p_target_name TEXT) RETURNS VOID AS $$
+DECLARE
+    v_source UUID;
+    v_target UUID;
+BEGIN
+    SELECT id INTO v_source FROM creatures WHERE name = p_source_name;
+    IF v_source IS NULL THEN
+        RAISE EXCEPTION 'creature % does not exist', p_source_name;
+    END IF;
+
+    SELECT id INTO v_target FROM creatures WHERE name = p_target_name;
+    IF v_target IS NULL THEN
+        RAISE EXCEPTION 'creature % does not exist', p_target_name;
+    END IF;
+
+    UPDATE lists_soulcores t
+    SET status = s.status,
+        added_by_user_id = s.added_by_user_id,
+        reserved_for_user_id = s.reserved_for_user_id,
+        deleted_at = s.deleted_at
+    FROM lists_soulcores s
+    WHERE s.creature_id = v_source AND t.creature_id = v_target AND t.list_id = s.list_id
+        AND soulcore_progress(s.status, s.deleted_at) > soulcore_progress(t.status, t.deleted_at);
+
+    UPDATE lists_soulcores s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM lists_soulcores t
+            WHERE t.list_id = s.list_id AND t.creature_id = v_target
+        );
+    DELETE FROM lists_soulcores WHERE creature_id = v_source;
+
+    UPDATE characters_soulcores t
+    SET created_at = s.created_at,
+        deleted_at = NULL
+    FROM characters_soulcores s
+    WHERE s.creature_id = v_source AND t.creature_id = v_target AND t.character_id = s.character_id
+        AND s.deleted_at IS NULL AND t.deleted_at IS NOT NULL;
+
+    UPDATE characters_soulcores s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM characters_soulcores t
+            WHERE t.character_id = s.character_id AND t.creature_id = v_target
+        );
+    DELETE FROM characters_soulcores WHERE creature_id = v_source;
+
+    UPDATE character_soulcore_suggestions s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM character_soulcore_suggestions t
+            WHERE t.character_id = s.character_id AND t.creature_id = v_target
+        );
+    DELETE FROM character_soulcore_suggestions WHERE creature_id = v_source;
+
+    UPDATE list_scope_creatures s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM list_scope_creatures t
+            WHERE t.list_id = s.list_id AND t.creature_id = v_target
+        );
+    DELETE FROM list_scope_creatures WHERE creature_id = v_source;
+
+    UPDATE list_activity SET creature_id = v_target WHERE creature_id = v_source;
+
+    UPDATE creature_aliases SET creature_id = v_target WHERE creature_id = v_source;
+
+    DELETE FROM creatures WHERE id = v_source;
+
+    INSERT INTO creature_aliases (creature_id, name, kind)
+    VALUES (v_target, p_source_name, 'merged');
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION merge_creature(p_source_name TEXT, p_target_name TEXT) RETURNS VOID AS $$
+DECLARE
+    v_source UUID;
+    v_target UUID;
+BEGIN
+    SELECT id INTO v_source FROM creatures WHERE name = p_source_name;
+    IF v_source IS NULL THEN
+        RAISE EXCEPTION 'creature % does not exist', p_source_name;
+    END IF;
+
+    SELECT id INTO v_target FROM creatures WHERE name = p_target_name;
+    IF v_target IS NULL THEN
+        RAISE EXCEPTION 'creature % does not exist', p_target_name;
+    END IF;
+
+    UPDATE lists_soulcores s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM lists_soulcores t
+            WHERE t.list_id = s.list_id AND t.creature_id = v_target
+        );
+    DELETE FROM lists_soulcores WHERE creature_id = v_source;
+
+    UPDATE characters_soulcores s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM characters_soulcores t
+            WHERE t.character_id = s.character_id AND t.creature_id = v_target
+        );
+    DELETE FROM characters_soulcores WHERE creature_id = v_source;
+
+    UPDATE character_soulcore_suggestions s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM character_soulcore_suggestions t
+            WHERE t.character_id = s.character_id AND t.creature_id = v_target
+        );
+    DELETE FROM character_soulcore_suggestions WHERE creature_id = v_source;
+
+    UPDATE list_scope_creatures s SET creature_id = v_target
+    WHERE s.creature_id = v_source
+        AND NOT EXISTS (
+            SELECT 1 FROM list_scope_creatures t
+            WHERE t.list_id = s.list_id AND t.creature_id = v_target
+        );
+    DELETE FROM list_scope_creatures WHERE creature_id = v_source;
+
+    UPDATE list_activity SET creature_id = v_target WHERE creature_id = v_source;
+
+    UPDATE creature_aliases SET creature_id = v_target WHERE creature_id = v_source;
+
+    DELETE FROM creatures WHERE id = v_source;
+
+    INSERT INTO creature_aliases (creature_id, name, kind)
+    VALUES (v_target, p_source_name, 'merged');
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS soulcore_progress(soulcore_status, TIMESTAMP WITH TIME ZONE);
+-- +goose StatementEnd
@@ -43,12 +43,28 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcceptListReactivation mocks base method.
+func (m *MockStore) AcceptListReactivation(ctx context.Context, arg db.AcceptListReactivationParams) (db.ListRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptListReactivation", ctx, arg)
+	ret0, _ := ret[0].(db.ListRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptListReactivation indicates an expected call of AcceptListReactivation.
+func (mr *MockStoreMockRecorder) AcceptListReactivation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptListReactivation", reflect.TypeOf((*MockStore)(nil).AcceptListReactivation), ctx, arg)
+}
+
 // AddCharacterSoulcore mocks base method.
-func (m *MockStore) AddCharacterSoulcore(ctx context.Context, arg db.AddCharacterSoulcoreParams) error {
+func (m *MockStore) AddCharacterSoulcore(ctx context.Context, arg db.AddCharacterSoulcoreParams) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddCharacterSoulcore", ctx, arg)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddCharacterSoulcore indicates an expected call of AddCharacterSoulcore.
@@ -71,6 +87,20 @@ func (mr *MockStoreMockRecorder) AddListCharacter(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddListCharacter", reflect.TypeOf((*MockStore)(nil).AddListCharacter), ctx, arg)
 }
 
+// AddListScopeCreatures mocks base method.
+func (m *MockStore) AddListScopeCreatures(ctx context.Context, arg db.AddListScopeCreaturesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddListScopeCreatures", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddListScopeCreatures indicates an expected call of AddListScopeCreatures.
+func (mr *MockStoreMockRecorder) AddListScopeCreatures(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddListScopeCreatures", reflect.TypeOf((*MockStore)(nil).AddListScopeCreatures), ctx, arg)
+}
+
 // AddSoulcoreToList mocks base method.
 func (m *MockStore) AddSoulcoreToList(ctx context.Context, arg db.AddSoulcoreToListParams) error {
 	m.ctrl.T.Helper()
@@ -85,6 +115,96 @@ func (mr *MockStoreMockRecorder) AddSoulcoreToList(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSoulcoreToList", reflect.TypeOf((*MockStore)(nil).AddSoulcoreToList), ctx, arg)
 }
 
+// AddSoulcoreToListIfMissing mocks base method.
+func (m *MockStore) AddSoulcoreToListIfMissing(ctx context.Context, arg db.AddSoulcoreToListIfMissingParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSoulcoreToListIfMissing", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSoulcoreToListIfMissing indicates an expected call of AddSoulcoreToListIfMissing.
+func (mr *MockStoreMockRecorder) AddSoulcoreToListIfMissing(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSoulcoreToListIfMissing", reflect.TypeOf((*MockStore)(nil).AddSoulcoreToListIfMissing), ctx, arg)
+}
+
+// ApproveListJoinRequest mocks base method.
+func (m *MockStore) ApproveListJoinRequest(ctx context.Context, arg db.ApproveListJoinRequestParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveListJoinRequest", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveListJoinRequest indicates an expected call of ApproveListJoinRequest.
+func (mr *MockStoreMockRecorder) ApproveListJoinRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveListJoinRequest", reflect.TypeOf((*MockStore)(nil).ApproveListJoinRequest), ctx, arg)
+}
+
+// CloneListMembers mocks base method.
+func (m *MockStore) CloneListMembers(ctx context.Context, arg db.CloneListMembersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneListMembers", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneListMembers indicates an expected call of CloneListMembers.
+func (mr *MockStoreMockRecorder) CloneListMembers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneListMembers", reflect.TypeOf((*MockStore)(nil).CloneListMembers), ctx, arg)
+}
+
+// CloneListSoulcores mocks base method.
+func (m *MockStore) CloneListSoulcores(ctx context.Context, arg db.CloneListSoulcoresParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneListSoulcores", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneListSoulcores indicates an expected call of CloneListSoulcores.
+func (mr *MockStoreMockRecorder) CloneListSoulcores(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneListSoulcores", reflect.TypeOf((*MockStore)(nil).CloneListSoulcores), ctx, arg)
+}
+
+// CountCreatures mocks base method.
+func (m *MockStore) CountCreatures(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCreatures", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCreatures indicates an expected call of CountCreatures.
+func (mr *MockStoreMockRecorder) CountCreatures(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCreatures", reflect.TypeOf((*MockStore)(nil).CountCreatures), ctx)
+}
+
+// CountListMembersOutsideWorld mocks base method.
+func (m *MockStore) CountListMembersOutsideWorld(ctx context.Context, arg db.CountListMembersOutsideWorldParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountListMembersOutsideWorld", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountListMembersOutsideWorld indicates an expected call of CountListMembersOutsideWorld.
+func (mr *MockStoreMockRecorder) CountListMembersOutsideWorld(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountListMembersOutsideWorld", reflect.TypeOf((*MockStore)(nil).CountListMembersOutsideWorld), ctx, arg)
+}
+
 // CreateAnonymousUser mocks base method.
 func (m *MockStore) CreateAnonymousUser(ctx context.Context, id uuid.UUID) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +265,20 @@ func (mr *MockStoreMockRecorder) CreateChatMessage(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatMessage", reflect.TypeOf((*MockStore)(nil).CreateChatMessage), ctx, arg)
 }
 
+// CreateCreature mocks base method.
+func (m *MockStore) CreateCreature(ctx context.Context, arg db.CreateCreatureParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCreature", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCreature indicates an expected call of CreateCreature.
+func (mr *MockStoreMockRecorder) CreateCreature(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCreature", reflect.TypeOf((*MockStore)(nil).CreateCreature), ctx, arg)
+}
+
 // CreateList mocks base method.
 func (m *MockStore) CreateList(ctx context.Context, arg db.CreateListParams) (db.List, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +294,107 @@ func (mr *MockStoreMockRecorder) CreateList(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateList", reflect.TypeOf((*MockStore)(nil).CreateList), ctx, arg)
 }
 
+// CreateListActivity mocks base method.
+func (m *MockStore) CreateListActivity(ctx context.Context, arg db.CreateListActivityParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListActivity", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateListActivity indicates an expected call of CreateListActivity.
+func (mr *MockStoreMockRecorder) CreateListActivity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListActivity", reflect.TypeOf((*MockStore)(nil).CreateListActivity), ctx, arg)
+}
+
+// CreateListDistribution mocks base method.
+func (m *MockStore) CreateListDistribution(ctx context.Context, arg db.CreateListDistributionParams) (db.ListDistribution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListDistribution", ctx, arg)
+	ret0, _ := ret[0].(db.ListDistribution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateListDistribution indicates an expected call of CreateListDistribution.
+func (mr *MockStoreMockRecorder) CreateListDistribution(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListDistribution", reflect.TypeOf((*MockStore)(nil).CreateListDistribution), ctx, arg)
+}
+
+// CreateListInvite mocks base method.
+func (m *MockStore) CreateListInvite(ctx context.Context, arg db.CreateListInviteParams) (db.ListInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListInvite", ctx, arg)
+	ret0, _ := ret[0].(db.ListInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateListInvite indicates an expected call of CreateListInvite.
+func (mr *MockStoreMockRecorder) CreateListInvite(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListInvite", reflect.TypeOf((*MockStore)(nil).CreateListInvite), ctx, arg)
+}
+
+// CreateListJoinRequest mocks base method.
+func (m *MockStore) CreateListJoinRequest(ctx context.Context, arg db.CreateListJoinRequestParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListJoinRequest", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateListJoinRequest indicates an expected call of CreateListJoinRequest.
+func (mr *MockStoreMockRecorder) CreateListJoinRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListJoinRequest", reflect.TypeOf((*MockStore)(nil).CreateListJoinRequest), ctx, arg)
+}
+
+// CreateListReactivations mocks base method.
+func (m *MockStore) CreateListReactivations(ctx context.Context, arg db.CreateListReactivationsParams) ([]db.CreateListReactivationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListReactivations", ctx, arg)
+	ret0, _ := ret[0].([]db.CreateListReactivationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateListReactivations indicates an expected call of CreateListReactivations.
+func (mr *MockStoreMockRecorder) CreateListReactivations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListReactivations", reflect.TypeOf((*MockStore)(nil).CreateListReactivations), ctx, arg)
+}
+
+// CreateListRemoval mocks base method.
+func (m *MockStore) CreateListRemoval(ctx context.Context, arg db.CreateListRemovalParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListRemoval", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateListRemoval indicates an expected call of CreateListRemoval.
+func (mr *MockStoreMockRecorder) CreateListRemoval(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListRemoval", reflect.TypeOf((*MockStore)(nil).CreateListRemoval), ctx, arg)
+}
+
+// CreateListShareRedirect mocks base method.
+func (m *MockStore) CreateListShareRedirect(ctx context.Context, arg db.CreateListShareRedirectParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListShareRedirect", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateListShareRedirect indicates an expected call of CreateListShareRedirect.
+func (mr *MockStoreMockRecorder) CreateListShareRedirect(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListShareRedirect", reflect.TypeOf((*MockStore)(nil).CreateListShareRedirect), ctx, arg)
+}
+
 // CreateSoulcoreSuggestion mocks base method.
 func (m *MockStore) CreateSoulcoreSuggestion(ctx context.Context, arg db.CreateSoulcoreSuggestionParams) error {
 	m.ctrl.T.Helper()
@@ -231,6 +466,94 @@ func (mr *MockStoreMockRecorder) DeleteChatMessage(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChatMessage", reflect.TypeOf((*MockStore)(nil).DeleteChatMessage), ctx, arg)
 }
 
+// DeleteCreature mocks base method.
+func (m *MockStore) DeleteCreature(ctx context.Context, name string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCreature", ctx, name)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCreature indicates an expected call of DeleteCreature.
+func (mr *MockStoreMockRecorder) DeleteCreature(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCreature", reflect.TypeOf((*MockStore)(nil).DeleteCreature), ctx, name)
+}
+
+// DeleteCreatureTranslation mocks base method.
+func (m *MockStore) DeleteCreatureTranslation(ctx context.Context, arg db.DeleteCreatureTranslationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCreatureTranslation", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCreatureTranslation indicates an expected call of DeleteCreatureTranslation.
+func (mr *MockStoreMockRecorder) DeleteCreatureTranslation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCreatureTranslation", reflect.TypeOf((*MockStore)(nil).DeleteCreatureTranslation), ctx, arg)
+}
+
+// DeleteListJoinRequest mocks base method.
+func (m *MockStore) DeleteListJoinRequest(ctx context.Context, arg db.DeleteListJoinRequestParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteListJoinRequest", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteListJoinRequest indicates an expected call of DeleteListJoinRequest.
+func (mr *MockStoreMockRecorder) DeleteListJoinRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListJoinRequest", reflect.TypeOf((*MockStore)(nil).DeleteListJoinRequest), ctx, arg)
+}
+
+// DeleteListReactivation mocks base method.
+func (m *MockStore) DeleteListReactivation(ctx context.Context, arg db.DeleteListReactivationParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteListReactivation", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteListReactivation indicates an expected call of DeleteListReactivation.
+func (mr *MockStoreMockRecorder) DeleteListReactivation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListReactivation", reflect.TypeOf((*MockStore)(nil).DeleteListReactivation), ctx, arg)
+}
+
+// DeleteListRemoval mocks base method.
+func (m *MockStore) DeleteListRemoval(ctx context.Context, arg db.DeleteListRemovalParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteListRemoval", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteListRemoval indicates an expected call of DeleteListRemoval.
+func (mr *MockStoreMockRecorder) DeleteListRemoval(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListRemoval", reflect.TypeOf((*MockStore)(nil).DeleteListRemoval), ctx, arg)
+}
+
+// DeleteListScopeCreatures mocks base method.
+func (m *MockStore) DeleteListScopeCreatures(ctx context.Context, listID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteListScopeCreatures", ctx, listID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteListScopeCreatures indicates an expected call of DeleteListScopeCreatures.
+func (mr *MockStoreMockRecorder) DeleteListScopeCreatures(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListScopeCreatures", reflect.TypeOf((*MockStore)(nil).DeleteListScopeCreatures), ctx, listID)
+}
+
 // DeleteSoulcoreSuggestion mocks base method.
 func (m *MockStore) DeleteSoulcoreSuggestion(ctx context.Context, arg db.DeleteSoulcoreSuggestionParams) error {
 	m.ctrl.T.Helper()
@@ -245,6 +568,65 @@ func (mr *MockStoreMockRecorder) DeleteSoulcoreSuggestion(ctx, arg any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSoulcoreSuggestion", reflect.TypeOf((*MockStore)(nil).DeleteSoulcoreSuggestion), ctx, arg)
 }
 
+// DisableListShareCode mocks base method.
+func (m *MockStore) DisableListShareCode(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableListShareCode", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableListShareCode indicates an expected call of DisableListShareCode.
+func (mr *MockStoreMockRecorder) DisableListShareCode(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableListShareCode", reflect.TypeOf((*MockStore)(nil).DisableListShareCode), ctx, id)
+}
+
+// ExecTx mocks base method.
+func (m *MockStore) ExecTx(ctx context.Context, fn func(db.Querier) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecTx indicates an expected call of ExecTx.
+func (mr *MockStoreMockRecorder) ExecTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTx", reflect.TypeOf((*MockStore)(nil).ExecTx), ctx, fn)
+}
+
+// GetAllCreatureAliases mocks base method.
+func (m *MockStore) GetAllCreatureAliases(ctx context.Context) ([]db.GetAllCreatureAliasesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCreatureAliases", ctx)
+	ret0, _ := ret[0].([]db.GetAllCreatureAliasesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCreatureAliases indicates an expected call of GetAllCreatureAliases.
+func (mr *MockStoreMockRecorder) GetAllCreatureAliases(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCreatureAliases", reflect.TypeOf((*MockStore)(nil).GetAllCreatureAliases), ctx)
+}
+
+// GetAllCreatureTranslations mocks base method.
+func (m *MockStore) GetAllCreatureTranslations(ctx context.Context) ([]db.GetAllCreatureTranslationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCreatureTranslations", ctx)
+	ret0, _ := ret[0].([]db.GetAllCreatureTranslationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCreatureTranslations indicates an expected call of GetAllCreatureTranslations.
+func (mr *MockStoreMockRecorder) GetAllCreatureTranslations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCreatureTranslations", reflect.TypeOf((*MockStore)(nil).GetAllCreatureTranslations), ctx)
+}
+
 // GetCharacter mocks base method.
 func (m *MockStore) GetCharacter(ctx context.Context, id uuid.UUID) (db.Character, error) {
 	m.ctrl.T.Helper()
@@ -290,34 +672,49 @@ func (mr *MockStoreMockRecorder) GetCharacterClaim(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharacterClaim", reflect.TypeOf((*MockStore)(nil).GetCharacterClaim), ctx, arg)
 }
 
+// GetCharacterListIDs mocks base method.
+func (m *MockStore) GetCharacterListIDs(ctx context.Context, characterID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCharacterListIDs", ctx, characterID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCharacterListIDs indicates an expected call of GetCharacterListIDs.
+func (mr *MockStoreMockRecorder) GetCharacterListIDs(ctx, characterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharacterListIDs", reflect.TypeOf((*MockStore)(nil).GetCharacterListIDs), ctx, characterID)
+}
+
 // GetCharacterSoulcores mocks base method.
-func (m *MockStore) GetCharacterSoulcores(ctx context.Context, characterID uuid.UUID) ([]db.GetCharacterSoulcoresRow, error) {
+func (m *MockStore) GetCharacterSoulcores(ctx context.Context, arg db.GetCharacterSoulcoresParams) ([]db.GetCharacterSoulcoresRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCharacterSoulcores", ctx, characterID)
+	ret := m.ctrl.Call(m, "GetCharacterSoulcores", ctx, arg)
 	ret0, _ := ret[0].([]db.GetCharacterSoulcoresRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCharacterSoulcores indicates an expected call of GetCharacterSoulcores.
-func (mr *MockStoreMockRecorder) GetCharacterSoulcores(ctx, characterID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) GetCharacterSoulcores(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharacterSoulcores", reflect.TypeOf((*MockStore)(nil).GetCharacterSoulcores), ctx, characterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharacterSoulcores", reflect.TypeOf((*MockStore)(nil).GetCharacterSoulcores), ctx, arg)
 }
 
 // GetCharacterSuggestions mocks base method.
-func (m *MockStore) GetCharacterSuggestions(ctx context.Context, characterID uuid.UUID) ([]db.GetCharacterSuggestionsRow, error) {
+func (m *MockStore) GetCharacterSuggestions(ctx context.Context, arg db.GetCharacterSuggestionsParams) ([]db.GetCharacterSuggestionsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCharacterSuggestions", ctx, characterID)
+	ret := m.ctrl.Call(m, "GetCharacterSuggestions", ctx, arg)
 	ret0, _ := ret[0].([]db.GetCharacterSuggestionsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCharacterSuggestions indicates an expected call of GetCharacterSuggestions.
-func (mr *MockStoreMockRecorder) GetCharacterSuggestions(ctx, characterID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) GetCharacterSuggestions(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharacterSuggestions", reflect.TypeOf((*MockStore)(nil).GetCharacterSuggestions), ctx, characterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharacterSuggestions", reflect.TypeOf((*MockStore)(nil).GetCharacterSuggestions), ctx, arg)
 }
 
 // GetCharactersByUserID mocks base method.
@@ -335,6 +732,21 @@ func (mr *MockStoreMockRecorder) GetCharactersByUserID(ctx, userID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCharactersByUserID", reflect.TypeOf((*MockStore)(nil).GetCharactersByUserID), ctx, userID)
 }
 
+// GetChatMessage mocks base method.
+func (m *MockStore) GetChatMessage(ctx context.Context, arg db.GetChatMessageParams) (db.ListChatMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMessage", ctx, arg)
+	ret0, _ := ret[0].(db.ListChatMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMessage indicates an expected call of GetChatMessage.
+func (mr *MockStoreMockRecorder) GetChatMessage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMessage", reflect.TypeOf((*MockStore)(nil).GetChatMessage), ctx, arg)
+}
+
 // GetChatMessages mocks base method.
 func (m *MockStore) GetChatMessages(ctx context.Context, arg db.GetChatMessagesParams) ([]db.GetChatMessagesRow, error) {
 	m.ctrl.T.Helper()
@@ -395,68 +807,308 @@ func (mr *MockStoreMockRecorder) GetClaimByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimByID", reflect.TypeOf((*MockStore)(nil).GetClaimByID), ctx, id)
 }
 
-// GetCreatures mocks base method.
-func (m *MockStore) GetCreatures(ctx context.Context) ([]db.Creature, error) {
+// GetCreatureAliases mocks base method.
+func (m *MockStore) GetCreatureAliases(ctx context.Context, creatureID uuid.UUID) ([]db.CreatureAlias, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCreatures", ctx)
-	ret0, _ := ret[0].([]db.Creature)
+	ret := m.ctrl.Call(m, "GetCreatureAliases", ctx, creatureID)
+	ret0, _ := ret[0].([]db.CreatureAlias)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCreatures indicates an expected call of GetCreatures.
-func (mr *MockStoreMockRecorder) GetCreatures(ctx any) *gomock.Call {
+// GetCreatureAliases indicates an expected call of GetCreatureAliases.
+func (mr *MockStoreMockRecorder) GetCreatureAliases(ctx, creatureID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreatures", reflect.TypeOf((*MockStore)(nil).GetCreatures), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreatureAliases", reflect.TypeOf((*MockStore)(nil).GetCreatureAliases), ctx, creatureID)
 }
 
-// GetHighscoreCharacters mocks base method.
-func (m *MockStore) GetHighscoreCharacters(ctx context.Context, arg db.GetHighscoreCharactersParams) ([]db.GetHighscoreCharactersRow, error) {
+// GetCreatureByName mocks base method.
+func (m *MockStore) GetCreatureByName(ctx context.Context, name string) (db.Creature, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHighscoreCharacters", ctx, arg)
-	ret0, _ := ret[0].([]db.GetHighscoreCharactersRow)
+	ret := m.ctrl.Call(m, "GetCreatureByName", ctx, name)
+	ret0, _ := ret[0].(db.Creature)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetHighscoreCharacters indicates an expected call of GetHighscoreCharacters.
-func (mr *MockStoreMockRecorder) GetHighscoreCharacters(ctx, arg any) *gomock.Call {
+// GetCreatureByName indicates an expected call of GetCreatureByName.
+func (mr *MockStoreMockRecorder) GetCreatureByName(ctx, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighscoreCharacters", reflect.TypeOf((*MockStore)(nil).GetHighscoreCharacters), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreatureByName", reflect.TypeOf((*MockStore)(nil).GetCreatureByName), ctx, name)
 }
 
-// GetList mocks base method.
-func (m *MockStore) GetList(ctx context.Context, id uuid.UUID) (db.List, error) {
+// GetCreatureIDs mocks base method.
+func (m *MockStore) GetCreatureIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetList", ctx, id)
-	ret0, _ := ret[0].(db.List)
+	ret := m.ctrl.Call(m, "GetCreatureIDs", ctx, ids)
+	ret0, _ := ret[0].([]uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetList indicates an expected call of GetList.
-func (mr *MockStoreMockRecorder) GetList(ctx, id any) *gomock.Call {
+// GetCreatureIDs indicates an expected call of GetCreatureIDs.
+func (mr *MockStoreMockRecorder) GetCreatureIDs(ctx, ids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockStore)(nil).GetList), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreatureIDs", reflect.TypeOf((*MockStore)(nil).GetCreatureIDs), ctx, ids)
 }
 
-// GetListByShareCode mocks base method.
-func (m *MockStore) GetListByShareCode(ctx context.Context, shareCode uuid.UUID) (db.List, error) {
+// GetCreatures mocks base method.
+func (m *MockStore) GetCreatures(ctx context.Context, arg db.GetCreaturesParams) ([]db.GetCreaturesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetListByShareCode", ctx, shareCode)
-	ret0, _ := ret[0].(db.List)
+	ret := m.ctrl.Call(m, "GetCreatures", ctx, arg)
+	ret0, _ := ret[0].([]db.GetCreaturesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetListByShareCode indicates an expected call of GetListByShareCode.
-func (mr *MockStoreMockRecorder) GetListByShareCode(ctx, shareCode any) *gomock.Call {
+// GetCreatures indicates an expected call of GetCreatures.
+func (mr *MockStoreMockRecorder) GetCreatures(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByShareCode", reflect.TypeOf((*MockStore)(nil).GetListByShareCode), ctx, shareCode)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreatures", reflect.TypeOf((*MockStore)(nil).GetCreatures), ctx, arg)
 }
 
-// GetListMembers mocks base method.
-func (m *MockStore) GetListMembers(ctx context.Context, listID uuid.UUID) ([]db.GetListMembersRow, error) {
+// GetCreaturesByNames mocks base method.
+func (m *MockStore) GetCreaturesByNames(ctx context.Context, names []string) ([]db.GetCreaturesByNamesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreaturesByNames", ctx, names)
+	ret0, _ := ret[0].([]db.GetCreaturesByNamesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreaturesByNames indicates an expected call of GetCreaturesByNames.
+func (mr *MockStoreMockRecorder) GetCreaturesByNames(ctx, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreaturesByNames", reflect.TypeOf((*MockStore)(nil).GetCreaturesByNames), ctx, names)
+}
+
+// GetHighscoreCharacters mocks base method.
+func (m *MockStore) GetHighscoreCharacters(ctx context.Context, arg db.GetHighscoreCharactersParams) ([]db.GetHighscoreCharactersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHighscoreCharacters", ctx, arg)
+	ret0, _ := ret[0].([]db.GetHighscoreCharactersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHighscoreCharacters indicates an expected call of GetHighscoreCharacters.
+func (mr *MockStoreMockRecorder) GetHighscoreCharacters(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighscoreCharacters", reflect.TypeOf((*MockStore)(nil).GetHighscoreCharacters), ctx, arg)
+}
+
+// GetList mocks base method.
+func (m *MockStore) GetList(ctx context.Context, id uuid.UUID) (db.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, id)
+	ret0, _ := ret[0].(db.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockStoreMockRecorder) GetList(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockStore)(nil).GetList), ctx, id)
+}
+
+// GetListActivity mocks base method.
+func (m *MockStore) GetListActivity(ctx context.Context, arg db.GetListActivityParams) ([]db.GetListActivityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListActivity", ctx, arg)
+	ret0, _ := ret[0].([]db.GetListActivityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListActivity indicates an expected call of GetListActivity.
+func (mr *MockStoreMockRecorder) GetListActivity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListActivity", reflect.TypeOf((*MockStore)(nil).GetListActivity), ctx, arg)
+}
+
+// GetListByRedirectCode mocks base method.
+func (m *MockStore) GetListByRedirectCode(ctx context.Context, shareCode uuid.UUID) (db.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListByRedirectCode", ctx, shareCode)
+	ret0, _ := ret[0].(db.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListByRedirectCode indicates an expected call of GetListByRedirectCode.
+func (mr *MockStoreMockRecorder) GetListByRedirectCode(ctx, shareCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByRedirectCode", reflect.TypeOf((*MockStore)(nil).GetListByRedirectCode), ctx, shareCode)
+}
+
+// GetListByShareCode mocks base method.
+func (m *MockStore) GetListByShareCode(ctx context.Context, shareCode uuid.UUID) (db.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListByShareCode", ctx, shareCode)
+	ret0, _ := ret[0].(db.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListByShareCode indicates an expected call of GetListByShareCode.
+func (mr *MockStoreMockRecorder) GetListByShareCode(ctx, shareCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByShareCode", reflect.TypeOf((*MockStore)(nil).GetListByShareCode), ctx, shareCode)
+}
+
+// GetListDistribution mocks base method.
+func (m *MockStore) GetListDistribution(ctx context.Context, arg db.GetListDistributionParams) (db.ListDistribution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListDistribution", ctx, arg)
+	ret0, _ := ret[0].(db.ListDistribution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListDistribution indicates an expected call of GetListDistribution.
+func (mr *MockStoreMockRecorder) GetListDistribution(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListDistribution", reflect.TypeOf((*MockStore)(nil).GetListDistribution), ctx, arg)
+}
+
+// GetListDistributions mocks base method.
+func (m *MockStore) GetListDistributions(ctx context.Context, arg db.GetListDistributionsParams) ([]db.GetListDistributionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListDistributions", ctx, arg)
+	ret0, _ := ret[0].([]db.GetListDistributionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListDistributions indicates an expected call of GetListDistributions.
+func (mr *MockStoreMockRecorder) GetListDistributions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListDistributions", reflect.TypeOf((*MockStore)(nil).GetListDistributions), ctx, arg)
+}
+
+// GetListHuntCandidates mocks base method.
+func (m *MockStore) GetListHuntCandidates(ctx context.Context, listID uuid.UUID) ([]db.GetListHuntCandidatesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListHuntCandidates", ctx, listID)
+	ret0, _ := ret[0].([]db.GetListHuntCandidatesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListHuntCandidates indicates an expected call of GetListHuntCandidates.
+func (mr *MockStoreMockRecorder) GetListHuntCandidates(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListHuntCandidates", reflect.TypeOf((*MockStore)(nil).GetListHuntCandidates), ctx, listID)
+}
+
+// GetListInvite mocks base method.
+func (m *MockStore) GetListInvite(ctx context.Context, arg db.GetListInviteParams) (db.ListInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListInvite", ctx, arg)
+	ret0, _ := ret[0].(db.ListInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListInvite indicates an expected call of GetListInvite.
+func (mr *MockStoreMockRecorder) GetListInvite(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListInvite", reflect.TypeOf((*MockStore)(nil).GetListInvite), ctx, arg)
+}
+
+// GetListInviteByCode mocks base method.
+func (m *MockStore) GetListInviteByCode(ctx context.Context, code uuid.UUID) (db.ListInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListInviteByCode", ctx, code)
+	ret0, _ := ret[0].(db.ListInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListInviteByCode indicates an expected call of GetListInviteByCode.
+func (mr *MockStoreMockRecorder) GetListInviteByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListInviteByCode", reflect.TypeOf((*MockStore)(nil).GetListInviteByCode), ctx, code)
+}
+
+// GetListInvites mocks base method.
+func (m *MockStore) GetListInvites(ctx context.Context, listID uuid.UUID) ([]db.ListInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListInvites", ctx, listID)
+	ret0, _ := ret[0].([]db.ListInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListInvites indicates an expected call of GetListInvites.
+func (mr *MockStoreMockRecorder) GetListInvites(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListInvites", reflect.TypeOf((*MockStore)(nil).GetListInvites), ctx, listID)
+}
+
+// GetListJoinRequest mocks base method.
+func (m *MockStore) GetListJoinRequest(ctx context.Context, arg db.GetListJoinRequestParams) (db.ListJoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListJoinRequest", ctx, arg)
+	ret0, _ := ret[0].(db.ListJoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListJoinRequest indicates an expected call of GetListJoinRequest.
+func (mr *MockStoreMockRecorder) GetListJoinRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListJoinRequest", reflect.TypeOf((*MockStore)(nil).GetListJoinRequest), ctx, arg)
+}
+
+// GetListJoinRequests mocks base method.
+func (m *MockStore) GetListJoinRequests(ctx context.Context, listID uuid.UUID) ([]db.GetListJoinRequestsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListJoinRequests", ctx, listID)
+	ret0, _ := ret[0].([]db.GetListJoinRequestsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListJoinRequests indicates an expected call of GetListJoinRequests.
+func (mr *MockStoreMockRecorder) GetListJoinRequests(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListJoinRequests", reflect.TypeOf((*MockStore)(nil).GetListJoinRequests), ctx, listID)
+}
+
+// GetListMemberRole mocks base method.
+func (m *MockStore) GetListMemberRole(ctx context.Context, arg db.GetListMemberRoleParams) (db.ListRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMemberRole", ctx, arg)
+	ret0, _ := ret[0].(db.ListRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMemberRole indicates an expected call of GetListMemberRole.
+func (mr *MockStoreMockRecorder) GetListMemberRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMemberRole", reflect.TypeOf((*MockStore)(nil).GetListMemberRole), ctx, arg)
+}
+
+// GetListMemberUnlocks mocks base method.
+func (m *MockStore) GetListMemberUnlocks(ctx context.Context, listID uuid.UUID) ([]db.GetListMemberUnlocksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMemberUnlocks", ctx, listID)
+	ret0, _ := ret[0].([]db.GetListMemberUnlocksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMemberUnlocks indicates an expected call of GetListMemberUnlocks.
+func (mr *MockStoreMockRecorder) GetListMemberUnlocks(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMemberUnlocks", reflect.TypeOf((*MockStore)(nil).GetListMemberUnlocks), ctx, listID)
+}
+
+// GetListMembers mocks base method.
+func (m *MockStore) GetListMembers(ctx context.Context, listID uuid.UUID) ([]db.GetListMembersRow, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetListMembers", ctx, listID)
 	ret0, _ := ret[0].([]db.GetListMembersRow)
@@ -485,6 +1137,96 @@ func (mr *MockStoreMockRecorder) GetListMembersWithUnlocks(ctx, listID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMembersWithUnlocks", reflect.TypeOf((*MockStore)(nil).GetListMembersWithUnlocks), ctx, listID)
 }
 
+// GetListProgress mocks base method.
+func (m *MockStore) GetListProgress(ctx context.Context, listID uuid.UUID) (db.GetListProgressRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListProgress", ctx, listID)
+	ret0, _ := ret[0].(db.GetListProgressRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListProgress indicates an expected call of GetListProgress.
+func (mr *MockStoreMockRecorder) GetListProgress(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListProgress", reflect.TypeOf((*MockStore)(nil).GetListProgress), ctx, listID)
+}
+
+// GetListProgressGroups mocks base method.
+func (m *MockStore) GetListProgressGroups(ctx context.Context, arg db.GetListProgressGroupsParams) ([]db.GetListProgressGroupsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListProgressGroups", ctx, arg)
+	ret0, _ := ret[0].([]db.GetListProgressGroupsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListProgressGroups indicates an expected call of GetListProgressGroups.
+func (mr *MockStoreMockRecorder) GetListProgressGroups(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListProgressGroups", reflect.TypeOf((*MockStore)(nil).GetListProgressGroups), ctx, arg)
+}
+
+// GetListReactivations mocks base method.
+func (m *MockStore) GetListReactivations(ctx context.Context, listID uuid.UUID) ([]db.GetListReactivationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListReactivations", ctx, listID)
+	ret0, _ := ret[0].([]db.GetListReactivationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListReactivations indicates an expected call of GetListReactivations.
+func (mr *MockStoreMockRecorder) GetListReactivations(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListReactivations", reflect.TypeOf((*MockStore)(nil).GetListReactivations), ctx, listID)
+}
+
+// GetListRemovedMembers mocks base method.
+func (m *MockStore) GetListRemovedMembers(ctx context.Context, listID uuid.UUID) ([]db.GetListRemovedMembersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListRemovedMembers", ctx, listID)
+	ret0, _ := ret[0].([]db.GetListRemovedMembersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListRemovedMembers indicates an expected call of GetListRemovedMembers.
+func (mr *MockStoreMockRecorder) GetListRemovedMembers(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListRemovedMembers", reflect.TypeOf((*MockStore)(nil).GetListRemovedMembers), ctx, listID)
+}
+
+// GetListScope mocks base method.
+func (m *MockStore) GetListScope(ctx context.Context, listID uuid.UUID) (db.ListScope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListScope", ctx, listID)
+	ret0, _ := ret[0].(db.ListScope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListScope indicates an expected call of GetListScope.
+func (mr *MockStoreMockRecorder) GetListScope(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListScope", reflect.TypeOf((*MockStore)(nil).GetListScope), ctx, listID)
+}
+
+// GetListScopeCreatures mocks base method.
+func (m *MockStore) GetListScopeCreatures(ctx context.Context, listID uuid.UUID) ([]db.GetListScopeCreaturesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListScopeCreatures", ctx, listID)
+	ret0, _ := ret[0].([]db.GetListScopeCreaturesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListScopeCreatures indicates an expected call of GetListScopeCreatures.
+func (mr *MockStoreMockRecorder) GetListScopeCreatures(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListScopeCreatures", reflect.TypeOf((*MockStore)(nil).GetListScopeCreatures), ctx, listID)
+}
+
 // GetListSoulcore mocks base method.
 func (m *MockStore) GetListSoulcore(ctx context.Context, arg db.GetListSoulcoreParams) (db.GetListSoulcoreRow, error) {
 	m.ctrl.T.Helper()
@@ -501,18 +1243,33 @@ func (mr *MockStoreMockRecorder) GetListSoulcore(ctx, arg any) *gomock.Call {
 }
 
 // GetListSoulcores mocks base method.
-func (m *MockStore) GetListSoulcores(ctx context.Context, listID uuid.UUID) ([]db.GetListSoulcoresRow, error) {
+func (m *MockStore) GetListSoulcores(ctx context.Context, arg db.GetListSoulcoresParams) ([]db.GetListSoulcoresRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetListSoulcores", ctx, listID)
+	ret := m.ctrl.Call(m, "GetListSoulcores", ctx, arg)
 	ret0, _ := ret[0].([]db.GetListSoulcoresRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetListSoulcores indicates an expected call of GetListSoulcores.
-func (mr *MockStoreMockRecorder) GetListSoulcores(ctx, listID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) GetListSoulcores(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListSoulcores", reflect.TypeOf((*MockStore)(nil).GetListSoulcores), ctx, listID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListSoulcores", reflect.TypeOf((*MockStore)(nil).GetListSoulcores), ctx, arg)
+}
+
+// GetListUserCharacters mocks base method.
+func (m *MockStore) GetListUserCharacters(ctx context.Context, arg db.GetListUserCharactersParams) ([]db.GetListUserCharactersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListUserCharacters", ctx, arg)
+	ret0, _ := ret[0].([]db.GetListUserCharactersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListUserCharacters indicates an expected call of GetListUserCharacters.
+func (mr *MockStoreMockRecorder) GetListUserCharacters(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListUserCharacters", reflect.TypeOf((*MockStore)(nil).GetListUserCharacters), ctx, arg)
 }
 
 // GetListsByAuthorId mocks base method.
@@ -575,6 +1332,36 @@ func (mr *MockStoreMockRecorder) GetPendingSuggestionsForUser(ctx, userID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingSuggestionsForUser", reflect.TypeOf((*MockStore)(nil).GetPendingSuggestionsForUser), ctx, userID)
 }
 
+// GetPublicLists mocks base method.
+func (m *MockStore) GetPublicLists(ctx context.Context, arg db.GetPublicListsParams) ([]db.GetPublicListsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicLists", ctx, arg)
+	ret0, _ := ret[0].([]db.GetPublicListsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicLists indicates an expected call of GetPublicLists.
+func (mr *MockStoreMockRecorder) GetPublicLists(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicLists", reflect.TypeOf((*MockStore)(nil).GetPublicLists), ctx, arg)
+}
+
+// GetRemovedListSoulcore mocks base method.
+func (m *MockStore) GetRemovedListSoulcore(ctx context.Context, arg db.GetRemovedListSoulcoreParams) (db.GetRemovedListSoulcoreRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemovedListSoulcore", ctx, arg)
+	ret0, _ := ret[0].(db.GetRemovedListSoulcoreRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemovedListSoulcore indicates an expected call of GetRemovedListSoulcore.
+func (mr *MockStoreMockRecorder) GetRemovedListSoulcore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemovedListSoulcore", reflect.TypeOf((*MockStore)(nil).GetRemovedListSoulcore), ctx, arg)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockStore) GetUserByEmail(ctx context.Context, email pgtype.Text) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -620,6 +1407,21 @@ func (mr *MockStoreMockRecorder) GetUserCharacters(ctx, userID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCharacters", reflect.TypeOf((*MockStore)(nil).GetUserCharacters), ctx, userID)
 }
 
+// GetUserListReactivations mocks base method.
+func (m *MockStore) GetUserListReactivations(ctx context.Context, userID uuid.UUID) ([]db.GetUserListReactivationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListReactivations", ctx, userID)
+	ret0, _ := ret[0].([]db.GetUserListReactivationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListReactivations indicates an expected call of GetUserListReactivations.
+func (mr *MockStoreMockRecorder) GetUserListReactivations(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListReactivations", reflect.TypeOf((*MockStore)(nil).GetUserListReactivations), ctx, userID)
+}
+
 // GetUserLists mocks base method.
 func (m *MockStore) GetUserLists(ctx context.Context, authorID uuid.UUID) ([]db.GetUserListsRow, error) {
 	m.ctrl.T.Helper()
@@ -650,6 +1452,21 @@ func (mr *MockStoreMockRecorder) IsUserListMember(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserListMember", reflect.TypeOf((*MockStore)(nil).IsUserListMember), ctx, arg)
 }
 
+// IsUserRemovedFromList mocks base method.
+func (m *MockStore) IsUserRemovedFromList(ctx context.Context, arg db.IsUserRemovedFromListParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserRemovedFromList", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserRemovedFromList indicates an expected call of IsUserRemovedFromList.
+func (mr *MockStoreMockRecorder) IsUserRemovedFromList(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserRemovedFromList", reflect.TypeOf((*MockStore)(nil).IsUserRemovedFromList), ctx, arg)
+}
+
 // MarkListMessagesAsRead mocks base method.
 func (m *MockStore) MarkListMessagesAsRead(ctx context.Context, arg db.MarkListMessagesAsReadParams) error {
 	m.ctrl.T.Helper()
@@ -664,6 +1481,21 @@ func (mr *MockStoreMockRecorder) MarkListMessagesAsRead(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkListMessagesAsRead", reflect.TypeOf((*MockStore)(nil).MarkListMessagesAsRead), ctx, arg)
 }
 
+// MergeListMembers mocks base method.
+func (m *MockStore) MergeListMembers(ctx context.Context, arg db.MergeListMembersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeListMembers", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeListMembers indicates an expected call of MergeListMembers.
+func (mr *MockStoreMockRecorder) MergeListMembers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeListMembers", reflect.TypeOf((*MockStore)(nil).MergeListMembers), ctx, arg)
+}
+
 // MigrateAnonymousUser mocks base method.
 func (m *MockStore) MigrateAnonymousUser(ctx context.Context, arg db.MigrateAnonymousUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -679,6 +1511,123 @@ func (mr *MockStoreMockRecorder) MigrateAnonymousUser(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateAnonymousUser", reflect.TypeOf((*MockStore)(nil).MigrateAnonymousUser), ctx, arg)
 }
 
+// MoveListChatMessages mocks base method.
+func (m *MockStore) MoveListChatMessages(ctx context.Context, arg db.MoveListChatMessagesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveListChatMessages", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveListChatMessages indicates an expected call of MoveListChatMessages.
+func (mr *MockStoreMockRecorder) MoveListChatMessages(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveListChatMessages", reflect.TypeOf((*MockStore)(nil).MoveListChatMessages), ctx, arg)
+}
+
+// MoveListShareRedirects mocks base method.
+func (m *MockStore) MoveListShareRedirects(ctx context.Context, arg db.MoveListShareRedirectsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveListShareRedirects", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveListShareRedirects indicates an expected call of MoveListShareRedirects.
+func (mr *MockStoreMockRecorder) MoveListShareRedirects(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveListShareRedirects", reflect.TypeOf((*MockStore)(nil).MoveListShareRedirects), ctx, arg)
+}
+
+// MoveListSoulcoreSuggestions mocks base method.
+func (m *MockStore) MoveListSoulcoreSuggestions(ctx context.Context, arg db.MoveListSoulcoreSuggestionsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveListSoulcoreSuggestions", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveListSoulcoreSuggestions indicates an expected call of MoveListSoulcoreSuggestions.
+func (mr *MockStoreMockRecorder) MoveListSoulcoreSuggestions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveListSoulcoreSuggestions", reflect.TypeOf((*MockStore)(nil).MoveListSoulcoreSuggestions), ctx, arg)
+}
+
+// PurgeDeletedLists mocks base method.
+func (m *MockStore) PurgeDeletedLists(ctx context.Context, deletedBefore pgtype.Timestamptz) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedLists", ctx, deletedBefore)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedLists indicates an expected call of PurgeDeletedLists.
+func (mr *MockStoreMockRecorder) PurgeDeletedLists(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedLists", reflect.TypeOf((*MockStore)(nil).PurgeDeletedLists), ctx, deletedBefore)
+}
+
+// PurgeRemovedCharacterSoulcores mocks base method.
+func (m *MockStore) PurgeRemovedCharacterSoulcores(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeRemovedCharacterSoulcores", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeRemovedCharacterSoulcores indicates an expected call of PurgeRemovedCharacterSoulcores.
+func (mr *MockStoreMockRecorder) PurgeRemovedCharacterSoulcores(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeRemovedCharacterSoulcores", reflect.TypeOf((*MockStore)(nil).PurgeRemovedCharacterSoulcores), ctx, deletedBefore)
+}
+
+// PurgeRemovedListSoulcores mocks base method.
+func (m *MockStore) PurgeRemovedListSoulcores(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeRemovedListSoulcores", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeRemovedListSoulcores indicates an expected call of PurgeRemovedListSoulcores.
+func (mr *MockStoreMockRecorder) PurgeRemovedListSoulcores(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeRemovedListSoulcores", reflect.TypeOf((*MockStore)(nil).PurgeRemovedListSoulcores), ctx, deletedBefore)
+}
+
+// ReassignListReactivations mocks base method.
+func (m *MockStore) ReassignListReactivations(ctx context.Context, arg db.ReassignListReactivationsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignListReactivations", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReassignListReactivations indicates an expected call of ReassignListReactivations.
+func (mr *MockStoreMockRecorder) ReassignListReactivations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignListReactivations", reflect.TypeOf((*MockStore)(nil).ReassignListReactivations), ctx, arg)
+}
+
+// ReleaseListReservations mocks base method.
+func (m *MockStore) ReleaseListReservations(ctx context.Context, listID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseListReservations", ctx, listID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseListReservations indicates an expected call of ReleaseListReservations.
+func (mr *MockStoreMockRecorder) ReleaseListReservations(ctx, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseListReservations", reflect.TypeOf((*MockStore)(nil).ReleaseListReservations), ctx, listID)
+}
+
 // RemoveCharacterSoulcore mocks base method.
 func (m *MockStore) RemoveCharacterSoulcore(ctx context.Context, arg db.RemoveCharacterSoulcoreParams) error {
 	m.ctrl.T.Helper()
@@ -693,6 +1642,36 @@ func (mr *MockStoreMockRecorder) RemoveCharacterSoulcore(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCharacterSoulcore", reflect.TypeOf((*MockStore)(nil).RemoveCharacterSoulcore), ctx, arg)
 }
 
+// RemoveListCharacter mocks base method.
+func (m *MockStore) RemoveListCharacter(ctx context.Context, arg db.RemoveListCharacterParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveListCharacter", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveListCharacter indicates an expected call of RemoveListCharacter.
+func (mr *MockStoreMockRecorder) RemoveListCharacter(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveListCharacter", reflect.TypeOf((*MockStore)(nil).RemoveListCharacter), ctx, arg)
+}
+
+// RemoveListMember mocks base method.
+func (m *MockStore) RemoveListMember(ctx context.Context, arg db.RemoveListMemberParams) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveListMember", ctx, arg)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveListMember indicates an expected call of RemoveListMember.
+func (mr *MockStoreMockRecorder) RemoveListMember(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveListMember", reflect.TypeOf((*MockStore)(nil).RemoveListMember), ctx, arg)
+}
+
 // RemoveListSoulcore mocks base method.
 func (m *MockStore) RemoveListSoulcore(ctx context.Context, arg db.RemoveListSoulcoreParams) error {
 	m.ctrl.T.Helper()
@@ -707,6 +1686,169 @@ func (mr *MockStoreMockRecorder) RemoveListSoulcore(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveListSoulcore", reflect.TypeOf((*MockStore)(nil).RemoveListSoulcore), ctx, arg)
 }
 
+// RenameCreature mocks base method.
+func (m *MockStore) RenameCreature(ctx context.Context, arg db.RenameCreatureParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameCreature", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameCreature indicates an expected call of RenameCreature.
+func (mr *MockStoreMockRecorder) RenameCreature(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCreature", reflect.TypeOf((*MockStore)(nil).RenameCreature), ctx, arg)
+}
+
+// ReserveObtainedSoulcore mocks base method.
+func (m *MockStore) ReserveObtainedSoulcore(ctx context.Context, arg db.ReserveObtainedSoulcoreParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveObtainedSoulcore", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveObtainedSoulcore indicates an expected call of ReserveObtainedSoulcore.
+func (mr *MockStoreMockRecorder) ReserveObtainedSoulcore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveObtainedSoulcore", reflect.TypeOf((*MockStore)(nil).ReserveObtainedSoulcore), ctx, arg)
+}
+
+// RestoreCharacterSoulcore mocks base method.
+func (m *MockStore) RestoreCharacterSoulcore(ctx context.Context, arg db.RestoreCharacterSoulcoreParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCharacterSoulcore", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCharacterSoulcore indicates an expected call of RestoreCharacterSoulcore.
+func (mr *MockStoreMockRecorder) RestoreCharacterSoulcore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCharacterSoulcore", reflect.TypeOf((*MockStore)(nil).RestoreCharacterSoulcore), ctx, arg)
+}
+
+// RestoreList mocks base method.
+func (m *MockStore) RestoreList(ctx context.Context, arg db.RestoreListParams) (db.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreList", ctx, arg)
+	ret0, _ := ret[0].(db.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreList indicates an expected call of RestoreList.
+func (mr *MockStoreMockRecorder) RestoreList(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreList", reflect.TypeOf((*MockStore)(nil).RestoreList), ctx, arg)
+}
+
+// RestoreListSoulcore mocks base method.
+func (m *MockStore) RestoreListSoulcore(ctx context.Context, arg db.RestoreListSoulcoreParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreListSoulcore", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreListSoulcore indicates an expected call of RestoreListSoulcore.
+func (mr *MockStoreMockRecorder) RestoreListSoulcore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreListSoulcore", reflect.TypeOf((*MockStore)(nil).RestoreListSoulcore), ctx, arg)
+}
+
+// RevokeListInvite mocks base method.
+func (m *MockStore) RevokeListInvite(ctx context.Context, arg db.RevokeListInviteParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeListInvite", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeListInvite indicates an expected call of RevokeListInvite.
+func (mr *MockStoreMockRecorder) RevokeListInvite(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeListInvite", reflect.TypeOf((*MockStore)(nil).RevokeListInvite), ctx, arg)
+}
+
+// RotateListShareCode mocks base method.
+func (m *MockStore) RotateListShareCode(ctx context.Context, id uuid.UUID) (db.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateListShareCode", ctx, id)
+	ret0, _ := ret[0].(db.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateListShareCode indicates an expected call of RotateListShareCode.
+func (mr *MockStoreMockRecorder) RotateListShareCode(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateListShareCode", reflect.TypeOf((*MockStore)(nil).RotateListShareCode), ctx, id)
+}
+
+// SearchCreatures mocks base method.
+func (m *MockStore) SearchCreatures(ctx context.Context, arg db.SearchCreaturesParams) ([]db.SearchCreaturesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCreatures", ctx, arg)
+	ret0, _ := ret[0].([]db.SearchCreaturesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCreatures indicates an expected call of SearchCreatures.
+func (mr *MockStoreMockRecorder) SearchCreatures(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCreatures", reflect.TypeOf((*MockStore)(nil).SearchCreatures), ctx, arg)
+}
+
+// SoftDeleteList mocks base method.
+func (m *MockStore) SoftDeleteList(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteList", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteList indicates an expected call of SoftDeleteList.
+func (mr *MockStoreMockRecorder) SoftDeleteList(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteList", reflect.TypeOf((*MockStore)(nil).SoftDeleteList), ctx, id)
+}
+
+// SwapListCharacter mocks base method.
+func (m *MockStore) SwapListCharacter(ctx context.Context, arg db.SwapListCharacterParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapListCharacter", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapListCharacter indicates an expected call of SwapListCharacter.
+func (mr *MockStoreMockRecorder) SwapListCharacter(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapListCharacter", reflect.TypeOf((*MockStore)(nil).SwapListCharacter), ctx, arg)
+}
+
+// TransferListOwnership mocks base method.
+func (m *MockStore) TransferListOwnership(ctx context.Context, arg db.TransferListOwnershipParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferListOwnership", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferListOwnership indicates an expected call of TransferListOwnership.
+func (mr *MockStoreMockRecorder) TransferListOwnership(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferListOwnership", reflect.TypeOf((*MockStore)(nil).TransferListOwnership), ctx, arg)
+}
+
 // UpdateCharacterOwner mocks base method.
 func (m *MockStore) UpdateCharacterOwner(ctx context.Context, arg db.UpdateCharacterOwnerParams) (db.Character, error) {
 	m.ctrl.T.Helper()
@@ -737,20 +1879,123 @@ func (mr *MockStoreMockRecorder) UpdateClaimStatus(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClaimStatus", reflect.TypeOf((*MockStore)(nil).UpdateClaimStatus), ctx, arg)
 }
 
-// UpdateSoulcoreStatus mocks base method.
-func (m *MockStore) UpdateSoulcoreStatus(ctx context.Context, arg db.UpdateSoulcoreStatusParams) error {
+// UpdateCreatureBestiary mocks base method.
+func (m *MockStore) UpdateCreatureBestiary(ctx context.Context, arg db.UpdateCreatureBestiaryParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSoulcoreStatus", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateCreatureBestiary", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// UpdateCreatureBestiary indicates an expected call of UpdateCreatureBestiary.
+func (mr *MockStoreMockRecorder) UpdateCreatureBestiary(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCreatureBestiary", reflect.TypeOf((*MockStore)(nil).UpdateCreatureBestiary), ctx, arg)
+}
+
+// UpdateCreatureDifficulty mocks base method.
+func (m *MockStore) UpdateCreatureDifficulty(ctx context.Context, arg db.UpdateCreatureDifficultyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCreatureDifficulty", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCreatureDifficulty indicates an expected call of UpdateCreatureDifficulty.
+func (mr *MockStoreMockRecorder) UpdateCreatureDifficulty(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCreatureDifficulty", reflect.TypeOf((*MockStore)(nil).UpdateCreatureDifficulty), ctx, arg)
+}
+
+// UpdateList mocks base method.
+func (m *MockStore) UpdateList(ctx context.Context, arg db.UpdateListParams) (db.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateList", ctx, arg)
+	ret0, _ := ret[0].(db.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockStoreMockRecorder) UpdateList(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockStore)(nil).UpdateList), ctx, arg)
+}
+
+// UpdateListMemberRole mocks base method.
+func (m *MockStore) UpdateListMemberRole(ctx context.Context, arg db.UpdateListMemberRoleParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateListMemberRole", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateListMemberRole indicates an expected call of UpdateListMemberRole.
+func (mr *MockStoreMockRecorder) UpdateListMemberRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateListMemberRole", reflect.TypeOf((*MockStore)(nil).UpdateListMemberRole), ctx, arg)
+}
+
+// UpdateSoulcoreStatus mocks base method.
+func (m *MockStore) UpdateSoulcoreStatus(ctx context.Context, arg db.UpdateSoulcoreStatusParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSoulcoreStatus", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateSoulcoreStatus indicates an expected call of UpdateSoulcoreStatus.
 func (mr *MockStoreMockRecorder) UpdateSoulcoreStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSoulcoreStatus", reflect.TypeOf((*MockStore)(nil).UpdateSoulcoreStatus), ctx, arg)
 }
 
+// UpsertCreatureTranslation mocks base method.
+func (m *MockStore) UpsertCreatureTranslation(ctx context.Context, arg db.UpsertCreatureTranslationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCreatureTranslation", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCreatureTranslation indicates an expected call of UpsertCreatureTranslation.
+func (mr *MockStoreMockRecorder) UpsertCreatureTranslation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCreatureTranslation", reflect.TypeOf((*MockStore)(nil).UpsertCreatureTranslation), ctx, arg)
+}
+
+// UpsertListScope mocks base method.
+func (m *MockStore) UpsertListScope(ctx context.Context, arg db.UpsertListScopeParams) (db.ListScope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertListScope", ctx, arg)
+	ret0, _ := ret[0].(db.ListScope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertListScope indicates an expected call of UpsertListScope.
+func (mr *MockStoreMockRecorder) UpsertListScope(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertListScope", reflect.TypeOf((*MockStore)(nil).UpsertListScope), ctx, arg)
+}
+
+// UseListInvite mocks base method.
+func (m *MockStore) UseListInvite(ctx context.Context, id uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseListInvite", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseListInvite indicates an expected call of UseListInvite.
+func (mr *MockStoreMockRecorder) UseListInvite(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseListInvite", reflect.TypeOf((*MockStore)(nil).UseListInvite), ctx, id)
+}
+
 // VerifyEmail mocks base method.
 func (m *MockStore) VerifyEmail(ctx context.Context, arg db.VerifyEmailParams) error {
 	m.ctrl.T.Helper()
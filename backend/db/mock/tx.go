@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"go.uber.org/mock/gomock"
+)
+
+// TxRecorder counts how the transactions run through a MockStore ended
+type TxRecorder struct {
+	Committed  int
+	RolledBack int
+}
+
+// ExpectTx lets store run the functions passed to ExecTx against itself, so the
+// statements inside a transaction are matched by the usual expectations. The
+// recorder tells tests whether those transactions committed or rolled back.
+func ExpectTx(store *MockStore) *TxRecorder {
+	recorder := &TxRecorder{}
+	store.EXPECT().
+		ExecTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(db.Querier) error) error {
+			if err := fn(store); err != nil {
+				recorder.RolledBack++
+				return err
+			}
+			recorder.Committed++
+			return nil
+		}).
+		AnyTimes()
+	return recorder
+}
@@ -0,0 +1,40 @@
+-- name: CreateListActivity :exec
+-- Appends an entry to the activity log of a list. The actor's character in the list is
+-- looked up from their membership, zero creature and target user IDs are stored as NULL.
+INSERT INTO list_activity (list_id, actor_id, character_id, action, creature_id, target_user_id, old_value, new_value)
+VALUES (
+    @list_id,
+    @actor_id,
+    (SELECT lu.character_id FROM lists_users lu WHERE lu.list_id = @list_id AND lu.user_id = @actor_id ORDER BY lu.active DESC LIMIT 1),
+    @action,
+    NULLIF(@creature_id::uuid, '00000000-0000-0000-0000-000000000000'::uuid),
+    NULLIF(@target_user_id::uuid, '00000000-0000-0000-0000-000000000000'::uuid),
+    sqlc.narg('old_value'),
+    sqlc.narg('new_value')
+);
+
+-- name: GetListActivity :many
+-- Returns a page of the activity log of a list, newest first. A non-zero member_id only
+-- keeps entries where that member either acted or was acted upon.
+SELECT
+    a.id,
+    a.actor_id,
+    a.character_id,
+    c.name as character_name,
+    a.action,
+    a.creature_id,
+    cr.name as creature_name,
+    a.target_user_id,
+    a.old_value,
+    a.new_value,
+    a.created_at,
+    COUNT(*) OVER() as total_count
+FROM list_activity a
+LEFT JOIN characters c ON c.id = a.character_id
+LEFT JOIN creatures cr ON cr.id = a.creature_id
+WHERE a.list_id = @list_id
+  AND (@member_id::uuid = '00000000-0000-0000-0000-000000000000'::uuid
+       OR a.actor_id = @member_id::uuid
+       OR a.target_user_id = @member_id::uuid)
+ORDER BY a.created_at DESC, a.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
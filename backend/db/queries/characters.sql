@@ -18,13 +18,31 @@ INSERT INTO characters (user_id, name, world)
 VALUES ($1, $2, $3)
 RETURNING *;
 
--- name: AddCharacterSoulcore :exec
+-- name: AddCharacterSoulcore :execrows
+-- Adding a removed soulcore again revives it, adding one the character already has
+-- affects no rows
 INSERT INTO characters_soulcores (character_id, creature_id)
-VALUES ($1, $2);
+VALUES ($1, $2)
+ON CONFLICT (character_id, creature_id) DO UPDATE
+SET deleted_at = NULL, created_at = NOW()
+WHERE characters_soulcores.deleted_at IS NOT NULL;
 
 -- name: RemoveCharacterSoulcore :exec
+-- Leaves a tombstone behind that can be restored until it is purged
+UPDATE characters_soulcores
+SET deleted_at = NOW()
+WHERE character_id = $1 AND creature_id = $2 AND deleted_at IS NULL;
+
+-- name: RestoreCharacterSoulcore :execrows
+UPDATE characters_soulcores
+SET deleted_at = NULL
+WHERE character_id = sqlc.arg('character_id')
+  AND creature_id = sqlc.arg('creature_id')
+  AND deleted_at > sqlc.arg('restorable_since')::timestamptz;
+
+-- name: PurgeRemovedCharacterSoulcores :execrows
 DELETE FROM characters_soulcores
-WHERE character_id = $1 AND creature_id = $2;
+WHERE deleted_at < sqlc.arg('deleted_before')::timestamptz;
 
 -- name: CreateCharacterClaim :one
 INSERT INTO character_claims (character_id, claimer_id, verification_code, status)
@@ -71,11 +89,14 @@ WHERE c.status = 'pending'
   AND c.created_at > NOW() - INTERVAL '24 hours';
 
 -- name: GetCharacterSoulcores :many
-SELECT cs.character_id, cs.creature_id, c.name as creature_name, c.difficulty
+-- localized_name is the creature's name in lang, or its English name without a translation
+SELECT cs.character_id, cs.creature_id, c.name as creature_name, c.difficulty,
+    COALESCE(t.name, c.name)::text AS localized_name
 FROM characters_soulcores cs
 JOIN creatures c ON c.id = cs.creature_id
-WHERE cs.character_id = $1
-ORDER BY c.name;
+LEFT JOIN creature_translations t ON t.creature_id = c.id AND t.language = @lang::text
+WHERE cs.character_id = @character_id AND cs.deleted_at IS NULL
+ORDER BY localized_name, c.name;
 
 -- name: GetHighscoreCharacters :many
 WITH character_cores AS (
@@ -83,6 +104,7 @@ WITH character_cores AS (
         cs.character_id,
         COUNT(cs.creature_id) as core_count
     FROM characters_soulcores cs
+    WHERE cs.deleted_at IS NULL
     GROUP BY cs.character_id
 )
 SELECT 
@@ -31,9 +31,13 @@ JOIN characters c ON lcm.character_id = c.id
 WHERE lcm.list_id = $1 AND lcm.created_at > $2
 ORDER BY lcm.created_at ASC;
 
+-- name: GetChatMessage :one
+SELECT * FROM list_chat_messages
+WHERE id = $1 AND list_id = $2;
+
 -- name: DeleteChatMessage :exec
 DELETE FROM list_chat_messages
-WHERE id = $1 AND user_id = $2;
+WHERE id = $1 AND list_id = $2;
 
 -- name: DeleteAllChatMessages :exec
 DELETE FROM list_chat_messages
@@ -44,7 +48,7 @@ WITH user_lists AS (
     SELECT DISTINCT l.id, l.name
     FROM lists l
     JOIN lists_users lu ON l.id = lu.list_id
-    WHERE lu.user_id = $1
+    WHERE lu.user_id = $1 AND l.deleted_at IS NULL
 ),
 last_read_times AS (
     SELECT user_id, list_id, last_read_at
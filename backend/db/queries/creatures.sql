@@ -1,4 +1,159 @@
 -- name: GetCreatures :many
-SELECT id, name, difficulty
-FROM creatures
-ORDER BY name;
\ No newline at end of file
+-- GetCreatures lists the creatures matching every filter that is set. Class, race and
+-- location are compared ignoring case, location matches any of a creature's locations.
+-- localized_name is the creature's name in lang, or its English name without a translation.
+SELECT c.id, c.name, c.difficulty, c.bestiary_class, c.race, c.charm_points, c.locations, c.sprite,
+    COALESCE(t.name, c.name)::text AS localized_name
+FROM creatures c
+LEFT JOIN creature_translations t ON t.creature_id = c.id AND t.language = @lang::text
+WHERE (sqlc.narg('bestiary_class')::text IS NULL OR lower(c.bestiary_class) = lower(sqlc.narg('bestiary_class')::text))
+    AND (sqlc.narg('race')::text IS NULL OR lower(c.race) = lower(sqlc.narg('race')::text))
+    AND (sqlc.narg('location')::text IS NULL OR EXISTS (
+        SELECT 1 FROM unnest(c.locations) l WHERE lower(l) = lower(sqlc.narg('location')::text)
+    ))
+    AND (sqlc.narg('min_difficulty')::int IS NULL OR c.difficulty >= sqlc.narg('min_difficulty')::int)
+    AND (sqlc.narg('max_difficulty')::int IS NULL OR c.difficulty <= sqlc.narg('max_difficulty')::int)
+ORDER BY localized_name, c.name;
+
+-- name: CountCreatures :one
+SELECT COUNT(*) FROM creatures;
+
+-- name: GetCreatureIDs :many
+-- GetCreatureIDs returns the IDs out of ids that belong to known creatures
+SELECT id FROM creatures
+WHERE id = ANY(@ids::uuid[]);
+
+-- name: GetCreaturesByNames :many
+-- GetCreaturesByNames looks creatures up by name or alias, ignoring case. names must be lower case.
+-- lookup_name is the name out of names a creature was found by.
+SELECT c.id, c.name, lower(c.name)::text AS lookup_name
+FROM creatures c
+WHERE lower(c.name) = ANY(@names::text[])
+UNION ALL
+SELECT c.id, c.name, lower(a.name)::text AS lookup_name
+FROM creature_aliases a
+JOIN creatures c ON c.id = a.creature_id
+WHERE lower(a.name) = ANY(@names::text[])
+    AND NOT EXISTS (SELECT 1 FROM creatures c2 WHERE lower(c2.name) = lower(a.name));
+
+-- name: GetCreatureByName :one
+-- GetCreatureByName looks a creature up by name, ignoring case, falling back to its aliases
+SELECT c.id, c.name, c.difficulty, c.bestiary_class, c.race, c.charm_points, c.locations, c.sprite
+FROM creatures c
+WHERE lower(c.name) = lower(@name)
+    OR c.id = (SELECT a.creature_id FROM creature_aliases a WHERE lower(a.name) = lower(@name))
+ORDER BY lower(c.name) = lower(@name) DESC
+LIMIT 1;
+
+-- name: GetCreatureAliases :many
+SELECT id, creature_id, name, kind, created_at
+FROM creature_aliases
+WHERE creature_id = @creature_id
+ORDER BY created_at, name;
+
+-- name: GetAllCreatureAliases :many
+-- GetAllCreatureAliases returns every alias along with the current name of its creature
+SELECT a.name, c.name AS creature_name
+FROM creature_aliases a
+JOIN creatures c ON c.id = a.creature_id
+ORDER BY a.name;
+
+-- name: GetAllCreatureTranslations :many
+-- GetAllCreatureTranslations returns every translation along with the name of its creature
+SELECT t.language, c.name AS creature_name, t.name
+FROM creature_translations t
+JOIN creatures c ON c.id = t.creature_id
+ORDER BY t.language, c.name;
+
+-- name: UpsertCreatureTranslation :exec
+INSERT INTO creature_translations (creature_id, language, name)
+SELECT c.id, @language::text, @name::text
+FROM creatures c
+WHERE c.name = @creature_name
+ON CONFLICT (creature_id, language) DO UPDATE SET name = EXCLUDED.name;
+
+-- name: DeleteCreatureTranslation :exec
+DELETE FROM creature_translations
+WHERE language = @language
+    AND creature_id = (SELECT c.id FROM creatures c WHERE c.name = @creature_name);
+
+-- name: CreateCreature :exec
+INSERT INTO creatures (name, difficulty)
+VALUES (@name, @difficulty);
+
+-- name: RenameCreature :exec
+-- RenameCreature renames a creature and keeps its old name as an alias
+SELECT rename_creature(@old_name::text, @new_name::text);
+
+-- name: UpdateCreatureDifficulty :exec
+UPDATE creatures
+SET difficulty = @difficulty
+WHERE name = @name;
+
+-- name: UpdateCreatureBestiary :exec
+UPDATE creatures
+SET bestiary_class = @bestiary_class,
+    race = @race,
+    charm_points = @charm_points,
+    locations = @locations,
+    sprite = @sprite
+WHERE name = @name;
+
+-- name: DeleteCreature :execrows
+-- DeleteCreature fails for creatures that are still referenced, those have to be merged instead
+DELETE FROM creatures
+WHERE name = @name;
+
+-- name: SearchCreatures :many
+-- SearchCreatures ranks the creatures whose name or an alias matches query: exact matches
+-- first, then prefix matches, then matches at the start of a word, then anything else
+-- containing query or similar to it, by trigram similarity. query must be lower case.
+-- matched_alias is the alias a creature was found by when it matched better than its name.
+-- Zero character and list IDs leave out the not yet unlocked and not yet in list filters.
+WITH q AS (
+    SELECT @query::text AS query,
+        replace(replace(replace(@query::text, '\', '\\'), '%', '\%'), '_', '\_') AS pattern
+),
+matches AS (
+    SELECT c.id AS creature_id, c.name AS matched_name, false AS is_alias
+    FROM creatures c, q
+    WHERE lower(c.name) LIKE '%' || q.pattern || '%' OR lower(c.name) % q.query
+    UNION ALL
+    SELECT a.creature_id, a.name, true
+    FROM creature_aliases a, q
+    WHERE lower(a.name) LIKE '%' || q.pattern || '%' OR lower(a.name) % q.query
+),
+scored AS (
+    SELECT DISTINCT ON (m.creature_id)
+        m.creature_id,
+        m.matched_name,
+        m.is_alias,
+        (CASE
+            WHEN lower(m.matched_name) = q.query THEN 3
+            WHEN lower(m.matched_name) LIKE q.pattern || '%' THEN 2
+            WHEN lower(m.matched_name) LIKE '% ' || q.pattern || '%' THEN 1
+            ELSE 0
+        END + similarity(lower(m.matched_name), q.query))::float8 AS score
+    FROM matches m, q
+    ORDER BY m.creature_id, score DESC, m.is_alias
+)
+SELECT
+    c.id,
+    c.name,
+    c.difficulty,
+    (CASE WHEN s.is_alias THEN s.matched_name END)::text AS matched_alias,
+    s.score
+FROM scored s
+JOIN creatures c ON c.id = s.creature_id
+WHERE (sqlc.narg('min_difficulty')::int IS NULL OR c.difficulty >= sqlc.narg('min_difficulty')::int)
+    AND (sqlc.narg('max_difficulty')::int IS NULL OR c.difficulty <= sqlc.narg('max_difficulty')::int)
+    AND (@character_id::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR NOT EXISTS (
+        SELECT 1 FROM characters_soulcores cs
+        WHERE cs.character_id = @character_id AND cs.creature_id = c.id AND cs.deleted_at IS NULL
+    ))
+    AND (@list_id::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR NOT EXISTS (
+        SELECT 1 FROM lists_soulcores ls
+        WHERE ls.list_id = @list_id AND ls.creature_id = c.id AND ls.deleted_at IS NULL
+    ))
+ORDER BY s.score DESC, c.name
+LIMIT sqlc.arg('limit');
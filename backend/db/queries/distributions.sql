@@ -0,0 +1,25 @@
+-- name: CreateListDistribution :one
+INSERT INTO list_distributions (list_id, created_by, mode, seed, assignments, reserved)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetListDistributions :many
+-- Returns a page of the distributions of a list, newest first
+SELECT
+    d.id,
+    d.list_id,
+    d.created_by,
+    d.mode,
+    d.seed,
+    d.assignments,
+    d.reserved,
+    d.created_at,
+    COUNT(*) OVER() as total_count
+FROM list_distributions d
+WHERE d.list_id = $1
+ORDER BY d.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetListDistribution :one
+SELECT * FROM list_distributions
+WHERE id = $1 AND list_id = $2;
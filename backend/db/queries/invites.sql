@@ -0,0 +1,32 @@
+-- name: CreateListInvite :one
+INSERT INTO list_invites (list_id, created_by, expires_at, max_uses)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetListInvites :many
+SELECT * FROM list_invites
+WHERE list_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetListInvite :one
+SELECT * FROM list_invites
+WHERE id = $1 AND list_id = $2;
+
+-- name: GetListInviteByCode :one
+SELECT li.* FROM list_invites li
+JOIN lists l ON l.id = li.list_id
+WHERE li.code = $1 AND l.deleted_at IS NULL;
+
+-- name: RevokeListInvite :execrows
+UPDATE list_invites
+SET revoked_at = NOW()
+WHERE id = $1 AND list_id = $2 AND revoked_at IS NULL;
+
+-- name: UseListInvite :execrows
+-- Counts a use only while the invite is still valid, so concurrent joins cannot exceed max_uses
+UPDATE list_invites
+SET use_count = use_count + 1
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+  AND (max_uses IS NULL OR use_count < max_uses);
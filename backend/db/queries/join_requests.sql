@@ -0,0 +1,32 @@
+-- name: CreateListJoinRequest :exec
+INSERT INTO list_join_requests (list_id, user_id, character_id)
+VALUES ($1, $2, $3);
+
+-- name: GetListJoinRequest :one
+SELECT * FROM list_join_requests
+WHERE list_id = $1 AND user_id = $2;
+
+-- name: GetListJoinRequests :many
+SELECT r.list_id, r.user_id, r.character_id, c.name AS character_name, r.created_at
+FROM list_join_requests r
+JOIN characters c ON c.id = r.character_id
+WHERE r.list_id = $1
+ORDER BY r.created_at;
+
+-- name: ApproveListJoinRequest :one
+-- Turns a pending request into a membership in one statement. No row is returned
+-- when the request is gone or its character changed hands in the meantime.
+WITH approved AS (
+    DELETE FROM list_join_requests r
+    WHERE r.list_id = $1 AND r.user_id = $2
+    RETURNING r.list_id, r.user_id, r.character_id
+)
+INSERT INTO lists_users (list_id, user_id, character_id, role)
+SELECT a.list_id, a.user_id, a.character_id, 'member'
+FROM approved a
+JOIN characters c ON c.id = a.character_id AND c.user_id = a.user_id
+RETURNING character_id;
+
+-- name: DeleteListJoinRequest :execrows
+DELETE FROM list_join_requests
+WHERE list_id = $1 AND user_id = $2;
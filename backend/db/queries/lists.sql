@@ -4,12 +4,12 @@ VALUES ($1, $2, $3)
 RETURNING *;
 
 -- name: AddListCharacter :exec
-INSERT INTO lists_users (list_id, user_id, character_id)
-VALUES ($1, $2, $3);
+INSERT INTO lists_users (list_id, user_id, character_id, role)
+VALUES ($1, $2, $3, $4);
 
 -- name: GetList :one
 SELECT * FROM lists
-WHERE id = $1;
+WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetMembers :many
 SELECT * FROM lists_users
@@ -17,11 +17,11 @@ WHERE list_id = $1;
 
 -- name: GetListsByAuthorId :many
 SELECT * FROM lists
-WHERE author_id = $1;
+WHERE author_id = $1 AND deleted_at IS NULL;
 
 -- name: GetListByShareCode :one
 SELECT * FROM lists
-WHERE share_code = $1;
+WHERE share_code = $1 AND share_code_enabled = true AND deleted_at IS NULL;
 
 -- name: IsUserListMember :one
 SELECT EXISTS (
@@ -31,18 +31,29 @@ SELECT EXISTS (
 ) as is_member;
 
 -- name: GetListMembers :many
+-- One row per character. Soulcores are credited to the user, so obtained_count and
+-- unlocked_count are shared by all of a user's characters while character_unlocked_count
+-- counts the cores the character itself unlocked.
 SELECT 
   u.id as user_id,
+  c.id as character_id,
   c.name as character_name,
-  COUNT(DISTINCT CASE WHEN ls.status = 'obtained' OR ls.status = 'unlocked' THEN ls.creature_id END) as obtained_count,
+  COUNT(DISTINCT CASE WHEN ls.status <> 'wanted' THEN ls.creature_id END) as obtained_count,
   COUNT(DISTINCT CASE WHEN ls.status = 'unlocked' THEN ls.creature_id END) as unlocked_count,
-  lu.active as is_active
+  (
+    SELECT COUNT(*) FROM characters_soulcores cs
+    WHERE cs.character_id = c.id AND cs.deleted_at IS NULL
+      AND creature_in_list_scope($1, cs.creature_id)
+  ) as character_unlocked_count,
+  lu.active as is_active,
+  lu.role
 FROM lists_users lu
 JOIN users u ON lu.user_id = u.id
 JOIN characters c ON lu.character_id = c.id
-LEFT JOIN lists_soulcores ls ON ls.list_id = $1 AND ls.added_by_user_id = u.id
+LEFT JOIN lists_soulcores ls ON ls.list_id = $1 AND ls.added_by_user_id = u.id AND ls.deleted_at IS NULL
+    AND creature_in_list_scope($1, ls.creature_id)
 WHERE lu.list_id = $1
-GROUP BY u.id, c.name, lu.active;
+GROUP BY u.id, c.id, c.name, lu.active, lu.role;
 
 -- name: GetListMembersWithUnlocks :many
 WITH member_unlocks AS (
@@ -56,6 +67,7 @@ WITH member_unlocks AS (
         ) as unlocked_creatures
     FROM characters_soulcores cs
     JOIN creatures c ON c.id = cs.creature_id
+    WHERE cs.deleted_at IS NULL
     GROUP BY cs.character_id
 )
 SELECT 
@@ -63,31 +75,53 @@ SELECT
     c.id as character_id,
     c.name as character_name,
     COALESCE(mu.unlocked_creatures, '[]'::jsonb) as unlocked_creatures,
-    COUNT(DISTINCT CASE WHEN ls.status = 'obtained' OR ls.status = 'unlocked' THEN ls.creature_id END) as obtained_count,
+    COUNT(DISTINCT CASE WHEN ls.status <> 'wanted' THEN ls.creature_id END) as obtained_count,
     COUNT(DISTINCT CASE WHEN ls.status = 'unlocked' THEN ls.creature_id END) as unlocked_count,
-    lu.active as is_active
+    lu.active as is_active,
+    lu.role
 FROM lists_users lu 
 JOIN users u ON lu.user_id = u.id
 JOIN characters c ON lu.character_id = c.id
-LEFT JOIN lists_soulcores ls ON ls.list_id = $1
+LEFT JOIN lists_soulcores ls ON ls.list_id = $1 AND ls.deleted_at IS NULL
+    AND creature_in_list_scope($1, ls.creature_id)
 LEFT JOIN member_unlocks mu ON mu.character_id = c.id
 WHERE lu.list_id = $1
-GROUP BY u.id, c.id, c.name, lu.active, mu.unlocked_creatures;
+GROUP BY u.id, c.id, c.name, lu.active, lu.role, mu.unlocked_creatures;
+
+-- name: GetListMemberUnlocks :many
+-- The soulcores the characters of a list's members unlocked, one row per character and
+-- creature. in_scope tells whether the creature is in the list's scope.
+SELECT lu.character_id, c.id AS creature_id, c.name AS creature_name,
+    creature_in_list_scope($1, c.id)::bool AS in_scope
+FROM lists_users lu
+JOIN characters_soulcores cs ON cs.character_id = lu.character_id AND cs.deleted_at IS NULL
+JOIN creatures c ON c.id = cs.creature_id
+WHERE lu.list_id = $1
+ORDER BY lu.character_id, c.name;
 
 -- name: GetListSoulcores :many
+-- localized_name is the creature's name in lang, or its English name without a translation
 SELECT 
   ls.list_id,
   ls.creature_id,
   ls.status,
   cr.name as creature_name,
   c.name as added_by,
-  ls.added_by_user_id
+  ls.added_by_user_id,
+  ls.reserved_for_user_id,
+  COALESCE(t.name, cr.name)::text AS localized_name
 FROM lists_soulcores ls
 JOIN creatures cr ON ls.creature_id = cr.id
-LEFT JOIN lists_users lu ON ls.list_id = lu.list_id AND ls.added_by_user_id = lu.user_id
-LEFT JOIN characters c ON lu.character_id = c.id
-WHERE ls.list_id = $1
-ORDER BY cr.name;
+LEFT JOIN creature_translations t ON t.creature_id = cr.id AND t.language = @lang::text
+LEFT JOIN LATERAL (
+    SELECT ch.name FROM lists_users lu
+    JOIN characters ch ON ch.id = lu.character_id
+    WHERE lu.list_id = ls.list_id AND lu.user_id = ls.added_by_user_id
+    ORDER BY lu.active DESC, ch.name
+    LIMIT 1
+) c ON true
+WHERE ls.list_id = @list_id AND ls.deleted_at IS NULL
+ORDER BY localized_name, cr.name;
 
 -- name: GetListSoulcore :one
 SELECT 
@@ -96,29 +130,324 @@ SELECT
   ls.status,
   cr.name as creature_name,
   c.name as added_by,
-  ls.added_by_user_id
+  ls.added_by_user_id,
+  ls.reserved_for_user_id
 FROM lists_soulcores ls
 JOIN creatures cr ON ls.creature_id = cr.id
-LEFT JOIN lists_users lu ON ls.list_id = lu.list_id AND ls.added_by_user_id = lu.user_id
-LEFT JOIN characters c ON lu.character_id = c.id
-WHERE ls.list_id = $1 AND ls.creature_id = $2;
+LEFT JOIN LATERAL (
+    SELECT ch.name FROM lists_users lu
+    JOIN characters ch ON ch.id = lu.character_id
+    WHERE lu.list_id = ls.list_id AND lu.user_id = ls.added_by_user_id
+    ORDER BY lu.active DESC, ch.name
+    LIMIT 1
+) c ON true
+WHERE ls.list_id = $1 AND ls.creature_id = $2 AND ls.deleted_at IS NULL;
+
+-- name: GetListHuntCandidates :many
+-- Returns every creature in the scope of a list that an active, non-viewer member still has
+-- to unlock, with the members who need it and the status of the list's own soulcore, if any
+SELECT
+    cr.id as creature_id,
+    cr.name as creature_name,
+    cr.difficulty,
+    ls.status as list_status,
+    jsonb_agg(
+        jsonb_build_object(
+            'user_id', lu.user_id,
+            'character_id', c.id,
+            'character_name', c.name
+        ) ORDER BY c.name
+    ) as needed_by
+FROM creatures cr
+JOIN lists_users lu ON lu.list_id = $1 AND lu.active AND lu.role <> 'viewer'
+JOIN characters c ON c.id = lu.character_id
+LEFT JOIN lists_soulcores ls ON ls.list_id = lu.list_id AND ls.creature_id = cr.id AND ls.deleted_at IS NULL
+WHERE creature_in_list_scope($1, cr.id)
+    AND NOT EXISTS (
+        SELECT 1 FROM characters_soulcores cs
+        WHERE cs.character_id = c.id AND cs.creature_id = cr.id AND cs.deleted_at IS NULL
+    )
+GROUP BY cr.id, cr.name, cr.difficulty, ls.status;
 
 -- name: AddSoulcoreToList :exec
-INSERT INTO lists_soulcores (list_id, creature_id, status, added_by_user_id)
-VALUES ($1, $2, $3, $4)
+-- A zero reserved_for_user_id is stored as NULL
+INSERT INTO lists_soulcores (list_id, creature_id, status, added_by_user_id, reserved_for_user_id)
+VALUES (@list_id, @creature_id, @status, @added_by_user_id, NULLIF(@reserved_for_user_id::uuid, '00000000-0000-0000-0000-000000000000'::uuid))
 ON CONFLICT (list_id, creature_id) DO UPDATE
-SET status = EXCLUDED.status, added_by_user_id = EXCLUDED.added_by_user_id;
+SET status = EXCLUDED.status,
+    added_by_user_id = EXCLUDED.added_by_user_id,
+    reserved_for_user_id = EXCLUDED.reserved_for_user_id,
+    deleted_at = NULL;
+
+-- name: AddSoulcoreToListIfMissing :execrows
+-- AddSoulcoreToListIfMissing adds a soulcore unless the list already has it, which
+-- leaves the existing entry untouched and affects no rows. Removed entries are replaced.
+INSERT INTO lists_soulcores (list_id, creature_id, status, added_by_user_id, reserved_for_user_id)
+VALUES (@list_id, @creature_id, @status, @added_by_user_id, NULLIF(@reserved_for_user_id::uuid, '00000000-0000-0000-0000-000000000000'::uuid))
+ON CONFLICT (list_id, creature_id) DO UPDATE
+SET status = EXCLUDED.status,
+    added_by_user_id = EXCLUDED.added_by_user_id,
+    reserved_for_user_id = EXCLUDED.reserved_for_user_id,
+    deleted_at = NULL
+WHERE lists_soulcores.deleted_at IS NOT NULL;
+
+-- name: UpdateSoulcoreStatus :execrows
+-- Sets the status along with the member a reserved core is earmarked for, a zero
+-- reserved_for_user_id clears the reservation. Only a soulcore still in from_status
+-- changes, so a status changed in the meantime affects no rows.
+UPDATE lists_soulcores
+SET status = @status,
+    reserved_for_user_id = NULLIF(@reserved_for_user_id::uuid, '00000000-0000-0000-0000-000000000000'::uuid)
+WHERE list_id = @list_id AND creature_id = @creature_id AND status = @from_status AND deleted_at IS NULL;
 
--- name: UpdateSoulcoreStatus :exec
+-- name: ReserveObtainedSoulcore :execrows
+-- ReserveObtainedSoulcore reserves a soulcore for a member only while it is still obtained,
+-- so a core unlocked or traded in the meantime affects no rows
 UPDATE lists_soulcores
-SET status = $3
-WHERE list_id = $1 AND creature_id = $2;
+SET status = 'reserved',
+    reserved_for_user_id = @reserved_for_user_id::uuid
+WHERE list_id = @list_id AND creature_id = @creature_id AND status = 'obtained' AND deleted_at IS NULL;
 
 -- name: RemoveListSoulcore :exec
+-- Only marks the soulcore as removed, so it can be restored until the removal is purged
+UPDATE lists_soulcores
+SET deleted_at = NOW()
+WHERE list_id = $1 AND creature_id = $2 AND deleted_at IS NULL;
+
+-- name: GetRemovedListSoulcore :one
+SELECT 
+  ls.list_id,
+  ls.creature_id,
+  ls.status,
+  cr.name as creature_name,
+  c.name as added_by,
+  ls.added_by_user_id,
+  ls.reserved_for_user_id
+FROM lists_soulcores ls
+JOIN creatures cr ON ls.creature_id = cr.id
+LEFT JOIN LATERAL (
+    SELECT ch.name FROM lists_users lu
+    JOIN characters ch ON ch.id = lu.character_id
+    WHERE lu.list_id = ls.list_id AND lu.user_id = ls.added_by_user_id
+    ORDER BY lu.active DESC, ch.name
+    LIMIT 1
+) c ON true
+WHERE ls.list_id = sqlc.arg('list_id')
+  AND ls.creature_id = sqlc.arg('creature_id')
+  AND ls.deleted_at > sqlc.arg('restorable_since')::timestamptz;
+
+-- name: RestoreListSoulcore :exec
+UPDATE lists_soulcores
+SET deleted_at = NULL
+WHERE list_id = $1 AND creature_id = $2 AND deleted_at IS NOT NULL;
+
+-- name: PurgeRemovedListSoulcores :execrows
 DELETE FROM lists_soulcores
-WHERE list_id = $1 AND creature_id = $2;
+WHERE deleted_at < sqlc.arg('deleted_before')::timestamptz;
 
 -- name: DeactivateCharacterListMemberships :exec
 UPDATE lists_users
 SET active = false
 WHERE character_id = $1;
+
+-- name: GetCharacterListIDs :many
+SELECT list_id FROM lists_users
+WHERE character_id = $1;
+
+-- name: GetListMemberRole :one
+-- Users with several characters in a list get their highest role
+SELECT lu.role FROM lists_users lu
+JOIN lists l ON l.id = lu.list_id
+WHERE lu.list_id = $1 AND lu.user_id = $2 AND lu.active = true AND l.deleted_at IS NULL
+ORDER BY lu.role
+LIMIT 1;
+
+-- name: UpdateListMemberRole :execrows
+UPDATE lists_users
+SET role = $3
+WHERE list_id = $1 AND user_id = $2;
+
+-- name: RemoveListMember :many
+-- Removes every character of the user from the list together with the
+-- soulcore suggestions the list generated for them
+WITH removed AS (
+    DELETE FROM lists_users
+    WHERE list_id = $1 AND user_id = $2
+    RETURNING character_id
+), cleared_suggestions AS (
+    DELETE FROM character_soulcore_suggestions
+    WHERE list_id = $1 AND character_id IN (SELECT character_id FROM removed)
+)
+SELECT character_id FROM removed;
+
+-- name: GetListUserCharacters :many
+-- Every character the user takes part in the list with
+SELECT lu.character_id, c.name as character_name, lu.active, lu.role
+FROM lists_users lu
+JOIN characters c ON c.id = lu.character_id
+WHERE lu.list_id = $1 AND lu.user_id = $2
+ORDER BY c.name;
+
+-- name: SwapListCharacter :execrows
+-- Puts another character of the user in place of one of theirs, keeping the role. Soulcores
+-- are credited to the user, so their contributions stay. Suggestions the list made for the
+-- replaced character are cleared.
+WITH cleared_suggestions AS (
+    DELETE FROM character_soulcore_suggestions
+    WHERE list_id = @list_id AND character_id = @character_id
+)
+UPDATE lists_users
+SET character_id = @new_character_id, active = true
+WHERE list_id = @list_id AND user_id = @user_id AND character_id = @character_id;
+
+-- name: RemoveListCharacter :execrows
+-- Removes a single character of the user from the list with the suggestions made for it
+WITH cleared_suggestions AS (
+    DELETE FROM character_soulcore_suggestions
+    WHERE list_id = $1 AND character_id = $3
+)
+DELETE FROM lists_users
+WHERE list_id = $1 AND user_id = $2 AND character_id = $3;
+
+-- name: CreateListRemoval :exec
+INSERT INTO list_removed_members (list_id, user_id, removed_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (list_id, user_id) DO UPDATE
+SET removed_by = EXCLUDED.removed_by, removed_at = NOW();
+
+-- name: DeleteListRemoval :execrows
+DELETE FROM list_removed_members
+WHERE list_id = $1 AND user_id = $2;
+
+-- name: IsUserRemovedFromList :one
+SELECT EXISTS (
+  SELECT 1
+  FROM list_removed_members
+  WHERE list_id = $1 AND user_id = $2
+) as is_removed;
+
+-- name: GetListRemovedMembers :many
+SELECT
+  lr.user_id,
+  lr.removed_by,
+  lr.removed_at,
+  COALESCE(string_agg(c.name, ', ' ORDER BY c.name), '')::text as character_names
+FROM list_removed_members lr
+JOIN lists l ON l.id = lr.list_id
+LEFT JOIN characters c ON c.user_id = lr.user_id AND c.world = l.world
+WHERE lr.list_id = $1
+GROUP BY lr.user_id, lr.removed_by, lr.removed_at
+ORDER BY lr.removed_at DESC;
+
+-- name: TransferListOwnership :execrows
+-- Moves author_id and the owner role in one statement, the previous owner stays as moderator
+WITH updated_list AS (
+    UPDATE lists
+    SET author_id = $2, updated_at = NOW()
+    WHERE id = $1
+    RETURNING id
+)
+UPDATE lists_users
+SET role = CASE WHEN user_id = $2 THEN 'owner'::list_role ELSE 'moderator'::list_role END
+WHERE list_id = (SELECT id FROM updated_list)
+  AND (user_id = $2 OR role = 'owner');
+
+-- name: UpdateList :one
+UPDATE lists
+SET name = COALESCE(sqlc.narg('name'), name),
+    world = COALESCE(sqlc.narg('world'), world),
+    approval_required = COALESCE(sqlc.narg('approval_required'), approval_required),
+    visibility = COALESCE(sqlc.narg('visibility'), visibility),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: CountListMembersOutsideWorld :one
+SELECT COUNT(DISTINCT lu.user_id)
+FROM lists_users lu
+JOIN characters c ON c.id = lu.character_id
+WHERE lu.list_id = $1 AND lu.active = true AND c.world <> $2;
+
+-- name: SoftDeleteList :execrows
+UPDATE lists
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreList :one
+-- Lists that were merged into another list cannot be restored
+UPDATE lists
+SET deleted_at = NULL, updated_at = NOW()
+WHERE id = sqlc.arg('id')
+  AND author_id = sqlc.arg('author_id')
+  AND deleted_at > sqlc.arg('restorable_since')::timestamptz
+  AND NOT EXISTS (SELECT 1 FROM list_share_redirects r WHERE r.source_list_id = lists.id)
+RETURNING *;
+
+-- name: PurgeDeletedLists :many
+-- Removes lists deleted before the cutoff together with every row that references them
+WITH purged AS (
+    SELECT id FROM lists
+    WHERE deleted_at < sqlc.arg('deleted_before')::timestamptz
+    FOR UPDATE
+), suggestions AS (
+    DELETE FROM character_soulcore_suggestions WHERE list_id IN (SELECT id FROM purged)
+), read_status AS (
+    DELETE FROM list_user_read_status WHERE list_id IN (SELECT id FROM purged)
+), chat_messages AS (
+    DELETE FROM list_chat_messages WHERE list_id IN (SELECT id FROM purged)
+), soulcores AS (
+    DELETE FROM lists_soulcores WHERE list_id IN (SELECT id FROM purged)
+), members AS (
+    DELETE FROM lists_users WHERE list_id IN (SELECT id FROM purged)
+), removed_members AS (
+    DELETE FROM list_removed_members WHERE list_id IN (SELECT id FROM purged)
+), invites AS (
+    DELETE FROM list_invites WHERE list_id IN (SELECT id FROM purged)
+), join_requests AS (
+    DELETE FROM list_join_requests WHERE list_id IN (SELECT id FROM purged)
+), activity AS (
+    DELETE FROM list_activity WHERE list_id IN (SELECT id FROM purged)
+), distributions AS (
+    DELETE FROM list_distributions WHERE list_id IN (SELECT id FROM purged)
+), scope_creatures AS (
+    DELETE FROM list_scope_creatures WHERE list_id IN (SELECT id FROM purged)
+), scopes AS (
+    DELETE FROM list_scopes WHERE list_id IN (SELECT id FROM purged)
+), redirects AS (
+    DELETE FROM list_share_redirects WHERE list_id IN (SELECT id FROM purged)
+), reactivations AS (
+    DELETE FROM list_reactivations WHERE list_id IN (SELECT id FROM purged)
+)
+DELETE FROM lists
+WHERE id IN (SELECT id FROM purged)
+RETURNING id;
+
+-- name: RotateListShareCode :one
+UPDATE lists
+SET share_code = gen_random_uuid(), share_code_enabled = true, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DisableListShareCode :execrows
+UPDATE lists
+SET share_code_enabled = false, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetPublicLists :many
+SELECT
+    l.id,
+    l.name,
+    l.world,
+    l.share_code,
+    l.share_code_enabled,
+    l.approval_required,
+    l.created_at,
+    (SELECT COUNT(DISTINCT lu.user_id) FROM lists_users lu WHERE lu.list_id = l.id AND lu.active = true) as member_count,
+    (SELECT COUNT(*) FROM lists_soulcores ls WHERE ls.list_id = l.id AND ls.status <> 'wanted' AND ls.deleted_at IS NULL AND creature_in_list_scope(l.id, ls.creature_id)) as obtained_count,
+    (SELECT COUNT(*) FROM lists_soulcores ls WHERE ls.list_id = l.id AND ls.status = 'unlocked' AND ls.deleted_at IS NULL AND creature_in_list_scope(l.id, ls.creature_id)) as unlocked_count,
+    (SELECT COUNT(*) FROM creatures cr WHERE creature_in_list_scope(l.id, cr.id)) as scope_size,
+    COUNT(*) OVER() as total_count
+FROM lists l
+WHERE l.world = $1 AND l.visibility = 'public' AND l.deleted_at IS NULL
+ORDER BY unlocked_count DESC, l.created_at DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,86 @@
+-- name: CloneListMembers :execrows
+-- Copies the active members of a list whose character is on the clone's world, except the user
+-- cloning it who already owns the clone. The source owner joins the clone as a moderator.
+INSERT INTO lists_users (list_id, user_id, character_id, role)
+SELECT @target_list_id::uuid, lu.user_id, lu.character_id,
+    CASE WHEN lu.role = 'owner' THEN 'moderator'::list_role ELSE lu.role END
+FROM lists_users lu
+JOIN characters c ON c.id = lu.character_id
+WHERE lu.list_id = @source_list_id
+  AND lu.active = true
+  AND lu.user_id <> @cloned_by::uuid
+  AND c.world = @world::text
+ON CONFLICT DO NOTHING;
+
+-- name: CloneListSoulcores :execrows
+-- Copies the soulcores of a list into its clone. Cores added by users who are not in the
+-- clone are credited to the user cloning it, reservations for them are released.
+INSERT INTO lists_soulcores (list_id, creature_id, status, added_by_user_id, reserved_for_user_id)
+SELECT @target_list_id::uuid, ls.creature_id,
+    CASE WHEN ls.status = 'reserved' AND reserved.user_id IS NULL THEN 'obtained'::soulcore_status ELSE ls.status END,
+    COALESCE(added.user_id, @cloned_by::uuid),
+    reserved.user_id
+FROM lists_soulcores ls
+LEFT JOIN (SELECT DISTINCT user_id FROM lists_users WHERE list_id = @target_list_id::uuid) added
+    ON added.user_id = ls.added_by_user_id
+LEFT JOIN (SELECT DISTINCT user_id FROM lists_users WHERE list_id = @target_list_id::uuid) reserved
+    ON reserved.user_id = ls.reserved_for_user_id
+WHERE ls.list_id = @source_list_id AND ls.deleted_at IS NULL;
+
+-- name: MergeListMembers :execrows
+-- Moves the members of the source list into the target list. Users who are already in the
+-- target or were removed from it are left out, the source owner joins as a moderator.
+INSERT INTO lists_users (list_id, user_id, character_id, active, role)
+SELECT @target_list_id::uuid, lu.user_id, lu.character_id, lu.active,
+    CASE WHEN lu.role = 'owner' THEN 'moderator'::list_role ELSE lu.role END
+FROM lists_users lu
+WHERE lu.list_id = @source_list_id
+  AND NOT EXISTS (
+      SELECT 1 FROM lists_users t
+      WHERE t.list_id = @target_list_id::uuid AND t.user_id = lu.user_id
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM list_removed_members r
+      WHERE r.list_id = @target_list_id::uuid AND r.user_id = lu.user_id
+  )
+ON CONFLICT DO NOTHING;
+
+-- name: MoveListChatMessages :execrows
+UPDATE list_chat_messages
+SET list_id = @target_list_id
+WHERE list_id = @source_list_id;
+
+-- name: MoveListSoulcoreSuggestions :exec
+UPDATE character_soulcore_suggestions
+SET list_id = @target_list_id
+WHERE list_id = @source_list_id;
+
+-- name: ReleaseListReservations :execrows
+-- Releases the reservations of a list made for users who are not in it, the soulcores go
+-- back to obtained so the members can reserve them again.
+UPDATE lists_soulcores ls
+SET status = 'obtained', reserved_for_user_id = NULL
+WHERE ls.list_id = $1
+  AND ls.status = 'reserved'
+  AND ls.deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM lists_users lu
+      WHERE lu.list_id = ls.list_id AND lu.user_id = ls.reserved_for_user_id
+  );
+
+-- name: CreateListShareRedirect :exec
+INSERT INTO list_share_redirects (share_code, source_list_id, list_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (share_code) DO UPDATE SET list_id = EXCLUDED.list_id;
+
+-- name: MoveListShareRedirects :exec
+-- Points the redirects leading to the source list at the target list instead
+UPDATE list_share_redirects
+SET list_id = @target_list_id
+WHERE list_id = @source_list_id;
+
+-- name: GetListByRedirectCode :one
+-- Resolves the share code of a merged list to the list it was merged into
+SELECT l.* FROM list_share_redirects r
+JOIN lists l ON l.id = r.list_id
+WHERE r.share_code = $1 AND l.share_code_enabled = true AND l.deleted_at IS NULL;
@@ -0,0 +1,59 @@
+-- name: ReassignListReactivations :exec
+-- Hands the reactivations still pending for a character over to its newest owner
+UPDATE list_reactivations
+SET user_id = $2, created_at = NOW()
+WHERE character_id = $1;
+
+-- name: CreateListReactivations :many
+-- Offers the active list memberships of a character to the user claiming it. Runs before
+-- the memberships are deactivated.
+INSERT INTO list_reactivations (list_id, character_id, previous_user_id, user_id)
+SELECT lu.list_id, lu.character_id, lu.user_id, @user_id::uuid
+FROM lists_users lu
+JOIN lists l ON l.id = lu.list_id
+WHERE lu.character_id = @character_id AND lu.active = true AND l.deleted_at IS NULL
+ON CONFLICT (list_id, character_id) DO UPDATE
+SET previous_user_id = EXCLUDED.previous_user_id, user_id = EXCLUDED.user_id, created_at = NOW()
+RETURNING list_id, previous_user_id;
+
+-- name: GetUserListReactivations :many
+SELECT r.list_id, l.name AS list_name, l.world, r.character_id, c.name AS character_name, r.created_at
+FROM list_reactivations r
+JOIN lists l ON l.id = r.list_id
+JOIN characters c ON c.id = r.character_id
+WHERE r.user_id = $1 AND l.deleted_at IS NULL
+ORDER BY r.created_at DESC;
+
+-- name: GetListReactivations :many
+SELECT r.character_id, c.name AS character_name, r.previous_user_id, r.user_id, r.created_at
+FROM list_reactivations r
+JOIN characters c ON c.id = r.character_id
+WHERE r.list_id = $1
+ORDER BY r.created_at DESC;
+
+-- name: AcceptListReactivation :one
+-- Turns a pending reactivation into a membership of the new owner in one statement. The
+-- character takes the user's role when they are in the list already. No row is returned
+-- when the reactivation is gone, the list was deleted or the character changed hands again.
+WITH accepted AS (
+    DELETE FROM list_reactivations r
+    WHERE r.list_id = $1 AND r.character_id = $2 AND r.user_id = $3
+    RETURNING r.list_id, r.character_id, r.user_id
+)
+INSERT INTO lists_users (list_id, user_id, character_id, role)
+SELECT a.list_id, a.user_id, a.character_id,
+    COALESCE((
+        SELECT lu.role FROM lists_users lu
+        WHERE lu.list_id = a.list_id AND lu.user_id = a.user_id AND lu.active = true
+        ORDER BY lu.role
+        LIMIT 1
+    ), 'member')
+FROM accepted a
+JOIN lists l ON l.id = a.list_id AND l.deleted_at IS NULL
+JOIN characters c ON c.id = a.character_id AND c.user_id = a.user_id
+ON CONFLICT (list_id, user_id, character_id) DO UPDATE SET active = true
+RETURNING role;
+
+-- name: DeleteListReactivation :execrows
+DELETE FROM list_reactivations
+WHERE list_id = $1 AND character_id = $2 AND user_id = $3;
@@ -0,0 +1,53 @@
+-- name: GetListScope :one
+SELECT * FROM list_scopes
+WHERE list_id = $1;
+
+-- name: UpsertListScope :one
+INSERT INTO list_scopes (list_id, min_difficulty, max_difficulty, target_date)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (list_id) DO UPDATE
+SET min_difficulty = EXCLUDED.min_difficulty,
+    max_difficulty = EXCLUDED.max_difficulty,
+    target_date = EXCLUDED.target_date,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetListScopeCreatures :many
+SELECT creature_id, excluded FROM list_scope_creatures
+WHERE list_id = $1
+ORDER BY creature_id;
+
+-- name: DeleteListScopeCreatures :exec
+DELETE FROM list_scope_creatures
+WHERE list_id = $1;
+
+-- name: AddListScopeCreatures :exec
+INSERT INTO list_scope_creatures (list_id, creature_id, excluded)
+SELECT DISTINCT @list_id::uuid, unnest(@creature_ids::uuid[]), @excluded::boolean
+ON CONFLICT (list_id, creature_id) DO UPDATE SET excluded = EXCLUDED.excluded;
+
+-- name: GetListProgress :one
+-- Counts the creatures in the scope of a list and how many of them the list obtained and unlocked
+SELECT
+    (SELECT COUNT(*) FROM creatures cr WHERE creature_in_list_scope(@list_id::uuid, cr.id)) as scope_size,
+    COUNT(*) FILTER (WHERE ls.status <> 'wanted') as obtained_count,
+    COUNT(*) FILTER (WHERE ls.status = 'unlocked') as unlocked_count
+FROM lists_soulcores ls
+WHERE ls.list_id = @list_id::uuid AND ls.deleted_at IS NULL
+    AND creature_in_list_scope(@list_id::uuid, ls.creature_id);
+
+-- name: GetListProgressGroups :many
+-- GetListProgressGroups breaks the progress of a list down by the bestiary class or the race
+-- of the creatures in its scope, group_by is 'class' or 'race'. Creatures without one are
+-- counted under a NULL group, which comes last.
+SELECT
+    (CASE WHEN @group_by::text = 'race' THEN cr.race ELSE cr.bestiary_class END)::text AS group_name,
+    COUNT(*) AS scope_size,
+    COUNT(ls.creature_id) FILTER (WHERE ls.status <> 'wanted') AS obtained_count,
+    COUNT(ls.creature_id) FILTER (WHERE ls.status = 'unlocked') AS unlocked_count
+FROM creatures cr
+LEFT JOIN lists_soulcores ls
+    ON ls.list_id = @list_id::uuid AND ls.creature_id = cr.id AND ls.deleted_at IS NULL
+WHERE creature_in_list_scope(@list_id::uuid, cr.id)
+GROUP BY 1
+ORDER BY 1 NULLS LAST;
@@ -4,10 +4,13 @@ VALUES ($1, $2, $3)
 ON CONFLICT DO NOTHING;
 
 -- name: GetCharacterSuggestions :many
-SELECT cs.character_id, cs.creature_id, cs.list_id, cs.suggested_at, c.name as creature_name
+-- localized_name is the creature's name in lang, or its English name without a translation
+SELECT cs.character_id, cs.creature_id, cs.list_id, cs.suggested_at, c.name as creature_name,
+    COALESCE(t.name, c.name)::text AS localized_name
 FROM character_soulcore_suggestions cs
 JOIN creatures c ON c.id = cs.creature_id
-WHERE cs.character_id = $1
+LEFT JOIN creature_translations t ON t.creature_id = c.id AND t.language = @lang::text
+WHERE cs.character_id = @character_id
 ORDER BY cs.suggested_at DESC;
 
 -- name: DeleteSoulcoreSuggestion :exec
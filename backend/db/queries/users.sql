@@ -38,16 +38,25 @@ WITH user_lists AS (
     -- Get lists where user is the author
     SELECT l.*, lu.character_id, TRUE as is_author
     FROM lists l
-    LEFT JOIN lists_users lu ON l.id = lu.list_id AND lu.user_id = l.author_id
-    WHERE l.author_id = $1
+    LEFT JOIN LATERAL (
+        SELECT character_id FROM lists_users
+        WHERE list_id = l.id AND user_id = l.author_id
+        ORDER BY active DESC, character_id
+        LIMIT 1
+    ) lu ON true
+    WHERE l.author_id = $1 AND l.deleted_at IS NULL
     
     UNION ALL
     
-    -- Get lists where user is a member
+    -- Get lists where user is a member, once even with several characters in it
     SELECT l.*, lu.character_id, FALSE as is_author
     FROM lists l
-    JOIN lists_users lu ON l.id = lu.list_id
-    WHERE lu.user_id = $1 AND l.author_id != $1
+    JOIN (
+        SELECT DISTINCT ON (list_id) list_id, character_id FROM lists_users
+        WHERE user_id = $1
+        ORDER BY list_id, active DESC, character_id
+    ) lu ON l.id = lu.list_id
+    WHERE l.author_id != $1 AND l.deleted_at IS NULL
 )
 SELECT DISTINCT
     ul.id,
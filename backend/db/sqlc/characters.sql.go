@@ -12,9 +12,12 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const addCharacterSoulcore = `-- name: AddCharacterSoulcore :exec
+const addCharacterSoulcore = `-- name: AddCharacterSoulcore :execrows
 INSERT INTO characters_soulcores (character_id, creature_id)
 VALUES ($1, $2)
+ON CONFLICT (character_id, creature_id) DO UPDATE
+SET deleted_at = NULL, created_at = NOW()
+WHERE characters_soulcores.deleted_at IS NOT NULL
 `
 
 type AddCharacterSoulcoreParams struct {
@@ -22,9 +25,14 @@ type AddCharacterSoulcoreParams struct {
 	CreatureID  uuid.UUID `json:"creature_id"`
 }
 
-func (q *Queries) AddCharacterSoulcore(ctx context.Context, arg AddCharacterSoulcoreParams) error {
-	_, err := q.db.Exec(ctx, addCharacterSoulcore, arg.CharacterID, arg.CreatureID)
-	return err
+// Adding a removed soulcore again revives it, adding one the character already has
+// affects no rows
+func (q *Queries) AddCharacterSoulcore(ctx context.Context, arg AddCharacterSoulcoreParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addCharacterSoulcore, arg.CharacterID, arg.CreatureID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const createCharacter = `-- name: CreateCharacter :one
@@ -147,22 +155,31 @@ func (q *Queries) GetCharacterClaim(ctx context.Context, arg GetCharacterClaimPa
 }
 
 const getCharacterSoulcores = `-- name: GetCharacterSoulcores :many
-SELECT cs.character_id, cs.creature_id, c.name as creature_name, c.difficulty
+SELECT cs.character_id, cs.creature_id, c.name as creature_name, c.difficulty,
+    COALESCE(t.name, c.name)::text AS localized_name
 FROM characters_soulcores cs
 JOIN creatures c ON c.id = cs.creature_id
-WHERE cs.character_id = $1
-ORDER BY c.name
+LEFT JOIN creature_translations t ON t.creature_id = c.id AND t.language = $1::text
+WHERE cs.character_id = $2 AND cs.deleted_at IS NULL
+ORDER BY localized_name, c.name
 `
 
+type GetCharacterSoulcoresParams struct {
+	Lang        string    `json:"lang"`
+	CharacterID uuid.UUID `json:"character_id"`
+}
+
 type GetCharacterSoulcoresRow struct {
-	CharacterID  uuid.UUID   `json:"character_id"`
-	CreatureID   uuid.UUID   `json:"creature_id"`
-	CreatureName string      `json:"creature_name"`
-	Difficulty   pgtype.Int4 `json:"difficulty"`
+	CharacterID   uuid.UUID   `json:"character_id"`
+	CreatureID    uuid.UUID   `json:"creature_id"`
+	CreatureName  string      `json:"creature_name"`
+	Difficulty    pgtype.Int4 `json:"difficulty"`
+	LocalizedName string      `json:"localized_name"`
 }
 
-func (q *Queries) GetCharacterSoulcores(ctx context.Context, characterID uuid.UUID) ([]GetCharacterSoulcoresRow, error) {
-	rows, err := q.db.Query(ctx, getCharacterSoulcores, characterID)
+// localized_name is the creature's name in lang, or its English name without a translation
+func (q *Queries) GetCharacterSoulcores(ctx context.Context, arg GetCharacterSoulcoresParams) ([]GetCharacterSoulcoresRow, error) {
+	rows, err := q.db.Query(ctx, getCharacterSoulcores, arg.Lang, arg.CharacterID)
 	if err != nil {
 		return nil, err
 	}
@@ -175,6 +192,7 @@ func (q *Queries) GetCharacterSoulcores(ctx context.Context, characterID uuid.UU
 			&i.CreatureID,
 			&i.CreatureName,
 			&i.Difficulty,
+			&i.LocalizedName,
 		); err != nil {
 			return nil, err
 		}
@@ -262,6 +280,7 @@ WITH character_cores AS (
         cs.character_id,
         COUNT(cs.creature_id) as core_count
     FROM characters_soulcores cs
+    WHERE cs.deleted_at IS NULL
     GROUP BY cs.character_id
 )
 SELECT 
@@ -399,9 +418,23 @@ func (q *Queries) GetUserCharacters(ctx context.Context, userID uuid.UUID) ([]Ge
 	return items, nil
 }
 
-const removeCharacterSoulcore = `-- name: RemoveCharacterSoulcore :exec
+const purgeRemovedCharacterSoulcores = `-- name: PurgeRemovedCharacterSoulcores :execrows
 DELETE FROM characters_soulcores
-WHERE character_id = $1 AND creature_id = $2
+WHERE deleted_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeRemovedCharacterSoulcores(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeRemovedCharacterSoulcores, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const removeCharacterSoulcore = `-- name: RemoveCharacterSoulcore :exec
+UPDATE characters_soulcores
+SET deleted_at = NOW()
+WHERE character_id = $1 AND creature_id = $2 AND deleted_at IS NULL
 `
 
 type RemoveCharacterSoulcoreParams struct {
@@ -409,11 +442,34 @@ type RemoveCharacterSoulcoreParams struct {
 	CreatureID  uuid.UUID `json:"creature_id"`
 }
 
+// Leaves a tombstone behind that can be restored until it is purged
 func (q *Queries) RemoveCharacterSoulcore(ctx context.Context, arg RemoveCharacterSoulcoreParams) error {
 	_, err := q.db.Exec(ctx, removeCharacterSoulcore, arg.CharacterID, arg.CreatureID)
 	return err
 }
 
+const restoreCharacterSoulcore = `-- name: RestoreCharacterSoulcore :execrows
+UPDATE characters_soulcores
+SET deleted_at = NULL
+WHERE character_id = $1
+  AND creature_id = $2
+  AND deleted_at > $3::timestamptz
+`
+
+type RestoreCharacterSoulcoreParams struct {
+	CharacterID     uuid.UUID          `json:"character_id"`
+	CreatureID      uuid.UUID          `json:"creature_id"`
+	RestorableSince pgtype.Timestamptz `json:"restorable_since"`
+}
+
+func (q *Queries) RestoreCharacterSoulcore(ctx context.Context, arg RestoreCharacterSoulcoreParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreCharacterSoulcore, arg.CharacterID, arg.CreatureID, arg.RestorableSince)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateCharacterOwner = `-- name: UpdateCharacterOwner :one
 UPDATE characters
 SET user_id = $2,
@@ -56,19 +56,43 @@ func (q *Queries) DeleteAllChatMessages(ctx context.Context, listID uuid.UUID) e
 
 const deleteChatMessage = `-- name: DeleteChatMessage :exec
 DELETE FROM list_chat_messages
-WHERE id = $1 AND user_id = $2
+WHERE id = $1 AND list_id = $2
 `
 
 type DeleteChatMessageParams struct {
 	ID     uuid.UUID `json:"id"`
-	UserID uuid.UUID `json:"user_id"`
+	ListID uuid.UUID `json:"list_id"`
 }
 
 func (q *Queries) DeleteChatMessage(ctx context.Context, arg DeleteChatMessageParams) error {
-	_, err := q.db.Exec(ctx, deleteChatMessage, arg.ID, arg.UserID)
+	_, err := q.db.Exec(ctx, deleteChatMessage, arg.ID, arg.ListID)
 	return err
 }
 
+const getChatMessage = `-- name: GetChatMessage :one
+SELECT id, list_id, user_id, character_id, message, created_at FROM list_chat_messages
+WHERE id = $1 AND list_id = $2
+`
+
+type GetChatMessageParams struct {
+	ID     uuid.UUID `json:"id"`
+	ListID uuid.UUID `json:"list_id"`
+}
+
+func (q *Queries) GetChatMessage(ctx context.Context, arg GetChatMessageParams) (ListChatMessage, error) {
+	row := q.db.QueryRow(ctx, getChatMessage, arg.ID, arg.ListID)
+	var i ListChatMessage
+	err := row.Scan(
+		&i.ID,
+		&i.ListID,
+		&i.UserID,
+		&i.CharacterID,
+		&i.Message,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getChatMessages = `-- name: GetChatMessages :many
 SELECT 
     lcm.id,
@@ -187,7 +211,7 @@ WITH user_lists AS (
     SELECT DISTINCT l.id, l.name
     FROM lists l
     JOIN lists_users lu ON l.id = lu.list_id
-    WHERE lu.user_id = $1
+    WHERE lu.user_id = $1 AND l.deleted_at IS NULL
 ),
 last_read_times AS (
     SELECT user_id, list_id, last_read_at
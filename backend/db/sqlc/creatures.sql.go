@@ -7,24 +7,325 @@ package db
 
 import (
 	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countCreatures = `-- name: CountCreatures :one
+SELECT COUNT(*) FROM creatures
+`
+
+func (q *Queries) CountCreatures(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countCreatures)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createCreature = `-- name: CreateCreature :exec
+INSERT INTO creatures (name, difficulty)
+VALUES ($1, $2)
+`
+
+type CreateCreatureParams struct {
+	Name       string      `json:"name"`
+	Difficulty pgtype.Int4 `json:"difficulty"`
+}
+
+func (q *Queries) CreateCreature(ctx context.Context, arg CreateCreatureParams) error {
+	_, err := q.db.Exec(ctx, createCreature, arg.Name, arg.Difficulty)
+	return err
+}
+
+const deleteCreature = `-- name: DeleteCreature :execrows
+DELETE FROM creatures
+WHERE name = $1
+`
+
+// DeleteCreature fails for creatures that are still referenced, those have to be merged instead
+func (q *Queries) DeleteCreature(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCreature, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteCreatureTranslation = `-- name: DeleteCreatureTranslation :exec
+DELETE FROM creature_translations
+WHERE language = $1
+    AND creature_id = (SELECT c.id FROM creatures c WHERE c.name = $2)
+`
+
+type DeleteCreatureTranslationParams struct {
+	Language     string `json:"language"`
+	CreatureName string `json:"creature_name"`
+}
+
+func (q *Queries) DeleteCreatureTranslation(ctx context.Context, arg DeleteCreatureTranslationParams) error {
+	_, err := q.db.Exec(ctx, deleteCreatureTranslation, arg.Language, arg.CreatureName)
+	return err
+}
+
+const getAllCreatureAliases = `-- name: GetAllCreatureAliases :many
+SELECT a.name, c.name AS creature_name
+FROM creature_aliases a
+JOIN creatures c ON c.id = a.creature_id
+ORDER BY a.name
+`
+
+type GetAllCreatureAliasesRow struct {
+	Name         string `json:"name"`
+	CreatureName string `json:"creature_name"`
+}
+
+// GetAllCreatureAliases returns every alias along with the current name of its creature
+func (q *Queries) GetAllCreatureAliases(ctx context.Context) ([]GetAllCreatureAliasesRow, error) {
+	rows, err := q.db.Query(ctx, getAllCreatureAliases)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAllCreatureAliasesRow{}
+	for rows.Next() {
+		var i GetAllCreatureAliasesRow
+		if err := rows.Scan(&i.Name, &i.CreatureName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllCreatureTranslations = `-- name: GetAllCreatureTranslations :many
+SELECT t.language, c.name AS creature_name, t.name
+FROM creature_translations t
+JOIN creatures c ON c.id = t.creature_id
+ORDER BY t.language, c.name
+`
+
+type GetAllCreatureTranslationsRow struct {
+	Language     string `json:"language"`
+	CreatureName string `json:"creature_name"`
+	Name         string `json:"name"`
+}
+
+// GetAllCreatureTranslations returns every translation along with the name of its creature
+func (q *Queries) GetAllCreatureTranslations(ctx context.Context) ([]GetAllCreatureTranslationsRow, error) {
+	rows, err := q.db.Query(ctx, getAllCreatureTranslations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAllCreatureTranslationsRow{}
+	for rows.Next() {
+		var i GetAllCreatureTranslationsRow
+		if err := rows.Scan(&i.Language, &i.CreatureName, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCreatureAliases = `-- name: GetCreatureAliases :many
+SELECT id, creature_id, name, kind, created_at
+FROM creature_aliases
+WHERE creature_id = $1
+ORDER BY created_at, name
+`
+
+func (q *Queries) GetCreatureAliases(ctx context.Context, creatureID uuid.UUID) ([]CreatureAlias, error) {
+	rows, err := q.db.Query(ctx, getCreatureAliases, creatureID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CreatureAlias{}
+	for rows.Next() {
+		var i CreatureAlias
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatureID,
+			&i.Name,
+			&i.Kind,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCreatureByName = `-- name: GetCreatureByName :one
+SELECT c.id, c.name, c.difficulty, c.bestiary_class, c.race, c.charm_points, c.locations, c.sprite
+FROM creatures c
+WHERE lower(c.name) = lower($1)
+    OR c.id = (SELECT a.creature_id FROM creature_aliases a WHERE lower(a.name) = lower($1))
+ORDER BY lower(c.name) = lower($1) DESC
+LIMIT 1
+`
+
+// GetCreatureByName looks a creature up by name, ignoring case, falling back to its aliases
+func (q *Queries) GetCreatureByName(ctx context.Context, name string) (Creature, error) {
+	row := q.db.QueryRow(ctx, getCreatureByName, name)
+	var i Creature
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Difficulty,
+		&i.BestiaryClass,
+		&i.Race,
+		&i.CharmPoints,
+		&i.Locations,
+		&i.Sprite,
+	)
+	return i, err
+}
+
+const getCreatureIDs = `-- name: GetCreatureIDs :many
+SELECT id FROM creatures
+WHERE id = ANY($1::uuid[])
+`
+
+// GetCreatureIDs returns the IDs out of ids that belong to known creatures
+func (q *Queries) GetCreatureIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getCreatureIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCreatures = `-- name: GetCreatures :many
-SELECT id, name, difficulty
-FROM creatures
-ORDER BY name
+SELECT c.id, c.name, c.difficulty, c.bestiary_class, c.race, c.charm_points, c.locations, c.sprite,
+    COALESCE(t.name, c.name)::text AS localized_name
+FROM creatures c
+LEFT JOIN creature_translations t ON t.creature_id = c.id AND t.language = $1::text
+WHERE ($2::text IS NULL OR lower(c.bestiary_class) = lower($2::text))
+    AND ($3::text IS NULL OR lower(c.race) = lower($3::text))
+    AND ($4::text IS NULL OR EXISTS (
+        SELECT 1 FROM unnest(c.locations) l WHERE lower(l) = lower($4::text)
+    ))
+    AND ($5::int IS NULL OR c.difficulty >= $5::int)
+    AND ($6::int IS NULL OR c.difficulty <= $6::int)
+ORDER BY localized_name, c.name
+`
+
+type GetCreaturesParams struct {
+	Lang          string      `json:"lang"`
+	BestiaryClass pgtype.Text `json:"bestiary_class"`
+	Race          pgtype.Text `json:"race"`
+	Location      pgtype.Text `json:"location"`
+	MinDifficulty pgtype.Int4 `json:"min_difficulty"`
+	MaxDifficulty pgtype.Int4 `json:"max_difficulty"`
+}
+
+type GetCreaturesRow struct {
+	ID            uuid.UUID   `json:"id"`
+	Name          string      `json:"name"`
+	Difficulty    pgtype.Int4 `json:"difficulty"`
+	BestiaryClass pgtype.Text `json:"bestiary_class"`
+	Race          pgtype.Text `json:"race"`
+	CharmPoints   pgtype.Int4 `json:"charm_points"`
+	Locations     []string    `json:"locations"`
+	Sprite        pgtype.Text `json:"sprite"`
+	LocalizedName string      `json:"localized_name"`
+}
+
+// GetCreatures lists the creatures matching every filter that is set. Class, race and
+// location are compared ignoring case, location matches any of a creature's locations.
+// localized_name is the creature's name in lang, or its English name without a translation.
+func (q *Queries) GetCreatures(ctx context.Context, arg GetCreaturesParams) ([]GetCreaturesRow, error) {
+	rows, err := q.db.Query(ctx, getCreatures,
+		arg.Lang,
+		arg.BestiaryClass,
+		arg.Race,
+		arg.Location,
+		arg.MinDifficulty,
+		arg.MaxDifficulty,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCreaturesRow{}
+	for rows.Next() {
+		var i GetCreaturesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Difficulty,
+			&i.BestiaryClass,
+			&i.Race,
+			&i.CharmPoints,
+			&i.Locations,
+			&i.Sprite,
+			&i.LocalizedName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCreaturesByNames = `-- name: GetCreaturesByNames :many
+SELECT c.id, c.name, lower(c.name)::text AS lookup_name
+FROM creatures c
+WHERE lower(c.name) = ANY($1::text[])
+UNION ALL
+SELECT c.id, c.name, lower(a.name)::text AS lookup_name
+FROM creature_aliases a
+JOIN creatures c ON c.id = a.creature_id
+WHERE lower(a.name) = ANY($1::text[])
+    AND NOT EXISTS (SELECT 1 FROM creatures c2 WHERE lower(c2.name) = lower(a.name))
 `
 
-func (q *Queries) GetCreatures(ctx context.Context) ([]Creature, error) {
-	rows, err := q.db.Query(ctx, getCreatures)
+type GetCreaturesByNamesRow struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	LookupName string    `json:"lookup_name"`
+}
+
+// GetCreaturesByNames looks creatures up by name or alias, ignoring case. names must be lower case.
+// lookup_name is the name out of names a creature was found by.
+func (q *Queries) GetCreaturesByNames(ctx context.Context, names []string) ([]GetCreaturesByNamesRow, error) {
+	rows, err := q.db.Query(ctx, getCreaturesByNames, names)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Creature{}
+	items := []GetCreaturesByNamesRow{}
 	for rows.Next() {
-		var i Creature
-		if err := rows.Scan(&i.ID, &i.Name, &i.Difficulty); err != nil {
+		var i GetCreaturesByNamesRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.LookupName); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -34,3 +335,189 @@ func (q *Queries) GetCreatures(ctx context.Context) ([]Creature, error) {
 	}
 	return items, nil
 }
+
+const renameCreature = `-- name: RenameCreature :exec
+SELECT rename_creature($1::text, $2::text)
+`
+
+type RenameCreatureParams struct {
+	OldName string `json:"old_name"`
+	NewName string `json:"new_name"`
+}
+
+// RenameCreature renames a creature and keeps its old name as an alias
+func (q *Queries) RenameCreature(ctx context.Context, arg RenameCreatureParams) error {
+	_, err := q.db.Exec(ctx, renameCreature, arg.OldName, arg.NewName)
+	return err
+}
+
+const searchCreatures = `-- name: SearchCreatures :many
+WITH q AS (
+    SELECT $1::text AS query,
+        replace(replace(replace($1::text, '\', '\\'), '%', '\%'), '_', '\_') AS pattern
+),
+matches AS (
+    SELECT c.id AS creature_id, c.name AS matched_name, false AS is_alias
+    FROM creatures c, q
+    WHERE lower(c.name) LIKE '%' || q.pattern || '%' OR lower(c.name) % q.query
+    UNION ALL
+    SELECT a.creature_id, a.name, true
+    FROM creature_aliases a, q
+    WHERE lower(a.name) LIKE '%' || q.pattern || '%' OR lower(a.name) % q.query
+),
+scored AS (
+    SELECT DISTINCT ON (m.creature_id)
+        m.creature_id,
+        m.matched_name,
+        m.is_alias,
+        (CASE
+            WHEN lower(m.matched_name) = q.query THEN 3
+            WHEN lower(m.matched_name) LIKE q.pattern || '%' THEN 2
+            WHEN lower(m.matched_name) LIKE '% ' || q.pattern || '%' THEN 1
+            ELSE 0
+        END + similarity(lower(m.matched_name), q.query))::float8 AS score
+    FROM matches m, q
+    ORDER BY m.creature_id, score DESC, m.is_alias
+)
+SELECT
+    c.id,
+    c.name,
+    c.difficulty,
+    (CASE WHEN s.is_alias THEN s.matched_name END)::text AS matched_alias,
+    s.score
+FROM scored s
+JOIN creatures c ON c.id = s.creature_id
+WHERE ($2::int IS NULL OR c.difficulty >= $2::int)
+    AND ($3::int IS NULL OR c.difficulty <= $3::int)
+    AND ($4::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR NOT EXISTS (
+        SELECT 1 FROM characters_soulcores cs
+        WHERE cs.character_id = $4 AND cs.creature_id = c.id AND cs.deleted_at IS NULL
+    ))
+    AND ($5::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR NOT EXISTS (
+        SELECT 1 FROM lists_soulcores ls
+        WHERE ls.list_id = $5 AND ls.creature_id = c.id AND ls.deleted_at IS NULL
+    ))
+ORDER BY s.score DESC, c.name
+LIMIT $6
+`
+
+type SearchCreaturesParams struct {
+	Query         string      `json:"query"`
+	MinDifficulty pgtype.Int4 `json:"min_difficulty"`
+	MaxDifficulty pgtype.Int4 `json:"max_difficulty"`
+	CharacterID   uuid.UUID   `json:"character_id"`
+	ListID        uuid.UUID   `json:"list_id"`
+	Limit         int32       `json:"limit"`
+}
+
+type SearchCreaturesRow struct {
+	ID           uuid.UUID   `json:"id"`
+	Name         string      `json:"name"`
+	Difficulty   pgtype.Int4 `json:"difficulty"`
+	MatchedAlias pgtype.Text `json:"matched_alias"`
+	Score        float64     `json:"score"`
+}
+
+// SearchCreatures ranks the creatures whose name or an alias matches query: exact matches
+// first, then prefix matches, then matches at the start of a word, then anything else
+// containing query or similar to it, by trigram similarity. query must be lower case.
+// matched_alias is the alias a creature was found by when it matched better than its name.
+// Zero character and list IDs leave out the not yet unlocked and not yet in list filters.
+func (q *Queries) SearchCreatures(ctx context.Context, arg SearchCreaturesParams) ([]SearchCreaturesRow, error) {
+	rows, err := q.db.Query(ctx, searchCreatures,
+		arg.Query,
+		arg.MinDifficulty,
+		arg.MaxDifficulty,
+		arg.CharacterID,
+		arg.ListID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchCreaturesRow{}
+	for rows.Next() {
+		var i SearchCreaturesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Difficulty,
+			&i.MatchedAlias,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCreatureBestiary = `-- name: UpdateCreatureBestiary :exec
+UPDATE creatures
+SET bestiary_class = $1,
+    race = $2,
+    charm_points = $3,
+    locations = $4,
+    sprite = $5
+WHERE name = $6
+`
+
+type UpdateCreatureBestiaryParams struct {
+	BestiaryClass pgtype.Text `json:"bestiary_class"`
+	Race          pgtype.Text `json:"race"`
+	CharmPoints   pgtype.Int4 `json:"charm_points"`
+	Locations     []string    `json:"locations"`
+	Sprite        pgtype.Text `json:"sprite"`
+	Name          string      `json:"name"`
+}
+
+func (q *Queries) UpdateCreatureBestiary(ctx context.Context, arg UpdateCreatureBestiaryParams) error {
+	_, err := q.db.Exec(ctx, updateCreatureBestiary,
+		arg.BestiaryClass,
+		arg.Race,
+		arg.CharmPoints,
+		arg.Locations,
+		arg.Sprite,
+		arg.Name,
+	)
+	return err
+}
+
+const updateCreatureDifficulty = `-- name: UpdateCreatureDifficulty :exec
+UPDATE creatures
+SET difficulty = $1
+WHERE name = $2
+`
+
+type UpdateCreatureDifficultyParams struct {
+	Difficulty pgtype.Int4 `json:"difficulty"`
+	Name       string      `json:"name"`
+}
+
+func (q *Queries) UpdateCreatureDifficulty(ctx context.Context, arg UpdateCreatureDifficultyParams) error {
+	_, err := q.db.Exec(ctx, updateCreatureDifficulty, arg.Difficulty, arg.Name)
+	return err
+}
+
+const upsertCreatureTranslation = `-- name: UpsertCreatureTranslation :exec
+INSERT INTO creature_translations (creature_id, language, name)
+SELECT c.id, $1::text, $2::text
+FROM creatures c
+WHERE c.name = $3
+ON CONFLICT (creature_id, language) DO UPDATE SET name = EXCLUDED.name
+`
+
+type UpsertCreatureTranslationParams struct {
+	Language     string `json:"language"`
+	Name         string `json:"name"`
+	CreatureName string `json:"creature_name"`
+}
+
+func (q *Queries) UpsertCreatureTranslation(ctx context.Context, arg UpsertCreatureTranslationParams) error {
+	_, err := q.db.Exec(ctx, upsertCreatureTranslation, arg.Language, arg.Name, arg.CreatureName)
+	return err
+}
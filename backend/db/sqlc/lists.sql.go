@@ -14,49 +14,115 @@ import (
 )
 
 const addListCharacter = `-- name: AddListCharacter :exec
-INSERT INTO lists_users (list_id, user_id, character_id)
-VALUES ($1, $2, $3)
+INSERT INTO lists_users (list_id, user_id, character_id, role)
+VALUES ($1, $2, $3, $4)
 `
 
 type AddListCharacterParams struct {
 	ListID      uuid.UUID `json:"list_id"`
 	UserID      uuid.UUID `json:"user_id"`
 	CharacterID uuid.UUID `json:"character_id"`
+	Role        ListRole  `json:"role"`
 }
 
 func (q *Queries) AddListCharacter(ctx context.Context, arg AddListCharacterParams) error {
-	_, err := q.db.Exec(ctx, addListCharacter, arg.ListID, arg.UserID, arg.CharacterID)
+	_, err := q.db.Exec(ctx, addListCharacter,
+		arg.ListID,
+		arg.UserID,
+		arg.CharacterID,
+		arg.Role,
+	)
 	return err
 }
 
 const addSoulcoreToList = `-- name: AddSoulcoreToList :exec
-INSERT INTO lists_soulcores (list_id, creature_id, status, added_by_user_id)
-VALUES ($1, $2, $3, $4)
+INSERT INTO lists_soulcores (list_id, creature_id, status, added_by_user_id, reserved_for_user_id)
+VALUES ($1, $2, $3, $4, NULLIF($5::uuid, '00000000-0000-0000-0000-000000000000'::uuid))
 ON CONFLICT (list_id, creature_id) DO UPDATE
-SET status = EXCLUDED.status, added_by_user_id = EXCLUDED.added_by_user_id
+SET status = EXCLUDED.status,
+    added_by_user_id = EXCLUDED.added_by_user_id,
+    reserved_for_user_id = EXCLUDED.reserved_for_user_id,
+    deleted_at = NULL
 `
 
 type AddSoulcoreToListParams struct {
-	ListID        uuid.UUID      `json:"list_id"`
-	CreatureID    uuid.UUID      `json:"creature_id"`
-	Status        SoulcoreStatus `json:"status"`
-	AddedByUserID uuid.UUID      `json:"added_by_user_id"`
+	ListID            uuid.UUID      `json:"list_id"`
+	CreatureID        uuid.UUID      `json:"creature_id"`
+	Status            SoulcoreStatus `json:"status"`
+	AddedByUserID     uuid.UUID      `json:"added_by_user_id"`
+	ReservedForUserID uuid.UUID      `json:"reserved_for_user_id"`
 }
 
+// A zero reserved_for_user_id is stored as NULL
 func (q *Queries) AddSoulcoreToList(ctx context.Context, arg AddSoulcoreToListParams) error {
 	_, err := q.db.Exec(ctx, addSoulcoreToList,
 		arg.ListID,
 		arg.CreatureID,
 		arg.Status,
 		arg.AddedByUserID,
+		arg.ReservedForUserID,
 	)
 	return err
 }
 
+const addSoulcoreToListIfMissing = `-- name: AddSoulcoreToListIfMissing :execrows
+INSERT INTO lists_soulcores (list_id, creature_id, status, added_by_user_id, reserved_for_user_id)
+VALUES ($1, $2, $3, $4, NULLIF($5::uuid, '00000000-0000-0000-0000-000000000000'::uuid))
+ON CONFLICT (list_id, creature_id) DO UPDATE
+SET status = EXCLUDED.status,
+    added_by_user_id = EXCLUDED.added_by_user_id,
+    reserved_for_user_id = EXCLUDED.reserved_for_user_id,
+    deleted_at = NULL
+WHERE lists_soulcores.deleted_at IS NOT NULL
+`
+
+type AddSoulcoreToListIfMissingParams struct {
+	ListID            uuid.UUID      `json:"list_id"`
+	CreatureID        uuid.UUID      `json:"creature_id"`
+	Status            SoulcoreStatus `json:"status"`
+	AddedByUserID     uuid.UUID      `json:"added_by_user_id"`
+	ReservedForUserID uuid.UUID      `json:"reserved_for_user_id"`
+}
+
+// AddSoulcoreToListIfMissing adds a soulcore unless the list already has it, which
+// leaves the existing entry untouched and affects no rows. Removed entries are replaced.
+func (q *Queries) AddSoulcoreToListIfMissing(ctx context.Context, arg AddSoulcoreToListIfMissingParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addSoulcoreToListIfMissing,
+		arg.ListID,
+		arg.CreatureID,
+		arg.Status,
+		arg.AddedByUserID,
+		arg.ReservedForUserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countListMembersOutsideWorld = `-- name: CountListMembersOutsideWorld :one
+SELECT COUNT(DISTINCT lu.user_id)
+FROM lists_users lu
+JOIN characters c ON c.id = lu.character_id
+WHERE lu.list_id = $1 AND lu.active = true AND c.world <> $2
+`
+
+type CountListMembersOutsideWorldParams struct {
+	ListID uuid.UUID `json:"list_id"`
+	World  string    `json:"world"`
+}
+
+func (q *Queries) CountListMembersOutsideWorld(ctx context.Context, arg CountListMembersOutsideWorldParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countListMembersOutsideWorld, arg.ListID, arg.World)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createList = `-- name: CreateList :one
 INSERT INTO lists (author_id, name, world)
 VALUES ($1, $2, $3)
-RETURNING id, author_id, name, share_code, world, created_at, updated_at
+RETURNING id, author_id, name, share_code, world, created_at, updated_at, deleted_at, share_code_enabled, approval_required, visibility
 `
 
 type CreateListParams struct {
@@ -76,10 +142,32 @@ func (q *Queries) CreateList(ctx context.Context, arg CreateListParams) (List, e
 		&i.World,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ShareCodeEnabled,
+		&i.ApprovalRequired,
+		&i.Visibility,
 	)
 	return i, err
 }
 
+const createListRemoval = `-- name: CreateListRemoval :exec
+INSERT INTO list_removed_members (list_id, user_id, removed_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (list_id, user_id) DO UPDATE
+SET removed_by = EXCLUDED.removed_by, removed_at = NOW()
+`
+
+type CreateListRemovalParams struct {
+	ListID    uuid.UUID `json:"list_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	RemovedBy uuid.UUID `json:"removed_by"`
+}
+
+func (q *Queries) CreateListRemoval(ctx context.Context, arg CreateListRemovalParams) error {
+	_, err := q.db.Exec(ctx, createListRemoval, arg.ListID, arg.UserID, arg.RemovedBy)
+	return err
+}
+
 const deactivateCharacterListMemberships = `-- name: DeactivateCharacterListMemberships :exec
 UPDATE lists_users
 SET active = false
@@ -91,9 +179,66 @@ func (q *Queries) DeactivateCharacterListMemberships(ctx context.Context, charac
 	return err
 }
 
+const deleteListRemoval = `-- name: DeleteListRemoval :execrows
+DELETE FROM list_removed_members
+WHERE list_id = $1 AND user_id = $2
+`
+
+type DeleteListRemovalParams struct {
+	ListID uuid.UUID `json:"list_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteListRemoval(ctx context.Context, arg DeleteListRemovalParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteListRemoval, arg.ListID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const disableListShareCode = `-- name: DisableListShareCode :execrows
+UPDATE lists
+SET share_code_enabled = false, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) DisableListShareCode(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, disableListShareCode, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getCharacterListIDs = `-- name: GetCharacterListIDs :many
+SELECT list_id FROM lists_users
+WHERE character_id = $1
+`
+
+func (q *Queries) GetCharacterListIDs(ctx context.Context, characterID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getCharacterListIDs, characterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var list_id uuid.UUID
+		if err := rows.Scan(&list_id); err != nil {
+			return nil, err
+		}
+		items = append(items, list_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getList = `-- name: GetList :one
-SELECT id, author_id, name, share_code, world, created_at, updated_at FROM lists
-WHERE id = $1
+SELECT id, author_id, name, share_code, world, created_at, updated_at, deleted_at, share_code_enabled, approval_required, visibility FROM lists
+WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetList(ctx context.Context, id uuid.UUID) (List, error) {
@@ -107,13 +252,17 @@ func (q *Queries) GetList(ctx context.Context, id uuid.UUID) (List, error) {
 		&i.World,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ShareCodeEnabled,
+		&i.ApprovalRequired,
+		&i.Visibility,
 	)
 	return i, err
 }
 
 const getListByShareCode = `-- name: GetListByShareCode :one
-SELECT id, author_id, name, share_code, world, created_at, updated_at FROM lists
-WHERE share_code = $1
+SELECT id, author_id, name, share_code, world, created_at, updated_at, deleted_at, share_code_enabled, approval_required, visibility FROM lists
+WHERE share_code = $1 AND share_code_enabled = true AND deleted_at IS NULL
 `
 
 func (q *Queries) GetListByShareCode(ctx context.Context, shareCode uuid.UUID) (List, error) {
@@ -127,33 +276,177 @@ func (q *Queries) GetListByShareCode(ctx context.Context, shareCode uuid.UUID) (
 		&i.World,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ShareCodeEnabled,
+		&i.ApprovalRequired,
+		&i.Visibility,
 	)
 	return i, err
 }
 
+const getListHuntCandidates = `-- name: GetListHuntCandidates :many
+SELECT
+    cr.id as creature_id,
+    cr.name as creature_name,
+    cr.difficulty,
+    ls.status as list_status,
+    jsonb_agg(
+        jsonb_build_object(
+            'user_id', lu.user_id,
+            'character_id', c.id,
+            'character_name', c.name
+        ) ORDER BY c.name
+    ) as needed_by
+FROM creatures cr
+JOIN lists_users lu ON lu.list_id = $1 AND lu.active AND lu.role <> 'viewer'
+JOIN characters c ON c.id = lu.character_id
+LEFT JOIN lists_soulcores ls ON ls.list_id = lu.list_id AND ls.creature_id = cr.id AND ls.deleted_at IS NULL
+WHERE creature_in_list_scope($1, cr.id)
+    AND NOT EXISTS (
+        SELECT 1 FROM characters_soulcores cs
+        WHERE cs.character_id = c.id AND cs.creature_id = cr.id AND cs.deleted_at IS NULL
+    )
+GROUP BY cr.id, cr.name, cr.difficulty, ls.status
+`
+
+type GetListHuntCandidatesRow struct {
+	CreatureID   uuid.UUID          `json:"creature_id"`
+	CreatureName string             `json:"creature_name"`
+	Difficulty   pgtype.Int4        `json:"difficulty"`
+	ListStatus   NullSoulcoreStatus `json:"list_status"`
+	NeededBy     json.RawMessage    `json:"needed_by"`
+}
+
+// Returns every creature in the scope of a list that an active, non-viewer member still has
+// to unlock, with the members who need it and the status of the list's own soulcore, if any
+func (q *Queries) GetListHuntCandidates(ctx context.Context, listID uuid.UUID) ([]GetListHuntCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, getListHuntCandidates, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetListHuntCandidatesRow{}
+	for rows.Next() {
+		var i GetListHuntCandidatesRow
+		if err := rows.Scan(
+			&i.CreatureID,
+			&i.CreatureName,
+			&i.Difficulty,
+			&i.ListStatus,
+			&i.NeededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getListMemberRole = `-- name: GetListMemberRole :one
+SELECT lu.role FROM lists_users lu
+JOIN lists l ON l.id = lu.list_id
+WHERE lu.list_id = $1 AND lu.user_id = $2 AND lu.active = true AND l.deleted_at IS NULL
+ORDER BY lu.role
+LIMIT 1
+`
+
+type GetListMemberRoleParams struct {
+	ListID uuid.UUID `json:"list_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// Users with several characters in a list get their highest role
+func (q *Queries) GetListMemberRole(ctx context.Context, arg GetListMemberRoleParams) (ListRole, error) {
+	row := q.db.QueryRow(ctx, getListMemberRole, arg.ListID, arg.UserID)
+	var role ListRole
+	err := row.Scan(&role)
+	return role, err
+}
+
+const getListMemberUnlocks = `-- name: GetListMemberUnlocks :many
+SELECT lu.character_id, c.id AS creature_id, c.name AS creature_name,
+    creature_in_list_scope($1, c.id)::bool AS in_scope
+FROM lists_users lu
+JOIN characters_soulcores cs ON cs.character_id = lu.character_id AND cs.deleted_at IS NULL
+JOIN creatures c ON c.id = cs.creature_id
+WHERE lu.list_id = $1
+ORDER BY lu.character_id, c.name
+`
+
+type GetListMemberUnlocksRow struct {
+	CharacterID  uuid.UUID `json:"character_id"`
+	CreatureID   uuid.UUID `json:"creature_id"`
+	CreatureName string    `json:"creature_name"`
+	InScope      bool      `json:"in_scope"`
+}
+
+// The soulcores the characters of a list's members unlocked, one row per character and
+// creature. in_scope tells whether the creature is in the list's scope.
+func (q *Queries) GetListMemberUnlocks(ctx context.Context, listID uuid.UUID) ([]GetListMemberUnlocksRow, error) {
+	rows, err := q.db.Query(ctx, getListMemberUnlocks, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetListMemberUnlocksRow{}
+	for rows.Next() {
+		var i GetListMemberUnlocksRow
+		if err := rows.Scan(
+			&i.CharacterID,
+			&i.CreatureID,
+			&i.CreatureName,
+			&i.InScope,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getListMembers = `-- name: GetListMembers :many
 SELECT 
   u.id as user_id,
+  c.id as character_id,
   c.name as character_name,
-  COUNT(DISTINCT CASE WHEN ls.status = 'obtained' OR ls.status = 'unlocked' THEN ls.creature_id END) as obtained_count,
+  COUNT(DISTINCT CASE WHEN ls.status <> 'wanted' THEN ls.creature_id END) as obtained_count,
   COUNT(DISTINCT CASE WHEN ls.status = 'unlocked' THEN ls.creature_id END) as unlocked_count,
-  lu.active as is_active
+  (
+    SELECT COUNT(*) FROM characters_soulcores cs
+    WHERE cs.character_id = c.id AND cs.deleted_at IS NULL
+      AND creature_in_list_scope($1, cs.creature_id)
+  ) as character_unlocked_count,
+  lu.active as is_active,
+  lu.role
 FROM lists_users lu
 JOIN users u ON lu.user_id = u.id
 JOIN characters c ON lu.character_id = c.id
-LEFT JOIN lists_soulcores ls ON ls.list_id = $1 AND ls.added_by_user_id = u.id
+LEFT JOIN lists_soulcores ls ON ls.list_id = $1 AND ls.added_by_user_id = u.id AND ls.deleted_at IS NULL
+    AND creature_in_list_scope($1, ls.creature_id)
 WHERE lu.list_id = $1
-GROUP BY u.id, c.name, lu.active
+GROUP BY u.id, c.id, c.name, lu.active, lu.role
 `
 
 type GetListMembersRow struct {
-	UserID        uuid.UUID `json:"user_id"`
-	CharacterName string    `json:"character_name"`
-	ObtainedCount int64     `json:"obtained_count"`
-	UnlockedCount int64     `json:"unlocked_count"`
-	IsActive      bool      `json:"is_active"`
+	UserID                 uuid.UUID `json:"user_id"`
+	CharacterID            uuid.UUID `json:"character_id"`
+	CharacterName          string    `json:"character_name"`
+	ObtainedCount          int64     `json:"obtained_count"`
+	UnlockedCount          int64     `json:"unlocked_count"`
+	CharacterUnlockedCount int64     `json:"character_unlocked_count"`
+	IsActive               bool      `json:"is_active"`
+	Role                   ListRole  `json:"role"`
 }
 
+// One row per character. Soulcores are credited to the user, so obtained_count and
+// unlocked_count are shared by all of a user's characters while character_unlocked_count
+// counts the cores the character itself unlocked.
 func (q *Queries) GetListMembers(ctx context.Context, listID uuid.UUID) ([]GetListMembersRow, error) {
 	rows, err := q.db.Query(ctx, getListMembers, listID)
 	if err != nil {
@@ -165,10 +458,13 @@ func (q *Queries) GetListMembers(ctx context.Context, listID uuid.UUID) ([]GetLi
 		var i GetListMembersRow
 		if err := rows.Scan(
 			&i.UserID,
+			&i.CharacterID,
 			&i.CharacterName,
 			&i.ObtainedCount,
 			&i.UnlockedCount,
+			&i.CharacterUnlockedCount,
 			&i.IsActive,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -192,6 +488,7 @@ WITH member_unlocks AS (
         ) as unlocked_creatures
     FROM characters_soulcores cs
     JOIN creatures c ON c.id = cs.creature_id
+    WHERE cs.deleted_at IS NULL
     GROUP BY cs.character_id
 )
 SELECT 
@@ -199,16 +496,18 @@ SELECT
     c.id as character_id,
     c.name as character_name,
     COALESCE(mu.unlocked_creatures, '[]'::jsonb) as unlocked_creatures,
-    COUNT(DISTINCT CASE WHEN ls.status = 'obtained' OR ls.status = 'unlocked' THEN ls.creature_id END) as obtained_count,
+    COUNT(DISTINCT CASE WHEN ls.status <> 'wanted' THEN ls.creature_id END) as obtained_count,
     COUNT(DISTINCT CASE WHEN ls.status = 'unlocked' THEN ls.creature_id END) as unlocked_count,
-    lu.active as is_active
+    lu.active as is_active,
+    lu.role
 FROM lists_users lu 
 JOIN users u ON lu.user_id = u.id
 JOIN characters c ON lu.character_id = c.id
-LEFT JOIN lists_soulcores ls ON ls.list_id = $1
+LEFT JOIN lists_soulcores ls ON ls.list_id = $1 AND ls.deleted_at IS NULL
+    AND creature_in_list_scope($1, ls.creature_id)
 LEFT JOIN member_unlocks mu ON mu.character_id = c.id
 WHERE lu.list_id = $1
-GROUP BY u.id, c.id, c.name, lu.active, mu.unlocked_creatures
+GROUP BY u.id, c.id, c.name, lu.active, lu.role, mu.unlocked_creatures
 `
 
 type GetListMembersWithUnlocksRow struct {
@@ -219,6 +518,7 @@ type GetListMembersWithUnlocksRow struct {
 	ObtainedCount     int64           `json:"obtained_count"`
 	UnlockedCount     int64           `json:"unlocked_count"`
 	IsActive          bool            `json:"is_active"`
+	Role              ListRole        `json:"role"`
 }
 
 func (q *Queries) GetListMembersWithUnlocks(ctx context.Context, listID uuid.UUID) ([]GetListMembersWithUnlocksRow, error) {
@@ -238,6 +538,53 @@ func (q *Queries) GetListMembersWithUnlocks(ctx context.Context, listID uuid.UUI
 			&i.ObtainedCount,
 			&i.UnlockedCount,
 			&i.IsActive,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getListRemovedMembers = `-- name: GetListRemovedMembers :many
+SELECT
+  lr.user_id,
+  lr.removed_by,
+  lr.removed_at,
+  COALESCE(string_agg(c.name, ', ' ORDER BY c.name), '')::text as character_names
+FROM list_removed_members lr
+JOIN lists l ON l.id = lr.list_id
+LEFT JOIN characters c ON c.user_id = lr.user_id AND c.world = l.world
+WHERE lr.list_id = $1
+GROUP BY lr.user_id, lr.removed_by, lr.removed_at
+ORDER BY lr.removed_at DESC
+`
+
+type GetListRemovedMembersRow struct {
+	UserID         uuid.UUID          `json:"user_id"`
+	RemovedBy      uuid.UUID          `json:"removed_by"`
+	RemovedAt      pgtype.Timestamptz `json:"removed_at"`
+	CharacterNames string             `json:"character_names"`
+}
+
+func (q *Queries) GetListRemovedMembers(ctx context.Context, listID uuid.UUID) ([]GetListRemovedMembersRow, error) {
+	rows, err := q.db.Query(ctx, getListRemovedMembers, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetListRemovedMembersRow{}
+	for rows.Next() {
+		var i GetListRemovedMembersRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.RemovedBy,
+			&i.RemovedAt,
+			&i.CharacterNames,
 		); err != nil {
 			return nil, err
 		}
@@ -256,12 +603,18 @@ SELECT
   ls.status,
   cr.name as creature_name,
   c.name as added_by,
-  ls.added_by_user_id
+  ls.added_by_user_id,
+  ls.reserved_for_user_id
 FROM lists_soulcores ls
 JOIN creatures cr ON ls.creature_id = cr.id
-LEFT JOIN lists_users lu ON ls.list_id = lu.list_id AND ls.added_by_user_id = lu.user_id
-LEFT JOIN characters c ON lu.character_id = c.id
-WHERE ls.list_id = $1 AND ls.creature_id = $2
+LEFT JOIN LATERAL (
+    SELECT ch.name FROM lists_users lu
+    JOIN characters ch ON ch.id = lu.character_id
+    WHERE lu.list_id = ls.list_id AND lu.user_id = ls.added_by_user_id
+    ORDER BY lu.active DESC, ch.name
+    LIMIT 1
+) c ON true
+WHERE ls.list_id = $1 AND ls.creature_id = $2 AND ls.deleted_at IS NULL
 `
 
 type GetListSoulcoreParams struct {
@@ -270,12 +623,13 @@ type GetListSoulcoreParams struct {
 }
 
 type GetListSoulcoreRow struct {
-	ListID        uuid.UUID      `json:"list_id"`
-	CreatureID    uuid.UUID      `json:"creature_id"`
-	Status        SoulcoreStatus `json:"status"`
-	CreatureName  string         `json:"creature_name"`
-	AddedBy       pgtype.Text    `json:"added_by"`
-	AddedByUserID uuid.UUID      `json:"added_by_user_id"`
+	ListID            uuid.UUID      `json:"list_id"`
+	CreatureID        uuid.UUID      `json:"creature_id"`
+	Status            SoulcoreStatus `json:"status"`
+	CreatureName      string         `json:"creature_name"`
+	AddedBy           pgtype.Text    `json:"added_by"`
+	AddedByUserID     uuid.UUID      `json:"added_by_user_id"`
+	ReservedForUserID uuid.UUID      `json:"reserved_for_user_id"`
 }
 
 func (q *Queries) GetListSoulcore(ctx context.Context, arg GetListSoulcoreParams) (GetListSoulcoreRow, error) {
@@ -288,6 +642,7 @@ func (q *Queries) GetListSoulcore(ctx context.Context, arg GetListSoulcoreParams
 		&i.CreatureName,
 		&i.AddedBy,
 		&i.AddedByUserID,
+		&i.ReservedForUserID,
 	)
 	return i, err
 }
@@ -299,26 +654,42 @@ SELECT
   ls.status,
   cr.name as creature_name,
   c.name as added_by,
-  ls.added_by_user_id
+  ls.added_by_user_id,
+  ls.reserved_for_user_id,
+  COALESCE(t.name, cr.name)::text AS localized_name
 FROM lists_soulcores ls
 JOIN creatures cr ON ls.creature_id = cr.id
-LEFT JOIN lists_users lu ON ls.list_id = lu.list_id AND ls.added_by_user_id = lu.user_id
-LEFT JOIN characters c ON lu.character_id = c.id
-WHERE ls.list_id = $1
-ORDER BY cr.name
+LEFT JOIN creature_translations t ON t.creature_id = cr.id AND t.language = $1::text
+LEFT JOIN LATERAL (
+    SELECT ch.name FROM lists_users lu
+    JOIN characters ch ON ch.id = lu.character_id
+    WHERE lu.list_id = ls.list_id AND lu.user_id = ls.added_by_user_id
+    ORDER BY lu.active DESC, ch.name
+    LIMIT 1
+) c ON true
+WHERE ls.list_id = $2 AND ls.deleted_at IS NULL
+ORDER BY localized_name, cr.name
 `
 
+type GetListSoulcoresParams struct {
+	Lang   string    `json:"lang"`
+	ListID uuid.UUID `json:"list_id"`
+}
+
 type GetListSoulcoresRow struct {
-	ListID        uuid.UUID      `json:"list_id"`
-	CreatureID    uuid.UUID      `json:"creature_id"`
-	Status        SoulcoreStatus `json:"status"`
-	CreatureName  string         `json:"creature_name"`
-	AddedBy       pgtype.Text    `json:"added_by"`
-	AddedByUserID uuid.UUID      `json:"added_by_user_id"`
+	ListID            uuid.UUID      `json:"list_id"`
+	CreatureID        uuid.UUID      `json:"creature_id"`
+	Status            SoulcoreStatus `json:"status"`
+	CreatureName      string         `json:"creature_name"`
+	AddedBy           pgtype.Text    `json:"added_by"`
+	AddedByUserID     uuid.UUID      `json:"added_by_user_id"`
+	ReservedForUserID uuid.UUID      `json:"reserved_for_user_id"`
+	LocalizedName     string         `json:"localized_name"`
 }
 
-func (q *Queries) GetListSoulcores(ctx context.Context, listID uuid.UUID) ([]GetListSoulcoresRow, error) {
-	rows, err := q.db.Query(ctx, getListSoulcores, listID)
+// localized_name is the creature's name in lang, or its English name without a translation
+func (q *Queries) GetListSoulcores(ctx context.Context, arg GetListSoulcoresParams) ([]GetListSoulcoresRow, error) {
+	rows, err := q.db.Query(ctx, getListSoulcores, arg.Lang, arg.ListID)
 	if err != nil {
 		return nil, err
 	}
@@ -333,6 +704,54 @@ func (q *Queries) GetListSoulcores(ctx context.Context, listID uuid.UUID) ([]Get
 			&i.CreatureName,
 			&i.AddedBy,
 			&i.AddedByUserID,
+			&i.ReservedForUserID,
+			&i.LocalizedName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getListUserCharacters = `-- name: GetListUserCharacters :many
+SELECT lu.character_id, c.name as character_name, lu.active, lu.role
+FROM lists_users lu
+JOIN characters c ON c.id = lu.character_id
+WHERE lu.list_id = $1 AND lu.user_id = $2
+ORDER BY c.name
+`
+
+type GetListUserCharactersParams struct {
+	ListID uuid.UUID `json:"list_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type GetListUserCharactersRow struct {
+	CharacterID   uuid.UUID `json:"character_id"`
+	CharacterName string    `json:"character_name"`
+	Active        bool      `json:"active"`
+	Role          ListRole  `json:"role"`
+}
+
+// Every character the user takes part in the list with
+func (q *Queries) GetListUserCharacters(ctx context.Context, arg GetListUserCharactersParams) ([]GetListUserCharactersRow, error) {
+	rows, err := q.db.Query(ctx, getListUserCharacters, arg.ListID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetListUserCharactersRow{}
+	for rows.Next() {
+		var i GetListUserCharactersRow
+		if err := rows.Scan(
+			&i.CharacterID,
+			&i.CharacterName,
+			&i.Active,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -345,8 +764,8 @@ func (q *Queries) GetListSoulcores(ctx context.Context, listID uuid.UUID) ([]Get
 }
 
 const getListsByAuthorId = `-- name: GetListsByAuthorId :many
-SELECT id, author_id, name, share_code, world, created_at, updated_at FROM lists
-WHERE author_id = $1
+SELECT id, author_id, name, share_code, world, created_at, updated_at, deleted_at, share_code_enabled, approval_required, visibility FROM lists
+WHERE author_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetListsByAuthorId(ctx context.Context, authorID uuid.UUID) ([]List, error) {
@@ -366,6 +785,10 @@ func (q *Queries) GetListsByAuthorId(ctx context.Context, authorID uuid.UUID) ([
 			&i.World,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ShareCodeEnabled,
+			&i.ApprovalRequired,
+			&i.Visibility,
 		); err != nil {
 			return nil, err
 		}
@@ -378,7 +801,7 @@ func (q *Queries) GetListsByAuthorId(ctx context.Context, authorID uuid.UUID) ([
 }
 
 const getMembers = `-- name: GetMembers :many
-SELECT list_id, user_id, character_id, active FROM lists_users
+SELECT list_id, user_id, character_id, active, role FROM lists_users
 WHERE list_id = $1
 `
 
@@ -396,6 +819,80 @@ func (q *Queries) GetMembers(ctx context.Context, listID uuid.UUID) ([]ListsUser
 			&i.UserID,
 			&i.CharacterID,
 			&i.Active,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicLists = `-- name: GetPublicLists :many
+SELECT
+    l.id,
+    l.name,
+    l.world,
+    l.share_code,
+    l.share_code_enabled,
+    l.approval_required,
+    l.created_at,
+    (SELECT COUNT(DISTINCT lu.user_id) FROM lists_users lu WHERE lu.list_id = l.id AND lu.active = true) as member_count,
+    (SELECT COUNT(*) FROM lists_soulcores ls WHERE ls.list_id = l.id AND ls.status <> 'wanted' AND ls.deleted_at IS NULL AND creature_in_list_scope(l.id, ls.creature_id)) as obtained_count,
+    (SELECT COUNT(*) FROM lists_soulcores ls WHERE ls.list_id = l.id AND ls.status = 'unlocked' AND ls.deleted_at IS NULL AND creature_in_list_scope(l.id, ls.creature_id)) as unlocked_count,
+    (SELECT COUNT(*) FROM creatures cr WHERE creature_in_list_scope(l.id, cr.id)) as scope_size,
+    COUNT(*) OVER() as total_count
+FROM lists l
+WHERE l.world = $1 AND l.visibility = 'public' AND l.deleted_at IS NULL
+ORDER BY unlocked_count DESC, l.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetPublicListsParams struct {
+	World  string `json:"world"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+type GetPublicListsRow struct {
+	ID               uuid.UUID          `json:"id"`
+	Name             string             `json:"name"`
+	World            string             `json:"world"`
+	ShareCode        uuid.UUID          `json:"share_code"`
+	ShareCodeEnabled bool               `json:"share_code_enabled"`
+	ApprovalRequired bool               `json:"approval_required"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	MemberCount      int64              `json:"member_count"`
+	ObtainedCount    int64              `json:"obtained_count"`
+	UnlockedCount    int64              `json:"unlocked_count"`
+	ScopeSize        int64              `json:"scope_size"`
+	TotalCount       int64              `json:"total_count"`
+}
+
+func (q *Queries) GetPublicLists(ctx context.Context, arg GetPublicListsParams) ([]GetPublicListsRow, error) {
+	rows, err := q.db.Query(ctx, getPublicLists, arg.World, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPublicListsRow{}
+	for rows.Next() {
+		var i GetPublicListsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.World,
+			&i.ShareCode,
+			&i.ShareCodeEnabled,
+			&i.ApprovalRequired,
+			&i.CreatedAt,
+			&i.MemberCount,
+			&i.ObtainedCount,
+			&i.UnlockedCount,
+			&i.ScopeSize,
+			&i.TotalCount,
 		); err != nil {
 			return nil, err
 		}
@@ -407,6 +904,60 @@ func (q *Queries) GetMembers(ctx context.Context, listID uuid.UUID) ([]ListsUser
 	return items, nil
 }
 
+const getRemovedListSoulcore = `-- name: GetRemovedListSoulcore :one
+SELECT 
+  ls.list_id,
+  ls.creature_id,
+  ls.status,
+  cr.name as creature_name,
+  c.name as added_by,
+  ls.added_by_user_id,
+  ls.reserved_for_user_id
+FROM lists_soulcores ls
+JOIN creatures cr ON ls.creature_id = cr.id
+LEFT JOIN LATERAL (
+    SELECT ch.name FROM lists_users lu
+    JOIN characters ch ON ch.id = lu.character_id
+    WHERE lu.list_id = ls.list_id AND lu.user_id = ls.added_by_user_id
+    ORDER BY lu.active DESC, ch.name
+    LIMIT 1
+) c ON true
+WHERE ls.list_id = $1
+  AND ls.creature_id = $2
+  AND ls.deleted_at > $3::timestamptz
+`
+
+type GetRemovedListSoulcoreParams struct {
+	ListID          uuid.UUID          `json:"list_id"`
+	CreatureID      uuid.UUID          `json:"creature_id"`
+	RestorableSince pgtype.Timestamptz `json:"restorable_since"`
+}
+
+type GetRemovedListSoulcoreRow struct {
+	ListID            uuid.UUID      `json:"list_id"`
+	CreatureID        uuid.UUID      `json:"creature_id"`
+	Status            SoulcoreStatus `json:"status"`
+	CreatureName      string         `json:"creature_name"`
+	AddedBy           pgtype.Text    `json:"added_by"`
+	AddedByUserID     uuid.UUID      `json:"added_by_user_id"`
+	ReservedForUserID uuid.UUID      `json:"reserved_for_user_id"`
+}
+
+func (q *Queries) GetRemovedListSoulcore(ctx context.Context, arg GetRemovedListSoulcoreParams) (GetRemovedListSoulcoreRow, error) {
+	row := q.db.QueryRow(ctx, getRemovedListSoulcore, arg.ListID, arg.CreatureID, arg.RestorableSince)
+	var i GetRemovedListSoulcoreRow
+	err := row.Scan(
+		&i.ListID,
+		&i.CreatureID,
+		&i.Status,
+		&i.CreatureName,
+		&i.AddedBy,
+		&i.AddedByUserID,
+		&i.ReservedForUserID,
+	)
+	return i, err
+}
+
 const isUserListMember = `-- name: IsUserListMember :one
 SELECT EXISTS (
   SELECT 1
@@ -427,9 +978,166 @@ func (q *Queries) IsUserListMember(ctx context.Context, arg IsUserListMemberPara
 	return is_member, err
 }
 
-const removeListSoulcore = `-- name: RemoveListSoulcore :exec
+const isUserRemovedFromList = `-- name: IsUserRemovedFromList :one
+SELECT EXISTS (
+  SELECT 1
+  FROM list_removed_members
+  WHERE list_id = $1 AND user_id = $2
+) as is_removed
+`
+
+type IsUserRemovedFromListParams struct {
+	ListID uuid.UUID `json:"list_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) IsUserRemovedFromList(ctx context.Context, arg IsUserRemovedFromListParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isUserRemovedFromList, arg.ListID, arg.UserID)
+	var is_removed bool
+	err := row.Scan(&is_removed)
+	return is_removed, err
+}
+
+const purgeDeletedLists = `-- name: PurgeDeletedLists :many
+WITH purged AS (
+    SELECT id FROM lists
+    WHERE deleted_at < $1::timestamptz
+    FOR UPDATE
+), suggestions AS (
+    DELETE FROM character_soulcore_suggestions WHERE list_id IN (SELECT id FROM purged)
+), read_status AS (
+    DELETE FROM list_user_read_status WHERE list_id IN (SELECT id FROM purged)
+), chat_messages AS (
+    DELETE FROM list_chat_messages WHERE list_id IN (SELECT id FROM purged)
+), soulcores AS (
+    DELETE FROM lists_soulcores WHERE list_id IN (SELECT id FROM purged)
+), members AS (
+    DELETE FROM lists_users WHERE list_id IN (SELECT id FROM purged)
+), removed_members AS (
+    DELETE FROM list_removed_members WHERE list_id IN (SELECT id FROM purged)
+), invites AS (
+    DELETE FROM list_invites WHERE list_id IN (SELECT id FROM purged)
+), join_requests AS (
+    DELETE FROM list_join_requests WHERE list_id IN (SELECT id FROM purged)
+), activity AS (
+    DELETE FROM list_activity WHERE list_id IN (SELECT id FROM purged)
+), distributions AS (
+    DELETE FROM list_distributions WHERE list_id IN (SELECT id FROM purged)
+), scope_creatures AS (
+    DELETE FROM list_scope_creatures WHERE list_id IN (SELECT id FROM purged)
+), scopes AS (
+    DELETE FROM list_scopes WHERE list_id IN (SELECT id FROM purged)
+), redirects AS (
+    DELETE FROM list_share_redirects WHERE list_id IN (SELECT id FROM purged)
+), reactivations AS (
+    DELETE FROM list_reactivations WHERE list_id IN (SELECT id FROM purged)
+)
+DELETE FROM lists
+WHERE id IN (SELECT id FROM purged)
+RETURNING id
+`
+
+// Removes lists deleted before the cutoff together with every row that references them
+func (q *Queries) PurgeDeletedLists(ctx context.Context, deletedBefore pgtype.Timestamptz) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, purgeDeletedLists, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeRemovedListSoulcores = `-- name: PurgeRemovedListSoulcores :execrows
 DELETE FROM lists_soulcores
-WHERE list_id = $1 AND creature_id = $2
+WHERE deleted_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeRemovedListSoulcores(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeRemovedListSoulcores, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const removeListCharacter = `-- name: RemoveListCharacter :execrows
+WITH cleared_suggestions AS (
+    DELETE FROM character_soulcore_suggestions
+    WHERE list_id = $1 AND character_id = $3
+)
+DELETE FROM lists_users
+WHERE list_id = $1 AND user_id = $2 AND character_id = $3
+`
+
+type RemoveListCharacterParams struct {
+	ListID      uuid.UUID `json:"list_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	CharacterID uuid.UUID `json:"character_id"`
+}
+
+// Removes a single character of the user from the list with the suggestions made for it
+func (q *Queries) RemoveListCharacter(ctx context.Context, arg RemoveListCharacterParams) (int64, error) {
+	result, err := q.db.Exec(ctx, removeListCharacter, arg.ListID, arg.UserID, arg.CharacterID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const removeListMember = `-- name: RemoveListMember :many
+WITH removed AS (
+    DELETE FROM lists_users
+    WHERE list_id = $1 AND user_id = $2
+    RETURNING character_id
+), cleared_suggestions AS (
+    DELETE FROM character_soulcore_suggestions
+    WHERE list_id = $1 AND character_id IN (SELECT character_id FROM removed)
+)
+SELECT character_id FROM removed
+`
+
+type RemoveListMemberParams struct {
+	ListID uuid.UUID `json:"list_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// Removes every character of the user from the list together with the
+// soulcore suggestions the list generated for them
+func (q *Queries) RemoveListMember(ctx context.Context, arg RemoveListMemberParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, removeListMember, arg.ListID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var character_id uuid.UUID
+		if err := rows.Scan(&character_id); err != nil {
+			return nil, err
+		}
+		items = append(items, character_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeListSoulcore = `-- name: RemoveListSoulcore :exec
+UPDATE lists_soulcores
+SET deleted_at = NOW()
+WHERE list_id = $1 AND creature_id = $2 AND deleted_at IS NULL
 `
 
 type RemoveListSoulcoreParams struct {
@@ -437,24 +1145,279 @@ type RemoveListSoulcoreParams struct {
 	CreatureID uuid.UUID `json:"creature_id"`
 }
 
+// Only marks the soulcore as removed, so it can be restored until the removal is purged
 func (q *Queries) RemoveListSoulcore(ctx context.Context, arg RemoveListSoulcoreParams) error {
 	_, err := q.db.Exec(ctx, removeListSoulcore, arg.ListID, arg.CreatureID)
 	return err
 }
 
-const updateSoulcoreStatus = `-- name: UpdateSoulcoreStatus :exec
+const reserveObtainedSoulcore = `-- name: ReserveObtainedSoulcore :execrows
 UPDATE lists_soulcores
-SET status = $3
-WHERE list_id = $1 AND creature_id = $2
+SET status = 'reserved',
+    reserved_for_user_id = $1::uuid
+WHERE list_id = $2 AND creature_id = $3 AND status = 'obtained' AND deleted_at IS NULL
 `
 
-type UpdateSoulcoreStatusParams struct {
-	ListID     uuid.UUID      `json:"list_id"`
-	CreatureID uuid.UUID      `json:"creature_id"`
-	Status     SoulcoreStatus `json:"status"`
+type ReserveObtainedSoulcoreParams struct {
+	ReservedForUserID uuid.UUID `json:"reserved_for_user_id"`
+	ListID            uuid.UUID `json:"list_id"`
+	CreatureID        uuid.UUID `json:"creature_id"`
+}
+
+// ReserveObtainedSoulcore reserves a soulcore for a member only while it is still obtained,
+// so a core unlocked or traded in the meantime affects no rows
+func (q *Queries) ReserveObtainedSoulcore(ctx context.Context, arg ReserveObtainedSoulcoreParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reserveObtainedSoulcore, arg.ReservedForUserID, arg.ListID, arg.CreatureID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreList = `-- name: RestoreList :one
+UPDATE lists
+SET deleted_at = NULL, updated_at = NOW()
+WHERE id = $1
+  AND author_id = $2
+  AND deleted_at > $3::timestamptz
+  AND NOT EXISTS (SELECT 1 FROM list_share_redirects r WHERE r.source_list_id = lists.id)
+RETURNING id, author_id, name, share_code, world, created_at, updated_at, deleted_at, share_code_enabled, approval_required, visibility
+`
+
+type RestoreListParams struct {
+	ID              uuid.UUID          `json:"id"`
+	AuthorID        uuid.UUID          `json:"author_id"`
+	RestorableSince pgtype.Timestamptz `json:"restorable_since"`
+}
+
+// Lists that were merged into another list cannot be restored
+func (q *Queries) RestoreList(ctx context.Context, arg RestoreListParams) (List, error) {
+	row := q.db.QueryRow(ctx, restoreList, arg.ID, arg.AuthorID, arg.RestorableSince)
+	var i List
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.Name,
+		&i.ShareCode,
+		&i.World,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ShareCodeEnabled,
+		&i.ApprovalRequired,
+		&i.Visibility,
+	)
+	return i, err
 }
 
-func (q *Queries) UpdateSoulcoreStatus(ctx context.Context, arg UpdateSoulcoreStatusParams) error {
-	_, err := q.db.Exec(ctx, updateSoulcoreStatus, arg.ListID, arg.CreatureID, arg.Status)
+const restoreListSoulcore = `-- name: RestoreListSoulcore :exec
+UPDATE lists_soulcores
+SET deleted_at = NULL
+WHERE list_id = $1 AND creature_id = $2 AND deleted_at IS NOT NULL
+`
+
+type RestoreListSoulcoreParams struct {
+	ListID     uuid.UUID `json:"list_id"`
+	CreatureID uuid.UUID `json:"creature_id"`
+}
+
+func (q *Queries) RestoreListSoulcore(ctx context.Context, arg RestoreListSoulcoreParams) error {
+	_, err := q.db.Exec(ctx, restoreListSoulcore, arg.ListID, arg.CreatureID)
 	return err
 }
+
+const rotateListShareCode = `-- name: RotateListShareCode :one
+UPDATE lists
+SET share_code = gen_random_uuid(), share_code_enabled = true, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, author_id, name, share_code, world, created_at, updated_at, deleted_at, share_code_enabled, approval_required, visibility
+`
+
+func (q *Queries) RotateListShareCode(ctx context.Context, id uuid.UUID) (List, error) {
+	row := q.db.QueryRow(ctx, rotateListShareCode, id)
+	var i List
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.Name,
+		&i.ShareCode,
+		&i.World,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ShareCodeEnabled,
+		&i.ApprovalRequired,
+		&i.Visibility,
+	)
+	return i, err
+}
+
+const softDeleteList = `-- name: SoftDeleteList :execrows
+UPDATE lists
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteList(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteList, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const swapListCharacter = `-- name: SwapListCharacter :execrows
+WITH cleared_suggestions AS (
+    DELETE FROM character_soulcore_suggestions
+    WHERE list_id = $1 AND character_id = $2
+)
+UPDATE lists_users
+SET character_id = $3, active = true
+WHERE list_id = $1 AND user_id = $4 AND character_id = $2
+`
+
+type SwapListCharacterParams struct {
+	ListID         uuid.UUID `json:"list_id"`
+	CharacterID    uuid.UUID `json:"character_id"`
+	NewCharacterID uuid.UUID `json:"new_character_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+// Puts another character of the user in place of one of theirs, keeping the role. Soulcores
+// are credited to the user, so their contributions stay. Suggestions the list made for the
+// replaced character are cleared.
+func (q *Queries) SwapListCharacter(ctx context.Context, arg SwapListCharacterParams) (int64, error) {
+	result, err := q.db.Exec(ctx, swapListCharacter,
+		arg.ListID,
+		arg.CharacterID,
+		arg.NewCharacterID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const transferListOwnership = `-- name: TransferListOwnership :execrows
+WITH updated_list AS (
+    UPDATE lists
+    SET author_id = $2, updated_at = NOW()
+    WHERE id = $1
+    RETURNING id
+)
+UPDATE lists_users
+SET role = CASE WHEN user_id = $2 THEN 'owner'::list_role ELSE 'moderator'::list_role END
+WHERE list_id = (SELECT id FROM updated_list)
+  AND (user_id = $2 OR role = 'owner')
+`
+
+type TransferListOwnershipParams struct {
+	ID       uuid.UUID `json:"id"`
+	AuthorID uuid.UUID `json:"author_id"`
+}
+
+// Moves author_id and the owner role in one statement, the previous owner stays as moderator
+func (q *Queries) TransferListOwnership(ctx context.Context, arg TransferListOwnershipParams) (int64, error) {
+	result, err := q.db.Exec(ctx, transferListOwnership, arg.ID, arg.AuthorID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateList = `-- name: UpdateList :one
+UPDATE lists
+SET name = COALESCE($1, name),
+    world = COALESCE($2, world),
+    approval_required = COALESCE($3, approval_required),
+    visibility = COALESCE($4, visibility),
+    updated_at = NOW()
+WHERE id = $5 AND deleted_at IS NULL
+RETURNING id, author_id, name, share_code, world, created_at, updated_at, deleted_at, share_code_enabled, approval_required, visibility
+`
+
+type UpdateListParams struct {
+	Name             pgtype.Text        `json:"name"`
+	World            pgtype.Text        `json:"world"`
+	ApprovalRequired pgtype.Bool        `json:"approval_required"`
+	Visibility       NullListVisibility `json:"visibility"`
+	ID               uuid.UUID          `json:"id"`
+}
+
+func (q *Queries) UpdateList(ctx context.Context, arg UpdateListParams) (List, error) {
+	row := q.db.QueryRow(ctx, updateList,
+		arg.Name,
+		arg.World,
+		arg.ApprovalRequired,
+		arg.Visibility,
+		arg.ID,
+	)
+	var i List
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.Name,
+		&i.ShareCode,
+		&i.World,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ShareCodeEnabled,
+		&i.ApprovalRequired,
+		&i.Visibility,
+	)
+	return i, err
+}
+
+const updateListMemberRole = `-- name: UpdateListMemberRole :execrows
+UPDATE lists_users
+SET role = $3
+WHERE list_id = $1 AND user_id = $2
+`
+
+type UpdateListMemberRoleParams struct {
+	ListID uuid.UUID `json:"list_id"`
+	UserID uuid.UUID `json:"user_id"`
+	Role   ListRole  `json:"role"`
+}
+
+func (q *Queries) UpdateListMemberRole(ctx context.Context, arg UpdateListMemberRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateListMemberRole, arg.ListID, arg.UserID, arg.Role)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateSoulcoreStatus = `-- name: UpdateSoulcoreStatus :execrows
+UPDATE lists_soulcores
+SET status = $1,
+    reserved_for_user_id = NULLIF($2::uuid, '00000000-0000-0000-0000-000000000000'::uuid)
+WHERE list_id = $3 AND creature_id = $4 AND status = $5 AND deleted_at IS NULL
+`
+
+type UpdateSoulcoreStatusParams struct {
+	Status            SoulcoreStatus `json:"status"`
+	ReservedForUserID uuid.UUID      `json:"reserved_for_user_id"`
+	ListID            uuid.UUID      `json:"list_id"`
+	CreatureID        uuid.UUID      `json:"creature_id"`
+	FromStatus        SoulcoreStatus `json:"from_status"`
+}
+
+// Sets the status along with the member a reserved core is earmarked for, a zero
+// reserved_for_user_id clears the reservation. Only a soulcore still in from_status
+// changes, so a status changed in the meantime affects no rows.
+func (q *Queries) UpdateSoulcoreStatus(ctx context.Context, arg UpdateSoulcoreStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateSoulcoreStatus,
+		arg.Status,
+		arg.ReservedForUserID,
+		arg.ListID,
+		arg.CreatureID,
+		arg.FromStatus,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
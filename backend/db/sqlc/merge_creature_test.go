@@ -0,0 +1,131 @@
+package db_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// migratedTx starts a transaction on the database named by TEST_DATABASE_URL, which has to
+// be migrated with goose up. Everything a test does is rolled back afterwards.
+func migratedTx(t *testing.T) pgx.Tx {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, url)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close(ctx) })
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { tx.Rollback(ctx) })
+	return tx
+}
+
+func insertID(t *testing.T, tx pgx.Tx, query string, args ...any) uuid.UUID {
+	var id uuid.UUID
+	require.NoError(t, tx.QueryRow(context.Background(), query, args...).Scan(&id))
+	return id
+}
+
+func TestMergeCreatureKeepsProgress(t *testing.T) {
+	tx := migratedTx(t)
+	ctx := context.Background()
+	suffix := uuid.NewString()
+
+	owner := insertID(t, tx, `INSERT INTO users DEFAULT VALUES RETURNING id`)
+	member := insertID(t, tx, `INSERT INTO users DEFAULT VALUES RETURNING id`)
+	character := insertID(t, tx, `INSERT INTO characters (user_id, name, world) VALUES ($1, $2, 'Antica') RETURNING id`,
+		member, "Knight "+suffix)
+
+	source := insertID(t, tx, `INSERT INTO creatures (name) VALUES ($1) RETURNING id`, "Duplicate "+suffix)
+	target := insertID(t, tx, `INSERT INTO creatures (name) VALUES ($1) RETURNING id`, "Original "+suffix)
+
+	newList := func() uuid.UUID {
+		return insertID(t, tx, `INSERT INTO lists (author_id, name, world) VALUES ($1, 'Hunt', 'Antica') RETURNING id`, owner)
+	}
+	addSoulcore := func(list, creature, addedBy uuid.UUID, status string, reservedFor *uuid.UUID, removed bool) {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO lists_soulcores (list_id, creature_id, added_by_user_id, status, reserved_for_user_id, deleted_at)
+			VALUES ($1, $2, $3, $4, $5, CASE WHEN $6::bool THEN NOW() END)`,
+			list, creature, addedBy, status, reservedFor, removed)
+		require.NoError(t, err)
+	}
+
+	// The source is further along and takes over the target's row
+	unlockedSource := newList()
+	addSoulcore(unlockedSource, target, owner, "wanted", nil, false)
+	addSoulcore(unlockedSource, source, member, "unlocked", nil, false)
+
+	// The source carries its reservation over
+	reservedSource := newList()
+	addSoulcore(reservedSource, target, owner, "obtained", nil, false)
+	addSoulcore(reservedSource, source, member, "reserved", &member, false)
+
+	// The target is further along and stays as it is
+	obtainedTarget := newList()
+	addSoulcore(obtainedTarget, target, owner, "obtained", nil, false)
+	addSoulcore(obtainedTarget, source, member, "wanted", nil, false)
+
+	// A removed soulcore never wins over a live one
+	removedSource := newList()
+	addSoulcore(removedSource, target, owner, "wanted", nil, false)
+	addSoulcore(removedSource, source, member, "unlocked", nil, true)
+
+	// Only the source is in the list
+	sourceOnly := newList()
+	addSoulcore(sourceOnly, source, member, "traded", nil, false)
+
+	// A live unlock wins over a removed one
+	_, err := tx.Exec(ctx, `
+		INSERT INTO characters_soulcores (character_id, creature_id, deleted_at) VALUES ($1, $2, NOW()), ($1, $3, NULL)`,
+		character, target, source)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(ctx, `SELECT merge_creature($1, $2)`, "Duplicate "+suffix, "Original "+suffix)
+	require.NoError(t, err)
+
+	type soulcore struct {
+		status      string
+		addedBy     uuid.UUID
+		reservedFor *uuid.UUID
+		removed     bool
+	}
+	get := func(list uuid.UUID) soulcore {
+		var sc soulcore
+		err := tx.QueryRow(ctx, `
+			SELECT status::text, added_by_user_id, reserved_for_user_id, deleted_at IS NOT NULL
+			FROM lists_soulcores WHERE list_id = $1 AND creature_id = $2`, list, target).
+			Scan(&sc.status, &sc.addedBy, &sc.reservedFor, &sc.removed)
+		require.NoError(t, err)
+		return sc
+	}
+
+	require.Equal(t, soulcore{status: "unlocked", addedBy: member}, get(unlockedSource))
+	require.Equal(t, soulcore{status: "reserved", addedBy: member, reservedFor: &member}, get(reservedSource))
+	require.Equal(t, soulcore{status: "obtained", addedBy: owner}, get(obtainedTarget))
+	require.Equal(t, soulcore{status: "wanted", addedBy: owner}, get(removedSource))
+	require.Equal(t, soulcore{status: "traded", addedBy: member}, get(sourceOnly))
+
+	var unlockRemoved bool
+	err = tx.QueryRow(ctx, `
+		SELECT deleted_at IS NOT NULL FROM characters_soulcores WHERE character_id = $1 AND creature_id = $2`,
+		character, target).Scan(&unlockRemoved)
+	require.NoError(t, err)
+	require.False(t, unlockRemoved)
+
+	var left int
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM lists_soulcores WHERE creature_id = $1) +
+			(SELECT COUNT(*) FROM characters_soulcores WHERE creature_id = $1) +
+			(SELECT COUNT(*) FROM creatures WHERE id = $1)`, source).Scan(&left)
+	require.NoError(t, err)
+	require.Zero(t, left)
+}
@@ -6,16 +6,244 @@ package db
 
 import (
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type CreatureAliasKind string
+
+const (
+	CreatureAliasKindHistorical CreatureAliasKind = "historical"
+	CreatureAliasKindMerged     CreatureAliasKind = "merged"
+	CreatureAliasKindCommunity  CreatureAliasKind = "community"
+)
+
+func (e *CreatureAliasKind) Scan(src any) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CreatureAliasKind(s)
+	case string:
+		*e = CreatureAliasKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CreatureAliasKind: %T", src)
+	}
+	return nil
+}
+
+type NullCreatureAliasKind struct {
+	CreatureAliasKind CreatureAliasKind `json:"creature_alias_kind"`
+	Valid             bool              `json:"valid"` // Valid is true if CreatureAliasKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCreatureAliasKind) Scan(value any) error {
+	if value == nil {
+		ns.CreatureAliasKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CreatureAliasKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCreatureAliasKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CreatureAliasKind), nil
+}
+
+type DistributionMode string
+
+const (
+	DistributionModeNeed        DistributionMode = "need"
+	DistributionModeRoundRobin  DistributionMode = "round_robin"
+	DistributionModeFewestCores DistributionMode = "fewest_cores"
+	DistributionModeRandom      DistributionMode = "random"
+)
+
+func (e *DistributionMode) Scan(src any) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DistributionMode(s)
+	case string:
+		*e = DistributionMode(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DistributionMode: %T", src)
+	}
+	return nil
+}
+
+type NullDistributionMode struct {
+	DistributionMode DistributionMode `json:"distribution_mode"`
+	Valid            bool             `json:"valid"` // Valid is true if DistributionMode is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDistributionMode) Scan(value any) error {
+	if value == nil {
+		ns.DistributionMode, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DistributionMode.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDistributionMode) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DistributionMode), nil
+}
+
+type ListActivityAction string
+
+const (
+	ListActivityActionSoulcoreAdded          ListActivityAction = "soulcore_added"
+	ListActivityActionSoulcoreRemoved        ListActivityAction = "soulcore_removed"
+	ListActivityActionSoulcoreRestored       ListActivityAction = "soulcore_restored"
+	ListActivityActionSoulcoreStatusChanged  ListActivityAction = "soulcore_status_changed"
+	ListActivityActionMemberJoined           ListActivityAction = "member_joined"
+	ListActivityActionMemberLeft             ListActivityAction = "member_left"
+	ListActivityActionMemberRemoved          ListActivityAction = "member_removed"
+	ListActivityActionMemberCharacterChanged ListActivityAction = "member_character_changed"
+	ListActivityActionCharacterClaimed       ListActivityAction = "character_claimed"
+	ListActivityActionChatMessageDeleted     ListActivityAction = "chat_message_deleted"
+)
+
+func (e *ListActivityAction) Scan(src any) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ListActivityAction(s)
+	case string:
+		*e = ListActivityAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ListActivityAction: %T", src)
+	}
+	return nil
+}
+
+type NullListActivityAction struct {
+	ListActivityAction ListActivityAction `json:"list_activity_action"`
+	Valid              bool               `json:"valid"` // Valid is true if ListActivityAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullListActivityAction) Scan(value any) error {
+	if value == nil {
+		ns.ListActivityAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ListActivityAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullListActivityAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ListActivityAction), nil
+}
+
+type ListRole string
+
+const (
+	ListRoleOwner     ListRole = "owner"
+	ListRoleModerator ListRole = "moderator"
+	ListRoleMember    ListRole = "member"
+	ListRoleViewer    ListRole = "viewer"
+)
+
+func (e *ListRole) Scan(src any) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ListRole(s)
+	case string:
+		*e = ListRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ListRole: %T", src)
+	}
+	return nil
+}
+
+type NullListRole struct {
+	ListRole ListRole `json:"list_role"`
+	Valid    bool     `json:"valid"` // Valid is true if ListRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullListRole) Scan(value any) error {
+	if value == nil {
+		ns.ListRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ListRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullListRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ListRole), nil
+}
+
+type ListVisibility string
+
+const (
+	ListVisibilityPrivate  ListVisibility = "private"
+	ListVisibilityUnlisted ListVisibility = "unlisted"
+	ListVisibilityPublic   ListVisibility = "public"
+)
+
+func (e *ListVisibility) Scan(src any) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ListVisibility(s)
+	case string:
+		*e = ListVisibility(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ListVisibility: %T", src)
+	}
+	return nil
+}
+
+type NullListVisibility struct {
+	ListVisibility ListVisibility `json:"list_visibility"`
+	Valid          bool           `json:"valid"` // Valid is true if ListVisibility is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullListVisibility) Scan(value any) error {
+	if value == nil {
+		ns.ListVisibility, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ListVisibility.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullListVisibility) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ListVisibility), nil
+}
+
 type SoulcoreStatus string
 
 const (
+	SoulcoreStatusWanted   SoulcoreStatus = "wanted"
+	SoulcoreStatusReserved SoulcoreStatus = "reserved"
 	SoulcoreStatusObtained SoulcoreStatus = "obtained"
+	SoulcoreStatusTraded   SoulcoreStatus = "traded"
 	SoulcoreStatusUnlocked SoulcoreStatus = "unlocked"
 )
 
@@ -85,22 +313,59 @@ type CharactersSoulcore struct {
 	CharacterID uuid.UUID          `json:"character_id"`
 	CreatureID  uuid.UUID          `json:"creature_id"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
 }
 
 type Creature struct {
-	ID         uuid.UUID   `json:"id"`
-	Name       string      `json:"name"`
-	Difficulty pgtype.Int4 `json:"difficulty"`
+	ID            uuid.UUID   `json:"id"`
+	Name          string      `json:"name"`
+	Difficulty    pgtype.Int4 `json:"difficulty"`
+	BestiaryClass pgtype.Text `json:"bestiary_class"`
+	Race          pgtype.Text `json:"race"`
+	CharmPoints   pgtype.Int4 `json:"charm_points"`
+	Locations     []string    `json:"locations"`
+	Sprite        pgtype.Text `json:"sprite"`
+}
+
+type CreatureAlias struct {
+	ID         uuid.UUID          `json:"id"`
+	CreatureID uuid.UUID          `json:"creature_id"`
+	Name       string             `json:"name"`
+	Kind       CreatureAliasKind  `json:"kind"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type CreatureTranslation struct {
+	CreatureID uuid.UUID `json:"creature_id"`
+	Language   string    `json:"language"`
+	Name       string    `json:"name"`
 }
 
 type List struct {
-	ID        uuid.UUID          `json:"id"`
-	AuthorID  uuid.UUID          `json:"author_id"`
-	Name      string             `json:"name"`
-	ShareCode uuid.UUID          `json:"share_code"`
-	World     string             `json:"world"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID               uuid.UUID          `json:"id"`
+	AuthorID         uuid.UUID          `json:"author_id"`
+	Name             string             `json:"name"`
+	ShareCode        uuid.UUID          `json:"share_code"`
+	World            string             `json:"world"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	ShareCodeEnabled bool               `json:"share_code_enabled"`
+	ApprovalRequired bool               `json:"approval_required"`
+	Visibility       ListVisibility     `json:"visibility"`
+}
+
+type ListActivity struct {
+	ID           uuid.UUID          `json:"id"`
+	ListID       uuid.UUID          `json:"list_id"`
+	ActorID      uuid.UUID          `json:"actor_id"`
+	CharacterID  uuid.UUID          `json:"character_id"`
+	Action       ListActivityAction `json:"action"`
+	CreatureID   uuid.UUID          `json:"creature_id"`
+	TargetUserID uuid.UUID          `json:"target_user_id"`
+	OldValue     pgtype.Text        `json:"old_value"`
+	NewValue     pgtype.Text        `json:"new_value"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 }
 
 type ListChatMessage struct {
@@ -112,6 +377,72 @@ type ListChatMessage struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
+type ListDistribution struct {
+	ID          uuid.UUID          `json:"id"`
+	ListID      uuid.UUID          `json:"list_id"`
+	CreatedBy   uuid.UUID          `json:"created_by"`
+	Mode        DistributionMode   `json:"mode"`
+	Seed        int64              `json:"seed"`
+	Assignments json.RawMessage    `json:"assignments"`
+	Reserved    bool               `json:"reserved"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type ListInvite struct {
+	ID        uuid.UUID          `json:"id"`
+	ListID    uuid.UUID          `json:"list_id"`
+	Code      uuid.UUID          `json:"code"`
+	CreatedBy uuid.UUID          `json:"created_by"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	MaxUses   pgtype.Int4        `json:"max_uses"`
+	UseCount  int32              `json:"use_count"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ListJoinRequest struct {
+	ListID      uuid.UUID          `json:"list_id"`
+	UserID      uuid.UUID          `json:"user_id"`
+	CharacterID uuid.UUID          `json:"character_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type ListReactivation struct {
+	ListID         uuid.UUID          `json:"list_id"`
+	CharacterID    uuid.UUID          `json:"character_id"`
+	PreviousUserID uuid.UUID          `json:"previous_user_id"`
+	UserID         uuid.UUID          `json:"user_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type ListRemovedMember struct {
+	ListID    uuid.UUID          `json:"list_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	RemovedBy uuid.UUID          `json:"removed_by"`
+	RemovedAt pgtype.Timestamptz `json:"removed_at"`
+}
+
+type ListScope struct {
+	ListID        uuid.UUID          `json:"list_id"`
+	MinDifficulty pgtype.Int4        `json:"min_difficulty"`
+	MaxDifficulty pgtype.Int4        `json:"max_difficulty"`
+	TargetDate    pgtype.Date        `json:"target_date"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ListScopeCreature struct {
+	ListID     uuid.UUID `json:"list_id"`
+	CreatureID uuid.UUID `json:"creature_id"`
+	Excluded   bool      `json:"excluded"`
+}
+
+type ListShareRedirect struct {
+	ShareCode    uuid.UUID          `json:"share_code"`
+	SourceListID uuid.UUID          `json:"source_list_id"`
+	ListID       uuid.UUID          `json:"list_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
 type ListUserReadStatus struct {
 	UserID     uuid.UUID          `json:"user_id"`
 	ListID     uuid.UUID          `json:"list_id"`
@@ -119,10 +450,12 @@ type ListUserReadStatus struct {
 }
 
 type ListsSoulcore struct {
-	ListID        uuid.UUID      `json:"list_id"`
-	CreatureID    uuid.UUID      `json:"creature_id"`
-	AddedByUserID uuid.UUID      `json:"added_by_user_id"`
-	Status        SoulcoreStatus `json:"status"`
+	ListID            uuid.UUID          `json:"list_id"`
+	CreatureID        uuid.UUID          `json:"creature_id"`
+	AddedByUserID     uuid.UUID          `json:"added_by_user_id"`
+	Status            SoulcoreStatus     `json:"status"`
+	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	ReservedForUserID uuid.UUID          `json:"reserved_for_user_id"`
 }
 
 type ListsUser struct {
@@ -130,6 +463,7 @@ type ListsUser struct {
 	UserID      uuid.UUID `json:"user_id"`
 	CharacterID uuid.UUID `json:"character_id"`
 	Active      bool      `json:"active"`
+	Role        ListRole  `json:"role"`
 }
 
 type User struct {
@@ -12,54 +12,213 @@ import (
 )
 
 type Querier interface {
-	AddCharacterSoulcore(ctx context.Context, arg AddCharacterSoulcoreParams) error
+	// Adding a removed soulcore again revives it, adding one the character already has
+	// affects no rows
+	AddCharacterSoulcore(ctx context.Context, arg AddCharacterSoulcoreParams) (int64, error)
 	AddListCharacter(ctx context.Context, arg AddListCharacterParams) error
+	AddListScopeCreatures(ctx context.Context, arg AddListScopeCreaturesParams) error
+	// A zero reserved_for_user_id is stored as NULL
 	AddSoulcoreToList(ctx context.Context, arg AddSoulcoreToListParams) error
+	// AddSoulcoreToListIfMissing adds a soulcore unless the list already has it, which
+	// leaves the existing entry untouched and affects no rows. Removed entries are replaced.
+	AddSoulcoreToListIfMissing(ctx context.Context, arg AddSoulcoreToListIfMissingParams) (int64, error)
+	// Turns a pending reactivation into a membership of the new owner in one statement. The
+	// character takes the user's role when they are in the list already. No row is returned
+	// when the reactivation is gone, the list was deleted or the character changed hands again.
+	AcceptListReactivation(ctx context.Context, arg AcceptListReactivationParams) (ListRole, error)
+	// Turns a pending request into a membership in one statement. No row is returned
+	// when the request is gone or its character changed hands in the meantime.
+	ApproveListJoinRequest(ctx context.Context, arg ApproveListJoinRequestParams) (uuid.UUID, error)
+	// Copies the active members of a list whose character is on the clone's world, except the user
+	// cloning it who already owns the clone. The source owner joins the clone as a moderator.
+	CloneListMembers(ctx context.Context, arg CloneListMembersParams) (int64, error)
+	// Copies the soulcores of a list into its clone. Cores added by users who are not in the
+	// clone are credited to the user cloning it, reservations for them are released.
+	CloneListSoulcores(ctx context.Context, arg CloneListSoulcoresParams) (int64, error)
+	CountCreatures(ctx context.Context) (int64, error)
+	CountListMembersOutsideWorld(ctx context.Context, arg CountListMembersOutsideWorldParams) (int64, error)
 	CreateAnonymousUser(ctx context.Context, id uuid.UUID) (User, error)
 	CreateCharacter(ctx context.Context, arg CreateCharacterParams) (Character, error)
 	CreateCharacterClaim(ctx context.Context, arg CreateCharacterClaimParams) (CharacterClaim, error)
 	CreateChatMessage(ctx context.Context, arg CreateChatMessageParams) (ListChatMessage, error)
+	CreateCreature(ctx context.Context, arg CreateCreatureParams) error
 	CreateList(ctx context.Context, arg CreateListParams) (List, error)
+	// Appends an entry to the activity log of a list. The actor's character in the list is
+	// looked up from their membership, zero creature and target user IDs are stored as NULL.
+	CreateListActivity(ctx context.Context, arg CreateListActivityParams) error
+	CreateListDistribution(ctx context.Context, arg CreateListDistributionParams) (ListDistribution, error)
+	CreateListInvite(ctx context.Context, arg CreateListInviteParams) (ListInvite, error)
+	CreateListJoinRequest(ctx context.Context, arg CreateListJoinRequestParams) error
+	// Offers the active list memberships of a character to the user claiming it. Runs before
+	// the memberships are deactivated.
+	CreateListReactivations(ctx context.Context, arg CreateListReactivationsParams) ([]CreateListReactivationsRow, error)
+	CreateListRemoval(ctx context.Context, arg CreateListRemovalParams) error
+	CreateListShareRedirect(ctx context.Context, arg CreateListShareRedirectParams) error
 	CreateSoulcoreSuggestion(ctx context.Context, arg CreateSoulcoreSuggestionParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	DeactivateCharacterListMemberships(ctx context.Context, characterID uuid.UUID) error
 	DeleteAllChatMessages(ctx context.Context, listID uuid.UUID) error
 	DeleteChatMessage(ctx context.Context, arg DeleteChatMessageParams) error
+	// DeleteCreature fails for creatures that are still referenced, those have to be merged instead
+	DeleteCreature(ctx context.Context, name string) (int64, error)
+	DeleteCreatureTranslation(ctx context.Context, arg DeleteCreatureTranslationParams) error
+	DeleteListJoinRequest(ctx context.Context, arg DeleteListJoinRequestParams) (int64, error)
+	DeleteListReactivation(ctx context.Context, arg DeleteListReactivationParams) (int64, error)
+	DeleteListRemoval(ctx context.Context, arg DeleteListRemovalParams) (int64, error)
+	DeleteListScopeCreatures(ctx context.Context, listID uuid.UUID) error
 	DeleteSoulcoreSuggestion(ctx context.Context, arg DeleteSoulcoreSuggestionParams) error
+	DisableListShareCode(ctx context.Context, id uuid.UUID) (int64, error)
+	// GetAllCreatureAliases returns every alias along with the current name of its creature
+	GetAllCreatureAliases(ctx context.Context) ([]GetAllCreatureAliasesRow, error)
+	// GetAllCreatureTranslations returns every translation along with the name of its creature
+	GetAllCreatureTranslations(ctx context.Context) ([]GetAllCreatureTranslationsRow, error)
 	GetCharacter(ctx context.Context, id uuid.UUID) (Character, error)
 	GetCharacterByName(ctx context.Context, name string) (Character, error)
 	GetCharacterClaim(ctx context.Context, arg GetCharacterClaimParams) (CharacterClaim, error)
-	GetCharacterSoulcores(ctx context.Context, characterID uuid.UUID) ([]GetCharacterSoulcoresRow, error)
-	GetCharacterSuggestions(ctx context.Context, characterID uuid.UUID) ([]GetCharacterSuggestionsRow, error)
+	GetCharacterListIDs(ctx context.Context, characterID uuid.UUID) ([]uuid.UUID, error)
+	// localized_name is the creature's name in lang, or its English name without a translation
+	GetCharacterSoulcores(ctx context.Context, arg GetCharacterSoulcoresParams) ([]GetCharacterSoulcoresRow, error)
+	// localized_name is the creature's name in lang, or its English name without a translation
+	GetCharacterSuggestions(ctx context.Context, arg GetCharacterSuggestionsParams) ([]GetCharacterSuggestionsRow, error)
 	GetCharactersByUserID(ctx context.Context, userID uuid.UUID) ([]Character, error)
+	GetChatMessage(ctx context.Context, arg GetChatMessageParams) (ListChatMessage, error)
 	GetChatMessages(ctx context.Context, arg GetChatMessagesParams) ([]GetChatMessagesRow, error)
 	GetChatMessagesByTimestamp(ctx context.Context, arg GetChatMessagesByTimestampParams) ([]GetChatMessagesByTimestampRow, error)
 	GetChatNotificationsForUser(ctx context.Context, userID uuid.UUID) ([]GetChatNotificationsForUserRow, error)
 	GetClaimByID(ctx context.Context, id uuid.UUID) (GetClaimByIDRow, error)
-	GetCreatures(ctx context.Context) ([]Creature, error)
+	GetCreatureAliases(ctx context.Context, creatureID uuid.UUID) ([]CreatureAlias, error)
+	// GetCreatureByName looks a creature up by name, ignoring case, falling back to its aliases
+	GetCreatureByName(ctx context.Context, name string) (Creature, error)
+	// GetCreatureIDs returns the IDs out of ids that belong to known creatures
+	GetCreatureIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	// GetCreatures lists the creatures matching every filter that is set. Class, race and
+	// location are compared ignoring case, location matches any of a creature's locations.
+	// localized_name is the creature's name in lang, or its English name without a translation.
+	GetCreatures(ctx context.Context, arg GetCreaturesParams) ([]GetCreaturesRow, error)
+	// GetCreaturesByNames looks creatures up by name or alias, ignoring case. names must be lower case.
+	// lookup_name is the name out of names a creature was found by.
+	GetCreaturesByNames(ctx context.Context, names []string) ([]GetCreaturesByNamesRow, error)
 	GetHighscoreCharacters(ctx context.Context, arg GetHighscoreCharactersParams) ([]GetHighscoreCharactersRow, error)
 	GetList(ctx context.Context, id uuid.UUID) (List, error)
+	// Returns a page of the activity log of a list, newest first. A non-zero member_id only
+	// keeps entries where that member either acted or was acted upon.
+	GetListActivity(ctx context.Context, arg GetListActivityParams) ([]GetListActivityRow, error)
+	// Resolves the share code of a merged list to the list it was merged into
+	GetListByRedirectCode(ctx context.Context, shareCode uuid.UUID) (List, error)
 	GetListByShareCode(ctx context.Context, shareCode uuid.UUID) (List, error)
+	GetListDistribution(ctx context.Context, arg GetListDistributionParams) (ListDistribution, error)
+	// Returns a page of the distributions of a list, newest first
+	GetListDistributions(ctx context.Context, arg GetListDistributionsParams) ([]GetListDistributionsRow, error)
+	// Returns every creature in the scope of a list that an active, non-viewer member still has
+	// to unlock, with the members who need it and the status of the list's own soulcore, if any
+	GetListHuntCandidates(ctx context.Context, listID uuid.UUID) ([]GetListHuntCandidatesRow, error)
+	GetListInvite(ctx context.Context, arg GetListInviteParams) (ListInvite, error)
+	GetListInviteByCode(ctx context.Context, code uuid.UUID) (ListInvite, error)
+	GetListInvites(ctx context.Context, listID uuid.UUID) ([]ListInvite, error)
+	GetListJoinRequest(ctx context.Context, arg GetListJoinRequestParams) (ListJoinRequest, error)
+	GetListJoinRequests(ctx context.Context, listID uuid.UUID) ([]GetListJoinRequestsRow, error)
+	GetListMemberRole(ctx context.Context, arg GetListMemberRoleParams) (ListRole, error)
+	// The soulcores the characters of a list's members unlocked, one row per character and
+	// creature. in_scope tells whether the creature is in the list's scope.
+	GetListMemberUnlocks(ctx context.Context, listID uuid.UUID) ([]GetListMemberUnlocksRow, error)
+	// One row per character. Soulcores are credited to the user, so obtained_count and
+	// unlocked_count are shared by all of a user's characters while character_unlocked_count
+	// counts the cores the character itself unlocked.
 	GetListMembers(ctx context.Context, listID uuid.UUID) ([]GetListMembersRow, error)
 	GetListMembersWithUnlocks(ctx context.Context, listID uuid.UUID) ([]GetListMembersWithUnlocksRow, error)
+	// Counts the creatures in the scope of a list and how many of them the list obtained and unlocked
+	GetListProgress(ctx context.Context, listID uuid.UUID) (GetListProgressRow, error)
+	// GetListProgressGroups breaks the progress of a list down by the bestiary class or the race
+	// of the creatures in its scope, group_by is 'class' or 'race'. Creatures without one are
+	// counted under a NULL group, which comes last.
+	GetListProgressGroups(ctx context.Context, arg GetListProgressGroupsParams) ([]GetListProgressGroupsRow, error)
+	GetListReactivations(ctx context.Context, listID uuid.UUID) ([]GetListReactivationsRow, error)
+	GetListRemovedMembers(ctx context.Context, listID uuid.UUID) ([]GetListRemovedMembersRow, error)
+	GetListScope(ctx context.Context, listID uuid.UUID) (ListScope, error)
+	GetListScopeCreatures(ctx context.Context, listID uuid.UUID) ([]GetListScopeCreaturesRow, error)
 	GetListSoulcore(ctx context.Context, arg GetListSoulcoreParams) (GetListSoulcoreRow, error)
-	GetListSoulcores(ctx context.Context, listID uuid.UUID) ([]GetListSoulcoresRow, error)
+	// localized_name is the creature's name in lang, or its English name without a translation
+	GetListSoulcores(ctx context.Context, arg GetListSoulcoresParams) ([]GetListSoulcoresRow, error)
+	// Every character the user takes part in the list with
+	GetListUserCharacters(ctx context.Context, arg GetListUserCharactersParams) ([]GetListUserCharactersRow, error)
 	GetListsByAuthorId(ctx context.Context, authorID uuid.UUID) ([]List, error)
 	GetMembers(ctx context.Context, listID uuid.UUID) ([]ListsUser, error)
 	GetPendingClaimsToCheck(ctx context.Context) ([]GetPendingClaimsToCheckRow, error)
 	GetPendingSuggestionsForUser(ctx context.Context, userID uuid.UUID) ([]GetPendingSuggestionsForUserRow, error)
+	GetPublicLists(ctx context.Context, arg GetPublicListsParams) ([]GetPublicListsRow, error)
 	GetUserByEmail(ctx context.Context, email pgtype.Text) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserCharacters(ctx context.Context, userID uuid.UUID) ([]GetUserCharactersRow, error)
+	GetUserListReactivations(ctx context.Context, userID uuid.UUID) ([]GetUserListReactivationsRow, error)
 	GetUserLists(ctx context.Context, authorID uuid.UUID) ([]GetUserListsRow, error)
+	GetRemovedListSoulcore(ctx context.Context, arg GetRemovedListSoulcoreParams) (GetRemovedListSoulcoreRow, error)
 	IsUserListMember(ctx context.Context, arg IsUserListMemberParams) (bool, error)
+	IsUserRemovedFromList(ctx context.Context, arg IsUserRemovedFromListParams) (bool, error)
 	MarkListMessagesAsRead(ctx context.Context, arg MarkListMessagesAsReadParams) error
+	// Moves the members of the source list into the target list. Users who are already in the
+	// target or were removed from it are left out, the source owner joins as a moderator.
+	MergeListMembers(ctx context.Context, arg MergeListMembersParams) (int64, error)
 	MigrateAnonymousUser(ctx context.Context, arg MigrateAnonymousUserParams) (User, error)
+	MoveListChatMessages(ctx context.Context, arg MoveListChatMessagesParams) (int64, error)
+	// Points the redirects leading to the source list at the target list instead
+	MoveListShareRedirects(ctx context.Context, arg MoveListShareRedirectsParams) error
+	MoveListSoulcoreSuggestions(ctx context.Context, arg MoveListSoulcoreSuggestionsParams) error
+	// Removes lists deleted before the cutoff together with every row that references them
+	PurgeDeletedLists(ctx context.Context, deletedBefore pgtype.Timestamptz) ([]uuid.UUID, error)
+	PurgeRemovedCharacterSoulcores(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error)
+	PurgeRemovedListSoulcores(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error)
+	// Hands the reactivations still pending for a character over to its newest owner
+	ReassignListReactivations(ctx context.Context, arg ReassignListReactivationsParams) error
+	// Releases the reservations of a list made for users who are not in it, the soulcores go
+	// back to obtained so the members can reserve them again.
+	ReleaseListReservations(ctx context.Context, listID uuid.UUID) (int64, error)
+	// Leaves a tombstone behind that can be restored until it is purged
 	RemoveCharacterSoulcore(ctx context.Context, arg RemoveCharacterSoulcoreParams) error
+	// Removes a single character of the user from the list with the suggestions made for it
+	RemoveListCharacter(ctx context.Context, arg RemoveListCharacterParams) (int64, error)
+	// Removes every character of the user from the list together with the
+	// soulcore suggestions the list generated for them
+	RemoveListMember(ctx context.Context, arg RemoveListMemberParams) ([]uuid.UUID, error)
+	// Only marks the soulcore as removed, so it can be restored until the removal is purged
 	RemoveListSoulcore(ctx context.Context, arg RemoveListSoulcoreParams) error
+	// RenameCreature renames a creature and keeps its old name as an alias
+	RenameCreature(ctx context.Context, arg RenameCreatureParams) error
+	// ReserveObtainedSoulcore reserves a soulcore for a member only while it is still obtained,
+	// so a core unlocked or traded in the meantime affects no rows
+	ReserveObtainedSoulcore(ctx context.Context, arg ReserveObtainedSoulcoreParams) (int64, error)
+	RestoreCharacterSoulcore(ctx context.Context, arg RestoreCharacterSoulcoreParams) (int64, error)
+	// Lists that were merged into another list cannot be restored
+	RestoreList(ctx context.Context, arg RestoreListParams) (List, error)
+	RestoreListSoulcore(ctx context.Context, arg RestoreListSoulcoreParams) error
+	RevokeListInvite(ctx context.Context, arg RevokeListInviteParams) (int64, error)
+	RotateListShareCode(ctx context.Context, id uuid.UUID) (List, error)
+	// SearchCreatures ranks the creatures whose name or an alias matches query: exact matches
+	// first, then prefix matches, then matches at the start of a word, then anything else
+	// containing query or similar to it, by trigram similarity. query must be lower case.
+	// matched_alias is the alias a creature was found by when it matched better than its name.
+	// Zero character and list IDs leave out the not yet unlocked and not yet in list filters.
+	SearchCreatures(ctx context.Context, arg SearchCreaturesParams) ([]SearchCreaturesRow, error)
+	SoftDeleteList(ctx context.Context, id uuid.UUID) (int64, error)
+	// Puts another character of the user in place of one of theirs, keeping the role. Soulcores
+	// are credited to the user, so their contributions stay. Suggestions the list made for the
+	// replaced character are cleared.
+	SwapListCharacter(ctx context.Context, arg SwapListCharacterParams) (int64, error)
+	// Moves author_id and the owner role in one statement, the previous owner stays as moderator
+	TransferListOwnership(ctx context.Context, arg TransferListOwnershipParams) (int64, error)
 	UpdateCharacterOwner(ctx context.Context, arg UpdateCharacterOwnerParams) (Character, error)
 	UpdateClaimStatus(ctx context.Context, arg UpdateClaimStatusParams) (CharacterClaim, error)
-	UpdateSoulcoreStatus(ctx context.Context, arg UpdateSoulcoreStatusParams) error
+	UpdateCreatureBestiary(ctx context.Context, arg UpdateCreatureBestiaryParams) error
+	UpdateCreatureDifficulty(ctx context.Context, arg UpdateCreatureDifficultyParams) error
+	UpdateList(ctx context.Context, arg UpdateListParams) (List, error)
+	UpdateListMemberRole(ctx context.Context, arg UpdateListMemberRoleParams) (int64, error)
+	// Sets the status along with the member a reserved core is earmarked for, a zero
+	// reserved_for_user_id clears the reservation. Only a soulcore still in from_status
+	// changes, so a status changed in the meantime affects no rows.
+	UpdateSoulcoreStatus(ctx context.Context, arg UpdateSoulcoreStatusParams) (int64, error)
+	UpsertCreatureTranslation(ctx context.Context, arg UpsertCreatureTranslationParams) error
+	UpsertListScope(ctx context.Context, arg UpsertListScopeParams) (ListScope, error)
+	// Counts a use only while the invite is still valid, so concurrent joins cannot exceed max_uses
+	UseListInvite(ctx context.Context, id uuid.UUID) (int64, error)
 	VerifyEmail(ctx context.Context, arg VerifyEmailParams) error
 }
 
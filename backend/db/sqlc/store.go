@@ -1,9 +1,35 @@
 package db
 
-import "github.com/jackc/pgx/v5/pgxpool"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Postgres error codes of transactions that failed only because of concurrent ones
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+)
+
+const (
+	// maxTxAttempts is how often ExecTx runs a transaction before giving up on conflicts
+	maxTxAttempts = 3
+	// txRetryDelay is the base delay between attempts, multiplied by the attempt number
+	txRetryDelay = 10 * time.Millisecond
+)
 
 type Store interface {
 	Querier
+	// ExecTx runs fn inside a serializable transaction and commits it when fn returns nil.
+	// fn may run several times when the transaction conflicts with a concurrent one, so it
+	// must not have side effects outside the database such as publishing events.
+	ExecTx(ctx context.Context, fn func(Querier) error) error
 }
 
 type SQLStore struct {
@@ -17,3 +43,46 @@ func NewStore(connPool *pgxpool.Pool) Store {
 		Queries:  New(connPool),
 	}
 }
+
+func (store *SQLStore) ExecTx(ctx context.Context, fn func(Querier) error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = store.execTx(ctx, fn)
+		if !IsRetryableTxError(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * txRetryDelay):
+		}
+	}
+	return err
+}
+
+func (store *SQLStore) execTx(ctx context.Context, fn func(Querier) error) error {
+	tx, err := store.ConnPool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return err
+	}
+
+	if err := fn(store.WithTx(tx)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return fmt.Errorf("tx err: %w, rollback err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// IsRetryableTxError reports whether err comes from a transaction that was aborted
+// because of a concurrent one and is likely to succeed when run again
+func IsRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailureCode || pgErr.Code == deadlockDetectedCode
+}
@@ -45,23 +45,32 @@ func (q *Queries) DeleteSoulcoreSuggestion(ctx context.Context, arg DeleteSoulco
 }
 
 const getCharacterSuggestions = `-- name: GetCharacterSuggestions :many
-SELECT cs.character_id, cs.creature_id, cs.list_id, cs.suggested_at, c.name as creature_name
+SELECT cs.character_id, cs.creature_id, cs.list_id, cs.suggested_at, c.name as creature_name,
+    COALESCE(t.name, c.name)::text AS localized_name
 FROM character_soulcore_suggestions cs
 JOIN creatures c ON c.id = cs.creature_id
-WHERE cs.character_id = $1
+LEFT JOIN creature_translations t ON t.creature_id = c.id AND t.language = $1::text
+WHERE cs.character_id = $2
 ORDER BY cs.suggested_at DESC
 `
 
+type GetCharacterSuggestionsParams struct {
+	Lang        string    `json:"lang"`
+	CharacterID uuid.UUID `json:"character_id"`
+}
+
 type GetCharacterSuggestionsRow struct {
-	CharacterID  uuid.UUID          `json:"character_id"`
-	CreatureID   uuid.UUID          `json:"creature_id"`
-	ListID       uuid.UUID          `json:"list_id"`
-	SuggestedAt  pgtype.Timestamptz `json:"suggested_at"`
-	CreatureName string             `json:"creature_name"`
+	CharacterID   uuid.UUID          `json:"character_id"`
+	CreatureID    uuid.UUID          `json:"creature_id"`
+	ListID        uuid.UUID          `json:"list_id"`
+	SuggestedAt   pgtype.Timestamptz `json:"suggested_at"`
+	CreatureName  string             `json:"creature_name"`
+	LocalizedName string             `json:"localized_name"`
 }
 
-func (q *Queries) GetCharacterSuggestions(ctx context.Context, characterID uuid.UUID) ([]GetCharacterSuggestionsRow, error) {
-	rows, err := q.db.Query(ctx, getCharacterSuggestions, characterID)
+// localized_name is the creature's name in lang, or its English name without a translation
+func (q *Queries) GetCharacterSuggestions(ctx context.Context, arg GetCharacterSuggestionsParams) ([]GetCharacterSuggestionsRow, error) {
+	rows, err := q.db.Query(ctx, getCharacterSuggestions, arg.Lang, arg.CharacterID)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +84,7 @@ func (q *Queries) GetCharacterSuggestions(ctx context.Context, characterID uuid.
 			&i.ListID,
 			&i.SuggestedAt,
 			&i.CreatureName,
+			&i.LocalizedName,
 		); err != nil {
 			return nil, err
 		}
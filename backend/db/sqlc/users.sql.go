@@ -119,18 +119,27 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 const getUserLists = `-- name: GetUserLists :many
 WITH user_lists AS (
     -- Get lists where user is the author
-    SELECT l.id, l.author_id, l.name, l.share_code, l.world, l.created_at, l.updated_at, lu.character_id, TRUE as is_author
+    SELECT l.*, lu.character_id, TRUE as is_author
     FROM lists l
-    LEFT JOIN lists_users lu ON l.id = lu.list_id AND lu.user_id = l.author_id
-    WHERE l.author_id = $1
+    LEFT JOIN LATERAL (
+        SELECT character_id FROM lists_users
+        WHERE list_id = l.id AND user_id = l.author_id
+        ORDER BY active DESC, character_id
+        LIMIT 1
+    ) lu ON true
+    WHERE l.author_id = $1 AND l.deleted_at IS NULL
     
     UNION ALL
     
-    -- Get lists where user is a member
-    SELECT l.id, l.author_id, l.name, l.share_code, l.world, l.created_at, l.updated_at, lu.character_id, FALSE as is_author
+    -- Get lists where user is a member, once even with several characters in it
+    SELECT l.*, lu.character_id, FALSE as is_author
     FROM lists l
-    JOIN lists_users lu ON l.id = lu.list_id
-    WHERE lu.user_id = $1 AND l.author_id != $1
+    JOIN (
+        SELECT DISTINCT ON (list_id) list_id, character_id FROM lists_users
+        WHERE user_id = $1
+        ORDER BY list_id, active DESC, character_id
+    ) lu ON l.id = lu.list_id
+    WHERE l.author_id != $1 AND l.deleted_at IS NULL
 )
 SELECT DISTINCT
     ul.id,
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+)
+
+// maxBatchSize limits the items of a batch request. It leaves room for every creature.
+const maxBatchSize = 1000
+
+// BatchResult tells what a batch request did with a single item
+type BatchResult string
+
+const (
+	BatchResultAdded             BatchResult = "added"
+	BatchResultAlreadyPresent    BatchResult = "already_present"
+	BatchResultNotFound          BatchResult = "not_found"
+	BatchResultUpdated           BatchResult = "updated"
+	BatchResultUnchanged         BatchResult = "unchanged"
+	BatchResultForbidden         BatchResult = "forbidden"
+	BatchResultInvalidTransition BatchResult = "invalid_transition"
+	BatchResultDismissed         BatchResult = "dismissed"
+	BatchResultInvalid           BatchResult = "invalid"
+	BatchResultSuggested         BatchResult = "suggested"
+)
+
+// BatchItemResult is the outcome for one creature of a batch request
+type BatchItemResult struct {
+	CreatureID uuid.UUID   `json:"creature_id"`
+	Result     BatchResult `json:"result"`
+}
+
+// BatchResponse lists the outcome of every item in request order, along with how
+// many items ended up with each result
+type BatchResponse struct {
+	Results []BatchItemResult   `json:"results"`
+	Counts  map[BatchResult]int `json:"counts"`
+}
+
+func newBatchResponse(results []BatchItemResult) BatchResponse {
+	counts := make(map[BatchResult]int)
+	for _, r := range results {
+		counts[r.Result]++
+	}
+	return BatchResponse{
+		Results: results,
+		Counts:  counts,
+	}
+}
+
+// validateBatchCreatureIDs rejects batches that are empty, too large or name a
+// creature more than once. field is the request field holding the batch.
+func validateBatchCreatureIDs(field string, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return apperror.ValidationError("Batch is empty", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  field,
+				Reason: "At least one creature is required",
+			})
+	}
+
+	if len(ids) > maxBatchSize {
+		return apperror.ValidationError("Batch is too large", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  field,
+				Value:  fmt.Sprint(len(ids)),
+				Reason: fmt.Sprintf("At most %d creatures are allowed", maxBatchSize),
+			})
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return apperror.ValidationError("Duplicate creature in batch", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  field,
+					Value:  id.String(),
+					Reason: "Each creature can only appear once",
+				})
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// BatchCreaturesRequest represents the request body for batch operations on creatures
+type BatchCreaturesRequest struct {
+	CreatureIDs []uuid.UUID `json:"creature_ids"`
+}
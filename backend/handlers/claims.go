@@ -20,6 +20,7 @@ import (
 
 type ClaimsHandler struct {
 	store     db.Store
+	events    services.EventPublisher
 	TibiaData services.TibiaDataServiceInterface
 }
 
@@ -30,9 +31,10 @@ type StartClaimResponse struct {
 	ClaimerID        string `json:"claimer_id,omitempty"` // ID of the claiming user
 }
 
-func NewClaimsHandler(store db.Store) *ClaimsHandler {
+func NewClaimsHandler(store db.Store, events services.EventPublisher) *ClaimsHandler {
 	return &ClaimsHandler{
 		store:     store,
+		events:    events,
 		TibiaData: services.NewTibiaDataService(),
 	}
 }
@@ -249,46 +251,12 @@ func (h *ClaimsHandler) CheckClaim(c echo.Context) error {
 		}
 
 		if verified {
-			// Update claim status to approved
-			updatedClaim, err := h.store.UpdateClaimStatus(ctx, db.UpdateClaimStatusParams{
-				CharacterID: claim.CharacterID,
-				ClaimerID:   claim.ClaimerID,
-				Status:      "approved",
-			})
+			updatedClaim, character, departures, err := h.approveClaim(ctx, claim.CharacterID, claim.ClaimerID)
 			if err != nil {
-				return apperror.DatabaseError("Failed to update claim status", err).
-					WithDetails(&apperror.DatabaseErrorDetails{
-						Operation: "UpdateClaimStatus",
-						Table:     "character_claims",
-					}).
-					Wrap(err)
+				return txError(err, "Failed to approve claim")
 			}
 
-			// First deactivate any existing list memberships
-			err = h.store.DeactivateCharacterListMemberships(ctx, claim.CharacterID)
-			if err != nil {
-				// Log the error but continue as this is not critical
-				apperror.DatabaseError("Failed to deactivate list memberships", err).
-					WithDetails(&apperror.DatabaseErrorDetails{
-						Operation: "DeactivateCharacterListMemberships",
-						Table:     "list_characters",
-					}).
-					LogError()
-			}
-
-			// Update character owner
-			character, err := h.store.UpdateCharacterOwner(ctx, db.UpdateCharacterOwnerParams{
-				ID:     claim.CharacterID,
-				UserID: claim.ClaimerID,
-			})
-			if err != nil {
-				return apperror.DatabaseError("Failed to update character owner", err).
-					WithDetails(&apperror.DatabaseErrorDetails{
-						Operation: "UpdateCharacterOwner",
-						Table:     "characters",
-					}).
-					Wrap(err)
-			}
+			h.publishCharacterClaimed(ctx, character.ID, character.Name, claim.ClaimerID, departures)
 
 			return c.JSON(http.StatusOK, map[string]any{
 				"claim_id":  updatedClaim.ID,
@@ -358,6 +326,21 @@ func (h *ClaimsHandler) ProcessPendingClaims() error {
 			status = "rejected"
 		}
 
+		if status == "approved" {
+			_, _, departures, err := h.approveClaim(ctx, claim.CharacterID, claim.ClaimerID)
+			if err != nil {
+				txError(err, "Failed to approve claim").
+					WithContext(apperror.ErrorContext{
+						Operation: "ProcessPendingClaims",
+					}).
+					LogError()
+				continue
+			}
+
+			h.publishCharacterClaimed(ctx, character.ID, character.Name, claim.ClaimerID, departures)
+			continue
+		}
+
 		// Update claim status
 		_, err = h.store.UpdateClaimStatus(ctx, db.UpdateClaimStatusParams{
 			CharacterID: claim.CharacterID,
@@ -374,28 +357,146 @@ func (h *ClaimsHandler) ProcessPendingClaims() error {
 					Operation: "ProcessPendingClaims",
 				}).
 				LogError()
-			continue
 		}
+	}
 
-		if status == "approved" {
-			// Update character owner
-			_, err = h.store.UpdateCharacterOwner(ctx, db.UpdateCharacterOwnerParams{
-				ID:     claim.CharacterID,
-				UserID: claim.ClaimerID,
+	return nil
+}
+
+// approveClaim approves a claim and hands the character over to the claimer. The claim,
+// the character's list memberships and its owner change together or not at all. The
+// memberships are deactivated and wait for the claimer to reactivate them. The returned
+// departures map each list the previous owner no longer belongs to, because the character
+// was their last one in it, to the previous owner.
+func (h *ClaimsHandler) approveClaim(ctx context.Context, characterID, claimerID uuid.UUID) (db.CharacterClaim, db.Character, map[uuid.UUID]uuid.UUID, error) {
+	var claim db.CharacterClaim
+	var character db.Character
+	departures := make(map[uuid.UUID]uuid.UUID)
+
+	err := h.store.ExecTx(ctx, func(q db.Querier) error {
+		var err error
+		claim, err = q.UpdateClaimStatus(ctx, db.UpdateClaimStatusParams{
+			CharacterID: characterID,
+			ClaimerID:   claimerID,
+			Status:      "approved",
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to update claim status", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "UpdateClaimStatus",
+					Table:     "character_claims",
+				}).
+				Wrap(err)
+		}
+
+		// The memberships are offered to the claimer, who accepts or declines each of them
+		if err := q.ReassignListReactivations(ctx, db.ReassignListReactivationsParams{
+			CharacterID: characterID,
+			UserID:      claimerID,
+		}); err != nil {
+			return apperror.DatabaseError("Failed to reassign list reactivations", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "ReassignListReactivations",
+					Table:     "list_reactivations",
+				}).
+				Wrap(err)
+		}
+
+		reactivations, err := q.CreateListReactivations(ctx, db.CreateListReactivationsParams{
+			UserID:      claimerID,
+			CharacterID: characterID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to create list reactivations", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "CreateListReactivations",
+					Table:     "list_reactivations",
+				}).
+				Wrap(err)
+		}
+
+		// The previous owner's memberships end with the claim
+		if err := q.DeactivateCharacterListMemberships(ctx, characterID); err != nil {
+			return apperror.DatabaseError("Failed to deactivate list memberships", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "DeactivateCharacterListMemberships",
+					Table:     "lists_users",
+				}).
+				Wrap(err)
+		}
+
+		character, err = q.UpdateCharacterOwner(ctx, db.UpdateCharacterOwnerParams{
+			ID:     characterID,
+			UserID: claimerID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to update character owner", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "UpdateCharacterOwner",
+					Table:     "characters",
+				}).
+				Wrap(err)
+		}
+
+		// The activity log tells list owners who took over the character
+		for _, r := range reactivations {
+			err := recordActivity(ctx, q, db.CreateListActivityParams{
+				ListID:       r.ListID,
+				ActorID:      claimerID,
+				Action:       db.ListActivityActionCharacterClaimed,
+				TargetUserID: r.PreviousUserID,
+				NewValue:     activityValue(character.Name),
 			})
 			if err != nil {
-				apperror.DatabaseError("Failed to update character owner", err).
+				return err
+			}
+
+			isMember, err := q.IsUserListMember(ctx, db.IsUserListMemberParams{
+				ListID: r.ListID,
+				UserID: r.PreviousUserID,
+			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to check list membership", err).
 					WithDetails(&apperror.DatabaseErrorDetails{
-						Operation: "UpdateCharacterOwner",
-						Table:     "characters",
+						Operation: "IsUserListMember",
+						Table:     "lists_users",
 					}).
-					WithContext(apperror.ErrorContext{
-						Operation: "ProcessPendingClaims",
-					}).
-					LogError()
+					Wrap(err)
+			}
+			if !isMember {
+				departures[r.ListID] = r.PreviousUserID
 			}
 		}
+
+		return nil
+	})
+
+	return claim, character, departures, err
+}
+
+// publishCharacterClaimed notifies the lists of a character that it has a new owner. Where
+// the previous owner lost their last character, the event names them so they get disconnected.
+func (h *ClaimsHandler) publishCharacterClaimed(ctx context.Context, characterID uuid.UUID, characterName string, ownerID uuid.UUID, departures map[uuid.UUID]uuid.UUID) {
+	listIDs, err := h.store.GetCharacterListIDs(ctx, characterID)
+	if err != nil {
+		apperror.DatabaseError("Failed to get character lists", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetCharacterListIDs",
+				Table:     "lists_users",
+			}).
+			LogError()
+		return
 	}
 
-	return nil
+	for _, listID := range listIDs {
+		payload := map[string]any{
+			"character_id":   characterID,
+			"character_name": characterName,
+			"user_id":        ownerID,
+		}
+		if previousOwnerID, ok := departures[listID]; ok {
+			payload["previous_user_id"] = previousOwnerID
+		}
+		publishListEvent(ctx, h.events, services.EventCharacterClaimed, listID, payload)
+	}
 }
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -226,7 +227,7 @@ func TestStartClaim(t *testing.T) {
 			tc.setupMocks(store, tibiaData, userID)
 
 			// Create handler with mock store and tibia data service
-			h := handlers.NewClaimsHandler(store)
+			h := handlers.NewClaimsHandler(store, services.NewMemoryEventBus())
 			h.TibiaData = tibiaData
 
 			// Execute handler
@@ -261,13 +262,20 @@ func TestStartClaim(t *testing.T) {
 }
 
 func TestCheckClaim(t *testing.T) {
+	// The claimed character was the previous owner's last one in listID
+	listID := uuid.New()
+	previousOwnerID := uuid.New()
+
 	testCases := []struct {
-		name          string
-		setupRequest  func(c echo.Context)
-		setupMocks    func(store *mockdb.MockStore, tibiaData *mockTibiaDataService, claimID uuid.UUID, userID uuid.UUID)
-		expectedCode  int
-		expectedError string
-		checkResponse func(t *testing.T, response map[string]any)
+		name           string
+		setupRequest   func(c echo.Context)
+		setupMocks     func(store *mockdb.MockStore, tibiaData *mockTibiaDataService, claimID uuid.UUID, userID uuid.UUID)
+		expectedCode   int
+		expectedError  string
+		expectedEvents int
+		rolledBack     bool
+		checkResponse  func(t *testing.T, response map[string]any)
+		checkEvents    func(t *testing.T, events []services.ListEvent)
 	}{
 		{
 			name: "Success - Claim Verified",
@@ -306,6 +314,21 @@ func TestCheckClaim(t *testing.T) {
 						VerificationCode: claim.VerificationCode,
 					}, nil)
 
+				store.EXPECT().
+					ReassignListReactivations(gomock.Any(), db.ReassignListReactivationsParams{
+						CharacterID: claim.CharacterID,
+						UserID:      claim.ClaimerID,
+					}).
+					Return(nil)
+
+				// The active memberships are offered to the claimer before they are deactivated
+				store.EXPECT().
+					CreateListReactivations(gomock.Any(), db.CreateListReactivationsParams{
+						UserID:      claim.ClaimerID,
+						CharacterID: claim.CharacterID,
+					}).
+					Return([]db.CreateListReactivationsRow{{ListID: listID, PreviousUserID: previousOwnerID}}, nil)
+
 				store.EXPECT().
 					DeactivateCharacterListMemberships(gomock.Any(), claim.CharacterID).
 					Return(nil)
@@ -320,13 +343,131 @@ func TestCheckClaim(t *testing.T) {
 						UserID: claim.ClaimerID,
 						Name:   claim.CharacterName,
 					}, nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:       listID,
+						ActorID:      claim.ClaimerID,
+						Action:       db.ListActivityActionCharacterClaimed,
+						TargetUserID: previousOwnerID,
+						NewValue:     pgtype.Text{String: claim.CharacterName, Valid: true},
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+						ListID: listID,
+						UserID: previousOwnerID,
+					}).
+					Return(false, nil)
+
+				// The character also has an inactive membership in another list
+				store.EXPECT().
+					GetCharacterListIDs(gomock.Any(), claim.CharacterID).
+					Return([]uuid.UUID{listID, uuid.New()}, nil)
+			},
+			expectedCode:   http.StatusOK,
+			expectedEvents: 2,
+			checkEvents: func(t *testing.T, events []services.ListEvent) {
+				for _, event := range events {
+					var payload map[string]any
+					require.NoError(t, json.Unmarshal(event.Payload, &payload))
+					if event.ListID == listID {
+						require.Equal(t, previousOwnerID.String(), payload["previous_user_id"])
+					} else {
+						require.NotContains(t, payload, "previous_user_id")
+					}
+				}
 			},
-			expectedCode: http.StatusOK,
 			checkResponse: func(t *testing.T, response map[string]any) {
 				require.Equal(t, "approved", response["status"])
 				require.NotNil(t, response["character"])
 			},
 		},
+		{
+			name: "Database Error - UpdateCharacterOwner",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, tibiaData *mockTibiaDataService, claimID uuid.UUID, userID uuid.UUID) {
+				claim := db.GetClaimByIDRow{
+					ID:               claimID,
+					CharacterID:      uuid.New(),
+					ClaimerID:        userID,
+					CharacterName:    "TestChar",
+					Status:           "pending",
+					VerificationCode: "TIBIACORES-1234",
+				}
+
+				store.EXPECT().
+					GetClaimByID(gomock.Any(), claimID).
+					Return(claim, nil)
+
+				tibiaData.verifyCharacterClaimFn = func(name, code string) (bool, error) {
+					return true, nil
+				}
+
+				store.EXPECT().
+					UpdateClaimStatus(gomock.Any(), gomock.Any()).
+					Return(db.CharacterClaim{Status: "approved"}, nil)
+
+				store.EXPECT().
+					ReassignListReactivations(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListReactivations(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				store.EXPECT().
+					DeactivateCharacterListMemberships(gomock.Any(), claim.CharacterID).
+					Return(nil)
+
+				store.EXPECT().
+					UpdateCharacterOwner(gomock.Any(), gomock.Any()).
+					Return(db.Character{}, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to update character owner",
+			rolledBack:    true,
+		},
+		{
+			name: "Database Error - CreateListReactivations",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, tibiaData *mockTibiaDataService, claimID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetClaimByID(gomock.Any(), claimID).
+					Return(db.GetClaimByIDRow{
+						ID:               claimID,
+						CharacterID:      uuid.New(),
+						ClaimerID:        userID,
+						CharacterName:    "TestChar",
+						Status:           "pending",
+						VerificationCode: "TIBIACORES-1234",
+					}, nil)
+
+				tibiaData.verifyCharacterClaimFn = func(name, code string) (bool, error) {
+					return true, nil
+				}
+
+				store.EXPECT().
+					UpdateClaimStatus(gomock.Any(), gomock.Any()).
+					Return(db.CharacterClaim{Status: "approved"}, nil)
+
+				store.EXPECT().
+					ReassignListReactivations(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListReactivations(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to create list reactivations",
+			rolledBack:    true,
+		},
 		{
 			name: "Invalid Claim ID",
 			setupRequest: func(c echo.Context) {
@@ -360,6 +501,7 @@ func TestCheckClaim(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
 			tibiaData := &mockTibiaDataService{}
 			claimID := uuid.New()
 			userID := uuid.New()
@@ -385,12 +527,26 @@ func TestCheckClaim(t *testing.T) {
 			// Setup mock expectations
 			tc.setupMocks(store, tibiaData, claimID, userID)
 
+			// Record events published to the lists of the claimed character
+			bus := services.NewMemoryEventBus()
+			var events []services.ListEvent
+			bus.Subscribe(func(ctx context.Context, event services.ListEvent) {
+				events = append(events, event)
+			})
+
 			// Create handler with mock store and tibia data service
-			h := handlers.NewClaimsHandler(store)
+			h := handlers.NewClaimsHandler(store, bus)
 			h.TibiaData = tibiaData
 
 			// Execute handler
 			err := h.CheckClaim(c)
+			require.Len(t, events, tc.expectedEvents)
+			for _, event := range events {
+				require.Equal(t, services.EventCharacterClaimed, event.Type)
+			}
+			if tc.checkEvents != nil {
+				tc.checkEvents(t, events)
+			}
 
 			// Check for expected error response
 			if tc.expectedError != "" {
@@ -399,6 +555,7 @@ func TestCheckClaim(t *testing.T) {
 				require.ErrorAs(t, err, &appErr)
 				require.Equal(t, tc.expectedCode, appErr.StatusCode)
 				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, tc.rolledBack, tx.RolledBack > 0)
 				return
 			}
 
@@ -492,6 +649,16 @@ func TestProcessPendingClaims(t *testing.T) {
 					}, nil).
 					AnyTimes()
 
+				store.EXPECT().
+					ReassignListReactivations(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+
+				store.EXPECT().
+					CreateListReactivations(gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					AnyTimes()
+
 				store.EXPECT().
 					DeactivateCharacterListMemberships(gomock.Any(), gomock.Any()).
 					Return(nil).
@@ -501,6 +668,11 @@ func TestProcessPendingClaims(t *testing.T) {
 					UpdateCharacterOwner(gomock.Any(), gomock.Any()).
 					Return(db.Character{}, nil).
 					AnyTimes()
+
+				store.EXPECT().
+					GetCharacterListIDs(gomock.Any(), gomock.Any()).
+					Return([]uuid.UUID{}, nil).
+					AnyTimes()
 			},
 		},
 	}
@@ -511,11 +683,12 @@ func TestProcessPendingClaims(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
 			tibiaData := &mockTibiaDataService{}
 
 			tc.setupMocks(store, tibiaData)
 
-			h := handlers.NewClaimsHandler(store)
+			h := handlers.NewClaimsHandler(store, services.NewMemoryEventBus())
 			h.TibiaData = tibiaData
 
 			err := h.ProcessPendingClaims()
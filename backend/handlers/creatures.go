@@ -1,23 +1,63 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 )
 
 type CreaturesHandler struct {
-	store db.Store
+	store  db.Store
+	policy *services.Policy
+}
+
+// CreatureDetails is a creature along with the other names it is known by
+type CreatureDetails struct {
+	Creature db.Creature        `json:"creature"`
+	Aliases  []db.CreatureAlias `json:"aliases"`
 }
 
 func NewCreaturesHandler(store db.Store) *CreaturesHandler {
-	return &CreaturesHandler{store: store}
+	return &CreaturesHandler{
+		store:  store,
+		policy: services.NewPolicy(store),
+	}
 }
 
+// GetCreatures lists every creature along with its bestiary data and its name in the
+// requested language. The optional class, race, location, min_difficulty and max_difficulty
+// query parameters narrow the list down; class, race and location ignore case.
 func (h *CreaturesHandler) GetCreatures(c echo.Context) error {
-	creatures, err := h.store.GetCreatures(c.Request().Context())
+	var params db.GetCreaturesParams
+
+	var err error
+	params.Lang, err = requestLanguage(c)
+	if err != nil {
+		return err
+	}
+
+	params.MinDifficulty, params.MaxDifficulty, err = difficultyRangeParams(c)
+	if err != nil {
+		return err
+	}
+
+	for _, filter := range []struct {
+		name  string
+		value *pgtype.Text
+	}{{"class", &params.BestiaryClass}, {"race", &params.Race}, {"location", &params.Location}} {
+		if value := strings.TrimSpace(c.QueryParam(filter.name)); value != "" {
+			*filter.value = pgtype.Text{String: value, Valid: true}
+		}
+	}
+
+	creatures, err := h.store.GetCreatures(c.Request().Context(), params)
 	if err != nil {
 		return apperror.DatabaseError("Failed to retrieve creatures", err).
 			WithDetails(&apperror.DatabaseErrorDetails{
@@ -29,3 +69,51 @@ func (h *CreaturesHandler) GetCreatures(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, creatures)
 }
+
+// GetCreature looks a creature up by name. Former names and community names resolve to
+// the creature they belong to.
+func (h *CreaturesHandler) GetCreature(c echo.Context) error {
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		return apperror.ValidationError("Creature name is required", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "name",
+				Reason: "Creature name cannot be empty",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	creature, err := h.store.GetCreatureByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Creature not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "name",
+					Value:  name,
+					Reason: "No creature or alias with this name",
+				})
+		}
+		return apperror.DatabaseError("Failed to get creature", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetCreatureByName",
+				Table:     "creatures",
+			}).
+			Wrap(err)
+	}
+
+	aliases, err := h.store.GetCreatureAliases(ctx, creature.ID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get creature aliases", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetCreatureAliases",
+				Table:     "creature_aliases",
+			}).
+			Wrap(err)
+	}
+
+	return c.JSON(http.StatusOK, CreatureDetails{
+		Creature: creature,
+		Aliases:  aliases,
+	})
+}
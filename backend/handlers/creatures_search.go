@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// maxCreatureSearchLength caps the search query, creature names are much shorter
+const maxCreatureSearchLength = 64
+
+// CreatureSearchResponse lists the creatures matching a search, best match first
+type CreatureSearchResponse struct {
+	Results []db.SearchCreaturesRow `json:"results"`
+}
+
+// SearchCreatures finds creatures by name or alias for autocompletion. Matches are ranked
+// exact first, then by prefix, then by similarity, so typos still find the creature. The
+// optional min_difficulty and max_difficulty, not_unlocked_by (a character ID) and
+// not_in_list (a list ID) query parameters narrow the results down; creatures of unknown
+// difficulty are left out when filtering by difficulty. Private lists can only be used as
+// a filter by their members.
+func (h *CreaturesHandler) SearchCreatures(c echo.Context) error {
+	query := strings.ToLower(strings.TrimSpace(c.QueryParam("q")))
+	if query == "" || utf8.RuneCountInString(query) > maxCreatureSearchLength {
+		return apperror.ValidationError("Invalid search query", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "q",
+				Value:  c.QueryParam("q"),
+				Reason: "Query must be between 1 and 64 characters",
+			})
+	}
+
+	params := db.SearchCreaturesParams{Query: query, Limit: 10}
+
+	var err error
+	params.MinDifficulty, params.MaxDifficulty, err = difficultyRangeParams(c)
+	if err != nil {
+		return err
+	}
+
+	if characterIDStr := c.QueryParam("not_unlocked_by"); characterIDStr != "" {
+		params.CharacterID, err = uuid.Parse(characterIDStr)
+		if err != nil {
+			return apperror.ValidationError("Invalid character ID", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "not_unlocked_by",
+					Value:  characterIDStr,
+					Reason: "Invalid UUID format",
+				})
+		}
+	}
+
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 50 {
+			return apperror.ValidationError("Invalid limit", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "limit",
+					Value:  limitStr,
+					Reason: "Limit must be between 1 and 50",
+				})
+		}
+		params.Limit = int32(limit)
+	}
+
+	ctx := c.Request().Context()
+
+	if listIDStr := c.QueryParam("not_in_list"); listIDStr != "" {
+		params.ListID, err = uuid.Parse(listIDStr)
+		if err != nil {
+			return apperror.ValidationError("Invalid list ID", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "not_in_list",
+					Value:  listIDStr,
+					Reason: "Invalid UUID format",
+				})
+		}
+
+		if err := h.checkListReadable(c, params.ListID); err != nil {
+			return err
+		}
+	}
+
+	results, err := h.store.SearchCreatures(ctx, params)
+	if err != nil {
+		return apperror.DatabaseError("Failed to search creatures", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "SearchCreatures",
+				Table:     "creatures",
+			}).
+			Wrap(err)
+	}
+
+	return c.JSON(http.StatusOK, CreatureSearchResponse{Results: results})
+}
+
+// checkListReadable lets anyone read unlisted and public lists, private ones only their
+// members. Private lists look the same as missing ones to everyone else.
+func (h *CreaturesHandler) checkListReadable(c echo.Context, listID uuid.UUID) error {
+	ctx := c.Request().Context()
+
+	list, err := h.store.GetList(ctx, listID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return apperror.DatabaseError("Failed to get list", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetList",
+				Table:     "lists",
+			})
+	}
+
+	notFound := apperror.NotFoundError("List not found", err).
+		WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "not_in_list",
+			Value:  listID.String(),
+			Reason: "List does not exist or is private",
+		})
+	if err != nil {
+		return notFound
+	}
+	if list.Visibility != db.ListVisibilityPrivate {
+		return nil
+	}
+
+	// Set by the optional auth middleware when a valid token was sent
+	userIDStr, _ := c.Get("user_id").(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return notFound
+	}
+
+	if _, err := h.policy.ListMembership(ctx, listID, userID); err != nil {
+		if errors.Is(err, services.ErrNotListMember) {
+			return notFound
+		}
+		return apperror.DatabaseError("Failed to check list membership", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListMemberRole",
+				Table:     "lists_users",
+			})
+	}
+	return nil
+}
+
+// difficultyParam parses an optional difficulty query parameter
+func difficultyParam(c echo.Context, name string) (pgtype.Int4, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return pgtype.Int4{}, nil
+	}
+
+	difficulty, err := strconv.Atoi(value)
+	if err != nil || difficulty < 0 || difficulty > services.MaxCreatureDifficulty {
+		return pgtype.Int4{}, apperror.ValidationError("Invalid difficulty", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  name,
+				Value:  value,
+				Reason: "Difficulty must be between 0 and 5",
+			})
+	}
+	return pgtype.Int4{Int32: int32(difficulty), Valid: true}, nil
+}
+
+// difficultyRangeParams parses the optional min_difficulty and max_difficulty query parameters
+func difficultyRangeParams(c echo.Context) (pgtype.Int4, pgtype.Int4, error) {
+	minDifficulty, err := difficultyParam(c, "min_difficulty")
+	if err != nil {
+		return pgtype.Int4{}, pgtype.Int4{}, err
+	}
+	maxDifficulty, err := difficultyParam(c, "max_difficulty")
+	if err != nil {
+		return pgtype.Int4{}, pgtype.Int4{}, err
+	}
+	if minDifficulty.Valid && maxDifficulty.Valid && minDifficulty.Int32 > maxDifficulty.Int32 {
+		return pgtype.Int4{}, pgtype.Int4{}, apperror.ValidationError("Invalid difficulty range", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "min_difficulty",
+				Value:  strconv.Itoa(int(minDifficulty.Int32)),
+				Reason: "Min difficulty cannot be above max difficulty",
+			})
+	}
+	return minDifficulty, maxDifficulty, nil
+}
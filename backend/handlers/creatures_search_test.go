@@ -0,0 +1,201 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/middleware"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchCreatures(t *testing.T) {
+	characterID := uuid.New()
+	listID := uuid.New()
+	userID := uuid.New()
+
+	results := []db.SearchCreaturesRow{
+		{ID: uuid.New(), Name: "Monk (Creature)", MatchedAlias: pgtype.Text{String: "Monk", Valid: true}, Score: 4},
+		{ID: uuid.New(), Name: "Monkey", Score: 2.5},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		userID        string
+		setupMocks    func(store *mockdb.MockStore)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			query: url.Values{
+				"q":               {" MONK "},
+				"min_difficulty":  {"1"},
+				"max_difficulty":  {"3"},
+				"not_unlocked_by": {characterID.String()},
+				"limit":           {"5"},
+			},
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchCreatures(gomock.Any(), db.SearchCreaturesParams{
+						Query:         "monk",
+						MinDifficulty: pgtype.Int4{Int32: 1, Valid: true},
+						MaxDifficulty: pgtype.Int4{Int32: 3, Valid: true},
+						CharacterID:   characterID,
+						Limit:         5,
+					}).
+					Return(results, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:  "Success - Public List Filter",
+			query: url.Values{"q": {"monk"}, "not_in_list": {listID.String()}},
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Visibility: db.ListVisibilityPublic}, nil)
+
+				store.EXPECT().
+					SearchCreatures(gomock.Any(), db.SearchCreaturesParams{Query: "monk", ListID: listID, Limit: 10}).
+					Return(results, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Success - Private List Filter For Member",
+			query:  url.Values{"q": {"monk"}, "not_in_list": {listID.String()}},
+			userID: userID.String(),
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Visibility: db.ListVisibilityPrivate}, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{ListID: listID, UserID: userID}).
+					Return(db.ListRoleViewer, nil)
+
+				store.EXPECT().
+					SearchCreatures(gomock.Any(), db.SearchCreaturesParams{Query: "monk", ListID: listID, Limit: 10}).
+					Return(results, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:  "Private List Filter Without Auth",
+			query: url.Values{"q": {"monk"}, "not_in_list": {listID.String()}},
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Visibility: db.ListVisibilityPrivate}, nil)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "List not found",
+		},
+		{
+			name:   "Private List Filter For Non Member",
+			query:  url.Values{"q": {"monk"}, "not_in_list": {listID.String()}},
+			userID: userID.String(),
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Visibility: db.ListVisibilityPrivate}, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "List not found",
+		},
+		{
+			name:          "Empty Query",
+			query:         url.Values{"q": {"  "}},
+			setupMocks:    func(store *mockdb.MockStore) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid search query",
+		},
+		{
+			name:          "Invalid Difficulty",
+			query:         url.Values{"q": {"monk"}, "max_difficulty": {"6"}},
+			setupMocks:    func(store *mockdb.MockStore) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid difficulty",
+		},
+		{
+			name:          "Invalid Difficulty Range",
+			query:         url.Values{"q": {"monk"}, "min_difficulty": {"4"}, "max_difficulty": {"2"}},
+			setupMocks:    func(store *mockdb.MockStore) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid difficulty range",
+		},
+		{
+			name:          "Invalid Character ID",
+			query:         url.Values{"q": {"monk"}, "not_unlocked_by": {"knight"}},
+			setupMocks:    func(store *mockdb.MockStore) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid character ID",
+		},
+		{
+			name:  "Database Error",
+			query: url.Values{"q": {"monk"}},
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchCreatures(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone)
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to search creatures",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.setupMocks(store)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/creatures/search?"+tc.query.Encode(), nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			if tc.userID != "" {
+				c.Set("user_id", tc.userID)
+			}
+
+			h := handlers.NewCreaturesHandler(store)
+			err := h.SearchCreatures(c)
+
+			if tc.expectedError != "" {
+				middleware.ErrorHandler(err, c)
+				require.Equal(t, tc.expectedCode, rec.Code)
+
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Contains(t, errorResponse["message"].(string), tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response handlers.CreatureSearchResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Results, 2)
+			require.Equal(t, "Monk", response.Results[0].MatchedAlias.String)
+		})
+	}
+}
@@ -21,17 +21,19 @@ import (
 func TestGetCreatures(t *testing.T) {
 	testCases := []struct {
 		name          string
+		query         string
+		language      string
 		setupMocks    func(store *mockdb.MockStore)
 		expectedCode  int
 		expectedError string
-		checkResponse func(t *testing.T, creatures []db.Creature, rec *httptest.ResponseRecorder)
+		checkResponse func(t *testing.T, creatures []db.GetCreaturesRow, rec *httptest.ResponseRecorder)
 	}{
 		{
 			name: "Success - Single Creature",
 			setupMocks: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetCreatures(gomock.Any()).
-					Return([]db.Creature{
+					GetCreatures(gomock.Any(), db.GetCreaturesParams{Lang: "en"}).
+					Return([]db.GetCreaturesRow{
 						{
 							ID:   uuid.New(),
 							Name: "Dragon",
@@ -43,7 +45,7 @@ func TestGetCreatures(t *testing.T) {
 					}, nil)
 			},
 			expectedCode: http.StatusOK,
-			checkResponse: func(t *testing.T, creatures []db.Creature, rec *httptest.ResponseRecorder) {
+			checkResponse: func(t *testing.T, creatures []db.GetCreaturesRow, rec *httptest.ResponseRecorder) {
 				require.Len(t, creatures, 1)
 				require.Equal(t, "Dragon", creatures[0].Name)
 			},
@@ -52,8 +54,8 @@ func TestGetCreatures(t *testing.T) {
 			name: "Success - Multiple Creatures",
 			setupMocks: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetCreatures(gomock.Any()).
-					Return([]db.Creature{
+					GetCreatures(gomock.Any(), db.GetCreaturesParams{Lang: "en"}).
+					Return([]db.GetCreaturesRow{
 						{
 							ID:         uuid.New(),
 							Name:       "Dragon",
@@ -67,7 +69,7 @@ func TestGetCreatures(t *testing.T) {
 					}, nil)
 			},
 			expectedCode: http.StatusOK,
-			checkResponse: func(t *testing.T, creatures []db.Creature, rec *httptest.ResponseRecorder) {
+			checkResponse: func(t *testing.T, creatures []db.GetCreaturesRow, rec *httptest.ResponseRecorder) {
 				require.Len(t, creatures, 2)
 				require.Equal(t, "Dragon", creatures[0].Name)
 				require.Equal(t, int32(2), creatures[0].Difficulty.Int32)
@@ -77,15 +79,93 @@ func TestGetCreatures(t *testing.T) {
 				require.True(t, creatures[1].Difficulty.Valid)
 			},
 		},
+		{
+			name:  "Success - Filtered",
+			query: "?class=Dragons&location=+Darashia+&min_difficulty=2",
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCreatures(gomock.Any(), db.GetCreaturesParams{
+						Lang:          "en",
+						BestiaryClass: pgtype.Text{String: "Dragons", Valid: true},
+						Location:      pgtype.Text{String: "Darashia", Valid: true},
+						MinDifficulty: pgtype.Int4{Int32: 2, Valid: true},
+					}).
+					Return([]db.GetCreaturesRow{
+						{
+							ID:            uuid.New(),
+							Name:          "Dragon",
+							Difficulty:    pgtype.Int4{Int32: 2, Valid: true},
+							BestiaryClass: pgtype.Text{String: "Dragons", Valid: true},
+							Race:          pgtype.Text{String: "Dragon", Valid: true},
+							CharmPoints:   pgtype.Int4{Int32: 25, Valid: true},
+							Locations:     []string{"Darashia Dragon Lair", "Darashia"},
+						},
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, creatures []db.GetCreaturesRow, rec *httptest.ResponseRecorder) {
+				require.Len(t, creatures, 1)
+				require.Equal(t, "Dragons", creatures[0].BestiaryClass.String)
+				require.Equal(t, []string{"Darashia Dragon Lair", "Darashia"}, creatures[0].Locations)
+			},
+		},
+		{
+			name:     "Success - Localized",
+			language: "pt-BR,pt;q=0.9,en;q=0.8",
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCreatures(gomock.Any(), db.GetCreaturesParams{Lang: "pt"}).
+					Return([]db.GetCreaturesRow{
+						{ID: uuid.New(), Name: "Dragon", LocalizedName: "Dragão"},
+						{ID: uuid.New(), Name: "Rat", LocalizedName: "Rat"},
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, creatures []db.GetCreaturesRow, rec *httptest.ResponseRecorder) {
+				require.Len(t, creatures, 2)
+				require.Equal(t, "Dragon", creatures[0].Name)
+				require.Equal(t, "Dragão", creatures[0].LocalizedName)
+				// Untranslated creatures keep their English name
+				require.Equal(t, "Rat", creatures[1].LocalizedName)
+			},
+		},
+		{
+			name:     "Success - Lang Parameter Wins",
+			query:    "?lang=PL",
+			language: "es",
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCreatures(gomock.Any(), db.GetCreaturesParams{Lang: "pl"}).
+					Return([]db.GetCreaturesRow{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, creatures []db.GetCreaturesRow, rec *httptest.ResponseRecorder) {
+				require.Empty(t, creatures)
+			},
+		},
+		{
+			name:          "Unsupported Language",
+			query:         "?lang=fr",
+			setupMocks:    func(store *mockdb.MockStore) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Unsupported language",
+		},
+		{
+			name:          "Invalid Difficulty Range",
+			query:         "?min_difficulty=4&max_difficulty=2",
+			setupMocks:    func(store *mockdb.MockStore) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid difficulty range",
+		},
 		{
 			name: "Empty Creatures List",
 			setupMocks: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetCreatures(gomock.Any()).
-					Return([]db.Creature{}, nil)
+					GetCreatures(gomock.Any(), db.GetCreaturesParams{Lang: "en"}).
+					Return([]db.GetCreaturesRow{}, nil)
 			},
 			expectedCode: http.StatusOK,
-			checkResponse: func(t *testing.T, creatures []db.Creature, rec *httptest.ResponseRecorder) {
+			checkResponse: func(t *testing.T, creatures []db.GetCreaturesRow, rec *httptest.ResponseRecorder) {
 				require.Len(t, creatures, 0)
 				require.Equal(t, "[]\n", rec.Body.String())
 			},
@@ -94,7 +174,7 @@ func TestGetCreatures(t *testing.T) {
 			name: "Database Error",
 			setupMocks: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetCreatures(gomock.Any()).
+					GetCreatures(gomock.Any(), db.GetCreaturesParams{Lang: "en"}).
 					Return(nil, sql.ErrConnDone)
 			},
 			expectedCode:  http.StatusInternalServerError,
@@ -112,7 +192,10 @@ func TestGetCreatures(t *testing.T) {
 			tc.setupMocks(store)
 
 			// Create HTTP request
-			req := httptest.NewRequest(http.MethodGet, "/api/creatures", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/creatures"+tc.query, nil)
+			if tc.language != "" {
+				req.Header.Set("Accept-Language", tc.language)
+			}
 			rec := httptest.NewRecorder()
 			e := echo.New()
 			c := e.NewContext(req, rec)
@@ -143,10 +226,116 @@ func TestGetCreatures(t *testing.T) {
 
 			// Check response body
 			if tc.checkResponse != nil {
-				var creatures []db.Creature
+				var creatures []db.GetCreaturesRow
 				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &creatures))
 				tc.checkResponse(t, creatures, rec)
 			}
 		})
 	}
 }
+
+func TestGetCreature(t *testing.T) {
+	creature := db.Creature{
+		ID:         uuid.New(),
+		Name:       "Horse (Taupe)",
+		Difficulty: pgtype.Int4{Int32: 1, Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		creatureName  string
+		setupMocks    func(store *mockdb.MockStore)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:         "Success - Former Name",
+			creatureName: "Horse (Dark Brown)",
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCreatureByName(gomock.Any(), "Horse (Dark Brown)").
+					Return(creature, nil)
+
+				store.EXPECT().
+					GetCreatureAliases(gomock.Any(), creature.ID).
+					Return([]db.CreatureAlias{
+						{ID: uuid.New(), CreatureID: creature.ID, Name: "Horse (Dark Brown)", Kind: db.CreatureAliasKindHistorical},
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Not Found",
+			creatureName: "Unicorn",
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCreatureByName(gomock.Any(), "Unicorn").
+					Return(db.Creature{}, sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Creature not found",
+		},
+		{
+			name:          "Empty Name",
+			creatureName:  " ",
+			setupMocks:    func(store *mockdb.MockStore) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Creature name is required",
+		},
+		{
+			name:         "Database Error - GetCreatureAliases",
+			creatureName: "Horse (Taupe)",
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCreatureByName(gomock.Any(), "Horse (Taupe)").
+					Return(creature, nil)
+
+				store.EXPECT().
+					GetCreatureAliases(gomock.Any(), creature.ID).
+					Return(nil, sql.ErrConnDone)
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get creature aliases",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.setupMocks(store)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/creatures/name", nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetPath("/api/creatures/:name")
+			c.SetParamNames("name")
+			c.SetParamValues(tc.creatureName)
+
+			h := handlers.NewCreaturesHandler(store)
+			err := h.GetCreature(c)
+
+			if tc.expectedError != "" {
+				middleware.ErrorHandler(err, c)
+				require.Equal(t, tc.expectedCode, rec.Code)
+
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Contains(t, errorResponse["message"].(string), tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response handlers.CreatureDetails
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, "Horse (Taupe)", response.Creature.Name)
+			require.Len(t, response.Aliases, 1)
+			require.Equal(t, db.CreatureAliasKindHistorical, response.Aliases[0].Kind)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// requestLanguage is the language creature names are returned in. The lang query parameter
+// wins over the Accept-Language header; an unsupported lang is an error while unsupported
+// header languages fall back to English.
+func requestLanguage(c echo.Context) (string, error) {
+	if lang := c.QueryParam("lang"); lang != "" {
+		lang = strings.ToLower(lang)
+		if !services.IsSupportedLanguage(lang) {
+			return "", apperror.ValidationError("Unsupported language", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "lang",
+					Value:  lang,
+					Reason: "Language must be one of " + strings.Join(services.SupportedLanguages, ", "),
+				})
+		}
+		return lang, nil
+	}
+	return services.PreferredLanguage(c.Request().Header.Get("Accept-Language")), nil
+}
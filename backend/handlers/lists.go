@@ -13,14 +13,21 @@ import (
 	"github.com/sergot/tibiacores/backend/auth"
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 )
 
 type ListsHandler struct {
-	store db.Store
+	store  db.Store
+	hub    *services.Hub
+	policy *services.Policy
 }
 
-func NewListsHandler(store db.Store) *ListsHandler {
-	return &ListsHandler{store}
+func NewListsHandler(store db.Store, hub *services.Hub) *ListsHandler {
+	return &ListsHandler{
+		store:  store,
+		hub:    hub,
+		policy: services.NewPolicy(store),
+	}
 }
 
 type CreateListRequest struct {
@@ -62,8 +69,8 @@ func (h *ListsHandler) CreateList(c echo.Context) error {
 
 	// Check if user is authenticated
 	var userID uuid.UUID
-	var token string
 	var err error
+	isNewUser := true
 
 	// Get authenticated user ID from context
 	if userIDStr, ok := c.Get("user_id").(string); ok && userIDStr != "" {
@@ -72,63 +79,99 @@ func (h *ListsHandler) CreateList(c echo.Context) error {
 		if err != nil {
 			return apperror.AuthorizationError("invalid user ID format", err)
 		}
-	} else {
-		// Create new anonymous user account
-		newUser, err := h.store.CreateAnonymousUser(ctx, uuid.New())
-		if err != nil {
-			return apperror.DatabaseError("failed to create user", err)
-		}
-		userID = newUser.ID
+		isNewUser = false
+	} else if req.CharacterName == "" || req.World == "" {
+		// For new users, we need character info
+		return apperror.ValidationError("Character name and world are required for first list", nil).WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "character_name,world",
+			Reason: "Both character name and world are required for new users",
+		})
+	}
 
-		// Generate token
-		token, err = auth.GenerateToken(userID.String(), false)
-		if err != nil {
-			return apperror.InternalError("failed to generate token", err).WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "GenerateToken",
-				Table:     "auth",
-			})
-		}
-		c.Response().Header().Set("X-Auth-Token", token)
+	// Handle new character case
+	if req.CharacterID == nil && (req.CharacterName == "" || req.World == "") {
+		return apperror.ValidationError("Character name and world are required for new character", nil).WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "character_name,world",
+			Reason: "Both character name and world are required for new characters",
+		})
+	}
 
-		// For new users, we need character info
-		if req.CharacterName == "" || req.World == "" {
-			return apperror.ValidationError("Character name and world are required for first list", nil).WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "character_name,world",
-				Reason: "Both character name and world are required for new users",
-			})
+	// The new user, their character, the list and the owner's membership are
+	// created together, so a failure leaves no half-created list behind
+	var list db.List
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		if isNewUser {
+			// Create new anonymous user account
+			newUser, err := q.CreateAnonymousUser(ctx, uuid.New())
+			if err != nil {
+				return apperror.DatabaseError("failed to create user", err)
+			}
+			userID = newUser.ID
 		}
-	}
 
-	// Handle existing character case
-	if req.CharacterID != nil {
-		// Verify character exists and belongs to user
-		char, err := h.store.GetCharacter(ctx, *req.CharacterID)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return apperror.NotFoundError("Character not found", err).WithDetails(&apperror.ValidationErrorDetails{
+		var characterID uuid.UUID
+		var world string
+		var err error
+		if req.CharacterID != nil {
+			// Verify character exists and belongs to user
+			char, err := q.GetCharacter(ctx, *req.CharacterID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return apperror.NotFoundError("Character not found", err).WithDetails(&apperror.ValidationErrorDetails{
+						Field:  "character_id",
+						Value:  req.CharacterID.String(),
+						Reason: "Character does not exist",
+					})
+				}
+				return apperror.DatabaseError("Failed to retrieve character", err).WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetCharacter",
+					Table:     "characters",
+				})
+			}
+			if !h.policy.CanManageCharacter(userID, char) {
+				return apperror.AuthorizationError("Character does not belong to user", nil).WithDetails(&apperror.ValidationErrorDetails{
 					Field:  "character_id",
 					Value:  req.CharacterID.String(),
-					Reason: "Character does not exist",
+					Reason: "Character belongs to a different user",
 				})
 			}
-			return apperror.DatabaseError("Failed to retrieve character", err).WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "GetCharacter",
-				Table:     "characters",
-			})
-		}
-		if char.UserID != userID {
-			return apperror.AuthorizationError("Character does not belong to user", nil).WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "character_id",
-				Value:  req.CharacterID.String(),
-				Reason: "Character belongs to a different user",
+
+			// Create list using character's world
+			characterID = char.ID
+			world = char.World
+		} else {
+			// Check if the character name is already taken
+			existingChar, err := q.GetCharacterByName(ctx, req.CharacterName)
+			if err == nil && existingChar.UserID != userID {
+				// Character belongs to another user, return conflict error
+				return apperror.ValidationError("Character name is already registered", nil).WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "character_name",
+					Value:  req.CharacterName,
+					Reason: "Character name is already taken by another user",
+				})
+			}
+
+			// Create character
+			character, err := q.CreateCharacter(ctx, db.CreateCharacterParams{
+				UserID: userID,
+				Name:   strings.TrimSpace(req.CharacterName),
+				World:  strings.TrimSpace(req.World),
 			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to create character", err).WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "CreateCharacter",
+					Table:     "characters",
+				})
+			}
+			characterID = character.ID
+			world = strings.TrimSpace(req.World)
 		}
 
-		// Create list using character's world
-		list, err := h.store.CreateList(ctx, db.CreateListParams{
+		// Create list
+		list, err = q.CreateList(ctx, db.CreateListParams{
 			AuthorID: userID,
-			Name:     req.Name,
-			World:    char.World,
+			Name:     strings.TrimSpace(req.Name),
+			World:    world,
 		})
 		if err != nil {
 			return apperror.DatabaseError("Failed to create list", err).WithDetails(&apperror.DatabaseErrorDetails{
@@ -138,10 +181,11 @@ func (h *ListsHandler) CreateList(c echo.Context) error {
 		}
 
 		// Add character to list
-		err = h.store.AddListCharacter(ctx, db.AddListCharacterParams{
+		err = q.AddListCharacter(ctx, db.AddListCharacterParams{
 			ListID:      list.ID,
 			UserID:      userID,
-			CharacterID: *req.CharacterID,
+			CharacterID: characterID,
+			Role:        db.ListRoleOwner,
 		})
 		if err != nil {
 			return apperror.DatabaseError("Failed to add character to list", err).WithDetails(&apperror.DatabaseErrorDetails{
@@ -149,91 +193,22 @@ func (h *ListsHandler) CreateList(c echo.Context) error {
 				Table:     "list_characters",
 			})
 		}
-
-		// Safely get has_email value
-		hasEmail := false
-		if hasEmailVal := c.Get("has_email"); hasEmailVal != nil {
-			if val, ok := hasEmailVal.(bool); ok {
-				hasEmail = val
-			}
-		}
-
-		return c.JSON(http.StatusCreated, CreateListResponse{
-			ID:        list.ID,
-			AuthorID:  list.AuthorID,
-			Name:      list.Name,
-			ShareCode: list.ShareCode,
-			World:     list.World,
-			CreatedAt: list.CreatedAt.Time,
-			UpdatedAt: list.UpdatedAt.Time,
-			HasEmail:  hasEmail,
-		})
-	}
-
-	// Handle new character case
-	if req.CharacterName == "" || req.World == "" {
-		return apperror.ValidationError("Character name and world are required for new character", nil).WithDetails(&apperror.ValidationErrorDetails{
-			Field:  "character_name,world",
-			Reason: "Both character name and world are required for new characters",
-		})
-	}
-
-	// Check if the character name is already taken
-	// This single check replaces the two separate checks in the original code
-	if req.CharacterName != "" {
-		existingChar, err := h.store.GetCharacterByName(ctx, req.CharacterName)
-		if err == nil {
-			// Character exists
-			if existingChar.UserID != userID {
-				// Character belongs to another user, return conflict error
-				return apperror.ValidationError("Character name is already registered", nil).WithDetails(&apperror.ValidationErrorDetails{
-					Field:  "character_name",
-					Value:  req.CharacterName,
-					Reason: "Character name is already taken by another user",
-				})
-			}
-			// Character belongs to current user, we could use it but for simplicity
-			// we'll create a new one as in the original flow
-		}
-	}
-
-	// Create character
-	character, err := h.store.CreateCharacter(ctx, db.CreateCharacterParams{
-		UserID: userID,
-		Name:   strings.TrimSpace(req.CharacterName),
-		World:  strings.TrimSpace(req.World),
+		return nil
 	})
 	if err != nil {
-		return apperror.DatabaseError("Failed to create character", err).WithDetails(&apperror.DatabaseErrorDetails{
-			Operation: "CreateCharacter",
-			Table:     "characters",
-		})
+		return txError(err, "Failed to create list")
 	}
 
-	// Create list
-	list, err := h.store.CreateList(ctx, db.CreateListParams{
-		AuthorID: userID,
-		Name:     strings.TrimSpace(req.Name),
-		World:    strings.TrimSpace(req.World),
-	})
-	if err != nil {
-		return apperror.DatabaseError("Failed to create list", err).WithDetails(&apperror.DatabaseErrorDetails{
-			Operation: "CreateList",
-			Table:     "lists",
-		})
-	}
-
-	// Add character to list
-	err = h.store.AddListCharacter(ctx, db.AddListCharacterParams{
-		ListID:      list.ID,
-		UserID:      userID,
-		CharacterID: character.ID,
-	})
-	if err != nil {
-		return apperror.DatabaseError("Failed to add character to list", err).WithDetails(&apperror.DatabaseErrorDetails{
-			Operation: "AddListCharacter",
-			Table:     "list_characters",
-		})
+	if isNewUser {
+		// Generate token
+		token, err := auth.GenerateToken(userID.String(), false)
+		if err != nil {
+			return apperror.InternalError("failed to generate token", err).WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GenerateToken",
+				Table:     "auth",
+			})
+		}
+		c.Response().Header().Set("X-Auth-Token", token)
 	}
 
 	// Safely get has_email value
@@ -257,23 +232,32 @@ func (h *ListsHandler) CreateList(c echo.Context) error {
 }
 
 type ListDetailResponse struct {
-	ID        uuid.UUID                `json:"id"`
-	AuthorID  uuid.UUID                `json:"author_id"`
-	Name      string                   `json:"name"`
-	ShareCode uuid.UUID                `json:"share_code"`
-	World     string                   `json:"world"`
-	CreatedAt time.Time                `json:"created_at"`
-	UpdatedAt time.Time                `json:"updated_at"`
-	Members   []MemberStats            `json:"members"`
-	SoulCores []db.GetListSoulcoresRow `json:"soul_cores"`
+	ID               uuid.UUID                `json:"id"`
+	AuthorID         uuid.UUID                `json:"author_id"`
+	Name             string                   `json:"name"`
+	ShareCode        uuid.UUID                `json:"share_code"`
+	ShareCodeEnabled bool                     `json:"share_code_enabled"`
+	ApprovalRequired bool                     `json:"approval_required"`
+	Visibility       db.ListVisibility        `json:"visibility"`
+	World            string                   `json:"world"`
+	CreatedAt        time.Time                `json:"created_at"`
+	UpdatedAt        time.Time                `json:"updated_at"`
+	Scope            *ListScope               `json:"scope"`
+	Progress         ListProgress             `json:"progress"`
+	Members          []MemberStats            `json:"members"`
+	SoulCores        []db.GetListSoulcoresRow `json:"soul_cores"`
 }
 
 type MemberStats struct {
-	UserID        uuid.UUID `json:"user_id"`
-	CharacterName string    `json:"character_name"`
-	ObtainedCount int64     `json:"obtained_count"`
-	UnlockedCount int64     `json:"unlocked_count"`
-	IsActive      bool      `json:"is_active"`
+	UserID        uuid.UUID   `json:"user_id"`
+	CharacterID   uuid.UUID   `json:"character_id"`
+	CharacterName string      `json:"character_name"`
+	ObtainedCount int64       `json:"obtained_count"`
+	UnlockedCount int64       `json:"unlocked_count"`
+	IsActive      bool        `json:"is_active"`
+	Role          db.ListRole `json:"role"`
+	// Obtained and unlocked counts are the user's contributions, shared by their characters
+	CharacterUnlockedCount int64 `json:"character_unlocked_count"`
 }
 
 // JoinListRequest represents the request body for joining a list
@@ -297,9 +281,12 @@ func (h *ListsHandler) JoinList(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Get the list by share code
-	list, err := h.store.GetListByShareCode(ctx, shareCode)
+	// Get the list by its share code or an invite code
+	list, invite, err := h.listByJoinCode(ctx, shareCode)
 	if err != nil {
+		if isInviteError(err) {
+			return apperror.ValidationError(err.Error(), err)
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			return apperror.NotFoundError("list not found", err)
 		}
@@ -308,7 +295,7 @@ func (h *ListsHandler) JoinList(c echo.Context) error {
 
 	// Check if user is authenticated
 	var userID uuid.UUID
-	var token string
+	isNewUser := true
 
 	// Get authenticated user ID from context
 	if userIDStr, ok := c.Get("user_id").(string); ok && userIDStr != "" {
@@ -317,96 +304,176 @@ func (h *ListsHandler) JoinList(c echo.Context) error {
 		if err != nil {
 			return apperror.AuthorizationError("invalid user ID format", err)
 		}
-	} else {
-		// Create new anonymous user account
-		newUser, err := h.store.CreateAnonymousUser(ctx, uuid.New())
-		if err != nil {
-			return apperror.DatabaseError("failed to create user", err)
-		}
-		userID = newUser.ID
+		isNewUser = false
 
-		// Generate token
-		token, err = auth.GenerateToken(userID.String(), false)
+		// Removed members need to be re-invited before they can use the share code again
+		isRemoved, err := h.store.IsUserRemovedFromList(ctx, db.IsUserRemovedFromListParams{
+			ListID: list.ID,
+			UserID: userID,
+		})
 		if err != nil {
-			return apperror.InternalError("failed to generate token", err).WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "GenerateToken",
-				Table:     "auth",
-			})
+			return apperror.DatabaseError("failed to check list removals", err)
 		}
-		c.Response().Header().Set("X-Auth-Token", token)
-
-		// For new users joining with a new character, require character info
-		if req.CharacterID == "" && (req.CharacterName == "" || req.World == "") {
-			return apperror.ValidationError("character_name and world are required for first join", nil)
+		if isRemoved {
+			return apperror.AuthorizationError("you were removed from this list and need to be re-invited", nil)
 		}
+	} else if req.CharacterID == "" && (req.CharacterName == "" || req.World == "") {
+		// For new users joining with a new character, require character info
+		return apperror.ValidationError("character_name and world are required for first join", nil)
 	}
 
-	// Check if user is already a member
-	isMember, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
-		ListID: list.ID,
-		UserID: userID,
-	})
-	if err != nil {
-		return apperror.DatabaseError("failed to check list membership", err)
-	}
-	if isMember {
-		return apperror.ValidationError("user is already a member of this list", nil)
-	}
-
+	// The new user, their character, the invite use and the membership or join request
+	// are created together, so a failed join leaves nothing behind
 	var character db.Character
-	if req.CharacterID != "" {
-		// Parse character ID from string to UUID
-		characterID, err := uuid.Parse(req.CharacterID)
-		if err != nil {
-			return apperror.ValidationError("invalid character ID format", err)
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		if isNewUser {
+			// Create new anonymous user account
+			newUser, err := q.CreateAnonymousUser(ctx, uuid.New())
+			if err != nil {
+				return apperror.DatabaseError("failed to create user", err)
+			}
+			userID = newUser.ID
 		}
 
-		// Verify character exists and belongs to user
-		character, err = h.store.GetCharacter(ctx, characterID)
+		// Check if user is already a member
+		isMember, err := q.IsUserListMember(ctx, db.IsUserListMemberParams{
+			ListID: list.ID,
+			UserID: userID,
+		})
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return apperror.NotFoundError("character not found", err)
+			return apperror.DatabaseError("failed to check list membership", err)
+		}
+		if isMember {
+			return apperror.ValidationError("user is already a member of this list", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "character_id",
+					Reason: "Members add or swap characters through the characters of the list",
+				})
+		}
+
+		// Lists requiring approval take a single pending request per user
+		if list.ApprovalRequired {
+			_, err := q.GetListJoinRequest(ctx, db.GetListJoinRequestParams{
+				ListID: list.ID,
+				UserID: userID,
+			})
+			if err == nil {
+				return apperror.ValidationError("join request is already pending", nil)
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return apperror.DatabaseError("failed to check join requests", err)
 			}
-			return apperror.DatabaseError("failed to retrieve character", err)
 		}
 
-		if character.UserID != userID {
-			return apperror.AuthorizationError("character does not belong to user", nil)
+		if req.CharacterID != "" {
+			// Parse character ID from string to UUID
+			characterID, err := uuid.Parse(req.CharacterID)
+			if err != nil {
+				return apperror.ValidationError("invalid character ID format", err)
+			}
+
+			// Verify character exists and belongs to user
+			character, err = q.GetCharacter(ctx, characterID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return apperror.NotFoundError("character not found", err)
+				}
+				return apperror.DatabaseError("failed to retrieve character", err)
+			}
+
+			if !h.policy.CanManageCharacter(userID, character) {
+				return apperror.AuthorizationError("character does not belong to user", nil)
+			}
+
+			if character.World != list.World {
+				return apperror.ValidationError("character world does not match list world", nil)
+			}
+		} else {
+			// Check if the character name is already taken
+			if req.CharacterName != "" {
+				existingChar, err := q.GetCharacterByName(ctx, req.CharacterName)
+				if err == nil && existingChar.UserID != userID {
+					return apperror.ValidationError("character name is already registered", nil)
+				}
+			}
+
+			// Create new character
+			character, err = q.CreateCharacter(ctx, db.CreateCharacterParams{
+				UserID: userID,
+				Name:   strings.TrimSpace(req.CharacterName),
+				World:  strings.TrimSpace(req.World),
+			})
+			if err != nil {
+				return apperror.DatabaseError("failed to create character", err)
+			}
 		}
 
-		if character.World != list.World {
-			return apperror.ValidationError("character world does not match list world", nil)
+		// Count the use only now, so failed joins don't use up limited invites
+		if invite != nil {
+			used, err := q.UseListInvite(ctx, invite.ID)
+			if err != nil {
+				return apperror.DatabaseError("failed to use invite", err)
+			}
+			if used == 0 {
+				return apperror.ValidationError("invite is no longer valid", nil)
+			}
 		}
-	} else {
-		// Check if the character name is already taken
-		if req.CharacterName != "" {
-			existingChar, err := h.store.GetCharacterByName(ctx, req.CharacterName)
-			if err == nil && existingChar.UserID != userID {
-				return apperror.ValidationError("character name is already registered", nil)
+
+		if list.ApprovalRequired {
+			err = q.CreateListJoinRequest(ctx, db.CreateListJoinRequestParams{
+				ListID:      list.ID,
+				UserID:      userID,
+				CharacterID: character.ID,
+			})
+			if err != nil {
+				return apperror.DatabaseError("failed to create join request", err)
 			}
+			return nil
 		}
 
-		// Create new character
-		character, err = h.store.CreateCharacter(ctx, db.CreateCharacterParams{
-			UserID: userID,
-			Name:   strings.TrimSpace(req.CharacterName),
-			World:  strings.TrimSpace(req.World),
+		// Add character to list
+		err = q.AddListCharacter(ctx, db.AddListCharacterParams{
+			ListID:      list.ID,
+			UserID:      userID,
+			CharacterID: character.ID,
+			Role:        db.ListRoleMember,
 		})
 		if err != nil {
-			return apperror.DatabaseError("failed to create character", err)
+			return apperror.DatabaseError("failed to add character to list", err)
 		}
-	}
 
-	// Add character to list
-	err = h.store.AddListCharacter(ctx, db.AddListCharacterParams{
-		ListID:      list.ID,
-		UserID:      userID,
-		CharacterID: character.ID,
+		return recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:  list.ID,
+			ActorID: userID,
+			Action:  db.ListActivityActionMemberJoined,
+		})
 	})
 	if err != nil {
-		return apperror.DatabaseError("failed to add character to list", err)
+		return txError(err, "failed to join list")
+	}
+
+	if isNewUser {
+		// Generate token
+		token, err := auth.GenerateToken(userID.String(), false)
+		if err != nil {
+			return apperror.InternalError("failed to generate token", err).WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GenerateToken",
+				Table:     "auth",
+			})
+		}
+		c.Response().Header().Set("X-Auth-Token", token)
 	}
 
+	if list.ApprovalRequired {
+		return h.joinRequested(c, list, userID, character)
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberJoined, list.ID, map[string]any{
+		"user_id":      userID,
+		"character_id": character.ID,
+		"role":         db.ListRoleMember,
+	})
+
 	// Get member stats for the newly added member
 	members, err := h.store.GetListMembers(ctx, list.ID)
 	if err != nil {
@@ -416,11 +483,14 @@ func (h *ListsHandler) JoinList(c echo.Context) error {
 	memberStats := make([]MemberStats, len(members))
 	for i, m := range members {
 		memberStats[i] = MemberStats{
-			UserID:        m.UserID,
-			CharacterName: m.CharacterName,
-			ObtainedCount: m.ObtainedCount,
-			UnlockedCount: m.UnlockedCount,
-			IsActive:      m.IsActive,
+			UserID:                 m.UserID,
+			CharacterID:            m.CharacterID,
+			CharacterName:          m.CharacterName,
+			ObtainedCount:          m.ObtainedCount,
+			UnlockedCount:          m.UnlockedCount,
+			IsActive:               m.IsActive,
+			Role:                   m.Role,
+			CharacterUnlockedCount: m.CharacterUnlockedCount,
 		}
 	}
 
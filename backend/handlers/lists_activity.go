@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+)
+
+// ListActivityResponse is a page of the activity log of a list
+type ListActivityResponse struct {
+	Activity   []ListActivityEntry `json:"activity"`
+	Pagination PaginationInfo      `json:"pagination"`
+}
+
+// ListActivityEntry is a single change made to a list. Creature and target user are only
+// set for actions that have one, old and new values hold what the change replaced.
+type ListActivityEntry struct {
+	ID            uuid.UUID             `json:"id"`
+	Action        db.ListActivityAction `json:"action"`
+	ActorID       uuid.UUID             `json:"actor_id"`
+	CharacterID   *uuid.UUID            `json:"character_id,omitempty"`
+	CharacterName pgtype.Text           `json:"character_name"`
+	CreatureID    *uuid.UUID            `json:"creature_id,omitempty"`
+	CreatureName  pgtype.Text           `json:"creature_name"`
+	TargetUserID  *uuid.UUID            `json:"target_user_id,omitempty"`
+	OldValue      pgtype.Text           `json:"old_value"`
+	NewValue      pgtype.Text           `json:"new_value"`
+	CreatedAt     time.Time             `json:"created_at"`
+}
+
+// recordActivity appends an entry to the activity log of a list. It runs inside the
+// transaction of the change it describes, so the log never disagrees with the list.
+func recordActivity(ctx context.Context, q db.Querier, arg db.CreateListActivityParams) error {
+	if err := q.CreateListActivity(ctx, arg); err != nil {
+		return apperror.DatabaseError("Failed to record list activity", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "CreateListActivity",
+				Table:     "list_activity",
+			})
+	}
+	return nil
+}
+
+// activityValue wraps a value for the old or new value of an activity entry
+func activityValue(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: true}
+}
+
+// optionalID returns nil for the zero UUID, which the activity log uses for "none"
+func optionalID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// GetListActivity returns the activity log of a list, newest first. The optional
+// member_id query parameter narrows it down to what one member did or had done to them.
+func (h *ListsHandler) GetListActivity(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var memberID uuid.UUID
+	if memberIDStr := c.QueryParam("member_id"); memberIDStr != "" {
+		memberID, err = uuid.Parse(memberIDStr)
+		if err != nil {
+			return apperror.ValidationError("Invalid member ID", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "member_id",
+					Value:  memberIDStr,
+					Reason: "Invalid UUID format",
+				})
+		}
+	}
+
+	// Get page number from query parameters, default to 1
+	pageStr := c.QueryParam("page")
+	page := 1
+	if pageStr != "" {
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			return apperror.ValidationError("Invalid page number", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "page",
+					Value:  pageStr,
+					Reason: "Page must be a positive integer",
+				})
+		}
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Any member of the list can read its activity
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	const pageSize = 20
+	offset := (page - 1) * pageSize
+
+	rows, err := h.store.GetListActivity(ctx, db.GetListActivityParams{
+		ListID:   listID,
+		MemberID: memberID,
+		Limit:    int32(pageSize),
+		Offset:   int32(offset),
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to get list activity", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListActivity",
+				Table:     "list_activity",
+			})
+	}
+
+	var totalRecords int64
+	if len(rows) > 0 {
+		totalRecords = rows[0].TotalCount
+	}
+	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	entries := make([]ListActivityEntry, len(rows))
+	for i, r := range rows {
+		entries[i] = ListActivityEntry{
+			ID:            r.ID,
+			Action:        r.Action,
+			ActorID:       r.ActorID,
+			CharacterID:   optionalID(r.CharacterID),
+			CharacterName: r.CharacterName,
+			CreatureID:    optionalID(r.CreatureID),
+			CreatureName:  r.CreatureName,
+			TargetUserID:  optionalID(r.TargetUserID),
+			OldValue:      r.OldValue,
+			NewValue:      r.NewValue,
+			CreatedAt:     r.CreatedAt.Time,
+		}
+	}
+
+	return c.JSON(http.StatusOK, ListActivityResponse{
+		Activity: entries,
+		Pagination: PaginationInfo{
+			TotalPages:   totalPages,
+			CurrentPage:  page,
+			TotalRecords: int(totalRecords),
+			PageSize:     pageSize,
+		},
+	})
+}
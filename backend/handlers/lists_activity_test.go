@@ -0,0 +1,198 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetListActivity(t *testing.T) {
+	memberID := uuid.New()
+	creatureID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupMocks    func(store *mockdb.MockStore, listID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		checkResponse func(t *testing.T, response handlers.ListActivityResponse)
+	}{
+		{
+			name:  "Success",
+			query: "?page=2",
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+
+				store.EXPECT().
+					GetListActivity(gomock.Any(), db.GetListActivityParams{
+						ListID: listID,
+						Limit:  20,
+						Offset: 20,
+					}).
+					Return([]db.GetListActivityRow{
+						{
+							ID:            uuid.New(),
+							ActorID:       userID,
+							CharacterID:   uuid.New(),
+							CharacterName: pgtype.Text{String: "Knight", Valid: true},
+							Action:        db.ListActivityActionSoulcoreStatusChanged,
+							CreatureID:    creatureID,
+							CreatureName:  pgtype.Text{String: "Dragon", Valid: true},
+							OldValue:      pgtype.Text{String: "obtained", Valid: true},
+							NewValue:      pgtype.Text{String: "unlocked", Valid: true},
+							TotalCount:    22,
+						},
+						{
+							ID:           uuid.New(),
+							ActorID:      userID,
+							Action:       db.ListActivityActionMemberRemoved,
+							TargetUserID: memberID,
+							TotalCount:   22,
+						},
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response handlers.ListActivityResponse) {
+				require.Len(t, response.Activity, 2)
+				require.Equal(t, db.ListActivityActionSoulcoreStatusChanged, response.Activity[0].Action)
+				require.Equal(t, &creatureID, response.Activity[0].CreatureID)
+				require.Equal(t, "Dragon", response.Activity[0].CreatureName.String)
+				require.Equal(t, "obtained", response.Activity[0].OldValue.String)
+				require.Equal(t, "unlocked", response.Activity[0].NewValue.String)
+				require.Nil(t, response.Activity[0].TargetUserID)
+				require.Nil(t, response.Activity[1].CreatureID)
+				require.Nil(t, response.Activity[1].CharacterID)
+				require.Equal(t, &memberID, response.Activity[1].TargetUserID)
+				require.Equal(t, 2, response.Pagination.TotalPages)
+				require.Equal(t, 2, response.Pagination.CurrentPage)
+				require.Equal(t, 22, response.Pagination.TotalRecords)
+			},
+		},
+		{
+			name:  "Success - Filtered by Member",
+			query: fmt.Sprintf("?member_id=%s", memberID),
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListActivity(gomock.Any(), db.GetListActivityParams{
+						ListID:   listID,
+						MemberID: memberID,
+						Limit:    20,
+						Offset:   0,
+					}).
+					Return([]db.GetListActivityRow{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response handlers.ListActivityResponse) {
+				require.Empty(t, response.Activity)
+				require.Equal(t, 1, response.Pagination.TotalPages)
+			},
+		},
+		{
+			name:  "Invalid Member ID",
+			query: "?member_id=invalid-uuid",
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid member ID",
+		},
+		{
+			name:  "Invalid Page",
+			query: "?page=0",
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid page number",
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Database Error - GetListActivity",
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListActivity(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get list activity",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/activity%s", listID, tc.query)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/activity")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.GetListActivity(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response handlers.ListActivityResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			tc.checkResponse(t, response)
+		})
+	}
+}
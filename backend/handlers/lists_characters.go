@@ -0,0 +1,494 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// ListCharacterRequest represents the request body for adding a character to a list or
+// swapping one of the user's characters for it
+type ListCharacterRequest struct {
+	CharacterID uuid.UUID `json:"character_id"`
+}
+
+// ListCharacter is one of the characters a user takes part in a list with
+type ListCharacter struct {
+	CharacterID   uuid.UUID   `json:"character_id"`
+	CharacterName string      `json:"character_name"`
+	Active        bool        `json:"active"`
+	Role          db.ListRole `json:"role"`
+}
+
+// listCharacterParams reads the list ID and the authenticated user from the request
+func listCharacterParams(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return listID, uuid.Nil, apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return listID, uuid.Nil, apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return listID, uuid.Nil, apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	return listID, userID, nil
+}
+
+// bindListCharacterRequest decodes and validates the body of ListCharacterRequest
+func bindListCharacterRequest(c echo.Context) (ListCharacterRequest, error) {
+	var req ListCharacterRequest
+	if err := c.Bind(&req); err != nil {
+		return req, apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	if req.CharacterID == uuid.Nil {
+		return req, apperror.ValidationError("Character ID is required", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Reason: "The character to take part in the list with is required",
+			})
+	}
+	return req, nil
+}
+
+// listCharacterCandidate loads a character the user wants to take part in the list with.
+// It has to belong to the user, live on the list's world and not be in the list yet.
+func (h *ListsHandler) listCharacterCandidate(ctx context.Context, q db.Querier, list db.List, userID, characterID uuid.UUID) (db.Character, error) {
+	char, err := q.GetCharacter(ctx, characterID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return char, apperror.NotFoundError("Character not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "character_id",
+					Value:  characterID.String(),
+					Reason: "Character does not exist",
+				})
+		}
+		return char, apperror.DatabaseError("Failed to retrieve character", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetCharacter",
+				Table:     "characters",
+			})
+	}
+
+	if !h.policy.CanManageCharacter(userID, char) {
+		return char, apperror.AuthorizationError("Character does not belong to user", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Value:  characterID.String(),
+				Reason: "Character belongs to a different user",
+			})
+	}
+
+	if char.World != list.World {
+		return char, apperror.ValidationError("Character world does not match list world", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Value:  char.World,
+				Reason: "Character must be on the world of the list",
+			})
+	}
+
+	listIDs, err := q.GetCharacterListIDs(ctx, char.ID)
+	if err != nil {
+		return char, apperror.DatabaseError("Failed to get character lists", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetCharacterListIDs",
+				Table:     "lists_users",
+			})
+	}
+	for _, id := range listIDs {
+		if id == list.ID {
+			return char, apperror.ValidationError("Character is already in this list", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "character_id",
+					Value:  characterID.String(),
+					Reason: "Character is already in this list",
+				})
+		}
+	}
+
+	return char, nil
+}
+
+// listUserCharacters returns the characters the user takes part in the list with
+func listUserCharacters(ctx context.Context, q db.Querier, listID, userID uuid.UUID) ([]ListCharacter, error) {
+	rows, err := q.GetListUserCharacters(ctx, db.GetListUserCharactersParams{
+		ListID: listID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, apperror.DatabaseError("Failed to get list characters", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListUserCharacters",
+				Table:     "lists_users",
+			})
+	}
+
+	chars := make([]ListCharacter, len(rows))
+	for i, r := range rows {
+		chars[i] = ListCharacter{
+			CharacterID:   r.CharacterID,
+			CharacterName: r.CharacterName,
+			Active:        r.Active,
+			Role:          r.Role,
+		}
+	}
+	return chars, nil
+}
+
+// findListCharacter picks one of the user's characters in the list
+func findListCharacter(chars []ListCharacter, characterID uuid.UUID) (ListCharacter, error) {
+	for _, char := range chars {
+		if char.CharacterID == characterID {
+			return char, nil
+		}
+	}
+	return ListCharacter{}, apperror.NotFoundError("Character is not in this list", nil).
+		WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "character_id",
+			Value:  characterID.String(),
+			Reason: "The user does not take part in the list with this character",
+		})
+}
+
+// GetListCharacters returns the characters the user takes part in a list with
+func (h *ListsHandler) GetListCharacters(c echo.Context) error {
+	listID, userID, err := listCharacterParams(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	chars, err := listUserCharacters(ctx, h.store, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, chars)
+}
+
+// AddListCharacter lets a member take part in a list with another of their characters on
+// the list's world. The character gets the member's role and its unlocks are tracked on
+// its own, soulcores the user adds stay credited to the user.
+func (h *ListsHandler) AddListCharacter(c echo.Context) error {
+	listID, userID, err := listCharacterParams(c)
+	if err != nil {
+		return err
+	}
+
+	req, err := bindListCharacterRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	list, err := h.getList(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	var char db.Character
+	var chars []ListCharacter
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		var err error
+		char, err = h.listCharacterCandidate(ctx, q, list, userID, req.CharacterID)
+		if err != nil {
+			return err
+		}
+
+		err = q.AddListCharacter(ctx, db.AddListCharacterParams{
+			ListID:      listID,
+			UserID:      userID,
+			CharacterID: char.ID,
+			Role:        membership.Role,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to add character to list", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "AddListCharacter",
+					Table:     "lists_users",
+				})
+		}
+
+		err = recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:   listID,
+			ActorID:  userID,
+			Action:   db.ListActivityActionMemberCharacterChanged,
+			NewValue: activityValue(char.Name),
+		})
+		if err != nil {
+			return err
+		}
+
+		chars, err = listUserCharacters(ctx, q, listID, userID)
+		return err
+	})
+	if err != nil {
+		return txError(err, "Failed to add character to list")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberCharacterChanged, listID, map[string]any{
+		"user_id":        userID,
+		"character_id":   char.ID,
+		"character_name": char.Name,
+	})
+
+	return c.JSON(http.StatusCreated, chars)
+}
+
+// SwapListCharacter puts another character of the user in place of one they take part in a
+// list with, for members switching mains on the same world. The role stays, and so do the
+// soulcores the user contributed since those are credited to the user.
+func (h *ListsHandler) SwapListCharacter(c echo.Context) error {
+	listID, userID, err := listCharacterParams(c)
+	if err != nil {
+		return err
+	}
+
+	characterID, err := uuid.Parse(c.Param("character_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid character ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Value:  c.Param("character_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	req, err := bindListCharacterRequest(c)
+	if err != nil {
+		return err
+	}
+
+	if req.CharacterID == characterID {
+		return apperror.ValidationError("Character is already in this list", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Value:  req.CharacterID.String(),
+				Reason: "The new character has to differ from the current one",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	list, err := h.getList(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	var char db.Character
+	var chars []ListCharacter
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		current, err := listUserCharacters(ctx, q, listID, userID)
+		if err != nil {
+			return err
+		}
+		previous, err := findListCharacter(current, characterID)
+		if err != nil {
+			return err
+		}
+
+		char, err = h.listCharacterCandidate(ctx, q, list, userID, req.CharacterID)
+		if err != nil {
+			return err
+		}
+
+		swapped, err := q.SwapListCharacter(ctx, db.SwapListCharacterParams{
+			ListID:         listID,
+			CharacterID:    characterID,
+			NewCharacterID: char.ID,
+			UserID:         userID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to swap list character", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "SwapListCharacter",
+					Table:     "lists_users",
+				})
+		}
+		if swapped == 0 {
+			return apperror.NotFoundError("Character is not in this list", nil)
+		}
+
+		err = recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:   listID,
+			ActorID:  userID,
+			Action:   db.ListActivityActionMemberCharacterChanged,
+			OldValue: activityValue(previous.CharacterName),
+			NewValue: activityValue(char.Name),
+		})
+		if err != nil {
+			return err
+		}
+
+		chars, err = listUserCharacters(ctx, q, listID, userID)
+		return err
+	})
+	if err != nil {
+		return txError(err, "Failed to swap list character")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberCharacterChanged, listID, map[string]any{
+		"user_id":          userID,
+		"character_id":     char.ID,
+		"character_name":   char.Name,
+		"old_character_id": characterID,
+	})
+
+	return c.JSON(http.StatusOK, chars)
+}
+
+// RemoveListCharacter takes one of the user's characters out of a list. The user stays a
+// member with their other characters, so the last active one cannot be removed; leaving the
+// list is the way out.
+func (h *ListsHandler) RemoveListCharacter(c echo.Context) error {
+	listID, userID, err := listCharacterParams(c)
+	if err != nil {
+		return err
+	}
+
+	characterID, err := uuid.Parse(c.Param("character_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid character ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Value:  c.Param("character_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	var chars []ListCharacter
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		current, err := listUserCharacters(ctx, q, listID, userID)
+		if err != nil {
+			return err
+		}
+		removed, err := findListCharacter(current, characterID)
+		if err != nil {
+			return err
+		}
+
+		remaining := 0
+		for _, char := range current {
+			if char.Active && char.CharacterID != characterID {
+				remaining++
+			}
+		}
+		if remaining == 0 {
+			return apperror.ValidationError("Cannot remove the last character, leave the list instead", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "character_id",
+					Value:  characterID.String(),
+					Reason: "The user has no other active character in the list",
+				})
+		}
+
+		if _, err := q.RemoveListCharacter(ctx, db.RemoveListCharacterParams{
+			ListID:      listID,
+			UserID:      userID,
+			CharacterID: characterID,
+		}); err != nil {
+			return apperror.DatabaseError("Failed to remove list character", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "RemoveListCharacter",
+					Table:     "lists_users",
+				})
+		}
+
+		err = recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:   listID,
+			ActorID:  userID,
+			Action:   db.ListActivityActionMemberCharacterChanged,
+			OldValue: activityValue(removed.CharacterName),
+		})
+		if err != nil {
+			return err
+		}
+
+		chars, err = listUserCharacters(ctx, q, listID, userID)
+		return err
+	})
+	if err != nil {
+		return txError(err, "Failed to remove list character")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberCharacterChanged, listID, map[string]any{
+		"user_id":          userID,
+		"old_character_id": characterID,
+	})
+
+	// Concurrent removals can each leave the other one's character as the last, so the
+	// membership is checked once they are committed
+	isMember, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
+		ListID: listID,
+		UserID: userID,
+	})
+	if err != nil {
+		apperror.DatabaseError("Failed to check list membership", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "IsUserListMember",
+				Table:     "lists_users",
+			}).
+			LogError()
+	} else if !isMember {
+		publishListEvent(ctx, h.hub, services.EventMemberLeft, listID, map[string]any{
+			"user_id": userID,
+		})
+	}
+
+	return c.JSON(http.StatusOK, chars)
+}
@@ -0,0 +1,568 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddListCharacter(t *testing.T) {
+	mainID := uuid.New()
+	altID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		body          handlers.ListCharacterRequest
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			body: handlers.ListCharacterRequest{CharacterID: altID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), altID).
+					Return(db.Character{ID: altID, UserID: userID, Name: "Alt", World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacterListIDs(gomock.Any(), altID).
+					Return([]uuid.UUID{uuid.New()}, nil)
+
+				// The character takes the member's role
+				store.EXPECT().
+					AddListCharacter(gomock.Any(), db.AddListCharacterParams{
+						ListID:      listID,
+						UserID:      userID,
+						CharacterID: altID,
+						Role:        db.ListRoleModerator,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:   listID,
+						ActorID:  userID,
+						Action:   db.ListActivityActionMemberCharacterChanged,
+						NewValue: pgtype.Text{String: "Alt", Valid: true},
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					GetListUserCharacters(gomock.Any(), db.GetListUserCharactersParams{ListID: listID, UserID: userID}).
+					Return([]db.GetListUserCharactersRow{
+						{CharacterID: altID, CharacterName: "Alt", Active: true, Role: db.ListRoleModerator},
+						{CharacterID: mainID, CharacterName: "Main", Active: true, Role: db.ListRoleModerator},
+					}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:          "Missing Character",
+			body:          handlers.ListCharacterRequest{},
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Character ID is required",
+		},
+		{
+			name: "Character Already In List",
+			body: handlers.ListCharacterRequest{CharacterID: altID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), altID).
+					Return(db.Character{ID: altID, UserID: userID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacterListIDs(gomock.Any(), altID).
+					Return([]uuid.UUID{listID}, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Character is already in this list",
+		},
+		{
+			name: "Character On Another World",
+			body: handlers.ListCharacterRequest{CharacterID: altID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), altID).
+					Return(db.Character{ID: altID, UserID: userID, World: "Secura"}, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Character world does not match list world",
+		},
+		{
+			name: "Character Of Another User",
+			body: handlers.ListCharacterRequest{CharacterID: altID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), altID).
+					Return(db.Character{ID: altID, UserID: uuid.New(), World: "Antica"}, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Character does not belong to user",
+		},
+		{
+			name: "Not a Member",
+			body: handlers.ListCharacterRequest{CharacterID: altID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s/characters", listID)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/characters")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.AddListCharacter(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, 0, tx.Committed)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+
+			var response []handlers.ListCharacter
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response, 2)
+		})
+	}
+}
+
+func TestSwapListCharacter(t *testing.T) {
+	oldID := uuid.New()
+	newID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		characterID   string
+		body          handlers.ListCharacterRequest
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:        "Success",
+			characterID: oldID.String(),
+			body:        handlers.ListCharacterRequest{CharacterID: newID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				gomock.InOrder(
+					store.EXPECT().
+						GetListUserCharacters(gomock.Any(), db.GetListUserCharactersParams{ListID: listID, UserID: userID}).
+						Return([]db.GetListUserCharactersRow{
+							{CharacterID: oldID, CharacterName: "Old Main", Active: true, Role: db.ListRoleOwner},
+						}, nil),
+					store.EXPECT().
+						GetListUserCharacters(gomock.Any(), db.GetListUserCharactersParams{ListID: listID, UserID: userID}).
+						Return([]db.GetListUserCharactersRow{
+							{CharacterID: newID, CharacterName: "New Main", Active: true, Role: db.ListRoleOwner},
+						}, nil),
+				)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), newID).
+					Return(db.Character{ID: newID, UserID: userID, Name: "New Main", World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacterListIDs(gomock.Any(), newID).
+					Return(nil, nil)
+
+				// The membership row is kept, so the role and the user's soulcores stay
+				store.EXPECT().
+					SwapListCharacter(gomock.Any(), db.SwapListCharacterParams{
+						ListID:         listID,
+						CharacterID:    oldID,
+						NewCharacterID: newID,
+						UserID:         userID,
+					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:   listID,
+						ActorID:  userID,
+						Action:   db.ListActivityActionMemberCharacterChanged,
+						OldValue: pgtype.Text{String: "Old Main", Valid: true},
+						NewValue: pgtype.Text{String: "New Main", Valid: true},
+					}).
+					Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "Character Not In List",
+			characterID: oldID.String(),
+			body:        handlers.ListCharacterRequest{CharacterID: newID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetListUserCharacters(gomock.Any(), gomock.Any()).
+					Return([]db.GetListUserCharactersRow{
+						{CharacterID: uuid.New(), CharacterName: "Someone", Active: true, Role: db.ListRoleMember},
+					}, nil)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Character is not in this list",
+		},
+		{
+			name:          "Same Character",
+			characterID:   oldID.String(),
+			body:          handlers.ListCharacterRequest{CharacterID: oldID},
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Character is already in this list",
+		},
+		{
+			name:          "Invalid Character ID",
+			characterID:   "invalid-uuid",
+			body:          handlers.ListCharacterRequest{CharacterID: newID},
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid character ID",
+		},
+		{
+			name:        "Database Error - SwapListCharacter",
+			characterID: oldID.String(),
+			body:        handlers.ListCharacterRequest{CharacterID: newID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetListUserCharacters(gomock.Any(), gomock.Any()).
+					Return([]db.GetListUserCharactersRow{
+						{CharacterID: oldID, CharacterName: "Old Main", Active: true, Role: db.ListRoleMember},
+					}, nil)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), newID).
+					Return(db.Character{ID: newID, UserID: userID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacterListIDs(gomock.Any(), newID).
+					Return(nil, nil)
+
+				store.EXPECT().
+					SwapListCharacter(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to swap list character",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s/characters/%s", listID, tc.characterID)
+			req := httptest.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/characters/:character_id")
+			c.SetParamNames("id", "character_id")
+			c.SetParamValues(listID.String(), tc.characterID)
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.SwapListCharacter(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, 0, tx.Committed)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+
+			var response []handlers.ListCharacter
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response, 1)
+			require.Equal(t, newID, response[0].CharacterID)
+		})
+	}
+}
+
+func TestRemoveListCharacter(t *testing.T) {
+	mainID := uuid.New()
+	altID := uuid.New()
+
+	// expectSuccess mocks removing the alt, leaving the main character
+	expectSuccess := func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+		store.EXPECT().
+			GetListMemberRole(gomock.Any(), gomock.Any()).
+			Return(db.ListRoleMember, nil)
+
+		gomock.InOrder(
+			store.EXPECT().
+				GetListUserCharacters(gomock.Any(), db.GetListUserCharactersParams{ListID: listID, UserID: userID}).
+				Return([]db.GetListUserCharactersRow{
+					{CharacterID: altID, CharacterName: "Alt", Active: true, Role: db.ListRoleMember},
+					{CharacterID: mainID, CharacterName: "Main", Active: true, Role: db.ListRoleMember},
+				}, nil),
+			store.EXPECT().
+				GetListUserCharacters(gomock.Any(), db.GetListUserCharactersParams{ListID: listID, UserID: userID}).
+				Return([]db.GetListUserCharactersRow{
+					{CharacterID: mainID, CharacterName: "Main", Active: true, Role: db.ListRoleMember},
+				}, nil),
+		)
+
+		store.EXPECT().
+			RemoveListCharacter(gomock.Any(), db.RemoveListCharacterParams{
+				ListID:      listID,
+				UserID:      userID,
+				CharacterID: altID,
+			}).
+			Return(int64(1), nil)
+
+		store.EXPECT().
+			CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+				ListID:   listID,
+				ActorID:  userID,
+				Action:   db.ListActivityActionMemberCharacterChanged,
+				OldValue: pgtype.Text{String: "Alt", Valid: true},
+			}).
+			Return(nil)
+	}
+
+	testCases := []struct {
+		name           string
+		setupMocks     func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode   int
+		expectedError  string
+		expectedEvents []string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				expectSuccess(store, listID, userID)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{ListID: listID, UserID: userID}).
+					Return(true, nil)
+			},
+			expectedCode:   http.StatusOK,
+			expectedEvents: []string{services.EventMemberCharacterChanged},
+		},
+		{
+			name: "Success - Concurrent Removal Of The Last Character",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				expectSuccess(store, listID, userID)
+
+				// The main character was removed at the same time
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{ListID: listID, UserID: userID}).
+					Return(false, nil)
+			},
+			expectedCode:   http.StatusOK,
+			expectedEvents: []string{services.EventMemberCharacterChanged, services.EventMemberLeft},
+		},
+		{
+			name: "Last Active Character",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				// An inactive character does not keep the membership going
+				store.EXPECT().
+					GetListUserCharacters(gomock.Any(), gomock.Any()).
+					Return([]db.GetListUserCharactersRow{
+						{CharacterID: altID, CharacterName: "Alt", Active: true, Role: db.ListRoleMember},
+						{CharacterID: mainID, CharacterName: "Main", Active: false, Role: db.ListRoleMember},
+					}, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Cannot remove the last character",
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/characters/%s", listID, altID)
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/characters/:character_id")
+			c.SetParamNames("id", "character_id")
+			c.SetParamValues(listID.String(), altID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			bus := services.NewMemoryEventBus()
+			var events []string
+			bus.Subscribe(func(ctx context.Context, event services.ListEvent) {
+				events = append(events, event.Type)
+			})
+
+			h := handlers.NewListsHandler(store, services.NewHub(bus))
+			err := h.RemoveListCharacter(c)
+			require.Equal(t, tc.expectedEvents, events)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, 0, tx.Committed)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+
+			var response []handlers.ListCharacter
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response, 1)
+			require.Equal(t, mainID, response[0].CharacterID)
+		})
+	}
+}
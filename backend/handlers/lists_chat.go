@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,6 +12,7 @@ import (
 	"github.com/labstack/echo/v4"
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 )
 
 // ChatMessage represents a chat message response
@@ -65,30 +68,27 @@ func (h *ListsHandler) CreateChatMessage(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Check if user is a member of the list
-	member, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
-		ListID: listID,
-		UserID: userID,
-	})
+	// Check the user's role in the list
+	membership, err := h.listMembership(ctx, listID, userID)
 	if err != nil {
-		return apperror.DatabaseError("Failed to check list membership", err).
-			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "IsUserListMember",
-				Table:     "lists_users",
-			})
+		return err
 	}
-	if !member {
-		return apperror.AuthorizationError("User is not a member of this list", nil).
+
+	if !membership.CanContribute() {
+		return apperror.AuthorizationError("Viewers cannot post chat messages", nil).
 			WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "list_id",
-				Value:  listID.String(),
-				Reason: "User is not a member of this list",
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Role does not allow posting messages",
 			})
 	}
 
 	// Parse message from request body
 	var messageReq struct {
-		Message     string `json:"message" validate:"required"`
+		// Messages reach the other members as list events, which the Postgres event bus
+		// sends as NOTIFY payloads of less than 8000 bytes. 1000 characters stay below
+		// that even when JSON escapes every one of them to six bytes.
+		Message     string `json:"message" validate:"required,max=1000"`
 		CharacterID string `json:"character_id" validate:"required,uuid"`
 	}
 
@@ -120,7 +120,7 @@ func (h *ListsHandler) CreateChatMessage(c echo.Context) error {
 			})
 	}
 
-	if character.UserID != userID {
+	if !h.policy.CanManageCharacter(userID, character) {
 		return apperror.AuthorizationError("Character does not belong to user", nil).
 			WithDetails(&apperror.ValidationErrorDetails{
 				Field:  "character_id",
@@ -154,6 +154,8 @@ func (h *ListsHandler) CreateChatMessage(c echo.Context) error {
 		CreatedAt:     message.CreatedAt.Time,
 	}
 
+	publishListEvent(ctx, h.hub, services.EventChatMessageCreated, listID, response)
+
 	return c.JSON(http.StatusCreated, response)
 }
 
@@ -191,25 +193,9 @@ func (h *ListsHandler) GetChatMessages(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Check if user is a member of the list
-	member, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
-		ListID: listID,
-		UserID: userID,
-	})
-	if err != nil {
-		return apperror.DatabaseError("Failed to check list membership", err).
-			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "IsUserListMember",
-				Table:     "lists_users",
-			})
-	}
-	if !member {
-		return apperror.AuthorizationError("User is not a member of this list", nil).
-			WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "list_id",
-				Value:  listID.String(),
-				Reason: "User is not a member of this list",
-			})
+	// Check the user's role in the list
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
 	}
 
 	// Get pagination parameters
@@ -327,6 +313,16 @@ func (h *ListsHandler) GetChatMessages(c echo.Context) error {
 
 // DeleteChatMessage deletes a chat message
 func (h *ListsHandler) DeleteChatMessage(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
 	messageID, err := uuid.Parse(c.Param("messageId"))
 	if err != nil {
 		return apperror.ValidationError("Invalid message ID", err).
@@ -359,19 +355,72 @@ func (h *ListsHandler) DeleteChatMessage(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Delete the message only if the user is the owner
-	err = h.store.DeleteChatMessage(ctx, db.DeleteChatMessageParams{
+	// Check the user's role in the list
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	message, err := h.store.GetChatMessage(ctx, db.GetChatMessageParams{
 		ID:     messageID,
-		UserID: userID,
+		ListID: listID,
 	})
 	if err != nil {
-		return apperror.DatabaseError("Failed to delete chat message", err).
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Chat message not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "messageId",
+					Value:  messageID.String(),
+					Reason: "Message does not exist in this list",
+				})
+		}
+		return apperror.DatabaseError("Failed to get chat message", err).
 			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "DeleteChatMessage",
+				Operation: "GetChatMessage",
 				Table:     "list_chat_messages",
 			})
 	}
 
+	// Authors can delete their own messages, moderators can delete any
+	if !membership.CanDeleteChatMessage(message.UserID) {
+		return apperror.AuthorizationError("Only list moderators or the author can delete this message", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "messageId",
+				Value:  messageID.String(),
+				Reason: "Not authorized to delete message",
+			})
+	}
+
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		err := q.DeleteChatMessage(ctx, db.DeleteChatMessageParams{
+			ID:     messageID,
+			ListID: listID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to delete chat message", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "DeleteChatMessage",
+					Table:     "list_chat_messages",
+				})
+		}
+
+		// The log keeps the deleted message, so moderation stays traceable
+		return recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:       listID,
+			ActorID:      userID,
+			Action:       db.ListActivityActionChatMessageDeleted,
+			TargetUserID: message.UserID,
+			OldValue:     activityValue(message.Message),
+		})
+	})
+	if err != nil {
+		return txError(err, "Failed to delete chat message")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventChatMessageDeleted, listID, map[string]string{
+		"id": messageID.String(),
+	})
+
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -458,25 +507,9 @@ func (h *ListsHandler) MarkChatMessagesAsRead(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Check if user is a member of the list
-	member, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
-		ListID: listID,
-		UserID: userID,
-	})
-	if err != nil {
-		return apperror.DatabaseError("Failed to check list membership", err).
-			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "IsUserListMember",
-				Table:     "lists_users",
-			})
-	}
-	if !member {
-		return apperror.AuthorizationError("User is not a member of this list", nil).
-			WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "list_id",
-				Value:  listID.String(),
-				Reason: "User is not a member of this list",
-			})
+	// Check the user's role in the list
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
 	}
 
 	// Mark messages as read
@@ -492,5 +525,10 @@ func (h *ListsHandler) MarkChatMessagesAsRead(c echo.Context) error {
 			})
 	}
 
+	publishListEvent(ctx, h.hub, services.EventChatMessagesRead, listID, map[string]any{
+		"user_id": userID.String(),
+		"read_at": time.Now(),
+	})
+
 	return c.NoContent(http.StatusNoContent)
 }
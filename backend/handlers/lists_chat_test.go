@@ -2,12 +2,14 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/sergot/tibiacores/backend/handlers"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
 	"github.com/sergot/tibiacores/backend/pkg/validator"
+	"github.com/sergot/tibiacores/backend/services"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -40,11 +43,11 @@ func TestCreateChatMessage(t *testing.T) {
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
 				// Check if user is a member of the list
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				// Get character
 				store.EXPECT().
@@ -121,27 +124,43 @@ func TestCreateChatMessage(t *testing.T) {
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
 				// Check if user is a member of the list
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(false, nil)
+					Return(db.ListRole(""), sql.ErrNoRows)
 			},
 			expectedCode:  http.StatusUnauthorized,
 			expectedError: "User is not a member of this list",
 		},
 		{
-			name: "Database Error - IsUserListMember",
+			name: "Viewer Cannot Post",
 			setupRequest: func(c echo.Context, body *bytes.Buffer) {
 				// Default setup is fine
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(false, errors.New("database error"))
+					Return(db.ListRoleViewer, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Viewers cannot post chat messages",
+		},
+		{
+			name: "Database Error - GetListMemberRole",
+			setupRequest: func(c echo.Context, body *bytes.Buffer) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRole(""), errors.New("database error"))
 			},
 			expectedCode:  http.StatusInternalServerError,
 			expectedError: "Failed to check list membership",
@@ -154,11 +173,11 @@ func TestCreateChatMessage(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 			},
 			expectedCode:  http.StatusBadRequest,
 			expectedError: "Invalid request body",
@@ -175,11 +194,32 @@ func TestCreateChatMessage(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Validation failed",
+		},
+		{
+			name: "Message Too Long",
+			setupRequest: func(c echo.Context, body *bytes.Buffer) {
+				body.Reset()
+				err := json.NewEncoder(body).Encode(map[string]any{
+					"message":      strings.Repeat("a", 1001),
+					"character_id": uuid.New().String(),
+				})
+				require.NoError(t, err)
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 			},
 			expectedCode:  http.StatusBadRequest,
 			expectedError: "Validation failed",
@@ -196,11 +236,11 @@ func TestCreateChatMessage(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 			},
 			expectedCode:  http.StatusBadRequest,
 			expectedError: "Validation failed",
@@ -212,11 +252,11 @@ func TestCreateChatMessage(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetCharacter(gomock.Any(), characterID).
@@ -232,11 +272,11 @@ func TestCreateChatMessage(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetCharacter(gomock.Any(), characterID).
@@ -257,11 +297,11 @@ func TestCreateChatMessage(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, characterID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetCharacter(gomock.Any(), characterID).
@@ -329,7 +369,7 @@ func TestCreateChatMessage(t *testing.T) {
 			tc.setupMocks(store, listID, userID, characterID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err = h.CreateChatMessage(c)
 
 			// Check for expected error response
@@ -355,6 +395,69 @@ func TestCreateChatMessage(t *testing.T) {
 	}
 }
 
+// The longest message allowed has to fit into a Postgres NOTIFY payload, or the event bus
+// drops the event and no member ever sees the message
+func TestCreateChatMessage_LongestMessageFitsEventBus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	listID := uuid.New()
+	userID := uuid.New()
+	characterID := uuid.New()
+
+	// Every character of the message is escaped to \u003c
+	message := strings.Repeat("<", 1000)
+
+	store.EXPECT().
+		GetListMemberRole(gomock.Any(), gomock.Any()).
+		Return(db.ListRoleMember, nil)
+
+	store.EXPECT().
+		GetCharacter(gomock.Any(), characterID).
+		Return(db.Character{ID: characterID, UserID: userID, Name: "Abcdefghijklmnopqrstuvwxyzabc", World: "Antica"}, nil)
+
+	store.EXPECT().
+		CreateChatMessage(gomock.Any(), gomock.Any()).
+		Return(db.ListChatMessage{
+			ID:          uuid.New(),
+			ListID:      listID,
+			UserID:      userID,
+			CharacterID: characterID,
+			Message:     message,
+			CreatedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		}, nil)
+
+	var events []services.ListEvent
+	bus := services.NewMemoryEventBus()
+	bus.Subscribe(func(ctx context.Context, event services.ListEvent) {
+		events = append(events, event)
+	})
+
+	body, err := json.Marshal(map[string]any{"message": message, "character_id": characterID.String()})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/lists/"+listID.String()+"/chat", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e := echo.New()
+	e.Validator = validator.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/lists/:id/chat")
+	c.SetParamNames("id")
+	c.SetParamValues(listID.String())
+	c.Set("user_id", userID.String())
+
+	h := handlers.NewListsHandler(store, services.NewHub(bus))
+	require.NoError(t, h.CreateChatMessage(c))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	require.Len(t, events, 1)
+	data, err := json.Marshal(events[0])
+	require.NoError(t, err)
+	require.Less(t, len(data), 8000)
+}
+
 func TestGetChatMessages(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -371,11 +474,11 @@ func TestGetChatMessages(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetChatMessages(gomock.Any(), db.GetChatMessagesParams{
@@ -409,11 +512,11 @@ func TestGetChatMessages(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetChatMessages(gomock.Any(), db.GetChatMessagesParams{
@@ -435,11 +538,11 @@ func TestGetChatMessages(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetChatMessagesByTimestamp(gomock.Any(), gomock.Any()).
@@ -478,11 +581,11 @@ func TestGetChatMessages(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(false, nil)
+					Return(db.ListRole(""), sql.ErrNoRows)
 			},
 			expectedCode:  http.StatusUnauthorized,
 			expectedError: "User is not a member of this list",
@@ -494,11 +597,11 @@ func TestGetChatMessages(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 			},
 			expectedCode:  http.StatusBadRequest,
 			expectedError: "Invalid since timestamp",
@@ -510,11 +613,11 @@ func TestGetChatMessages(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetChatMessages(gomock.Any(), db.GetChatMessagesParams{
@@ -561,7 +664,7 @@ func TestGetChatMessages(t *testing.T) {
 			tc.setupMocks(store, listID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err := h.GetChatMessages(c)
 
 			// Check for expected error response
@@ -591,7 +694,7 @@ func TestDeleteChatMessage(t *testing.T) {
 	testCases := []struct {
 		name          string
 		setupRequest  func(c echo.Context)
-		setupMocks    func(store *mockdb.MockStore, messageID uuid.UUID, userID uuid.UUID)
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID)
 		expectedCode  int
 		expectedError string
 	}{
@@ -600,22 +703,134 @@ func TestDeleteChatMessage(t *testing.T) {
 			setupRequest: func(c echo.Context) {
 				// Default setup is fine
 			},
-			setupMocks: func(store *mockdb.MockStore, messageID uuid.UUID, userID uuid.UUID) {
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetChatMessage(gomock.Any(), db.GetChatMessageParams{
+						ID:     messageID,
+						ListID: listID,
+					}).
+					Return(db.ListChatMessage{ID: messageID, ListID: listID, UserID: userID, Message: "hello"}, nil)
+
 				store.EXPECT().
 					DeleteChatMessage(gomock.Any(), db.DeleteChatMessageParams{
 						ID:     messageID,
-						UserID: userID,
+						ListID: listID,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:       listID,
+						ActorID:      userID,
+						Action:       db.ListActivityActionChatMessageDeleted,
+						TargetUserID: userID,
+						OldValue:     pgtype.Text{String: "hello", Valid: true},
+					}).
+					Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "Success - Moderator Deletes Other Member's Message",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetChatMessage(gomock.Any(), gomock.Any()).
+					Return(db.ListChatMessage{ID: messageID, ListID: listID, UserID: uuid.New()}, nil)
+
+				store.EXPECT().
+					DeleteChatMessage(gomock.Any(), db.DeleteChatMessageParams{
+						ID:     messageID,
+						ListID: listID,
 					}).
 					Return(nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
 			},
 			expectedCode: http.StatusNoContent,
 		},
+		{
+			name: "Member Deletes Other Member's Message",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetChatMessage(gomock.Any(), gomock.Any()).
+					Return(db.ListChatMessage{ID: messageID, ListID: listID, UserID: uuid.New()}, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators or the author can delete this message",
+		},
+		{
+			name: "Message Not Found",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetChatMessage(gomock.Any(), db.GetChatMessageParams{
+						ID:     messageID,
+						ListID: listID,
+					}).
+					Return(db.ListChatMessage{}, sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Chat message not found",
+		},
+		{
+			name: "User Not List Member",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Invalid List ID",
+			setupRequest: func(c echo.Context) {
+				c.SetParamValues("invalid-uuid", c.Param("messageId"))
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid list ID",
+		},
 		{
 			name: "Invalid Message ID",
 			setupRequest: func(c echo.Context) {
-				c.SetParamValues("list-id", "invalid-uuid")
+				c.SetParamValues(c.Param("id"), "invalid-uuid")
 			},
-			setupMocks: func(store *mockdb.MockStore, messageID uuid.UUID, userID uuid.UUID) {
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
 				// No mocks needed for this case
 			},
 			expectedCode:  http.StatusBadRequest,
@@ -626,7 +841,7 @@ func TestDeleteChatMessage(t *testing.T) {
 			setupRequest: func(c echo.Context) {
 				c.Set("user_id", "invalid-uuid")
 			},
-			setupMocks: func(store *mockdb.MockStore, messageID uuid.UUID, userID uuid.UUID) {
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
 				// No mocks needed for this case
 			},
 			expectedCode:  http.StatusUnauthorized,
@@ -637,7 +852,7 @@ func TestDeleteChatMessage(t *testing.T) {
 			setupRequest: func(c echo.Context) {
 				c.Set("user_id", nil)
 			},
-			setupMocks: func(store *mockdb.MockStore, messageID uuid.UUID, userID uuid.UUID) {
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
 				// No mocks needed for this case
 			},
 			expectedCode:  http.StatusUnauthorized,
@@ -648,11 +863,19 @@ func TestDeleteChatMessage(t *testing.T) {
 			setupRequest: func(c echo.Context) {
 				// Default setup is fine
 			},
-			setupMocks: func(store *mockdb.MockStore, messageID uuid.UUID, userID uuid.UUID) {
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetChatMessage(gomock.Any(), gomock.Any()).
+					Return(db.ListChatMessage{ID: messageID, ListID: listID, UserID: userID}, nil)
+
 				store.EXPECT().
 					DeleteChatMessage(gomock.Any(), db.DeleteChatMessageParams{
 						ID:     messageID,
-						UserID: userID,
+						ListID: listID,
 					}).
 					Return(errors.New("database error"))
 			},
@@ -668,6 +891,7 @@ func TestDeleteChatMessage(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
 			listID := uuid.New()
 			messageID := uuid.New()
 			userID := uuid.New()
@@ -692,10 +916,10 @@ func TestDeleteChatMessage(t *testing.T) {
 			}
 
 			// Setup mock expectations
-			tc.setupMocks(store, messageID, userID)
+			tc.setupMocks(store, listID, messageID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err := h.DeleteChatMessage(c)
 
 			// Check for expected error response
@@ -830,7 +1054,7 @@ func TestGetChatNotifications(t *testing.T) {
 			tc.setupMocks(store, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err := h.GetChatNotifications(c)
 
 			// Check for expected error response
@@ -871,11 +1095,11 @@ func TestMarkChatMessagesAsRead(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					MarkListMessagesAsRead(gomock.Any(), db.MarkListMessagesAsReadParams{
@@ -904,27 +1128,27 @@ func TestMarkChatMessagesAsRead(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(false, nil)
+					Return(db.ListRole(""), sql.ErrNoRows)
 			},
 			expectedCode:  http.StatusUnauthorized,
 			expectedError: "User is not a member of this list",
 		},
 		{
-			name: "Database Error - IsUserListMember",
+			name: "Database Error - GetListMemberRole",
 			setupRequest: func(c echo.Context) {
 				// Default setup is fine
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(false, errors.New("database error"))
+					Return(db.ListRole(""), errors.New("database error"))
 			},
 			expectedCode:  http.StatusInternalServerError,
 			expectedError: "Failed to check list membership",
@@ -936,11 +1160,11 @@ func TestMarkChatMessagesAsRead(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					MarkListMessagesAsRead(gomock.Any(), db.MarkListMessagesAsReadParams{
@@ -987,7 +1211,7 @@ func TestMarkChatMessagesAsRead(t *testing.T) {
 			tc.setupMocks(store, listID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err := h.MarkChatMessagesAsRead(c)
 
 			// Check for expected error response
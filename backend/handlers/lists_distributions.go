@@ -0,0 +1,518 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// ListDistributionResponse is a stored distribution of obtained soulcores. Keeping the
+// seed lets anyone rerun the distribution and check it came out the same.
+type ListDistributionResponse struct {
+	ID          uuid.UUID                         `json:"id"`
+	Mode        db.DistributionMode               `json:"mode"`
+	Seed        int64                             `json:"seed"`
+	CreatedBy   uuid.UUID                         `json:"created_by"`
+	Reserved    bool                              `json:"reserved"`
+	Assignments []services.DistributionAssignment `json:"assignments"`
+	Unassigned  []services.DistributionCore       `json:"unassigned"`
+	CreatedAt   time.Time                         `json:"created_at"`
+}
+
+// ListDistributionsResponse is a page of the distributions of a list
+type ListDistributionsResponse struct {
+	Distributions []ListDistributionResponse `json:"distributions"`
+	Pagination    PaginationInfo             `json:"pagination"`
+}
+
+func newListDistributionResponse(d db.ListDistribution) (ListDistributionResponse, error) {
+	var result services.DistributionResult
+	if err := json.Unmarshal(d.Assignments, &result); err != nil {
+		return ListDistributionResponse{}, err
+	}
+	return ListDistributionResponse{
+		ID:          d.ID,
+		Mode:        d.Mode,
+		Seed:        d.Seed,
+		CreatedBy:   d.CreatedBy,
+		Reserved:    d.Reserved,
+		Assignments: result.Assignments,
+		Unassigned:  result.Unassigned,
+		CreatedAt:   d.CreatedAt.Time,
+	}, nil
+}
+
+// DistributeSoulcores decides which member receives each obtained soulcore of a list and
+// stores the result with its seed. Only members who still lack a creature are considered
+// for its core. With reserve set the obtained cores are reserved for their recipients.
+func (h *ListsHandler) DistributeSoulcores(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req struct {
+		Mode        db.DistributionMode `json:"mode"`
+		Seed        *int64              `json:"seed"`
+		CreatureIDs []uuid.UUID         `json:"creature_ids"`
+		Reserve     bool                `json:"reserve"`
+	}
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	if !services.ValidDistributionMode(req.Mode) {
+		return apperror.ValidationError("Invalid distribution mode", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "mode",
+				Value:  string(req.Mode),
+				Reason: "Mode must be one of: need, round_robin, fewest_cores, random",
+			})
+	}
+
+	// Without creature_ids every obtained core is handed out
+	if len(req.CreatureIDs) > 0 {
+		if err := validateBatchCreatureIDs("creature_ids", req.CreatureIDs); err != nil {
+			return err
+		}
+	}
+
+	seed := rand.Int63()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanDistributeSoulcores() {
+		return apperror.AuthorizationError("Only list moderators can distribute soulcores", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userID.String(),
+				Reason: "Not authorized to distribute soulcores",
+			})
+	}
+
+	// The cores are read in the transaction and only reserved while still obtained, so a
+	// core traded or unlocked meanwhile fails the distribution instead of being taken back
+	var distribution db.ListDistribution
+	var result services.DistributionResult
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		cores, err := distributionCores(ctx, q, listID, req.CreatureIDs)
+		if err != nil {
+			return err
+		}
+
+		members, err := distributionMembers(ctx, q, listID)
+		if err != nil {
+			return err
+		}
+
+		result = services.Distribute(req.Mode, seed, cores, members)
+
+		assignments, err := json.Marshal(result)
+		if err != nil {
+			return apperror.InternalError("Failed to encode distribution", err)
+		}
+
+		if req.Reserve {
+			for _, a := range result.Assignments {
+				reserved, err := q.ReserveObtainedSoulcore(ctx, db.ReserveObtainedSoulcoreParams{
+					ReservedForUserID: a.UserID,
+					ListID:            listID,
+					CreatureID:        a.CreatureID,
+				})
+				if err != nil {
+					return apperror.DatabaseError("Failed to reserve soulcore", err).
+						WithDetails(&apperror.DatabaseErrorDetails{
+							Operation: "ReserveObtainedSoulcore",
+							Table:     "lists_soulcores",
+						})
+				}
+				if reserved == 0 {
+					return apperror.ConflictError("Soulcore is no longer obtained", nil).
+						WithDetails(&apperror.ValidationErrorDetails{
+							Field:  "creature_ids",
+							Value:  a.CreatureID.String(),
+							Reason: "The soulcore's status changed during the distribution",
+						})
+				}
+
+				err = recordActivity(ctx, q, db.CreateListActivityParams{
+					ListID:       listID,
+					ActorID:      userID,
+					Action:       db.ListActivityActionSoulcoreStatusChanged,
+					CreatureID:   a.CreatureID,
+					TargetUserID: a.UserID,
+					OldValue:     activityValue(string(db.SoulcoreStatusObtained)),
+					NewValue:     activityValue(string(db.SoulcoreStatusReserved)),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		distribution, err = q.CreateListDistribution(ctx, db.CreateListDistributionParams{
+			ListID:      listID,
+			CreatedBy:   userID,
+			Mode:        req.Mode,
+			Seed:        seed,
+			Assignments: assignments,
+			Reserved:    req.Reserve,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to store distribution", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "CreateListDistribution",
+					Table:     "list_distributions",
+				})
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to distribute soulcores")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventSoulcoresDistributed, listID, map[string]any{
+		"distribution_id": distribution.ID,
+		"mode":            distribution.Mode,
+		"reserved":        distribution.Reserved,
+		"user_id":         userID,
+	})
+
+	return c.JSON(http.StatusCreated, ListDistributionResponse{
+		ID:          distribution.ID,
+		Mode:        distribution.Mode,
+		Seed:        distribution.Seed,
+		CreatedBy:   distribution.CreatedBy,
+		Reserved:    distribution.Reserved,
+		Assignments: result.Assignments,
+		Unassigned:  result.Unassigned,
+		CreatedAt:   distribution.CreatedAt.Time,
+	})
+}
+
+// distributionCores returns the obtained soulcores of a list named by creatureIDs, or all of
+// them when creatureIDs is empty
+func distributionCores(ctx context.Context, q db.Querier, listID uuid.UUID, creatureIDs []uuid.UUID) ([]services.DistributionCore, error) {
+	soulcores, err := q.GetListSoulcores(ctx, db.GetListSoulcoresParams{ListID: listID})
+	if err != nil {
+		return nil, apperror.DatabaseError("Failed to get list soulcores", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListSoulcores",
+				Table:     "lists_soulcores",
+			})
+	}
+
+	obtained := make(map[uuid.UUID]services.DistributionCore)
+	for _, sc := range soulcores {
+		if sc.Status == db.SoulcoreStatusObtained {
+			obtained[sc.CreatureID] = services.DistributionCore{
+				CreatureID:   sc.CreatureID,
+				CreatureName: sc.CreatureName,
+			}
+		}
+	}
+
+	var cores []services.DistributionCore
+	if len(creatureIDs) == 0 {
+		for _, core := range obtained {
+			cores = append(cores, core)
+		}
+	} else {
+		for _, creatureID := range creatureIDs {
+			core, ok := obtained[creatureID]
+			if !ok {
+				return nil, apperror.ValidationError("Only obtained soulcores can be distributed", nil).
+					WithDetails(&apperror.ValidationErrorDetails{
+						Field:  "creature_ids",
+						Value:  creatureID.String(),
+						Reason: "The list has no obtained soulcore for this creature",
+					})
+			}
+			cores = append(cores, core)
+		}
+	}
+
+	if len(cores) == 0 {
+		return nil, apperror.ValidationError("No obtained soulcores to distribute", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "creature_ids",
+				Reason: "The list has no obtained soulcores",
+			})
+	}
+	return cores, nil
+}
+
+// distributionMembers returns the active members of a list who take part in a distribution
+// along with the creatures they have unlocked
+func distributionMembers(ctx context.Context, q db.Querier, listID uuid.UUID) ([]services.DistributionMember, error) {
+	rows, err := q.GetListMembersWithUnlocks(ctx, listID)
+	if err != nil {
+		return nil, apperror.DatabaseError("Failed to get list members", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListMembersWithUnlocks",
+				Table:     "lists_users",
+			})
+	}
+
+	// Viewers only watch the list, they don't take part in sharing its cores
+	var members []services.DistributionMember
+	for _, row := range rows {
+		if !row.IsActive || row.Role == db.ListRoleViewer {
+			continue
+		}
+
+		var unlocked []struct {
+			CreatureID uuid.UUID `json:"creature_id"`
+		}
+		if err := json.Unmarshal(row.UnlockedCreatures, &unlocked); err != nil {
+			return nil, apperror.DatabaseError("Failed to read member unlocks", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetListMembersWithUnlocks",
+					Table:     "characters_soulcores",
+				})
+		}
+
+		member := services.DistributionMember{
+			UserID:        row.UserID,
+			CharacterID:   row.CharacterID,
+			CharacterName: row.CharacterName,
+			Unlocked:      make(map[uuid.UUID]bool, len(unlocked)),
+		}
+		for _, u := range unlocked {
+			member.Unlocked[u.CreatureID] = true
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// GetListDistributions returns the distributions of a list, newest first
+func (h *ListsHandler) GetListDistributions(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get page number from query parameters, default to 1
+	pageStr := c.QueryParam("page")
+	page := 1
+	if pageStr != "" {
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			return apperror.ValidationError("Invalid page number", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "page",
+					Value:  pageStr,
+					Reason: "Page must be a positive integer",
+				})
+		}
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Any member of the list can audit its distributions
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	const pageSize = 20
+	offset := (page - 1) * pageSize
+
+	rows, err := h.store.GetListDistributions(ctx, db.GetListDistributionsParams{
+		ListID: listID,
+		Limit:  int32(pageSize),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to get list distributions", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListDistributions",
+				Table:     "list_distributions",
+			})
+	}
+
+	var totalRecords int64
+	if len(rows) > 0 {
+		totalRecords = rows[0].TotalCount
+	}
+	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	distributions := make([]ListDistributionResponse, len(rows))
+	for i, r := range rows {
+		distributions[i], err = newListDistributionResponse(db.ListDistribution{
+			ID:          r.ID,
+			ListID:      r.ListID,
+			CreatedBy:   r.CreatedBy,
+			Mode:        r.Mode,
+			Seed:        r.Seed,
+			Assignments: r.Assignments,
+			Reserved:    r.Reserved,
+			CreatedAt:   r.CreatedAt,
+		})
+		if err != nil {
+			return apperror.InternalError("Failed to decode distribution", err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, ListDistributionsResponse{
+		Distributions: distributions,
+		Pagination: PaginationInfo{
+			TotalPages:   totalPages,
+			CurrentPage:  page,
+			TotalRecords: int(totalRecords),
+			PageSize:     pageSize,
+		},
+	})
+}
+
+// GetListDistribution returns a single distribution of a list
+func (h *ListsHandler) GetListDistribution(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	distributionID, err := uuid.Parse(c.Param("distribution_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid distribution ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "distribution_id",
+				Value:  c.Param("distribution_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	distribution, err := h.store.GetListDistribution(ctx, db.GetListDistributionParams{
+		ID:     distributionID,
+		ListID: listID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Distribution not found", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetListDistribution",
+					Table:     "list_distributions",
+				})
+		}
+		return apperror.DatabaseError("Failed to get distribution", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListDistribution",
+				Table:     "list_distributions",
+			})
+	}
+
+	response, err := newListDistributionResponse(distribution)
+	if err != nil {
+		return apperror.InternalError("Failed to decode distribution", err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
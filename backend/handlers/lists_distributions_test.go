@@ -0,0 +1,397 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDistributeSoulcores(t *testing.T) {
+	dragonID := uuid.New()
+	demonID := uuid.New()
+	knightID := uuid.New()
+	druidID := uuid.New()
+	viewerID := uuid.New()
+
+	soulcores := []db.GetListSoulcoresRow{
+		{CreatureID: dragonID, CreatureName: "Dragon", Status: db.SoulcoreStatusObtained},
+		{CreatureID: demonID, CreatureName: "Demon", Status: db.SoulcoreStatusUnlocked},
+	}
+	members := []db.GetListMembersWithUnlocksRow{
+		{
+			UserID:            knightID,
+			CharacterID:       uuid.New(),
+			CharacterName:     "Knight",
+			UnlockedCreatures: json.RawMessage(fmt.Sprintf(`[{"creature_id":"%s","creature_name":"Dragon"}]`, dragonID)),
+			IsActive:          true,
+			Role:              db.ListRoleOwner,
+		},
+		{
+			UserID:            druidID,
+			CharacterID:       uuid.New(),
+			CharacterName:     "Druid",
+			UnlockedCreatures: json.RawMessage(`[]`),
+			IsActive:          true,
+			Role:              db.ListRoleMember,
+		},
+		{
+			UserID:            viewerID,
+			CharacterID:       uuid.New(),
+			CharacterName:     "Watcher",
+			UnlockedCreatures: json.RawMessage(`[]`),
+			IsActive:          true,
+			Role:              db.ListRoleViewer,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		body          string
+		setupMocks    func(store *mockdb.MockStore, listID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		checkResponse func(t *testing.T, response handlers.ListDistributionResponse)
+	}{
+		{
+			name: "Success - Stores Seed",
+			body: `{"mode":"need","seed":42}`,
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(soulcores, nil)
+
+				store.EXPECT().
+					GetListMembersWithUnlocks(gomock.Any(), listID).
+					Return(members, nil)
+
+				store.EXPECT().
+					CreateListDistribution(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateListDistributionParams) (db.ListDistribution, error) {
+						require.Equal(t, db.DistributionModeNeed, arg.Mode)
+						require.Equal(t, int64(42), arg.Seed)
+						require.False(t, arg.Reserved)
+						return db.ListDistribution{
+							ID:          uuid.New(),
+							ListID:      listID,
+							CreatedBy:   userID,
+							Mode:        arg.Mode,
+							Seed:        arg.Seed,
+							Assignments: arg.Assignments,
+							Reserved:    arg.Reserved,
+						}, nil
+					})
+			},
+			expectedCode: http.StatusCreated,
+			checkResponse: func(t *testing.T, response handlers.ListDistributionResponse) {
+				require.Equal(t, int64(42), response.Seed)
+				require.Len(t, response.Assignments, 1)
+				require.Equal(t, dragonID, response.Assignments[0].CreatureID)
+				require.Equal(t, druidID, response.Assignments[0].UserID)
+				require.Empty(t, response.Unassigned)
+			},
+		},
+		{
+			name: "Success - Reserves Cores",
+			body: fmt.Sprintf(`{"mode":"round_robin","creature_ids":["%s"],"reserve":true}`, dragonID),
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(soulcores, nil)
+
+				store.EXPECT().
+					GetListMembersWithUnlocks(gomock.Any(), listID).
+					Return(members, nil)
+
+				store.EXPECT().
+					ReserveObtainedSoulcore(gomock.Any(), db.ReserveObtainedSoulcoreParams{
+						ReservedForUserID: druidID,
+						ListID:            listID,
+						CreatureID:        dragonID,
+					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:       listID,
+						ActorID:      userID,
+						Action:       db.ListActivityActionSoulcoreStatusChanged,
+						CreatureID:   dragonID,
+						TargetUserID: druidID,
+						OldValue:     pgtype.Text{String: "obtained", Valid: true},
+						NewValue:     pgtype.Text{String: "reserved", Valid: true},
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListDistribution(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateListDistributionParams) (db.ListDistribution, error) {
+						require.True(t, arg.Reserved)
+						return db.ListDistribution{ID: uuid.New(), Mode: arg.Mode, Seed: arg.Seed, Reserved: true}, nil
+					})
+			},
+			expectedCode: http.StatusCreated,
+			checkResponse: func(t *testing.T, response handlers.ListDistributionResponse) {
+				require.True(t, response.Reserved)
+				require.Len(t, response.Assignments, 1)
+			},
+		},
+		{
+			name: "Soulcore Status Changed",
+			body: fmt.Sprintf(`{"mode":"need","creature_ids":["%s"],"reserve":true}`, dragonID),
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(soulcores, nil)
+
+				store.EXPECT().
+					GetListMembersWithUnlocks(gomock.Any(), listID).
+					Return(members, nil)
+
+				// Traded after it was read, the core is not reserved
+				store.EXPECT().
+					ReserveObtainedSoulcore(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "Soulcore is no longer obtained",
+		},
+		{
+			name:          "Duplicate Creature",
+			body:          fmt.Sprintf(`{"mode":"need","creature_ids":["%s","%s"]}`, dragonID, dragonID),
+			setupMocks:    func(store *mockdb.MockStore, listID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Duplicate creature in batch",
+		},
+		{
+			name:          "Invalid Mode",
+			body:          `{"mode":"dice"}`,
+			setupMocks:    func(store *mockdb.MockStore, listID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid distribution mode",
+		},
+		{
+			name: "Member Cannot Distribute",
+			body: `{"mode":"random"}`,
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can distribute soulcores",
+		},
+		{
+			name: "Creature Not Obtained",
+			body: fmt.Sprintf(`{"mode":"need","creature_ids":["%s"]}`, demonID),
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(soulcores, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Only obtained soulcores can be distributed",
+		},
+		{
+			name: "Database Error - CreateListDistribution",
+			body: `{"mode":"fewest_cores"}`,
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(soulcores, nil)
+
+				store.EXPECT().
+					GetListMembersWithUnlocks(gomock.Any(), listID).
+					Return(members, nil)
+
+				store.EXPECT().
+					CreateListDistribution(gomock.Any(), gomock.Any()).
+					Return(db.ListDistribution{}, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to store distribution",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/distributions", listID)
+			req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/distributions")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.DistributeSoulcores(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, 0, tx.Committed)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response handlers.ListDistributionResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestGetListDistribution(t *testing.T) {
+	distributionID := uuid.New()
+	creatureID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+
+				store.EXPECT().
+					GetListDistribution(gomock.Any(), db.GetListDistributionParams{ID: distributionID, ListID: listID}).
+					Return(db.ListDistribution{
+						ID:          distributionID,
+						ListID:      listID,
+						Mode:        db.DistributionModeRandom,
+						Seed:        7,
+						Assignments: json.RawMessage(fmt.Sprintf(`{"assignments":[{"creature_id":"%s","creature_name":"Dragon"}],"unassigned":[]}`, creatureID)),
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Not Found",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListDistribution(gomock.Any(), gomock.Any()).
+					Return(db.ListDistribution{}, sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Distribution not found",
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/distributions/%s", listID, distributionID)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/distributions/:distribution_id")
+			c.SetParamNames("id", "distribution_id")
+			c.SetParamValues(listID.String(), distributionID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.GetListDistribution(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response handlers.ListDistributionResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, int64(7), response.Seed)
+			require.Len(t, response.Assignments, 1)
+			require.Equal(t, creatureID, response.Assignments[0].CreatureID)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// streamHeartbeatInterval keeps idle event streams open through proxies
+var streamHeartbeatInterval = 25 * time.Second
+
+// StreamListEvents pushes real-time list events to a member using server-sent events
+func (h *ListsHandler) StreamListEvents(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Check the user's role in the list
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	client, err := h.hub.Subscribe(listID, userID)
+	if err != nil {
+		return apperror.InternalError("Event stream is unavailable", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  listID.String(),
+				Reason: "Server is shutting down",
+			})
+	}
+	defer h.hub.Unsubscribe(client)
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-client.Events():
+			if !ok {
+				// Dropped by the hub, the client reconnects and catches up with ?since.
+				// Members who left or were removed are turned away when they reconnect.
+				return nil
+			}
+			if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, event.Payload); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// publishListEvent broadcasts an event to connected list members. Delivery is
+// best effort, so failures are logged and never fail the request.
+func publishListEvent(ctx context.Context, events services.EventPublisher, eventType string, listID uuid.UUID, payload any) {
+	event, err := services.NewListEvent(eventType, listID, payload)
+	if err == nil {
+		err = events.Publish(ctx, event)
+	}
+	if err != nil {
+		apperror.InternalError("Failed to publish list event", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "event_type",
+				Value:  eventType,
+				Reason: "Event could not be delivered",
+			}).
+			LogError()
+	}
+}
@@ -0,0 +1,184 @@
+package handlers_test
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStreamListEvents(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupRequest  func(c echo.Context)
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Invalid List ID",
+			setupRequest: func(c echo.Context) {
+				c.SetParamValues("invalid-uuid")
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid list ID",
+		},
+		{
+			name: "No User ID in Context",
+			setupRequest: func(c echo.Context) {
+				c.Set("user_id", nil)
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Invalid user authentication",
+		},
+		{
+			name: "User Not List Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Database Error - GetListMemberRole",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRole(""), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to check list membership",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/lists/"+listID.String()+"/events", nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/events")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			if tc.setupRequest != nil {
+				tc.setupRequest(c)
+			}
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.StreamListEvents(c)
+
+			require.Error(t, err)
+			var appErr *apperror.AppError
+			require.ErrorAs(t, err, &appErr)
+			require.Equal(t, tc.expectedCode, appErr.StatusCode)
+			require.Contains(t, appErr.Message, tc.expectedError)
+		})
+	}
+}
+
+func TestStreamListEventsDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	hub := services.NewHub(services.NewMemoryEventBus())
+	listID := uuid.New()
+	userID := uuid.New()
+
+	store.EXPECT().
+		GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+			ListID: listID,
+			UserID: userID,
+		}).
+		Return(db.ListRoleMember, nil)
+
+	h := handlers.NewListsHandler(store, hub)
+	e := echo.New()
+	e.GET("/api/lists/:id/events", h.StreamListEvents, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", userID.String())
+			return next(c)
+		}
+	})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/lists/"+listID.String()+"/events", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get(echo.HeaderContentType))
+
+	// Wait until the stream is registered before publishing
+	require.Eventually(t, func() bool {
+		return hub.ClientCount(listID) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	event, err := services.NewListEvent(services.EventChatMessageDeleted, listID, map[string]string{"id": "42"})
+	require.NoError(t, err)
+	require.NoError(t, hub.Publish(context.Background(), event))
+
+	reader := bufio.NewReader(resp.Body)
+	eventLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "event: "+services.EventChatMessageDeleted, strings.TrimSpace(eventLine))
+
+	dataLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, `data: {"id":"42"}`, strings.TrimSpace(dataLine))
+
+	// Shutting the hub down ends the stream
+	hub.Close()
+	require.Eventually(t, func() bool {
+		_, err := reader.ReadString('\n')
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+}
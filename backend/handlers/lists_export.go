@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+)
+
+// Formats lists can be exported to and imported from
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// SoulcoreRecord is a soulcore of a list as it is exported and imported. Creatures are
+// named rather than referenced by ID, added_by is ignored on import.
+type SoulcoreRecord struct {
+	Creature string            `json:"creature"`
+	Status   db.SoulcoreStatus `json:"status"`
+	AddedBy  string            `json:"added_by,omitempty"`
+}
+
+// soulcoreCSVHeader is the header row of soulcore CSV files
+var soulcoreCSVHeader = []string{"creature", "status", "added_by"}
+
+// MemberProgressRecord is the progress of a list member's character as it is exported and
+// imported. Unlocked names the creatures in the list's scope the character unlocked, only
+// the character name and unlocked are read on import.
+type MemberProgressRecord struct {
+	CharacterName          string      `json:"character_name"`
+	Role                   db.ListRole `json:"role"`
+	Active                 bool        `json:"active"`
+	ObtainedCount          int64       `json:"obtained_count"`
+	UnlockedCount          int64       `json:"unlocked_count"`
+	CharacterUnlockedCount int64       `json:"character_unlocked_count"`
+	Unlocked               []string    `json:"unlocked"`
+}
+
+// memberCSVHeader is the header row of member progress CSV files
+var memberCSVHeader = []string{"character_name", "role", "active", "obtained_count", "unlocked_count", "character_unlocked_count", "unlocked"}
+
+// unlockedSeparator separates the creatures in the unlocked column of member CSV files
+const unlockedSeparator = ";"
+
+// transferFormat reads the format query parameter, JSON unless asked otherwise
+func transferFormat(c echo.Context) (string, error) {
+	format := c.QueryParam("format")
+	switch format {
+	case "":
+		return FormatJSON, nil
+	case FormatJSON, FormatCSV:
+		return format, nil
+	}
+	return "", apperror.ValidationError("Invalid format", nil).
+		WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "format",
+			Value:  format,
+			Reason: "Format must be json or csv",
+		})
+}
+
+// writeCSV sends rows as a CSV attachment named filename
+func writeCSV(c echo.Context, filename string, rows [][]string) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	res.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(res)
+	if err := w.WriteAll(rows); err != nil {
+		return apperror.InternalError("Failed to write CSV", err)
+	}
+	return nil
+}
+
+// ExportSoulcores returns the soulcores of a list as JSON or CSV, chosen by the format
+// query parameter
+func (h *ListsHandler) ExportSoulcores(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	format, err := transferFormat(c)
+	if err != nil {
+		return err
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Any member of the list can export it
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	soulcores, err := h.store.GetListSoulcores(ctx, db.GetListSoulcoresParams{ListID: listID})
+	if err != nil {
+		return apperror.DatabaseError("Failed to get soul cores", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListSoulcores",
+				Table:     "lists_soulcores",
+			})
+	}
+
+	records := make([]SoulcoreRecord, len(soulcores))
+	for i, s := range soulcores {
+		records[i] = SoulcoreRecord{
+			Creature: s.CreatureName,
+			Status:   s.Status,
+			AddedBy:  s.AddedBy.String,
+		}
+	}
+
+	if format == FormatJSON {
+		return c.JSON(http.StatusOK, records)
+	}
+
+	rows := [][]string{soulcoreCSVHeader}
+	for _, r := range records {
+		rows = append(rows, []string{r.Creature, string(r.Status), r.AddedBy})
+	}
+	return writeCSV(c, "soulcores.csv", rows)
+}
+
+// ExportMemberProgress returns the progress of every member of a list as JSON or CSV,
+// chosen by the format query parameter. Counts and unlocked creatures only cover creatures
+// in the list's scope.
+func (h *ListsHandler) ExportMemberProgress(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	format, err := transferFormat(c)
+	if err != nil {
+		return err
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	members, err := h.store.GetListMembers(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get list members", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListMembers",
+				Table:     "lists_users",
+			})
+	}
+
+	unlocks, err := h.store.GetListMemberUnlocks(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get member unlocks", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListMemberUnlocks",
+				Table:     "characters_soulcores",
+			})
+	}
+	unlocked := make(map[uuid.UUID][]string)
+	for _, u := range unlocks {
+		if u.InScope {
+			unlocked[u.CharacterID] = append(unlocked[u.CharacterID], u.CreatureName)
+		}
+	}
+
+	records := make([]MemberProgressRecord, len(members))
+	for i, m := range members {
+		records[i] = MemberProgressRecord{
+			CharacterName:          m.CharacterName,
+			Role:                   m.Role,
+			Active:                 m.IsActive,
+			ObtainedCount:          m.ObtainedCount,
+			UnlockedCount:          m.UnlockedCount,
+			CharacterUnlockedCount: m.CharacterUnlockedCount,
+			Unlocked:               unlocked[m.CharacterID],
+		}
+		if records[i].Unlocked == nil {
+			records[i].Unlocked = []string{}
+		}
+	}
+
+	if format == FormatJSON {
+		return c.JSON(http.StatusOK, records)
+	}
+
+	rows := [][]string{memberCSVHeader}
+	for _, r := range records {
+		rows = append(rows, []string{
+			r.CharacterName,
+			string(r.Role),
+			strconv.FormatBool(r.Active),
+			strconv.FormatInt(r.ObtainedCount, 10),
+			strconv.FormatInt(r.UnlockedCount, 10),
+			strconv.FormatInt(r.CharacterUnlockedCount, 10),
+			strings.Join(r.Unlocked, unlockedSeparator),
+		})
+	}
+	return writeCSV(c, "members.csv", rows)
+}
@@ -0,0 +1,330 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportSoulcores(t *testing.T) {
+	soulcores := []db.GetListSoulcoresRow{
+		{
+			CreatureID:   uuid.New(),
+			CreatureName: "Demon",
+			Status:       db.SoulcoreStatusObtained,
+			AddedBy:      pgtype.Text{String: "Knight", Valid: true},
+		},
+		{
+			CreatureID:   uuid.New(),
+			CreatureName: "Dragon, Lord",
+			Status:       db.SoulcoreStatusUnlocked,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success - JSON",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(soulcores, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var records []handlers.SoulcoreRecord
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &records))
+				require.Equal(t, []handlers.SoulcoreRecord{
+					{Creature: "Demon", Status: db.SoulcoreStatusObtained, AddedBy: "Knight"},
+					{Creature: "Dragon, Lord", Status: db.SoulcoreStatusUnlocked},
+				}, records)
+			},
+		},
+		{
+			name:  "Success - CSV",
+			query: "?format=csv",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(soulcores, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+				require.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "soulcores.csv")
+				require.Equal(t, "creature,status,added_by\nDemon,obtained,Knight\n\"Dragon, Lord\",unlocked,\n", rec.Body.String())
+			},
+		},
+		{
+			name:          "Invalid Format",
+			query:         "?format=xml",
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid format",
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Database Error - GetListSoulcores",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get soul cores",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/export/soulcores%s", listID, tc.query)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/export/soulcores")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.ExportSoulcores(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			tc.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestExportMemberProgress(t *testing.T) {
+	knightID, druidID := uuid.New(), uuid.New()
+	members := []db.GetListMembersRow{
+		{
+			UserID:                 uuid.New(),
+			CharacterID:            knightID,
+			CharacterName:          "Knight",
+			Role:                   db.ListRoleOwner,
+			IsActive:               true,
+			ObtainedCount:          3,
+			UnlockedCount:          2,
+			CharacterUnlockedCount: 4,
+		},
+		{
+			UserID:        uuid.New(),
+			CharacterID:   druidID,
+			CharacterName: "Druid",
+			Role:          db.ListRoleMember,
+			ObtainedCount: 1,
+		},
+	}
+	// The hydra is outside the list's scope and left out
+	unlocks := []db.GetListMemberUnlocksRow{
+		{CharacterID: knightID, CreatureName: "Demon", InScope: true},
+		{CharacterID: knightID, CreatureName: "Dragon", InScope: true},
+		{CharacterID: knightID, CreatureName: "Hydra"},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success - JSON",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), listID).
+					Return(members, nil)
+
+				store.EXPECT().
+					GetListMemberUnlocks(gomock.Any(), listID).
+					Return(unlocks, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var records []handlers.MemberProgressRecord
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &records))
+				require.Len(t, records, 2)
+				require.Equal(t, "Knight", records[0].CharacterName)
+				require.Equal(t, int64(2), records[0].UnlockedCount)
+				require.False(t, records[1].Active)
+				require.Equal(t, []string{"Demon", "Dragon"}, records[0].Unlocked)
+				require.Empty(t, records[1].Unlocked)
+			},
+		},
+		{
+			name:  "Success - CSV",
+			query: "?format=csv",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), listID).
+					Return(members, nil)
+
+				store.EXPECT().
+					GetListMemberUnlocks(gomock.Any(), listID).
+					Return(unlocks, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "members.csv")
+				require.Equal(t, "character_name,role,active,obtained_count,unlocked_count,character_unlocked_count,unlocked\n"+
+					"Knight,owner,true,3,2,4,Demon;Dragon\nDruid,member,false,1,0,0,\n", rec.Body.String())
+			},
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Database Error - GetListMembers",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get list members",
+		},
+		{
+			name: "Database Error - GetListMemberUnlocks",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), gomock.Any()).
+					Return(members, nil)
+
+				store.EXPECT().
+					GetListMemberUnlocks(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get member unlocks",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/export/members%s", listID, tc.query)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/export/members")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.ExportMemberProgress(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			tc.checkResponse(t, rec)
+		})
+	}
+}
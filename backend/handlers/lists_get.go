@@ -4,9 +4,11 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
 )
 
@@ -38,6 +40,11 @@ func (h *ListsHandler) GetList(c echo.Context) error {
 		})
 	}
 
+	lang, err := requestLanguage(c)
+	if err != nil {
+		return err
+	}
+
 	ctx := c.Request().Context()
 
 	// Get list details
@@ -56,44 +63,46 @@ func (h *ListsHandler) GetList(c echo.Context) error {
 		})
 	}
 
-	// Get member stats
-	members, err := h.store.GetListMembers(ctx, listID)
+	// Check the user's role in the list
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	scope, err := h.listScope(ctx, listID)
 	if err != nil {
-		return apperror.DatabaseError("Failed to get list members", err).WithDetails(&apperror.DatabaseErrorDetails{
-			Operation: "GetListMembers",
-			Table:     "lists_users",
-		})
+		return err
 	}
 
-	isMember := false
-	for _, member := range members {
-		if member.UserID == userID {
-			isMember = true
-			break
-		}
+	progress, err := h.listProgress(ctx, listID, scope, time.Now())
+	if err != nil {
+		return err
 	}
 
-	if !isMember {
-		return apperror.AuthorizationError("User is not a member of this list", nil).WithDetails(&apperror.ValidationErrorDetails{
-			Field:  "user_id",
-			Value:  userID.String(),
-			Reason: "User is not a member of the list",
+	// Get member stats, counted against the scope of the list
+	members, err := h.store.GetListMembers(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get list members", err).WithDetails(&apperror.DatabaseErrorDetails{
+			Operation: "GetListMembers",
+			Table:     "lists_users",
 		})
 	}
 
 	memberStats := make([]MemberStats, len(members))
 	for i, m := range members {
 		memberStats[i] = MemberStats{
-			UserID:        m.UserID,
-			CharacterName: m.CharacterName,
-			ObtainedCount: m.ObtainedCount,
-			UnlockedCount: m.UnlockedCount,
-			IsActive:      m.IsActive,
+			UserID:                 m.UserID,
+			CharacterID:            m.CharacterID,
+			CharacterName:          m.CharacterName,
+			ObtainedCount:          m.ObtainedCount,
+			UnlockedCount:          m.UnlockedCount,
+			IsActive:               m.IsActive,
+			Role:                   m.Role,
+			CharacterUnlockedCount: m.CharacterUnlockedCount,
 		}
 	}
 
 	// Get soul cores
-	soulCores, err := h.store.GetListSoulcores(ctx, listID)
+	soulCores, err := h.store.GetListSoulcores(ctx, db.GetListSoulcoresParams{Lang: lang, ListID: listID})
 	if err != nil {
 		return apperror.DatabaseError("Failed to get soul cores", err).WithDetails(&apperror.DatabaseErrorDetails{
 			Operation: "GetListSoulcores",
@@ -102,24 +111,31 @@ func (h *ListsHandler) GetList(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusOK, ListDetailResponse{
-		ID:        list.ID,
-		AuthorID:  list.AuthorID,
-		Name:      list.Name,
-		ShareCode: list.ShareCode,
-		World:     list.World,
-		CreatedAt: list.CreatedAt.Time,
-		UpdatedAt: list.UpdatedAt.Time,
-		Members:   memberStats,
-		SoulCores: soulCores,
+		ID:               list.ID,
+		AuthorID:         list.AuthorID,
+		Name:             list.Name,
+		ShareCode:        list.ShareCode,
+		ShareCodeEnabled: list.ShareCodeEnabled,
+		ApprovalRequired: list.ApprovalRequired,
+		Visibility:       list.Visibility,
+		World:            list.World,
+		CreatedAt:        list.CreatedAt.Time,
+		UpdatedAt:        list.UpdatedAt.Time,
+		Scope:            scope,
+		Progress:         progress,
+		Members:          memberStats,
+		SoulCores:        soulCores,
 	})
 }
 
 // ListPreviewResponse represents the public preview of a list
 type ListPreviewResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	World       string    `json:"world"`
-	MemberCount int       `json:"member_count"`
+	ID               uuid.UUID `json:"id"`
+	Name             string    `json:"name"`
+	World            string    `json:"world"`
+	MemberCount      int       `json:"member_count"`
+	ApprovalRequired bool      `json:"approval_required"`
+	Pending          bool      `json:"pending,omitempty"`
 }
 
 // GetListPreview returns basic information about a list by its share code
@@ -135,9 +151,16 @@ func (h *ListsHandler) GetListPreview(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Get the list by share code
-	list, err := h.store.GetListByShareCode(ctx, shareCode)
+	// Get the list by its share code or an invite code
+	list, _, err := h.listByJoinCode(ctx, shareCode)
 	if err != nil {
+		if isInviteError(err) {
+			return apperror.ValidationError("Invite is no longer valid", err).WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "share_code",
+				Value:  shareCode.String(),
+				Reason: err.Error(),
+			})
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			return apperror.NotFoundError("List not found", err).WithDetails(&apperror.ValidationErrorDetails{
 				Field:  "share_code",
@@ -161,9 +184,10 @@ func (h *ListsHandler) GetListPreview(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusOK, ListPreviewResponse{
-		ID:          list.ID,
-		Name:        list.Name,
-		World:       list.World,
-		MemberCount: len(members),
+		ID:               list.ID,
+		Name:             list.Name,
+		World:            list.World,
+		MemberCount:      len(members),
+		ApprovalRequired: list.ApprovalRequired,
 	})
 }
@@ -8,13 +8,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 	mockdb "github.com/sergot/tibiacores/backend/db/mock"
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/handlers"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -40,6 +43,23 @@ func TestGetList(t *testing.T) {
 					GetList(gomock.Any(), list.ID).
 					Return(list, nil)
 
+				// Check the user's role in the list
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: list.ID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				// The list tracks every creature
+				store.EXPECT().
+					GetListScope(gomock.Any(), list.ID).
+					Return(db.ListScope{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListProgress(gomock.Any(), list.ID).
+					Return(db.GetListProgressRow{ScopeSize: 200, ObtainedCount: 20, UnlockedCount: 10}, nil)
+
 				// Get list members - includes current user
 				store.EXPECT().
 					GetListMembers(gomock.Any(), list.ID).
@@ -50,7 +70,7 @@ func TestGetList(t *testing.T) {
 
 				// Get list soulcores
 				store.EXPECT().
-					GetListSoulcores(gomock.Any(), list.ID).
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{Lang: "en", ListID: list.ID}).
 					Return([]db.GetListSoulcoresRow{
 						{
 							CreatureID: uuid.New(),
@@ -65,6 +85,59 @@ func TestGetList(t *testing.T) {
 				require.Equal(t, list.World, response.World)
 				require.Equal(t, 2, len(response.Members))
 				require.Equal(t, 1, len(response.SoulCores))
+				require.Nil(t, response.Scope)
+				require.Equal(t, 5.0, response.Progress.UnlockedPercent)
+			},
+		},
+		{
+			name: "Success - Scoped Progress",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, list db.List, userID uuid.UUID) {
+				store.EXPECT().
+					GetList(gomock.Any(), list.ID).
+					Return(list, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+
+				store.EXPECT().
+					GetListScope(gomock.Any(), list.ID).
+					Return(db.ListScope{
+						ListID:        list.ID,
+						MaxDifficulty: pgtype.Int4{Int32: 2, Valid: true},
+						TargetDate:    pgtype.Date{Time: time.Now().AddDate(0, 0, 10), Valid: true},
+					}, nil)
+
+				store.EXPECT().
+					GetListScopeCreatures(gomock.Any(), list.ID).
+					Return([]db.GetListScopeCreaturesRow{{CreatureID: uuid.New(), Excluded: true}}, nil)
+
+				store.EXPECT().
+					GetListProgress(gomock.Any(), list.ID).
+					Return(db.GetListProgressRow{ScopeSize: 3, ObtainedCount: 2, UnlockedCount: 1}, nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), list.ID).
+					Return([]db.GetListMembersRow{{UserID: userID, CharacterName: "TestCharacter"}}, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{Lang: "en", ListID: list.ID}).
+					Return([]db.GetListSoulcoresRow{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response *handlers.ListDetailResponse, list db.List) {
+				require.NotNil(t, response.Scope)
+				require.Nil(t, response.Scope.MinDifficulty)
+				require.Equal(t, int32(2), *response.Scope.MaxDifficulty)
+				require.Empty(t, response.Scope.CreatureIDs)
+				require.Len(t, response.Scope.ExcludedCreatureIDs, 1)
+				require.Equal(t, int64(3), response.Progress.ScopeSize)
+				require.Equal(t, 66.7, response.Progress.ObtainedPercent)
+				require.Equal(t, 33.3, response.Progress.UnlockedPercent)
+				require.NotNil(t, response.Progress.DaysLeft)
 			},
 		},
 		{
@@ -89,13 +162,13 @@ func TestGetList(t *testing.T) {
 					GetList(gomock.Any(), list.ID).
 					Return(list, nil)
 
-				// Get list members - doesn't include current user
+				// User has no active membership
 				store.EXPECT().
-					GetListMembers(gomock.Any(), list.ID).
-					Return([]db.GetListMembersRow{
-						{UserID: uuid.New(), CharacterName: "OtherCharacter"},
-						{UserID: list.AuthorID, CharacterName: "AuthorCharacter"},
-					}, nil)
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: list.ID,
+						UserID: userID,
+					}).
+					Return(db.ListRole(""), sql.ErrNoRows)
 			},
 			expectedCode:  http.StatusUnauthorized,
 			expectedError: "User is not a member of this list",
@@ -125,6 +198,23 @@ func TestGetList(t *testing.T) {
 					GetList(gomock.Any(), list.ID).
 					Return(list, nil)
 
+				// Check the user's role in the list
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: list.ID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				// The list tracks every creature
+				store.EXPECT().
+					GetListScope(gomock.Any(), list.ID).
+					Return(db.ListScope{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListProgress(gomock.Any(), list.ID).
+					Return(db.GetListProgressRow{ScopeSize: 200, ObtainedCount: 20, UnlockedCount: 10}, nil)
+
 				// Error getting list members
 				store.EXPECT().
 					GetListMembers(gomock.Any(), list.ID).
@@ -144,6 +234,23 @@ func TestGetList(t *testing.T) {
 					GetList(gomock.Any(), list.ID).
 					Return(list, nil)
 
+				// Check the user's role in the list
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: list.ID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				// The list tracks every creature
+				store.EXPECT().
+					GetListScope(gomock.Any(), list.ID).
+					Return(db.ListScope{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListProgress(gomock.Any(), list.ID).
+					Return(db.GetListProgressRow{ScopeSize: 200, ObtainedCount: 20, UnlockedCount: 10}, nil)
+
 				// Get list members - includes current user
 				store.EXPECT().
 					GetListMembers(gomock.Any(), list.ID).
@@ -153,7 +260,7 @@ func TestGetList(t *testing.T) {
 
 				// Error getting soulcores
 				store.EXPECT().
-					GetListSoulcores(gomock.Any(), list.ID).
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{Lang: "en", ListID: list.ID}).
 					Return(nil, errors.New("database error"))
 			},
 			expectedCode:  http.StatusInternalServerError,
@@ -170,6 +277,23 @@ func TestGetList(t *testing.T) {
 					GetList(gomock.Any(), list.ID).
 					Return(list, nil)
 
+				// Check the user's role in the list
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: list.ID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				// The list tracks every creature
+				store.EXPECT().
+					GetListScope(gomock.Any(), list.ID).
+					Return(db.ListScope{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListProgress(gomock.Any(), list.ID).
+					Return(db.GetListProgressRow{ScopeSize: 200, ObtainedCount: 20, UnlockedCount: 10}, nil)
+
 				// Only the current user is a member
 				store.EXPECT().
 					GetListMembers(gomock.Any(), list.ID).
@@ -179,7 +303,7 @@ func TestGetList(t *testing.T) {
 
 				// Empty soulcores list
 				store.EXPECT().
-					GetListSoulcores(gomock.Any(), list.ID).
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{Lang: "en", ListID: list.ID}).
 					Return([]db.GetListSoulcoresRow{}, nil)
 			},
 			expectedCode: http.StatusOK,
@@ -229,7 +353,7 @@ func TestGetList(t *testing.T) {
 			tc.setupMocks(store, list, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err := h.GetList(c)
 
 			// Check for expected error response
@@ -311,10 +435,97 @@ func TestGetListPreview(t *testing.T) {
 				store.EXPECT().
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListByRedirectCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListInviteByCode(gomock.Any(), shareCode).
+					Return(db.ListInvite{}, sql.ErrNoRows)
 			},
 			expectedCode:  http.StatusNotFound,
 			expectedError: "List not found",
 		},
+		{
+			name: "Success - Invite Code",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, shareCode uuid.UUID, list db.List) {
+				store.EXPECT().
+					GetListByShareCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListByRedirectCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListInviteByCode(gomock.Any(), shareCode).
+					Return(db.ListInvite{
+						ID:        uuid.New(),
+						ListID:    list.ID,
+						Code:      shareCode,
+						ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+					}, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), list.ID).
+					Return(list, nil)
+
+				store.EXPECT().
+					GetMembers(gomock.Any(), list.ID).
+					Return([]db.ListsUser{{ListID: list.ID}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Success - Merged List Share Code",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, shareCode uuid.UUID, list db.List) {
+				store.EXPECT().
+					GetListByShareCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListByRedirectCode(gomock.Any(), shareCode).
+					Return(list, nil)
+
+				store.EXPECT().
+					GetMembers(gomock.Any(), list.ID).
+					Return([]db.ListsUser{{ListID: list.ID}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Expired Invite",
+			setupRequest: func(c echo.Context) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, shareCode uuid.UUID, list db.List) {
+				store.EXPECT().
+					GetListByShareCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListByRedirectCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListInviteByCode(gomock.Any(), shareCode).
+					Return(db.ListInvite{
+						ID:        uuid.New(),
+						ListID:    list.ID,
+						Code:      shareCode,
+						ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+					}, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invite is no longer valid",
+		},
 		{
 			name: "Error Getting Members",
 			setupRequest: func(c echo.Context) {
@@ -392,7 +603,7 @@ func TestGetListPreview(t *testing.T) {
 			tc.setupMocks(store, shareCode, list)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err := h.GetListPreview(c)
 
 			// Check for expected error response
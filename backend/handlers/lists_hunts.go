@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// HuntRecommendationsResponse lists the creatures a list should hunt next, best first
+type HuntRecommendationsResponse struct {
+	Recommendations []services.HuntTarget `json:"recommendations"`
+}
+
+// GetHuntRecommendations ranks the creatures the active members of a list still need by
+// how many of them need the core, weighted by difficulty. The optional max_difficulty,
+// exclude_reserved and limit query parameters narrow the ranking down.
+func (h *ListsHandler) GetHuntRecommendations(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var filter services.HuntFilter
+	if maxDifficultyStr := c.QueryParam("max_difficulty"); maxDifficultyStr != "" {
+		maxDifficulty, err := strconv.Atoi(maxDifficultyStr)
+		if err != nil || maxDifficulty < 0 || maxDifficulty > services.MaxCreatureDifficulty {
+			return apperror.ValidationError("Invalid max difficulty", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "max_difficulty",
+					Value:  maxDifficultyStr,
+					Reason: "Max difficulty must be between 0 and 5",
+				})
+		}
+		d := int32(maxDifficulty)
+		filter.MaxDifficulty = &d
+	}
+
+	if excludeReservedStr := c.QueryParam("exclude_reserved"); excludeReservedStr != "" {
+		filter.ExcludeReserved, err = strconv.ParseBool(excludeReservedStr)
+		if err != nil {
+			return apperror.ValidationError("Invalid exclude reserved flag", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "exclude_reserved",
+					Value:  excludeReservedStr,
+					Reason: "Must be true or false",
+				})
+		}
+	}
+
+	limit := 20
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			return apperror.ValidationError("Invalid limit", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "limit",
+					Value:  limitStr,
+					Reason: "Limit must be between 1 and 100",
+				})
+		}
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Any member of the list can see what to hunt next
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	rows, err := h.store.GetListHuntCandidates(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get hunt candidates", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListHuntCandidates",
+				Table:     "characters_soulcores",
+			})
+	}
+
+	targets := make([]services.HuntTarget, 0, len(rows))
+	for _, row := range rows {
+		target := services.HuntTarget{
+			CreatureID:   row.CreatureID,
+			CreatureName: row.CreatureName,
+		}
+		if row.Difficulty.Valid {
+			d := row.Difficulty.Int32
+			target.Difficulty = &d
+		}
+		if row.ListStatus.Valid {
+			status := row.ListStatus.SoulcoreStatus
+			target.ListStatus = &status
+		}
+		if err := json.Unmarshal(row.NeededBy, &target.NeededBy); err != nil {
+			return apperror.DatabaseError("Failed to read members needing a soulcore", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetListHuntCandidates",
+					Table:     "characters_soulcores",
+				})
+		}
+		targets = append(targets, target)
+	}
+
+	ranked := services.RankHuntTargets(targets, filter)
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return c.JSON(http.StatusOK, HuntRecommendationsResponse{
+		Recommendations: ranked,
+	})
+}
@@ -0,0 +1,187 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetHuntRecommendations(t *testing.T) {
+	knightID := uuid.New()
+	druidID := uuid.New()
+	neededBy := func(ids ...uuid.UUID) json.RawMessage {
+		members := make([]services.HuntMember, len(ids))
+		for i, id := range ids {
+			members[i] = services.HuntMember{UserID: id, CharacterID: uuid.New(), CharacterName: id.String()[:8]}
+		}
+		raw, _ := json.Marshal(members)
+		return raw
+	}
+	candidates := []db.GetListHuntCandidatesRow{
+		{
+			CreatureID:   uuid.New(),
+			CreatureName: "Demon",
+			Difficulty:   pgtype.Int4{Int32: 4, Valid: true},
+			NeededBy:     neededBy(knightID, druidID),
+		},
+		{
+			CreatureID:   uuid.New(),
+			CreatureName: "Dragon",
+			Difficulty:   pgtype.Int4{Int32: 2, Valid: true},
+			ListStatus:   db.NullSoulcoreStatus{SoulcoreStatus: db.SoulcoreStatusReserved, Valid: true},
+			NeededBy:     neededBy(knightID, druidID),
+		},
+		{
+			CreatureID:   uuid.New(),
+			CreatureName: "Rotworm",
+			Difficulty:   pgtype.Int4{Int32: 1, Valid: true},
+			NeededBy:     neededBy(druidID),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		checkResponse func(t *testing.T, response handlers.HuntRecommendationsResponse)
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+
+				store.EXPECT().
+					GetListHuntCandidates(gomock.Any(), listID).
+					Return(candidates, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response handlers.HuntRecommendationsResponse) {
+				require.Len(t, response.Recommendations, 3)
+				require.Equal(t, "Rotworm", response.Recommendations[0].CreatureName)
+				require.Equal(t, "Demon", response.Recommendations[1].CreatureName)
+				require.Equal(t, 2, response.Recommendations[1].NeedCount)
+				require.Len(t, response.Recommendations[1].NeededBy, 2)
+				require.Equal(t, "Dragon", response.Recommendations[2].CreatureName)
+				require.Equal(t, db.SoulcoreStatusReserved, *response.Recommendations[2].ListStatus)
+			},
+		},
+		{
+			name:  "Success - Filtered",
+			query: "?max_difficulty=3&exclude_reserved=true&limit=1",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListHuntCandidates(gomock.Any(), listID).
+					Return(candidates, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response handlers.HuntRecommendationsResponse) {
+				require.Len(t, response.Recommendations, 1)
+				require.Equal(t, "Rotworm", response.Recommendations[0].CreatureName)
+			},
+		},
+		{
+			name:          "Invalid Max Difficulty",
+			query:         "?max_difficulty=9",
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid max difficulty",
+		},
+		{
+			name:          "Invalid Exclude Reserved",
+			query:         "?exclude_reserved=maybe",
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid exclude reserved flag",
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Database Error - GetListHuntCandidates",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListHuntCandidates(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get hunt candidates",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/hunts%s", listID, tc.query)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/hunts")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.GetHuntRecommendations(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response handlers.HuntRecommendationsResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			tc.checkResponse(t, response)
+		})
+	}
+}
@@ -0,0 +1,768 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// Conflict policies for soulcores an import names that the list already has
+const (
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+)
+
+// ImportRowResult is the outcome for one row of an import. Rows are numbered from 1,
+// not counting the CSV header. Error explains rows that could not be imported. Member
+// progress imports give one result per unlocked creature of a row.
+type ImportRowResult struct {
+	Row           int         `json:"row"`
+	CharacterName string      `json:"character_name,omitempty"`
+	Creature      string      `json:"creature"`
+	CreatureID    *uuid.UUID  `json:"creature_id,omitempty"`
+	Result        BatchResult `json:"result"`
+	Error         string      `json:"error,omitempty"`
+}
+
+// ImportResponse lists the outcome of every row of an import in file order. Nothing is
+// written for a dry run, the results tell what the import would have done.
+type ImportResponse struct {
+	DryRun  bool                `json:"dry_run"`
+	Results []ImportRowResult   `json:"results"`
+	Counts  map[BatchResult]int `json:"counts"`
+}
+
+// readSoulcoreRecords reads the soulcores of an import in the given format. CSV files
+// need a header row naming the creature and status columns, other columns are ignored.
+func readSoulcoreRecords(body io.Reader, format string) ([]SoulcoreRecord, error) {
+	if format == FormatJSON {
+		var records []SoulcoreRecord
+		if err := json.NewDecoder(body).Decode(&records); err != nil {
+			return nil, apperror.ValidationError("Invalid request body", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "body",
+					Reason: "Invalid JSON format",
+				})
+		}
+		return records, nil
+	}
+
+	rows, err := readImportCSV(body, "creature", "status")
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]SoulcoreRecord, len(rows))
+	for i, row := range rows {
+		records[i] = SoulcoreRecord{
+			Creature: row["creature"],
+			Status:   db.SoulcoreStatus(row["status"]),
+		}
+	}
+	return records, nil
+}
+
+// readMemberRecords reads the member progress of an import in the given format. CSV files
+// need a header row naming the character_name and unlocked columns, other columns are
+// ignored. Unlocked creatures are separated by semicolons.
+func readMemberRecords(body io.Reader, format string) ([]MemberProgressRecord, error) {
+	if format == FormatJSON {
+		var records []MemberProgressRecord
+		if err := json.NewDecoder(body).Decode(&records); err != nil {
+			return nil, apperror.ValidationError("Invalid request body", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "body",
+					Reason: "Invalid JSON format",
+				})
+		}
+		return records, nil
+	}
+
+	rows, err := readImportCSV(body, "character_name", "unlocked")
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]MemberProgressRecord, len(rows))
+	for i, row := range rows {
+		records[i] = MemberProgressRecord{CharacterName: row["character_name"]}
+		for _, name := range strings.Split(row["unlocked"], unlockedSeparator) {
+			if name = strings.TrimSpace(name); name != "" {
+				records[i].Unlocked = append(records[i].Unlocked, name)
+			}
+		}
+	}
+	return records, nil
+}
+
+// readImportCSV reads the rows of a CSV import below its header row, each keyed by the
+// lower case column names. The header has to name every required column.
+func readImportCSV(body io.Reader, required ...string) ([]map[string]string, error) {
+	r := csv.NewReader(body)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid CSV format",
+			})
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, apperror.ValidationError("Invalid CSV header", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "body",
+					Value:  strings.Join(rows[0], ","),
+					Reason: "Header must name the " + strings.Join(required, " and ") + " columns",
+				})
+		}
+	}
+
+	records := make([]map[string]string, len(rows)-1)
+	for i, row := range rows[1:] {
+		records[i] = make(map[string]string, len(columns))
+		for name, col := range columns {
+			if col < len(row) {
+				records[i][name] = strings.TrimSpace(row[col])
+			}
+		}
+	}
+	return records, nil
+}
+
+// importDryRun reads the dry_run query parameter, false unless asked otherwise
+func importDryRun(c echo.Context) (bool, error) {
+	dryRunStr := c.QueryParam("dry_run")
+	if dryRunStr == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(dryRunStr)
+	if err != nil {
+		return false, apperror.ValidationError("Invalid dry run flag", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "dry_run",
+				Value:  dryRunStr,
+				Reason: "Must be true or false",
+			})
+	}
+	return dryRun, nil
+}
+
+// checkImportRecord reports why a record cannot be imported, or "" when it is well-formed
+func checkImportRecord(r SoulcoreRecord) string {
+	switch {
+	case strings.TrimSpace(r.Creature) == "":
+		return "Creature is required"
+	case !services.ValidSoulcoreStatus(r.Status):
+		return fmt.Sprintf("Unknown status %q", r.Status)
+	case services.SoulcoreStatusNeedsReservation(r.Status):
+		return "Reserved soulcores cannot be imported, import them as obtained and reserve them afterwards"
+	}
+	return ""
+}
+
+// ImportSoulcores adds the soulcores of a JSON or CSV file to a list in one transaction.
+// Creatures are matched by name or alias, ignoring case. Soulcores the list already has are skipped
+// unless on_conflict=overwrite, in which case their status is changed like a regular update
+// would. With dry_run=true nothing is written. Every row gets a result, malformed rows and
+// unknown creatures are reported and left out without failing the whole import.
+func (h *ListsHandler) ImportSoulcores(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	format, err := transferFormat(c)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := importDryRun(c)
+	if err != nil {
+		return err
+	}
+
+	onConflict := c.QueryParam("on_conflict")
+	switch onConflict {
+	case "":
+		onConflict = ConflictSkip
+	case ConflictSkip, ConflictOverwrite:
+	default:
+		return apperror.ValidationError("Invalid conflict policy", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "on_conflict",
+				Value:  onConflict,
+				Reason: "Conflict policy must be skip or overwrite",
+			})
+	}
+
+	records, err := readSoulcoreRecords(c.Request().Body, format)
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return apperror.ValidationError("Import is empty", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "At least one soulcore is required",
+			})
+	}
+
+	if len(records) > maxBatchSize {
+		return apperror.ValidationError("Import is too large", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Value:  fmt.Sprint(len(records)),
+				Reason: fmt.Sprintf("At most %d soulcores are allowed", maxBatchSize),
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanContribute() {
+		return apperror.AuthorizationError("Viewers cannot import soulcores", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Role does not allow adding soulcores",
+			})
+	}
+
+	// Check every row up front, only well-formed rows are looked up
+	checked := make([]ImportRowResult, len(records))
+	seen := make(map[string]int, len(records))
+	var names []string
+	for i, r := range records {
+		checked[i] = ImportRowResult{Row: i + 1, Creature: r.Creature}
+		if reason := checkImportRecord(r); reason != "" {
+			checked[i].Result = BatchResultInvalid
+			checked[i].Error = reason
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimSpace(r.Creature))
+		if first, ok := seen[name]; ok {
+			checked[i].Result = BatchResultInvalid
+			checked[i].Error = fmt.Sprintf("Creature already imported in row %d", first)
+			continue
+		}
+		seen[name] = i + 1
+		names = append(names, name)
+	}
+
+	var results []ImportRowResult
+	var updated []db.GetListSoulcoresRow
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		results = append([]ImportRowResult(nil), checked...)
+		updated = nil
+
+		creatures := make(map[string]db.GetCreaturesByNamesRow)
+		if len(names) > 0 {
+			rows, err := q.GetCreaturesByNames(ctx, names)
+			if err != nil {
+				return apperror.DatabaseError("Failed to get creatures", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "GetCreaturesByNames",
+						Table:     "creatures",
+					})
+			}
+			for _, cr := range rows {
+				creatures[cr.LookupName] = cr
+			}
+		}
+
+		soulcores, err := q.GetListSoulcores(ctx, db.GetListSoulcoresParams{ListID: listID})
+		if err != nil {
+			return apperror.DatabaseError("Failed to get soul cores", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetListSoulcores",
+					Table:     "lists_soulcores",
+				})
+		}
+		existing := make(map[uuid.UUID]db.GetListSoulcoresRow, len(soulcores))
+		for _, s := range soulcores {
+			existing[s.CreatureID] = s
+		}
+
+		// Rows naming a creature by its current name and by an alias resolve to the same creature
+		imported := make(map[uuid.UUID]int)
+
+		for i, r := range records {
+			if results[i].Result == BatchResultInvalid {
+				continue
+			}
+
+			creature, ok := creatures[strings.ToLower(strings.TrimSpace(r.Creature))]
+			if !ok {
+				results[i].Result = BatchResultNotFound
+				results[i].Error = "Unknown creature"
+				continue
+			}
+			results[i].Creature = creature.Name
+			results[i].CreatureID = &creature.ID
+
+			if first, ok := imported[creature.ID]; ok {
+				results[i].Result = BatchResultInvalid
+				results[i].Error = fmt.Sprintf("Creature already imported in row %d", first)
+				continue
+			}
+			imported[creature.ID] = i + 1
+
+			soulcore, exists := existing[creature.ID]
+			switch {
+			case !exists:
+				results[i].Result = BatchResultAdded
+			case soulcore.Status == r.Status:
+				results[i].Result = BatchResultUnchanged
+				continue
+			case onConflict == ConflictSkip:
+				results[i].Result = BatchResultAlreadyPresent
+				continue
+			case !membership.CanModifySoulcore(soulcore.AddedByUserID):
+				results[i].Result = BatchResultForbidden
+				continue
+			case !membership.CanChangeSoulcoreStatus(soulcore.Status, r.Status):
+				results[i].Result = BatchResultInvalidTransition
+				results[i].Error = "A soulcore cannot go from " + string(soulcore.Status) + " to " + string(r.Status)
+				continue
+			default:
+				results[i].Result = BatchResultUpdated
+			}
+
+			if dryRun {
+				continue
+			}
+
+			if !exists {
+				err := q.AddSoulcoreToList(ctx, db.AddSoulcoreToListParams{
+					ListID:        listID,
+					CreatureID:    creature.ID,
+					Status:        r.Status,
+					AddedByUserID: userID,
+				})
+				if err != nil {
+					return apperror.DatabaseError("Failed to add soul core", err).
+						WithDetails(&apperror.DatabaseErrorDetails{
+							Operation: "AddSoulcoreToList",
+							Table:     "list_soulcores",
+						})
+				}
+
+				err = recordActivity(ctx, q, db.CreateListActivityParams{
+					ListID:     listID,
+					ActorID:    userID,
+					Action:     db.ListActivityActionSoulcoreAdded,
+					CreatureID: creature.ID,
+					NewValue:   activityValue(string(r.Status)),
+				})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			changed, err := q.UpdateSoulcoreStatus(ctx, db.UpdateSoulcoreStatusParams{
+				ListID:     listID,
+				CreatureID: creature.ID,
+				Status:     r.Status,
+				FromStatus: soulcore.Status,
+			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to update soul core status", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "UpdateSoulcoreStatus",
+						Table:     "list_soulcores",
+					})
+			}
+			if changed == 0 {
+				return soulcoreStatusConflict(creature.ID, soulcore.Status)
+			}
+
+			err = recordActivity(ctx, q, db.CreateListActivityParams{
+				ListID:     listID,
+				ActorID:    userID,
+				Action:     db.ListActivityActionSoulcoreStatusChanged,
+				CreatureID: creature.ID,
+				OldValue:   activityValue(string(soulcore.Status)),
+				NewValue:   activityValue(string(r.Status)),
+			})
+			if err != nil {
+				return err
+			}
+
+			soulcore.Status = r.Status
+			updated = append(updated, soulcore)
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to import soul cores")
+	}
+
+	for _, soulcore := range updated {
+		publishListEvent(ctx, h.hub, services.EventSoulcoreStatusChanged, listID, map[string]any{
+			"creature_id":   soulcore.CreatureID,
+			"creature_name": soulcore.CreatureName,
+			"status":        soulcore.Status,
+			"user_id":       userID,
+		})
+
+		if soulcore.Status == db.SoulcoreStatusUnlocked {
+			h.shareUnlock(ctx, listID, soulcore.CreatureID, soulcore.AddedByUserID)
+		}
+	}
+
+	counts := make(map[BatchResult]int)
+	for _, r := range results {
+		counts[r.Result]++
+	}
+
+	return c.JSON(http.StatusOK, ImportResponse{
+		DryRun:  dryRun,
+		Results: results,
+		Counts:  counts,
+	})
+}
+
+// ImportMemberProgress imports the unlocked soulcores of a member progress export into a
+// list in one transaction. Characters are matched by name among the list's active members,
+// creatures by name or alias, both ignoring case. Unlocks of the importing user's own
+// characters are added right away; other members get them as suggestions to accept, since
+// their characters are not the importing user's to change. Unlocks only ever get added,
+// so unlike soulcore imports there is nothing to overwrite and no conflict policy. With
+// dry_run=true nothing is written. Every unlocked creature of a row gets a result, a row
+// that cannot be imported at all gets a single one.
+func (h *ListsHandler) ImportMemberProgress(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	format, err := transferFormat(c)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := importDryRun(c)
+	if err != nil {
+		return err
+	}
+
+	records, err := readMemberRecords(c.Request().Body, format)
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return apperror.ValidationError("Import is empty", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "At least one member is required",
+			})
+	}
+
+	var unlockCount int
+	for _, r := range records {
+		unlockCount += len(r.Unlocked)
+	}
+	if len(records) > maxBatchSize || unlockCount > maxBatchSize {
+		return apperror.ValidationError("Import is too large", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Value:  fmt.Sprint(unlockCount),
+				Reason: fmt.Sprintf("At most %d members and %d unlocked soulcores are allowed", maxBatchSize, maxBatchSize),
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanContribute() {
+		return apperror.AuthorizationError("Viewers cannot import member progress", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Role does not allow adding soulcores",
+			})
+	}
+
+	// Check every row up front, only creatures of well-formed rows are looked up
+	invalid := make(map[int]string)
+	seen := make(map[string]int, len(records))
+	var names []string
+	lookedUp := make(map[string]bool)
+	for i, r := range records {
+		character := strings.ToLower(strings.TrimSpace(r.CharacterName))
+		if character == "" {
+			invalid[i] = "Character name is required"
+			continue
+		}
+		if first, ok := seen[character]; ok {
+			invalid[i] = fmt.Sprintf("Character already imported in row %d", first)
+			continue
+		}
+		seen[character] = i + 1
+
+		for _, creature := range r.Unlocked {
+			name := strings.ToLower(strings.TrimSpace(creature))
+			if name != "" && !lookedUp[name] {
+				lookedUp[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	var results []ImportRowResult
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		results = nil
+
+		creatures := make(map[string]db.GetCreaturesByNamesRow)
+		if len(names) > 0 {
+			rows, err := q.GetCreaturesByNames(ctx, names)
+			if err != nil {
+				return apperror.DatabaseError("Failed to get creatures", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "GetCreaturesByNames",
+						Table:     "creatures",
+					})
+			}
+			for _, cr := range rows {
+				creatures[cr.LookupName] = cr
+			}
+		}
+
+		members, err := q.GetListMembers(ctx, listID)
+		if err != nil {
+			return apperror.DatabaseError("Failed to get list members", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetListMembers",
+					Table:     "lists_users",
+				})
+		}
+		characters := make(map[string]db.GetListMembersRow, len(members))
+		for _, m := range members {
+			if m.IsActive {
+				characters[strings.ToLower(m.CharacterName)] = m
+			}
+		}
+
+		unlocks, err := q.GetListMemberUnlocks(ctx, listID)
+		if err != nil {
+			return apperror.DatabaseError("Failed to get member unlocks", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetListMemberUnlocks",
+					Table:     "characters_soulcores",
+				})
+		}
+		unlocked := make(map[uuid.UUID]map[uuid.UUID]bool)
+		for _, u := range unlocks {
+			if unlocked[u.CharacterID] == nil {
+				unlocked[u.CharacterID] = make(map[uuid.UUID]bool)
+			}
+			unlocked[u.CharacterID][u.CreatureID] = true
+		}
+
+		for i, r := range records {
+			row := ImportRowResult{Row: i + 1, CharacterName: r.CharacterName}
+			if reason, ok := invalid[i]; ok {
+				row.Result = BatchResultInvalid
+				row.Error = reason
+				results = append(results, row)
+				continue
+			}
+
+			member, ok := characters[strings.ToLower(strings.TrimSpace(r.CharacterName))]
+			if !ok {
+				row.Result = BatchResultNotFound
+				row.Error = "Character is not an active member of the list"
+				results = append(results, row)
+				continue
+			}
+			row.CharacterName = member.CharacterName
+
+			if len(r.Unlocked) == 0 {
+				row.Result = BatchResultUnchanged
+				results = append(results, row)
+				continue
+			}
+
+			// Names of the same creature, current or former, only count once per character
+			imported := make(map[uuid.UUID]bool)
+
+			for _, name := range r.Unlocked {
+				result := row
+				result.Creature = name
+
+				if strings.TrimSpace(name) == "" {
+					result.Result = BatchResultInvalid
+					result.Error = "Creature is required"
+					results = append(results, result)
+					continue
+				}
+
+				creature, ok := creatures[strings.ToLower(strings.TrimSpace(name))]
+				if !ok {
+					result.Result = BatchResultNotFound
+					result.Error = "Unknown creature"
+					results = append(results, result)
+					continue
+				}
+				result.Creature = creature.Name
+				result.CreatureID = &creature.ID
+
+				switch {
+				case imported[creature.ID]:
+					result.Result = BatchResultInvalid
+					result.Error = "Creature already imported for this character"
+				case unlocked[member.CharacterID][creature.ID]:
+					result.Result = BatchResultAlreadyPresent
+				case member.UserID == userID:
+					result.Result = BatchResultAdded
+				default:
+					result.Result = BatchResultSuggested
+				}
+				imported[creature.ID] = true
+
+				if !dryRun {
+					if err := importUnlock(ctx, q, listID, member.CharacterID, creature.ID, &result); err != nil {
+						return err
+					}
+				}
+				results = append(results, result)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to import member progress")
+	}
+
+	counts := make(map[BatchResult]int)
+	for _, r := range results {
+		counts[r.Result]++
+	}
+
+	return c.JSON(http.StatusOK, ImportResponse{
+		DryRun:  dryRun,
+		Results: results,
+		Counts:  counts,
+	})
+}
+
+// importUnlock writes the unlock a member progress import decided on. The importing user's
+// own characters get the soulcore, other characters a suggestion for it.
+func importUnlock(ctx context.Context, q db.Querier, listID, characterID, creatureID uuid.UUID, result *ImportRowResult) error {
+	switch result.Result {
+	case BatchResultAdded:
+		added, err := q.AddCharacterSoulcore(ctx, db.AddCharacterSoulcoreParams{
+			CharacterID: characterID,
+			CreatureID:  creatureID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to add soulcore to character", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "AddCharacterSoulcore",
+					Table:     "characters_soulcores",
+				})
+		}
+		if added == 0 {
+			result.Result = BatchResultAlreadyPresent
+		}
+	case BatchResultSuggested:
+		err := q.CreateSoulcoreSuggestion(ctx, db.CreateSoulcoreSuggestionParams{
+			CharacterID: characterID,
+			CreatureID:  creatureID,
+			ListID:      listID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to create soulcore suggestion", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "CreateSoulcoreSuggestion",
+					Table:     "character_soulcore_suggestions",
+				})
+		}
+	}
+	return nil
+}
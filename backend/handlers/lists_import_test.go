@@ -0,0 +1,663 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportSoulcores(t *testing.T) {
+	otherUserID := uuid.New()
+	creatures := map[string]db.GetCreaturesByNamesRow{
+		"demon":           {ID: uuid.New(), Name: "Demon"},
+		"dragon":          {ID: uuid.New(), Name: "Dragon"},
+		"rat":             {ID: uuid.New(), Name: "Rat"},
+		"hydra":           {ID: uuid.New(), Name: "Hydra"},
+		"rotworm":         {ID: uuid.New(), Name: "Rotworm"},
+		"monk (creature)": {ID: uuid.New(), Name: "Monk (Creature)"},
+	}
+	// Former names resolve to the renamed creature
+	aliases := map[string]string{
+		"monk": "monk (creature)",
+	}
+	lookup := func(names ...string) []db.GetCreaturesByNamesRow {
+		var rows []db.GetCreaturesByNamesRow
+		for _, name := range names {
+			key := name
+			if alias, ok := aliases[name]; ok {
+				key = alias
+			}
+			if cr, ok := creatures[key]; ok {
+				cr.LookupName = name
+				rows = append(rows, cr)
+			}
+		}
+		return rows
+	}
+	listSoulcores := func(userID uuid.UUID) []db.GetListSoulcoresRow {
+		return []db.GetListSoulcoresRow{
+			{CreatureID: creatures["demon"].ID, CreatureName: "Demon", Status: db.SoulcoreStatusObtained, AddedByUserID: userID},
+			{CreatureID: creatures["dragon"].ID, CreatureName: "Dragon", Status: db.SoulcoreStatusUnlocked, AddedByUserID: otherUserID},
+			{CreatureID: creatures["hydra"].ID, CreatureName: "Hydra", Status: db.SoulcoreStatusObtained, AddedByUserID: otherUserID},
+			{CreatureID: creatures["rotworm"].ID, CreatureName: "Rotworm", Status: db.SoulcoreStatusUnlocked, AddedByUserID: userID},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		query           string
+		body            string
+		setupMocks      func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode    int
+		expectedError   string
+		expectedResults []handlers.BatchResult
+	}{
+		{
+			name:  "Success - CSV",
+			query: "?format=csv",
+			body: "Status,Creature,Notes\n" +
+				"unlocked,Demon,\n" +
+				"unlocked,dragon,\n" +
+				"obtained,Rat,first one\n" +
+				"obtained,Unicorn,\n" +
+				"obtained,,\n" +
+				"lost,Cyclops,\n" +
+				"wanted,RAT,\n",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), []string{"demon", "dragon", "rat", "unicorn"}).
+					Return(lookup("demon", "dragon", "rat"), nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(listSoulcores(userID), nil)
+
+				store.EXPECT().
+					AddSoulcoreToList(gomock.Any(), db.AddSoulcoreToListParams{
+						ListID:        listID,
+						CreatureID:    creatures["rat"].ID,
+						Status:        db.SoulcoreStatusObtained,
+						AddedByUserID: userID,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:     listID,
+						ActorID:    userID,
+						Action:     db.ListActivityActionSoulcoreAdded,
+						CreatureID: creatures["rat"].ID,
+						NewValue:   pgtype.Text{String: string(db.SoulcoreStatusObtained), Valid: true},
+					}).
+					Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultAlreadyPresent,
+				handlers.BatchResultUnchanged,
+				handlers.BatchResultAdded,
+				handlers.BatchResultNotFound,
+				handlers.BatchResultInvalid,
+				handlers.BatchResultInvalid,
+				handlers.BatchResultInvalid,
+			},
+		},
+		{
+			name:  "Success - Overwrite",
+			query: "?on_conflict=overwrite",
+			body:  `[{"creature":"Demon","status":"unlocked"},{"creature":"Hydra","status":"wanted"},{"creature":"Rotworm","status":"wanted"}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), []string{"demon", "hydra", "rotworm"}).
+					Return(lookup("demon", "hydra", "rotworm"), nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(listSoulcores(userID), nil)
+
+				store.EXPECT().
+					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
+						ListID:     listID,
+						CreatureID: creatures["demon"].ID,
+						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
+					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:     listID,
+						ActorID:    userID,
+						Action:     db.ListActivityActionSoulcoreStatusChanged,
+						CreatureID: creatures["demon"].ID,
+						OldValue:   pgtype.Text{String: string(db.SoulcoreStatusObtained), Valid: true},
+						NewValue:   pgtype.Text{String: string(db.SoulcoreStatusUnlocked), Valid: true},
+					}).
+					Return(nil)
+
+				// The unlocked core is shared with the list members once the import committed
+				store.EXPECT().
+					GetListMembersWithUnlocks(gomock.Any(), listID).
+					Return(nil, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultUpdated,
+				handlers.BatchResultForbidden,
+				handlers.BatchResultInvalidTransition,
+			},
+		},
+		{
+			name:  "Success - Dry Run",
+			query: "?on_conflict=overwrite&dry_run=true",
+			body:  `[{"creature":"Demon","status":"unlocked"},{"creature":"Rat","status":"obtained"}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), []string{"demon", "rat"}).
+					Return(lookup("demon", "rat"), nil)
+
+				// Nothing is written on a dry run
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(listSoulcores(userID), nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultUpdated,
+				handlers.BatchResultAdded,
+			},
+		},
+		{
+			name: "Success - Alias",
+			body: `[{"creature":"Monk","status":"obtained"},{"creature":"Monk (Creature)","status":"wanted"}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), []string{"monk", "monk (creature)"}).
+					Return(lookup("monk", "monk (creature)"), nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return(listSoulcores(userID), nil)
+
+				// Both rows name the same creature, only the first one is imported
+				store.EXPECT().
+					AddSoulcoreToList(gomock.Any(), db.AddSoulcoreToListParams{
+						ListID:        listID,
+						CreatureID:    creatures["monk (creature)"].ID,
+						Status:        db.SoulcoreStatusObtained,
+						AddedByUserID: userID,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultAdded,
+				handlers.BatchResultInvalid,
+			},
+		},
+		{
+			name:          "Empty Import",
+			body:          `[]`,
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Import is empty",
+		},
+		{
+			name:          "Invalid CSV Header",
+			query:         "?format=csv",
+			body:          "name,state\nDemon,obtained\n",
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid CSV header",
+		},
+		{
+			name:          "Invalid Conflict Policy",
+			query:         "?on_conflict=merge",
+			body:          `[{"creature":"Demon","status":"obtained"}]`,
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid conflict policy",
+		},
+		{
+			name:          "Invalid Dry Run Flag",
+			query:         "?dry_run=maybe",
+			body:          `[{"creature":"Demon","status":"obtained"}]`,
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid dry run flag",
+		},
+		{
+			name: "Viewer Cannot Import",
+			body: `[{"creature":"Demon","status":"obtained"}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Viewers cannot import soulcores",
+		},
+		{
+			name: "Not a Member",
+			body: `[{"creature":"Demon","status":"obtained"}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Database Error - GetCreaturesByNames",
+			body: `[{"creature":"Demon","status":"obtained"}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get creatures",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/import/soulcores%s", listID, tc.query)
+			req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/import/soulcores")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.ImportSoulcores(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+
+			var response handlers.ImportResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, strings.Contains(tc.query, "dry_run=true"), response.DryRun)
+			require.Len(t, response.Results, len(tc.expectedResults))
+			for i, result := range tc.expectedResults {
+				require.Equal(t, i+1, response.Results[i].Row)
+				require.Equal(t, result, response.Results[i].Result, "row %d", i+1)
+			}
+		})
+	}
+}
+
+func TestImportMemberProgress(t *testing.T) {
+	otherUserID := uuid.New()
+	knightID, druidID, sorcererID := uuid.New(), uuid.New(), uuid.New()
+	creatures := map[string]db.GetCreaturesByNamesRow{
+		"demon":           {ID: uuid.New(), Name: "Demon"},
+		"dragon":          {ID: uuid.New(), Name: "Dragon"},
+		"monk (creature)": {ID: uuid.New(), Name: "Monk (Creature)"},
+	}
+	lookup := func(names ...string) []db.GetCreaturesByNamesRow {
+		var rows []db.GetCreaturesByNamesRow
+		for _, name := range names {
+			key := name
+			if name == "monk" {
+				key = "monk (creature)"
+			}
+			if cr, ok := creatures[key]; ok {
+				cr.LookupName = name
+				rows = append(rows, cr)
+			}
+		}
+		return rows
+	}
+	// The importing user plays the knight, the sorcerer left the list
+	listMembers := func(userID uuid.UUID) []db.GetListMembersRow {
+		return []db.GetListMembersRow{
+			{UserID: userID, CharacterID: knightID, CharacterName: "Knight", IsActive: true, Role: db.ListRoleOwner},
+			{UserID: otherUserID, CharacterID: druidID, CharacterName: "Druid", IsActive: true, Role: db.ListRoleMember},
+			{UserID: uuid.New(), CharacterID: sorcererID, CharacterName: "Sorcerer", Role: db.ListRoleMember},
+		}
+	}
+	unlocks := []db.GetListMemberUnlocksRow{
+		{CharacterID: knightID, CreatureID: creatures["demon"].ID, CreatureName: "Demon", InScope: true},
+	}
+
+	type rowResult struct {
+		row    int
+		result handlers.BatchResult
+	}
+
+	testCases := []struct {
+		name            string
+		query           string
+		body            string
+		setupMocks      func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode    int
+		expectedError   string
+		expectedResults []rowResult
+	}{
+		{
+			name:  "Success - CSV",
+			query: "?format=csv",
+			body: "character_name,role,unlocked\n" +
+				"Knight,owner,Demon;Dragon\n" +
+				"druid,member,Dragon; Unicorn;\n" +
+				"Sorcerer,member,Demon\n" +
+				"Paladin,member,\n" +
+				"KNIGHT,owner,Demon\n" +
+				",member,Dragon\n",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), []string{"demon", "dragon", "unicorn"}).
+					Return(lookup("demon", "dragon"), nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), listID).
+					Return(listMembers(userID), nil)
+
+				store.EXPECT().
+					GetListMemberUnlocks(gomock.Any(), listID).
+					Return(unlocks, nil)
+
+				// The importing user's own character gets the soulcore
+				store.EXPECT().
+					AddCharacterSoulcore(gomock.Any(), db.AddCharacterSoulcoreParams{
+						CharacterID: knightID,
+						CreatureID:  creatures["dragon"].ID,
+					}).
+					Return(int64(1), nil)
+
+				// Other members get a suggestion
+				store.EXPECT().
+					CreateSoulcoreSuggestion(gomock.Any(), db.CreateSoulcoreSuggestionParams{
+						CharacterID: druidID,
+						CreatureID:  creatures["dragon"].ID,
+						ListID:      listID,
+					}).
+					Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []rowResult{
+				{1, handlers.BatchResultAlreadyPresent},
+				{1, handlers.BatchResultAdded},
+				{2, handlers.BatchResultSuggested},
+				{2, handlers.BatchResultNotFound},
+				{3, handlers.BatchResultNotFound},
+				{4, handlers.BatchResultNotFound},
+				{5, handlers.BatchResultInvalid},
+				{6, handlers.BatchResultInvalid},
+			},
+		},
+		{
+			name:  "Success - Dry Run",
+			query: "?dry_run=true",
+			body:  `[{"character_name":"Knight","unlocked":["Dragon"]},{"character_name":"Druid","unlocked":["Dragon"]},{"character_name":"Druid ","unlocked":[]}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), []string{"dragon"}).
+					Return(lookup("dragon"), nil)
+
+				// Nothing is written on a dry run
+				store.EXPECT().
+					GetListMembers(gomock.Any(), listID).
+					Return(listMembers(userID), nil)
+
+				store.EXPECT().
+					GetListMemberUnlocks(gomock.Any(), listID).
+					Return(unlocks, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []rowResult{
+				{1, handlers.BatchResultAdded},
+				{2, handlers.BatchResultSuggested},
+				{3, handlers.BatchResultInvalid},
+			},
+		},
+		{
+			name: "Success - Alias",
+			body: `[{"character_name":"Knight","unlocked":["Monk","Monk (Creature)",""]},{"character_name":"Druid","unlocked":[]}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), []string{"monk", "monk (creature)"}).
+					Return(lookup("monk", "monk (creature)"), nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), listID).
+					Return(listMembers(userID), nil)
+
+				store.EXPECT().
+					GetListMemberUnlocks(gomock.Any(), listID).
+					Return(unlocks, nil)
+
+				// Both names are the same creature, it is only added once
+				store.EXPECT().
+					AddCharacterSoulcore(gomock.Any(), db.AddCharacterSoulcoreParams{
+						CharacterID: knightID,
+						CreatureID:  creatures["monk (creature)"].ID,
+					}).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []rowResult{
+				{1, handlers.BatchResultAdded},
+				{1, handlers.BatchResultInvalid},
+				{1, handlers.BatchResultInvalid},
+				{2, handlers.BatchResultUnchanged},
+			},
+		},
+		{
+			name:          "Empty Import",
+			body:          `[]`,
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Import is empty",
+		},
+		{
+			name:          "Import Too Large",
+			body:          `[{"character_name":"Knight","unlocked":["Demon"` + strings.Repeat(`,"Demon"`, 1000) + `]}]`,
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Import is too large",
+		},
+		{
+			name:          "Invalid CSV Header",
+			query:         "?format=csv",
+			body:          "character_name,obtained_count\nKnight,3\n",
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid CSV header",
+		},
+		{
+			name:          "Invalid Dry Run Flag",
+			query:         "?dry_run=maybe",
+			body:          `[{"character_name":"Knight","unlocked":["Demon"]}]`,
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid dry run flag",
+		},
+		{
+			name: "Viewer Cannot Import",
+			body: `[{"character_name":"Knight","unlocked":["Demon"]}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Viewers cannot import member progress",
+		},
+		{
+			name: "Database Error - GetListMembers",
+			body: `[{"character_name":"Knight","unlocked":["Demon"]}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), gomock.Any()).
+					Return(lookup("demon"), nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get list members",
+		},
+		{
+			name: "Database Error - CreateSoulcoreSuggestion",
+			body: `[{"character_name":"Druid","unlocked":["Dragon"]}]`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreaturesByNames(gomock.Any(), gomock.Any()).
+					Return(lookup("dragon"), nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), gomock.Any()).
+					Return(listMembers(userID), nil)
+
+				store.EXPECT().
+					GetListMemberUnlocks(gomock.Any(), gomock.Any()).
+					Return(unlocks, nil)
+
+				store.EXPECT().
+					CreateSoulcoreSuggestion(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to create soulcore suggestion",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/import/members%s", listID, tc.query)
+			req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/import/members")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.ImportMemberProgress(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, 0, tx.Committed)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+
+			var response handlers.ImportResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, strings.Contains(tc.query, "dry_run=true"), response.DryRun)
+			require.Len(t, response.Results, len(tc.expectedResults))
+			for i, expected := range tc.expectedResults {
+				require.Equal(t, expected.row, response.Results[i].Row, "result %d", i+1)
+				require.Equal(t, expected.result, response.Results[i].Result, "result %d", i+1)
+			}
+		})
+	}
+}
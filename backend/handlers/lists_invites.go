@@ -0,0 +1,439 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// listByJoinCode resolves a code from an invite link. The list's own share code is
+// tried first, then share codes of lists merged into it and finally invite codes,
+// which must still be usable. The invite is nil when a share code matched.
+func (h *ListsHandler) listByJoinCode(ctx context.Context, code uuid.UUID) (db.List, *db.ListInvite, error) {
+	list, err := h.store.GetListByShareCode(ctx, code)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return list, nil, err
+	}
+
+	list, err = h.store.GetListByRedirectCode(ctx, code)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return list, nil, err
+	}
+
+	invite, err := h.store.GetListInviteByCode(ctx, code)
+	if err != nil {
+		return db.List{}, nil, err
+	}
+
+	if err := services.ValidateInvite(invite, time.Now()); err != nil {
+		return db.List{}, nil, err
+	}
+
+	list, err = h.store.GetList(ctx, invite.ListID)
+	if err != nil {
+		return db.List{}, nil, err
+	}
+
+	return list, &invite, nil
+}
+
+// isInviteError reports whether err means the invite exists but can no longer be used
+func isInviteError(err error) bool {
+	return errors.Is(err, services.ErrInviteRevoked) ||
+		errors.Is(err, services.ErrInviteExpired) ||
+		errors.Is(err, services.ErrInviteExhausted)
+}
+
+// CreateInviteRequest represents the request body for creating an invite, both limits are optional
+type CreateInviteRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxUses   *int32     `json:"max_uses,omitempty"`
+}
+
+// CreateInvite creates a new invite code for a list
+func (h *ListsHandler) CreateInvite(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req CreateInviteRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	params := db.CreateListInviteParams{ListID: listID}
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			return apperror.ValidationError("Expiry must be in the future", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "expires_at",
+					Value:  req.ExpiresAt.String(),
+					Reason: "Expiry time has already passed",
+				})
+		}
+		params.ExpiresAt = pgtype.Timestamptz{Time: *req.ExpiresAt, Valid: true}
+	}
+	if req.MaxUses != nil {
+		if *req.MaxUses <= 0 {
+			return apperror.ValidationError("Maximum uses must be positive", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "max_uses",
+					Reason: "Maximum uses must be greater than zero",
+				})
+		}
+		params.MaxUses = pgtype.Int4{Int32: *req.MaxUses, Valid: true}
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	params.CreatedBy = userID
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanInvite() {
+		return apperror.AuthorizationError("Viewers cannot create invites", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to invite members",
+			})
+	}
+
+	invite, err := h.store.CreateListInvite(ctx, params)
+	if err != nil {
+		return apperror.DatabaseError("Failed to create invite", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "CreateListInvite",
+				Table:     "list_invites",
+			})
+	}
+
+	return c.JSON(http.StatusCreated, invite)
+}
+
+// GetInvites returns the invites of a list. Moderators see every invite,
+// other members only the ones they created.
+func (h *ListsHandler) GetInvites(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanInvite() {
+		return apperror.AuthorizationError("Viewers cannot see invites", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to invite members",
+			})
+	}
+
+	invites, err := h.store.GetListInvites(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get invites", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListInvites",
+				Table:     "list_invites",
+			})
+	}
+
+	if !membership.IsModerator() {
+		own := []db.ListInvite{}
+		for _, invite := range invites {
+			if invite.CreatedBy == userID {
+				own = append(own, invite)
+			}
+		}
+		invites = own
+	}
+
+	return c.JSON(http.StatusOK, invites)
+}
+
+// RevokeInvite stops an invite from being used to join the list
+func (h *ListsHandler) RevokeInvite(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	inviteID, err := uuid.Parse(c.Param("invite_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid invite ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "invite_id",
+				Value:  c.Param("invite_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	invite, err := h.store.GetListInvite(ctx, db.GetListInviteParams{
+		ID:     inviteID,
+		ListID: listID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Invite not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "invite_id",
+					Value:  inviteID.String(),
+					Reason: "Invite does not exist",
+				})
+		}
+		return apperror.DatabaseError("Failed to get invite", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListInvite",
+				Table:     "list_invites",
+			})
+	}
+
+	if !membership.CanRevokeInvite(invite.CreatedBy) {
+		return apperror.AuthorizationError("Only list moderators or the creator can revoke this invite", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "invite_id",
+				Value:  inviteID.String(),
+				Reason: "Not authorized to revoke this invite",
+			})
+	}
+
+	if _, err := h.store.RevokeListInvite(ctx, db.RevokeListInviteParams{
+		ID:     inviteID,
+		ListID: listID,
+	}); err != nil {
+		return apperror.DatabaseError("Failed to revoke invite", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "RevokeListInvite",
+				Table:     "list_invites",
+			})
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// RotateShareCode replaces the list's share code with a new one, which also
+// re-enables it. Links with the old code stop working.
+func (h *ListsHandler) RotateShareCode(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanManageShareCode() {
+		return apperror.AuthorizationError("Only the list owner can manage the share code", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to manage the share code",
+			})
+	}
+
+	list, err := h.store.RotateListShareCode(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to rotate share code", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "RotateListShareCode",
+				Table:     "lists",
+			})
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"share_code":         list.ShareCode,
+		"share_code_enabled": list.ShareCodeEnabled,
+	})
+}
+
+// DisableShareCode revokes the list's share code so only invites can be used to join
+func (h *ListsHandler) DisableShareCode(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanManageShareCode() {
+		return apperror.AuthorizationError("Only the list owner can manage the share code", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to manage the share code",
+			})
+	}
+
+	if _, err := h.store.DisableListShareCode(ctx, listID); err != nil {
+		return apperror.DatabaseError("Failed to disable share code", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "DisableListShareCode",
+				Table:     "lists",
+			})
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,446 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateInvite(t *testing.T) {
+	maxUses := func(n int32) *int32 { return &n }
+	expiresAt := func(d time.Duration) *time.Time {
+		t := time.Now().Add(d)
+		return &t
+	}
+
+	testCases := []struct {
+		name          string
+		body          handlers.CreateInviteRequest
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success - Unlimited",
+			body: handlers.CreateInviteRequest{},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					CreateListInvite(gomock.Any(), db.CreateListInviteParams{
+						ListID:    listID,
+						CreatedBy: userID,
+					}).
+					Return(db.ListInvite{ID: uuid.New(), ListID: listID, Code: uuid.New(), CreatedBy: userID}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Success - Limited",
+			body: handlers.CreateInviteRequest{ExpiresAt: expiresAt(time.Hour), MaxUses: maxUses(3)},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					CreateListInvite(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateListInviteParams) (db.ListInvite, error) {
+						require.True(t, arg.ExpiresAt.Valid)
+						require.Equal(t, pgtype.Int4{Int32: 3, Valid: true}, arg.MaxUses)
+						return db.ListInvite{ID: uuid.New(), ListID: listID, CreatedBy: userID, ExpiresAt: arg.ExpiresAt, MaxUses: arg.MaxUses}, nil
+					})
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Expiry In The Past",
+			body: handlers.CreateInviteRequest{ExpiresAt: expiresAt(-time.Hour)},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Expiry must be in the future",
+		},
+		{
+			name: "Non-positive Max Uses",
+			body: handlers.CreateInviteRequest{MaxUses: maxUses(0)},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Maximum uses must be positive",
+		},
+		{
+			name: "Viewer Cannot Invite",
+			body: handlers.CreateInviteRequest{},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Viewers cannot create invites",
+		},
+		{
+			name: "Database Error - CreateListInvite",
+			body: handlers.CreateInviteRequest{},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					CreateListInvite(gomock.Any(), gomock.Any()).
+					Return(db.ListInvite{}, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to create invite",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s/invites", listID)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/invites")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.CreateInvite(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response db.ListInvite
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, listID, response.ListID)
+			require.Equal(t, userID, response.CreatedBy)
+		})
+	}
+}
+
+func TestGetInvites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	listID := uuid.New()
+	userID := uuid.New()
+
+	// A plain member only sees the invites they created
+	store.EXPECT().
+		GetListMemberRole(gomock.Any(), gomock.Any()).
+		Return(db.ListRoleMember, nil)
+
+	store.EXPECT().
+		GetListInvites(gomock.Any(), listID).
+		Return([]db.ListInvite{
+			{ID: uuid.New(), ListID: listID, CreatedBy: userID},
+			{ID: uuid.New(), ListID: listID, CreatedBy: uuid.New()},
+		}, nil)
+
+	url := fmt.Sprintf("/api/lists/%s/invites", listID)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	rec := httptest.NewRecorder()
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	c.SetPath("/api/lists/:id/invites")
+	c.SetParamNames("id")
+	c.SetParamValues(listID.String())
+	c.Set("user_id", userID.String())
+
+	h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+	require.NoError(t, h.GetInvites(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response []db.ListInvite
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response, 1)
+	require.Equal(t, userID, response[0].CreatedBy)
+}
+
+func TestRevokeInvite(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID, inviteID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success - Creator",
+			setupMocks: func(store *mockdb.MockStore, listID, inviteID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListInvite(gomock.Any(), db.GetListInviteParams{ID: inviteID, ListID: listID}).
+					Return(db.ListInvite{ID: inviteID, ListID: listID, CreatedBy: userID}, nil)
+
+				store.EXPECT().
+					RevokeListInvite(gomock.Any(), db.RevokeListInviteParams{ID: inviteID, ListID: listID}).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Success - Moderator",
+			setupMocks: func(store *mockdb.MockStore, listID, inviteID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetListInvite(gomock.Any(), gomock.Any()).
+					Return(db.ListInvite{ID: inviteID, ListID: listID, CreatedBy: uuid.New()}, nil)
+
+				store.EXPECT().
+					RevokeListInvite(gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Member Cannot Revoke Others",
+			setupMocks: func(store *mockdb.MockStore, listID, inviteID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListInvite(gomock.Any(), gomock.Any()).
+					Return(db.ListInvite{ID: inviteID, ListID: listID, CreatedBy: uuid.New()}, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators or the creator can revoke this invite",
+		},
+		{
+			name: "Invite Not Found",
+			setupMocks: func(store *mockdb.MockStore, listID, inviteID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListInvite(gomock.Any(), gomock.Any()).
+					Return(db.ListInvite{}, sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Invite not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			inviteID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/invites/%s", listID, inviteID)
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/invites/:invite_id")
+			c.SetParamNames("id", "invite_id")
+			c.SetParamValues(listID.String(), inviteID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, inviteID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.RevokeInvite(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestRotateShareCode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, newCode uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, newCode uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					RotateListShareCode(gomock.Any(), listID).
+					Return(db.List{ID: listID, ShareCode: newCode, ShareCodeEnabled: true}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Moderator Cannot Rotate",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, newCode uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only the list owner can manage the share code",
+		},
+		{
+			name: "Database Error - RotateListShareCode",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, newCode uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					RotateListShareCode(gomock.Any(), listID).
+					Return(db.List{}, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to rotate share code",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			newCode := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/share-code/rotate", listID)
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/share-code/rotate")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", uuid.New().String())
+
+			tc.setupMocks(store, listID, newCode)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.RotateShareCode(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response struct {
+				ShareCode        uuid.UUID `json:"share_code"`
+				ShareCodeEnabled bool      `json:"share_code_enabled"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, newCode, response.ShareCode)
+			require.True(t, response.ShareCodeEnabled)
+		})
+	}
+}
+
+func TestDisableShareCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	listID := uuid.New()
+
+	store.EXPECT().
+		GetListMemberRole(gomock.Any(), gomock.Any()).
+		Return(db.ListRoleOwner, nil)
+
+	store.EXPECT().
+		DisableListShareCode(gomock.Any(), listID).
+		Return(int64(1), nil)
+
+	url := fmt.Sprintf("/api/lists/%s/share-code", listID)
+	req := httptest.NewRequest(http.MethodDelete, url, nil)
+	rec := httptest.NewRecorder()
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	c.SetPath("/api/lists/:id/share-code")
+	c.SetParamNames("id")
+	c.SetParamValues(listID.String())
+	c.Set("user_id", uuid.New().String())
+
+	h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+	require.NoError(t, h.DisableShareCode(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
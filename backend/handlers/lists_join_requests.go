@@ -0,0 +1,427 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// joinRequested finishes JoinList for lists requiring approval. Instead of a membership
+// the user got a pending request and only sees the list preview until it is approved.
+func (h *ListsHandler) joinRequested(c echo.Context, list db.List, userID uuid.UUID, character db.Character) error {
+	ctx := c.Request().Context()
+
+	members, err := h.store.GetMembers(ctx, list.ID)
+	if err != nil {
+		return apperror.DatabaseError("failed to get list members", err)
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberJoinRequested, list.ID, map[string]any{
+		"user_id":        userID,
+		"character_name": character.Name,
+	})
+
+	return c.JSON(http.StatusAccepted, ListPreviewResponse{
+		ID:               list.ID,
+		Name:             list.Name,
+		World:            list.World,
+		MemberCount:      len(members),
+		ApprovalRequired: list.ApprovalRequired,
+		Pending:          true,
+	})
+}
+
+// GetJoinRequests returns the pending requests to join a list
+func (h *ListsHandler) GetJoinRequests(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanReviewJoinRequests() {
+		return apperror.AuthorizationError("Only list moderators can review join requests", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to review join requests",
+			})
+	}
+
+	requests, err := h.store.GetListJoinRequests(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get join requests", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListJoinRequests",
+				Table:     "list_join_requests",
+			})
+	}
+
+	return c.JSON(http.StatusOK, requests)
+}
+
+// ApproveJoinRequest adds a pending requester to the list as a member
+func (h *ListsHandler) ApproveJoinRequest(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	requesterID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid user ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  c.Param("user_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanReviewJoinRequests() {
+		return apperror.AuthorizationError("Only list moderators can review join requests", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to review join requests",
+			})
+	}
+
+	var characterID uuid.UUID
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		characterID, err = q.ApproveListJoinRequest(ctx, db.ApproveListJoinRequestParams{
+			ListID: listID,
+			UserID: requesterID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.NotFoundError("Join request not found", err).
+					WithDetails(&apperror.ValidationErrorDetails{
+						Field:  "user_id",
+						Value:  requesterID.String(),
+						Reason: "No pending join request for this user",
+					})
+			}
+			return apperror.DatabaseError("Failed to approve join request", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "ApproveListJoinRequest",
+					Table:     "list_join_requests",
+				})
+		}
+
+		return recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:       listID,
+			ActorID:      userID,
+			Action:       db.ListActivityActionMemberJoined,
+			TargetUserID: requesterID,
+		})
+	})
+	if err != nil {
+		return txError(err, "Failed to approve join request")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberJoined, listID, map[string]any{
+		"user_id":      requesterID,
+		"character_id": characterID,
+		"approved_by":  userID,
+	})
+
+	return c.NoContent(http.StatusOK)
+}
+
+// RejectJoinRequest discards a pending request to join the list
+func (h *ListsHandler) RejectJoinRequest(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	requesterID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid user ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  c.Param("user_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanReviewJoinRequests() {
+		return apperror.AuthorizationError("Only list moderators can review join requests", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to review join requests",
+			})
+	}
+
+	affected, err := h.store.DeleteListJoinRequest(ctx, db.DeleteListJoinRequestParams{
+		ListID: listID,
+		UserID: requesterID,
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to reject join request", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "DeleteListJoinRequest",
+				Table:     "list_join_requests",
+			})
+	}
+
+	if affected == 0 {
+		return apperror.NotFoundError("Join request not found", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  requesterID.String(),
+				Reason: "No pending join request for this user",
+			})
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetOwnJoinRequest returns the list preview to a user whose request to join is still pending
+func (h *ListsHandler) GetOwnJoinRequest(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	if _, err := h.store.GetListJoinRequest(ctx, db.GetListJoinRequestParams{
+		ListID: listID,
+		UserID: userID,
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Join request not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "list_id",
+					Value:  listID.String(),
+					Reason: "No pending join request for this list",
+				})
+		}
+		return apperror.DatabaseError("Failed to get join request", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListJoinRequest",
+				Table:     "list_join_requests",
+			})
+	}
+
+	list, err := h.store.GetList(ctx, listID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("List not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "list_id",
+					Value:  listID.String(),
+					Reason: "List does not exist",
+				})
+		}
+		return apperror.DatabaseError("Failed to get list", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetList",
+				Table:     "lists",
+			})
+	}
+
+	members, err := h.store.GetMembers(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get list members", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetMembers",
+				Table:     "lists_users",
+			})
+	}
+
+	return c.JSON(http.StatusOK, ListPreviewResponse{
+		ID:               list.ID,
+		Name:             list.Name,
+		World:            list.World,
+		MemberCount:      len(members),
+		ApprovalRequired: list.ApprovalRequired,
+		Pending:          true,
+	})
+}
+
+// CancelJoinRequest withdraws the user's own pending request to join a list
+func (h *ListsHandler) CancelJoinRequest(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	affected, err := h.store.DeleteListJoinRequest(ctx, db.DeleteListJoinRequestParams{
+		ListID: listID,
+		UserID: userID,
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to cancel join request", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "DeleteListJoinRequest",
+				Table:     "list_join_requests",
+			})
+	}
+
+	if affected == 0 {
+		return apperror.NotFoundError("Join request not found", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  listID.String(),
+				Reason: "No pending join request for this list",
+			})
+	}
+
+	return c.NoContent(http.StatusOK)
+}
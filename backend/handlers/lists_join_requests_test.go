@@ -0,0 +1,546 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// TestJoinListApprovalRequired covers joining lists that need a moderator's approval
+func TestJoinListApprovalRequired(t *testing.T) {
+	testCases := []struct {
+		name          string
+		anonymous     bool
+		setupMocks    func(store *mockdb.MockStore, list db.List, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success - Pending Request",
+			setupMocks: func(store *mockdb.MockStore, list db.List, userID uuid.UUID) {
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					GetListJoinRequest(gomock.Any(), db.GetListJoinRequestParams{ListID: list.ID, UserID: userID}).
+					Return(db.ListJoinRequest{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetCharacterByName(gomock.Any(), "NewCharacter").
+					Return(db.Character{}, sql.ErrNoRows)
+
+				characterID := uuid.New()
+				store.EXPECT().
+					CreateCharacter(gomock.Any(), gomock.Any()).
+					Return(db.Character{ID: characterID, UserID: userID, Name: "NewCharacter", World: "Antica"}, nil)
+
+				// The request is stored instead of a membership
+				store.EXPECT().
+					CreateListJoinRequest(gomock.Any(), db.CreateListJoinRequestParams{
+						ListID:      list.ID,
+						UserID:      userID,
+						CharacterID: characterID,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					GetMembers(gomock.Any(), list.ID).
+					Return([]db.ListsUser{{ListID: list.ID}}, nil)
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:      "Success - Anonymous User",
+			anonymous: true,
+			setupMocks: func(store *mockdb.MockStore, list db.List, userID uuid.UUID) {
+				store.EXPECT().
+					CreateAnonymousUser(gomock.Any(), gomock.Any()).
+					Return(db.User{ID: userID, IsAnonymous: true}, nil)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					GetListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(db.ListJoinRequest{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetCharacterByName(gomock.Any(), "NewCharacter").
+					Return(db.Character{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateCharacter(gomock.Any(), gomock.Any()).
+					Return(db.Character{ID: uuid.New(), UserID: userID, Name: "NewCharacter", World: "Antica"}, nil)
+
+				store.EXPECT().
+					CreateListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					GetMembers(gomock.Any(), list.ID).
+					Return([]db.ListsUser{{ListID: list.ID}}, nil)
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name: "Request Already Pending",
+			setupMocks: func(store *mockdb.MockStore, list db.List, userID uuid.UUID) {
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					GetListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(db.ListJoinRequest{ListID: list.ID, UserID: userID}, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "join request is already pending",
+		},
+		{
+			name: "Database Error - CreateListJoinRequest",
+			setupMocks: func(store *mockdb.MockStore, list db.List, userID uuid.UUID) {
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					GetListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(db.ListJoinRequest{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetCharacterByName(gomock.Any(), "NewCharacter").
+					Return(db.Character{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateCharacter(gomock.Any(), gomock.Any()).
+					Return(db.Character{ID: uuid.New(), UserID: userID, Name: "NewCharacter", World: "Antica"}, nil)
+
+				store.EXPECT().
+					CreateListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "failed to create join request",
+		},
+		{
+			name:      "Anonymous User - Database Error",
+			anonymous: true,
+			setupMocks: func(store *mockdb.MockStore, list db.List, userID uuid.UUID) {
+				store.EXPECT().
+					CreateAnonymousUser(gomock.Any(), gomock.Any()).
+					Return(db.User{ID: userID, IsAnonymous: true}, nil)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					GetListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(db.ListJoinRequest{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetCharacterByName(gomock.Any(), "NewCharacter").
+					Return(db.Character{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateCharacter(gomock.Any(), gomock.Any()).
+					Return(db.Character{ID: uuid.New(), UserID: userID, Name: "NewCharacter", World: "Antica"}, nil)
+
+				store.EXPECT().
+					CreateListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "failed to create join request",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			userID := uuid.New()
+			list := db.List{
+				ID:               uuid.New(),
+				AuthorID:         uuid.New(),
+				Name:             "Closed List",
+				World:            "Antica",
+				ShareCode:        uuid.New(),
+				ShareCodeEnabled: true,
+				ApprovalRequired: true,
+			}
+
+			store.EXPECT().
+				GetListByShareCode(gomock.Any(), list.ShareCode).
+				Return(list, nil)
+
+			body, err := json.Marshal(map[string]any{
+				"character_name": "NewCharacter",
+				"world":          "Antica",
+			})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/join/%s", list.ShareCode)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/join/:share_code")
+			c.SetParamNames("share_code")
+			c.SetParamValues(list.ShareCode.String())
+			if !tc.anonymous {
+				c.Set("user_id", userID.String())
+			}
+
+			tc.setupMocks(store, list, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.JoinList(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Equal(t, tc.expectedError, appErr.Message)
+
+				// Nothing the failed join created is kept, so no token is handed out
+				require.Equal(t, 1, tx.RolledBack)
+				require.Empty(t, rec.Header().Get("X-Auth-Token"))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			if tc.anonymous {
+				require.NotEmpty(t, rec.Header().Get("X-Auth-Token"))
+			}
+
+			// Pending requesters only get the preview
+			var response handlers.ListPreviewResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, list.ID, response.ID)
+			require.True(t, response.Pending)
+			require.True(t, response.ApprovalRequired)
+			require.Equal(t, 1, response.MemberCount)
+		})
+	}
+}
+
+func TestApproveJoinRequest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID, requesterID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID, requesterID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					ApproveListJoinRequest(gomock.Any(), db.ApproveListJoinRequestParams{
+						ListID: listID,
+						UserID: requesterID,
+					}).
+					Return(uuid.New(), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, arg db.CreateListActivityParams) error {
+						require.Equal(t, db.ListActivityActionMemberJoined, arg.Action)
+						require.Equal(t, requesterID, arg.TargetUserID)
+						return nil
+					})
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Member Cannot Approve",
+			setupMocks: func(store *mockdb.MockStore, listID, requesterID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can review join requests",
+		},
+		{
+			name: "Request Not Found",
+			setupMocks: func(store *mockdb.MockStore, listID, requesterID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					ApproveListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(uuid.Nil, sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Join request not found",
+		},
+		{
+			name: "Database Error - ApproveListJoinRequest",
+			setupMocks: func(store *mockdb.MockStore, listID, requesterID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					ApproveListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(uuid.Nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to approve join request",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
+			listID := uuid.New()
+			requesterID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/join-requests/%s/approve", listID, requesterID)
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/join-requests/:user_id/approve")
+			c.SetParamNames("id", "user_id")
+			c.SetParamValues(listID.String(), requesterID.String())
+			c.Set("user_id", uuid.New().String())
+
+			tc.setupMocks(store, listID, requesterID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.ApproveJoinRequest(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestRejectJoinRequest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID, requesterID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID, requesterID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					DeleteListJoinRequest(gomock.Any(), db.DeleteListJoinRequestParams{
+						ListID: listID,
+						UserID: requesterID,
+					}).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Viewer Cannot Reject",
+			setupMocks: func(store *mockdb.MockStore, listID, requesterID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can review join requests",
+		},
+		{
+			name: "Request Not Found",
+			setupMocks: func(store *mockdb.MockStore, listID, requesterID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					DeleteListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Join request not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			requesterID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/join-requests/%s", listID, requesterID)
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/join-requests/:user_id")
+			c.SetParamNames("id", "user_id")
+			c.SetParamValues(listID.String(), requesterID.String())
+			c.Set("user_id", uuid.New().String())
+
+			tc.setupMocks(store, listID, requesterID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.RejectJoinRequest(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestGetOwnJoinRequest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListJoinRequest(gomock.Any(), db.GetListJoinRequestParams{ListID: listID, UserID: userID}).
+					Return(db.ListJoinRequest{ListID: listID, UserID: userID}, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Name: "Closed List", World: "Antica", ApprovalRequired: true}, nil)
+
+				store.EXPECT().
+					GetMembers(gomock.Any(), listID).
+					Return([]db.ListsUser{{ListID: listID}, {ListID: listID}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "No Pending Request",
+			setupMocks: func(store *mockdb.MockStore, listID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListJoinRequest(gomock.Any(), gomock.Any()).
+					Return(db.ListJoinRequest{}, sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Join request not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/join-request", listID)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/join-request")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.GetOwnJoinRequest(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response handlers.ListPreviewResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.True(t, response.Pending)
+			require.Equal(t, 2, response.MemberCount)
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// ListRestoreWindow is how long a deleted list can be restored before it is purged
+const ListRestoreWindow = 7 * 24 * time.Hour
+
+// UpdateListRequest represents the request body for editing a list, omitted fields are left unchanged
+type UpdateListRequest struct {
+	Name             *string            `json:"name,omitempty"`
+	World            *string            `json:"world,omitempty"`
+	ApprovalRequired *bool              `json:"approval_required,omitempty"`
+	Visibility       *db.ListVisibility `json:"visibility,omitempty"`
+}
+
+// UpdateList renames a list, moves it to another world or changes its join and visibility settings
+func (h *ListsHandler) UpdateList(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req UpdateListRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	if req.Name == nil && req.World == nil && req.ApprovalRequired == nil && req.Visibility == nil {
+		return apperror.ValidationError("Nothing to update", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Provide a name, a world, the approval setting or a visibility",
+			})
+	}
+
+	params := db.UpdateListParams{ID: listID}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return apperror.ValidationError("Name cannot be empty", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "name",
+					Reason: "Name field cannot be empty",
+				})
+		}
+		params.Name = pgtype.Text{String: name, Valid: true}
+	}
+	if req.World != nil {
+		world := strings.TrimSpace(*req.World)
+		if world == "" {
+			return apperror.ValidationError("World cannot be empty", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "world",
+					Reason: "World field cannot be empty",
+				})
+		}
+		params.World = pgtype.Text{String: world, Valid: true}
+	}
+	if req.ApprovalRequired != nil {
+		params.ApprovalRequired = pgtype.Bool{Bool: *req.ApprovalRequired, Valid: true}
+	}
+	if req.Visibility != nil {
+		if !services.ValidListVisibility(*req.Visibility) {
+			return apperror.ValidationError("Invalid visibility", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "visibility",
+					Value:  string(*req.Visibility),
+					Reason: "Visibility must be private, unlisted or public",
+				})
+		}
+		params.Visibility = db.NullListVisibility{ListVisibility: *req.Visibility, Valid: true}
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanEditList() {
+		return apperror.AuthorizationError("Only list moderators can edit the list", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to edit the list",
+			})
+	}
+
+	// Every member has to be able to follow the list to its new world
+	if params.World.Valid {
+		outside, err := h.store.CountListMembersOutsideWorld(ctx, db.CountListMembersOutsideWorldParams{
+			ListID: listID,
+			World:  params.World.String,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to check member worlds", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "CountListMembersOutsideWorld",
+					Table:     "lists_users",
+				})
+		}
+		if outside > 0 {
+			return apperror.ValidationError("Cannot change the world while members from other worlds are present", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "world",
+					Value:  params.World.String,
+					Reason: "Some members have characters on other worlds",
+				})
+		}
+	}
+
+	list, err := h.store.UpdateList(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("List not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "id",
+					Value:  listID.String(),
+					Reason: "List does not exist",
+				})
+		}
+		return apperror.DatabaseError("Failed to update list", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "UpdateList",
+				Table:     "lists",
+			})
+	}
+
+	publishListEvent(ctx, h.hub, services.EventListUpdated, listID, map[string]any{
+		"name":              list.Name,
+		"world":             list.World,
+		"approval_required": list.ApprovalRequired,
+		"visibility":        list.Visibility,
+		"updated_by":        userID,
+	})
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// DeleteList soft deletes a list. It can be restored by its owner within
+// ListRestoreWindow, after which it is purged for good.
+func (h *ListsHandler) DeleteList(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanDeleteList() {
+		return apperror.AuthorizationError("Only the list owner can delete the list", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to delete the list",
+			})
+	}
+
+	affected, err := h.store.SoftDeleteList(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to delete list", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "SoftDeleteList",
+				Table:     "lists",
+			})
+	}
+
+	if affected == 0 {
+		return apperror.NotFoundError("List not found", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  listID.String(),
+				Reason: "List does not exist",
+			})
+	}
+
+	publishListEvent(ctx, h.hub, services.EventListDeleted, listID, map[string]any{
+		"deleted_by": userID,
+	})
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"restorable_until": time.Now().Add(ListRestoreWindow),
+	})
+}
+
+// RestoreList brings back a deleted list while it is still within the restore window
+func (h *ListsHandler) RestoreList(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Memberships of deleted lists are hidden, so ownership is checked by the query itself
+	list, err := h.store.RestoreList(ctx, db.RestoreListParams{
+		ID:              listID,
+		AuthorID:        userID,
+		RestorableSince: pgtype.Timestamptz{Time: time.Now().Add(-ListRestoreWindow), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Deleted list not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "id",
+					Value:  listID.String(),
+					Reason: "List is not deleted, not owned by the user or past its restore window",
+				})
+		}
+		return apperror.DatabaseError("Failed to restore list", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "RestoreList",
+				Table:     "lists",
+			})
+	}
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// PurgeDeletedLists permanently removes lists whose restore window has passed
+func (h *ListsHandler) PurgeDeletedLists() error {
+	ctx := context.Background()
+
+	purged, err := h.store.PurgeDeletedLists(ctx, pgtype.Timestamptz{
+		Time:  time.Now().Add(-ListRestoreWindow),
+		Valid: true,
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to purge deleted lists", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "PurgeDeletedLists",
+				Table:     "lists",
+			}).
+			Wrap(err)
+	}
+
+	if len(purged) > 0 {
+		slog.Info("purged deleted lists", "count", len(purged))
+	}
+
+	return nil
+}
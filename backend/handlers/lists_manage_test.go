@@ -0,0 +1,415 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateList(t *testing.T) {
+	name := func(s string) *string { return &s }
+	flag := func(b bool) *bool { return &b }
+	visibility := func(v db.ListVisibility) *db.ListVisibility { return &v }
+
+	testCases := []struct {
+		name          string
+		body          handlers.UpdateListRequest
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success - Rename",
+			body: handlers.UpdateListRequest{Name: name("  New Name  ")},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					UpdateList(gomock.Any(), db.UpdateListParams{
+						ID:   listID,
+						Name: pgtype.Text{String: "New Name", Valid: true},
+					}).
+					Return(db.List{ID: listID, Name: "New Name", World: "Antica"}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Success - Change World",
+			body: handlers.UpdateListRequest{World: name("Secura")},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					CountListMembersOutsideWorld(gomock.Any(), db.CountListMembersOutsideWorldParams{
+						ListID: listID,
+						World:  "Secura",
+					}).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					UpdateList(gomock.Any(), db.UpdateListParams{
+						ID:    listID,
+						World: pgtype.Text{String: "Secura", Valid: true},
+					}).
+					Return(db.List{ID: listID, Name: "Test List", World: "Secura"}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Success - Require Approval",
+			body: handlers.UpdateListRequest{ApprovalRequired: flag(true)},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					UpdateList(gomock.Any(), db.UpdateListParams{
+						ID:               listID,
+						ApprovalRequired: pgtype.Bool{Bool: true, Valid: true},
+					}).
+					Return(db.List{ID: listID, Name: "Test List", World: "Antica", ApprovalRequired: true}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Members From Other Worlds",
+			body: handlers.UpdateListRequest{World: name("Secura")},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					CountListMembersOutsideWorld(gomock.Any(), gomock.Any()).
+					Return(int64(2), nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Cannot change the world while members from other worlds are present",
+		},
+		{
+			name: "Nothing to Update",
+			body: handlers.UpdateListRequest{},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Nothing to update",
+		},
+		{
+			name: "Empty Name",
+			body: handlers.UpdateListRequest{Name: name("   ")},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Name cannot be empty",
+		},
+		{
+			name: "Invalid Visibility",
+			body: handlers.UpdateListRequest{Visibility: visibility("secret")},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid visibility",
+		},
+		{
+			name: "Success - Make Public",
+			body: handlers.UpdateListRequest{Visibility: visibility(db.ListVisibilityPublic)},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					UpdateList(gomock.Any(), db.UpdateListParams{
+						ID:         listID,
+						Visibility: db.NullListVisibility{ListVisibility: db.ListVisibilityPublic, Valid: true},
+					}).
+					Return(db.List{ID: listID, Name: "Test List", World: "Antica", Visibility: db.ListVisibilityPublic}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Member Cannot Edit",
+			body: handlers.UpdateListRequest{Name: name("New Name")},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can edit the list",
+		},
+		{
+			name: "Database Error - UpdateList",
+			body: handlers.UpdateListRequest{Name: name("New Name")},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					UpdateList(gomock.Any(), gomock.Any()).
+					Return(db.List{}, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to update list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s", listID)
+			req := httptest.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.UpdateList(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response db.List
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, listID, response.ID)
+		})
+	}
+}
+
+func TestDeleteList(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					SoftDeleteList(gomock.Any(), listID).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Moderator Cannot Delete",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only the list owner can delete the list",
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Database Error - SoftDeleteList",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					SoftDeleteList(gomock.Any(), listID).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to delete list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s", listID)
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.DeleteList(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response map[string]time.Time
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.WithinDuration(t, time.Now().Add(handlers.ListRestoreWindow), response["restorable_until"], time.Minute)
+		})
+	}
+}
+
+func TestRestoreList(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					RestoreList(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.RestoreListParams) (db.List, error) {
+						require.Equal(t, listID, arg.ID)
+						require.Equal(t, userID, arg.AuthorID)
+						require.WithinDuration(t, time.Now().Add(-handlers.ListRestoreWindow), arg.RestorableSince.Time, time.Minute)
+						return db.List{ID: listID, AuthorID: userID}, nil
+					})
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Not Restorable",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					RestoreList(gomock.Any(), gomock.Any()).
+					Return(db.List{}, sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Deleted list not found",
+		},
+		{
+			name: "Database Error - RestoreList",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					RestoreList(gomock.Any(), gomock.Any()).
+					Return(db.List{}, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to restore list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/restore", listID)
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/restore")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.RestoreList(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
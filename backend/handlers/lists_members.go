@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 )
 
 // GetListMembersWithUnlocks returns all members of a list with their unlocked soulcores
@@ -43,35 +45,490 @@ func (h *ListsHandler) GetListMembersWithUnlocks(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Check if user is a member of the list
-	isMember, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
-		ListID: listID,
-		UserID: userID,
-	})
+	// Check the user's role in the list
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	members, err := h.store.GetListMembersWithUnlocks(ctx, listID)
 	if err != nil {
-		return apperror.DatabaseError("Failed to check list membership", err).
+		return apperror.DatabaseError("Failed to get list members", err).
 			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "IsUserListMember",
+				Operation: "GetListMembersWithUnlocks",
 				Table:     "list_members",
 			})
 	}
-	if !isMember {
-		return apperror.AuthorizationError("User is not a member of this list", nil).
+
+	return c.JSON(http.StatusOK, members)
+}
+
+// LeaveList removes the current user and all of their characters from a list
+func (h *ListsHandler) LeaveList(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
 			WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "list_id",
-				Value:  listID.String(),
-				Reason: "User is not a member of this list",
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
 			})
 	}
 
-	members, err := h.store.GetListMembersWithUnlocks(ctx, listID)
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return apperror.DatabaseError("Failed to get list members", err).
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if membership.IsOwner() {
+		return apperror.ValidationError("The list owner must transfer ownership before leaving", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Owner cannot leave the list",
+			})
+	}
+
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		err := recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:  listID,
+			ActorID: userID,
+			Action:  db.ListActivityActionMemberLeft,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.RemoveListMember(ctx, db.RemoveListMemberParams{
+			ListID: listID,
+			UserID: userID,
+		}); err != nil {
+			return apperror.DatabaseError("Failed to leave list", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "RemoveListMember",
+					Table:     "lists_users",
+				})
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to leave list")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberLeft, listID, map[string]any{
+		"user_id": userID,
+	})
+
+	return c.NoContent(http.StatusOK)
+}
+
+// RemoveMember kicks a member out of a list. The removed user cannot rejoin
+// with the share code until a moderator re-invites them.
+func (h *ListsHandler) RemoveMember(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	targetUserID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid user ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  c.Param("user_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	if targetUserID == userID {
+		return apperror.ValidationError("Use leave to remove yourself from a list", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  targetUserID.String(),
+				Reason: "Target is the current user",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanManageMembers() {
+		return apperror.AuthorizationError("Only list moderators can manage members", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to manage members",
+			})
+	}
+
+	target, err := h.policy.ListMembership(ctx, listID, targetUserID)
+	if err != nil {
+		if errors.Is(err, services.ErrNotListMember) {
+			return apperror.NotFoundError("Member not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "user_id",
+					Value:  targetUserID.String(),
+					Reason: "User is not a member of this list",
+				})
+		}
+		return apperror.DatabaseError("Failed to get member role", err).
 			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "GetListMembersWithUnlocks",
-				Table:     "list_members",
+				Operation: "GetListMemberRole",
+				Table:     "lists_users",
 			})
 	}
 
-	return c.JSON(http.StatusOK, members)
+	if !membership.CanRemoveMember(target.Role) {
+		return apperror.AuthorizationError("Not allowed to remove this member", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  targetUserID.String(),
+				Reason: "Member outranks the current user",
+			})
+	}
+
+	// The removal is recorded together with the membership change, otherwise a removed
+	// member could rejoin with the share code
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		if _, err := q.RemoveListMember(ctx, db.RemoveListMemberParams{
+			ListID: listID,
+			UserID: targetUserID,
+		}); err != nil {
+			return apperror.DatabaseError("Failed to remove member", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "RemoveListMember",
+					Table:     "lists_users",
+				})
+		}
+
+		if err := q.CreateListRemoval(ctx, db.CreateListRemovalParams{
+			ListID:    listID,
+			UserID:    targetUserID,
+			RemovedBy: userID,
+		}); err != nil {
+			return apperror.DatabaseError("Failed to record member removal", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "CreateListRemoval",
+					Table:     "list_removed_members",
+				})
+		}
+
+		return recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:       listID,
+			ActorID:      userID,
+			Action:       db.ListActivityActionMemberRemoved,
+			TargetUserID: targetUserID,
+		})
+	})
+	if err != nil {
+		return txError(err, "Failed to remove member")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberRemoved, listID, map[string]any{
+		"user_id":    targetUserID,
+		"removed_by": userID,
+	})
+
+	return c.NoContent(http.StatusOK)
+}
+
+// TransferOwnershipRequest represents the request body for handing a list to another member
+type TransferOwnershipRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// TransferOwnership makes another member the owner of the list. The previous
+// owner stays in the list as a moderator.
+func (h *ListsHandler) TransferOwnership(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req TransferOwnershipRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	if req.UserID == uuid.Nil || req.UserID == userID {
+		return apperror.ValidationError("Invalid new owner", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  req.UserID.String(),
+				Reason: "New owner must be another member of the list",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanTransferOwnership() {
+		return apperror.AuthorizationError("Only the list owner can transfer ownership", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to transfer ownership",
+			})
+	}
+
+	if _, err := h.policy.ListMembership(ctx, listID, req.UserID); err != nil {
+		if errors.Is(err, services.ErrNotListMember) {
+			return apperror.NotFoundError("Member not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "user_id",
+					Value:  req.UserID.String(),
+					Reason: "User is not an active member of this list",
+				})
+		}
+		return apperror.DatabaseError("Failed to get member role", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListMemberRole",
+				Table:     "lists_users",
+			})
+	}
+
+	if _, err := h.store.TransferListOwnership(ctx, db.TransferListOwnershipParams{
+		ID:       listID,
+		AuthorID: req.UserID,
+	}); err != nil {
+		return apperror.DatabaseError("Failed to transfer ownership", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "TransferListOwnership",
+				Table:     "lists",
+			})
+	}
+
+	publishListEvent(ctx, h.hub, services.EventOwnershipTransferred, listID, map[string]any{
+		"owner_id":          req.UserID,
+		"previous_owner_id": userID,
+	})
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"owner_id": req.UserID,
+	})
+}
+
+// GetRemovedMembers returns the users that were removed from a list and cannot rejoin
+func (h *ListsHandler) GetRemovedMembers(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanManageMembers() {
+		return apperror.AuthorizationError("Only list moderators can manage members", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to manage members",
+			})
+	}
+
+	removed, err := h.store.GetListRemovedMembers(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get removed members", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListRemovedMembers",
+				Table:     "list_removed_members",
+			})
+	}
+
+	return c.JSON(http.StatusOK, removed)
+}
+
+// ReinviteMember lifts a removal so the user can join the list with its share code again
+func (h *ListsHandler) ReinviteMember(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	targetUserID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid user ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  c.Param("user_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanManageMembers() {
+		return apperror.AuthorizationError("Only list moderators can manage members", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to manage members",
+			})
+	}
+
+	affected, err := h.store.DeleteListRemoval(ctx, db.DeleteListRemovalParams{
+		ListID: listID,
+		UserID: targetUserID,
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to re-invite member", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "DeleteListRemoval",
+				Table:     "list_removed_members",
+			})
+	}
+
+	if affected == 0 {
+		return apperror.NotFoundError("Removed member not found", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  targetUserID.String(),
+				Reason: "User was not removed from this list",
+			})
+	}
+
+	return c.NoContent(http.StatusOK)
 }
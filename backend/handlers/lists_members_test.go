@@ -1,6 +1,8 @@
 package handlers_test
 
 import (
+	"bytes"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,6 +16,7 @@ import (
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/handlers"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -35,11 +38,11 @@ func TestGetListMembersWithUnlocks(t *testing.T) {
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				// Check if user is a member
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				// Get members with unlocks
 				store.EXPECT().
@@ -95,11 +98,11 @@ func TestGetListMembersWithUnlocks(t *testing.T) {
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				// Check if user is a member - returns false
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(false, nil)
+					Return(db.ListRole(""), sql.ErrNoRows)
 			},
 			expectedCode:  "authorization_error",
 			expectedError: "User is not a member of this list",
@@ -112,11 +115,11 @@ func TestGetListMembersWithUnlocks(t *testing.T) {
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
 				// Check if user is a member
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				// Database error when getting members
 				store.EXPECT().
@@ -160,7 +163,7 @@ func TestGetListMembersWithUnlocks(t *testing.T) {
 			tc.setupMocks(store, listID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err := h.GetListMembersWithUnlocks(c)
 
 			// Check for expected error response
@@ -186,3 +189,548 @@ func TestGetListMembersWithUnlocks(t *testing.T) {
 		})
 	}
 }
+
+func TestLeaveList(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:  listID,
+						ActorID: userID,
+						Action:  db.ListActivityActionMemberLeft,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					RemoveListMember(gomock.Any(), db.RemoveListMemberParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return([]uuid.UUID{uuid.New()}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Owner Must Transfer First",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "The list owner must transfer ownership before leaving",
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Database Error - RemoveListMember",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					RemoveListMember(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to leave list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/leave", listID)
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/leave")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.LeaveList(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupRequest  func(c echo.Context)
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		rolledBack    bool
+	}{
+		{
+			name: "Success - Moderator Removes Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					RemoveListMember(gomock.Any(), db.RemoveListMemberParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return([]uuid.UUID{uuid.New()}, nil)
+
+				store.EXPECT().
+					CreateListRemoval(gomock.Any(), db.CreateListRemovalParams{
+						ListID:    listID,
+						UserID:    targetID,
+						RemovedBy: userID,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:       listID,
+						ActorID:      userID,
+						Action:       db.ListActivityActionMemberRemoved,
+						TargetUserID: targetID,
+					}).
+					Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Cannot Remove Yourself",
+			setupRequest: func(c echo.Context) {
+				c.SetParamValues(c.Param("id"), c.Get("user_id").(string))
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Use leave to remove yourself from a list",
+		},
+		{
+			name: "Member Cannot Manage Members",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can manage members",
+		},
+		{
+			name: "Moderator Cannot Remove Moderator",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRoleModerator, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Not allowed to remove this member",
+		},
+		{
+			name: "Target Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Member not found",
+		},
+		{
+			name: "Database Error - CreateListRemoval",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					RemoveListMember(gomock.Any(), gomock.Any()).
+					Return([]uuid.UUID{uuid.New()}, nil)
+
+				store.EXPECT().
+					CreateListRemoval(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to record member removal",
+			rolledBack:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+			targetID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/members/%s", listID, targetID)
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/members/:user_id")
+			c.SetParamNames("id", "user_id")
+			c.SetParamValues(listID.String(), targetID.String())
+			c.Set("user_id", userID.String())
+
+			if tc.setupRequest != nil {
+				tc.setupRequest(c)
+			}
+
+			tc.setupMocks(store, listID, userID, targetID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.RemoveMember(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, tc.rolledBack, tx.RolledBack > 0)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestTransferOwnership(t *testing.T) {
+	testCases := []struct {
+		name          string
+		newOwner      func(userID uuid.UUID, targetID uuid.UUID) uuid.UUID
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					TransferListOwnership(gomock.Any(), db.TransferListOwnershipParams{
+						ID:       listID,
+						AuthorID: targetID,
+					}).
+					Return(int64(2), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Cannot Transfer to Yourself",
+			newOwner: func(userID uuid.UUID, targetID uuid.UUID) uuid.UUID {
+				return userID
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid new owner",
+		},
+		{
+			name: "Moderator Cannot Transfer",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only the list owner can transfer ownership",
+		},
+		{
+			name: "New Owner Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Member not found",
+		},
+		{
+			name: "Database Error - TransferListOwnership",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					TransferListOwnership(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to transfer ownership",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+			targetID := uuid.New()
+
+			newOwner := targetID
+			if tc.newOwner != nil {
+				newOwner = tc.newOwner(userID, targetID)
+			}
+
+			body, err := json.Marshal(handlers.TransferOwnershipRequest{UserID: newOwner})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s/transfer", listID)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/transfer")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID, targetID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.TransferOwnership(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, targetID.String(), response["owner_id"])
+		})
+	}
+}
+
+func TestReinviteMember(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					DeleteListRemoval(gomock.Any(), db.DeleteListRemovalParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Member Cannot Re-invite",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can manage members",
+		},
+		{
+			name: "User Was Not Removed",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					DeleteListRemoval(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Removed member not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+			targetID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/removed-members/%s", listID, targetID)
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/removed-members/:user_id")
+			c.SetParamNames("id", "user_id")
+			c.SetParamValues(listID.String(), targetID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID, targetID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.ReinviteMember(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
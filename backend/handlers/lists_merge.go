@@ -0,0 +1,485 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// CloneListRequest represents the request body for cloning a list. The clone is owned by
+// the given character and lives on its world, the name defaults to the source list's.
+type CloneListRequest struct {
+	CharacterID    uuid.UUID `json:"character_id"`
+	Name           string    `json:"name,omitempty"`
+	IncludeMembers bool      `json:"include_members"`
+}
+
+// CloneListResponse is the new list along with how much of the source was copied into it
+type CloneListResponse struct {
+	List          db.List `json:"list"`
+	MemberCount   int64   `json:"member_count"`
+	SoulcoreCount int64   `json:"soulcore_count"`
+}
+
+// MergeListRequest represents the request body for merging another list into a list
+type MergeListRequest struct {
+	SourceListID uuid.UUID `json:"source_list_id"`
+}
+
+// MergeListResponse tells how much of the source list ended up in the target list
+type MergeListResponse struct {
+	ListID               uuid.UUID `json:"list_id"`
+	SoulcoresMerged      int       `json:"soulcores_merged"`
+	MembersMoved         int64     `json:"members_moved"`
+	ChatMessagesMoved    int64     `json:"chat_messages_moved"`
+	ReservationsReleased int64     `json:"reservations_released"`
+}
+
+// getList loads a list that has not been deleted
+func (h *ListsHandler) getList(ctx context.Context, listID uuid.UUID) (db.List, error) {
+	list, err := h.store.GetList(ctx, listID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return list, apperror.NotFoundError("List not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "id",
+					Value:  listID.String(),
+					Reason: "List does not exist",
+				})
+		}
+		return list, apperror.DatabaseError("Failed to retrieve list", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetList",
+				Table:     "lists",
+			})
+	}
+	return list, nil
+}
+
+// CloneList copies a list's soulcores, and optionally its members, into a new list owned by
+// the user. Only members with a character on the clone's world are copied, which lets a
+// group split off a list for an alt world. Soulcores added by members who were not copied
+// are credited to the user cloning the list.
+func (h *ListsHandler) CloneList(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req CloneListRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	if req.CharacterID == uuid.Nil {
+		return apperror.ValidationError("Character ID is required", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Reason: "The character that will own the clone is required",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanCloneList() {
+		return apperror.AuthorizationError("Only list moderators can clone the list", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to clone the list",
+			})
+	}
+
+	source, err := h.getList(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = source.Name
+	}
+
+	var resp CloneListResponse
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		char, err := q.GetCharacter(ctx, req.CharacterID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.NotFoundError("Character not found", err).
+					WithDetails(&apperror.ValidationErrorDetails{
+						Field:  "character_id",
+						Value:  req.CharacterID.String(),
+						Reason: "Character does not exist",
+					})
+			}
+			return apperror.DatabaseError("Failed to retrieve character", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetCharacter",
+					Table:     "characters",
+				})
+		}
+		if !h.policy.CanManageCharacter(userID, char) {
+			return apperror.AuthorizationError("Character does not belong to user", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "character_id",
+					Value:  req.CharacterID.String(),
+					Reason: "Character belongs to a different user",
+				})
+		}
+
+		resp.List, err = q.CreateList(ctx, db.CreateListParams{
+			AuthorID: userID,
+			Name:     name,
+			World:    char.World,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to create list", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "CreateList",
+					Table:     "lists",
+				})
+		}
+
+		err = q.AddListCharacter(ctx, db.AddListCharacterParams{
+			ListID:      resp.List.ID,
+			UserID:      userID,
+			CharacterID: char.ID,
+			Role:        db.ListRoleOwner,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to add character to list", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "AddListCharacter",
+					Table:     "lists_users",
+				})
+		}
+		resp.MemberCount = 1
+
+		if req.IncludeMembers {
+			copied, err := q.CloneListMembers(ctx, db.CloneListMembersParams{
+				TargetListID: resp.List.ID,
+				SourceListID: listID,
+				ClonedBy:     userID,
+				World:        char.World,
+			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to copy list members", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "CloneListMembers",
+						Table:     "lists_users",
+					})
+			}
+			resp.MemberCount += copied
+		}
+
+		// Members go first, so soulcores can stay credited to the members who were copied
+		resp.SoulcoreCount, err = q.CloneListSoulcores(ctx, db.CloneListSoulcoresParams{
+			TargetListID: resp.List.ID,
+			ClonedBy:     userID,
+			SourceListID: listID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to copy soul cores", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "CloneListSoulcores",
+					Table:     "lists_soulcores",
+				})
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to clone list")
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// MergeList folds the source list into the list. Soulcores both lists track keep the status
+// furthest along the lifecycle, members and chat history move over and the source list is
+// deleted. Reservations for users who end up outside the merged list are released. The
+// source's share code keeps working and leads to the merged list. The user has to own both
+// lists and they have to be on the same world.
+func (h *ListsHandler) MergeList(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req MergeListRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	if req.SourceListID == uuid.Nil {
+		return apperror.ValidationError("Source list ID is required", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "source_list_id",
+				Reason: "The list to merge is required",
+			})
+	}
+
+	if req.SourceListID == listID {
+		return apperror.ValidationError("Cannot merge a list into itself", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "source_list_id",
+				Value:  req.SourceListID.String(),
+				Reason: "Source and target list are the same",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	for _, id := range []uuid.UUID{listID, req.SourceListID} {
+		membership, err := h.listMembership(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if !membership.CanMergeList() {
+			return apperror.AuthorizationError("Only the owner of both lists can merge them", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "role",
+					Value:  string(membership.Role),
+					Reason: "Not authorized to merge list " + id.String(),
+				})
+		}
+	}
+
+	target, err := h.getList(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	source, err := h.getList(ctx, req.SourceListID)
+	if err != nil {
+		return err
+	}
+
+	if source.World != target.World {
+		return apperror.ValidationError("Cannot merge lists on different worlds", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "source_list_id",
+				Value:  source.World,
+				Reason: "Source list is not on " + target.World,
+			})
+	}
+
+	resp := MergeListResponse{ListID: listID}
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		resp = MergeListResponse{ListID: listID}
+
+		targetCores, err := q.GetListSoulcores(ctx, db.GetListSoulcoresParams{ListID: listID})
+		if err != nil {
+			return apperror.DatabaseError("Failed to get soul cores", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetListSoulcores",
+					Table:     "lists_soulcores",
+				})
+		}
+		statuses := make(map[uuid.UUID]db.SoulcoreStatus, len(targetCores))
+		for _, s := range targetCores {
+			statuses[s.CreatureID] = s.Status
+		}
+
+		sourceCores, err := q.GetListSoulcores(ctx, db.GetListSoulcoresParams{ListID: source.ID})
+		if err != nil {
+			return apperror.DatabaseError("Failed to get soul cores", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetListSoulcores",
+					Table:     "lists_soulcores",
+				})
+		}
+
+		for _, s := range sourceCores {
+			if current, ok := statuses[s.CreatureID]; ok && services.MergeSoulcoreStatus(current, s.Status) == current {
+				continue
+			}
+
+			// The source's core wins, along with who added it and who it is reserved for
+			err := q.AddSoulcoreToList(ctx, db.AddSoulcoreToListParams{
+				ListID:            listID,
+				CreatureID:        s.CreatureID,
+				Status:            s.Status,
+				AddedByUserID:     s.AddedByUserID,
+				ReservedForUserID: s.ReservedForUserID,
+			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to merge soul core", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "AddSoulcoreToList",
+						Table:     "lists_soulcores",
+					})
+			}
+			resp.SoulcoresMerged++
+		}
+
+		resp.MembersMoved, err = q.MergeListMembers(ctx, db.MergeListMembersParams{
+			TargetListID: listID,
+			SourceListID: source.ID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to move list members", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "MergeListMembers",
+					Table:     "lists_users",
+				})
+		}
+
+		// Source cores may be reserved for users who were not moved over, nobody could act on those
+		resp.ReservationsReleased, err = q.ReleaseListReservations(ctx, listID)
+		if err != nil {
+			return apperror.DatabaseError("Failed to release reservations", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "ReleaseListReservations",
+					Table:     "lists_soulcores",
+				})
+		}
+
+		resp.ChatMessagesMoved, err = q.MoveListChatMessages(ctx, db.MoveListChatMessagesParams{
+			TargetListID: listID,
+			SourceListID: source.ID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to move chat messages", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "MoveListChatMessages",
+					Table:     "list_chat_messages",
+				})
+		}
+
+		err = q.MoveListSoulcoreSuggestions(ctx, db.MoveListSoulcoreSuggestionsParams{
+			TargetListID: listID,
+			SourceListID: source.ID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to move soulcore suggestions", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "MoveListSoulcoreSuggestions",
+					Table:     "character_soulcore_suggestions",
+				})
+		}
+
+		// Lists merged into the source earlier now lead to the target as well
+		err = q.MoveListShareRedirects(ctx, db.MoveListShareRedirectsParams{
+			TargetListID: listID,
+			SourceListID: source.ID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to move share code redirects", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "MoveListShareRedirects",
+					Table:     "list_share_redirects",
+				})
+		}
+
+		// A disabled share code stays disabled rather than becoming a way into the target
+		if source.ShareCodeEnabled {
+			err = q.CreateListShareRedirect(ctx, db.CreateListShareRedirectParams{
+				ShareCode:    source.ShareCode,
+				SourceListID: source.ID,
+				ListID:       listID,
+			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to redirect share code", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "CreateListShareRedirect",
+						Table:     "list_share_redirects",
+					})
+			}
+		}
+
+		if _, err := q.SoftDeleteList(ctx, source.ID); err != nil {
+			return apperror.DatabaseError("Failed to delete merged list", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "SoftDeleteList",
+					Table:     "lists",
+				})
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to merge lists")
+	}
+
+	// Members connected to either list learn where the merged list lives now
+	for _, id := range []uuid.UUID{listID, source.ID} {
+		publishListEvent(ctx, h.hub, services.EventListMerged, id, map[string]any{
+			"source_list_id": source.ID,
+			"target_list_id": listID,
+			"merged_by":      userID,
+		})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
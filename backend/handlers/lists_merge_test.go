@@ -0,0 +1,602 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCloneList(t *testing.T) {
+	characterID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		body          handlers.CloneListRequest
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		checkResponse func(t *testing.T, response handlers.CloneListResponse)
+	}{
+		{
+			name: "Success - With Members",
+			body: handlers.CloneListRequest{CharacterID: characterID, IncludeMembers: true},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				cloneID := uuid.New()
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Name: "Guild Hunt", World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID, World: "Secura"}, nil)
+
+				// The clone lives on the world of the owning character
+				store.EXPECT().
+					CreateList(gomock.Any(), db.CreateListParams{
+						AuthorID: userID,
+						Name:     "Guild Hunt",
+						World:    "Secura",
+					}).
+					Return(db.List{ID: cloneID, Name: "Guild Hunt", World: "Secura"}, nil)
+
+				store.EXPECT().
+					AddListCharacter(gomock.Any(), db.AddListCharacterParams{
+						ListID:      cloneID,
+						UserID:      userID,
+						CharacterID: characterID,
+						Role:        db.ListRoleOwner,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					CloneListMembers(gomock.Any(), db.CloneListMembersParams{
+						TargetListID: cloneID,
+						SourceListID: listID,
+						ClonedBy:     userID,
+						World:        "Secura",
+					}).
+					Return(int64(2), nil)
+
+				store.EXPECT().
+					CloneListSoulcores(gomock.Any(), db.CloneListSoulcoresParams{
+						TargetListID: cloneID,
+						ClonedBy:     userID,
+						SourceListID: listID,
+					}).
+					Return(int64(40), nil)
+			},
+			expectedCode: http.StatusCreated,
+			checkResponse: func(t *testing.T, response handlers.CloneListResponse) {
+				require.Equal(t, "Secura", response.List.World)
+				require.Equal(t, int64(3), response.MemberCount)
+				require.Equal(t, int64(40), response.SoulcoreCount)
+			},
+		},
+		{
+			name: "Success - Soulcores Only",
+			body: handlers.CloneListRequest{CharacterID: characterID, Name: " Alt Hunt "},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Name: "Guild Hunt", World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					CreateList(gomock.Any(), db.CreateListParams{
+						AuthorID: userID,
+						Name:     "Alt Hunt",
+						World:    "Antica",
+					}).
+					Return(db.List{ID: uuid.New(), Name: "Alt Hunt", World: "Antica"}, nil)
+
+				store.EXPECT().
+					AddListCharacter(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					CloneListSoulcores(gomock.Any(), gomock.Any()).
+					Return(int64(5), nil)
+			},
+			expectedCode: http.StatusCreated,
+			checkResponse: func(t *testing.T, response handlers.CloneListResponse) {
+				require.Equal(t, "Alt Hunt", response.List.Name)
+				require.Equal(t, int64(1), response.MemberCount)
+				require.Equal(t, int64(5), response.SoulcoreCount)
+			},
+		},
+		{
+			name:          "Missing Character",
+			body:          handlers.CloneListRequest{},
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Character ID is required",
+		},
+		{
+			name: "Member Cannot Clone",
+			body: handlers.CloneListRequest{CharacterID: characterID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can clone the list",
+		},
+		{
+			name: "Not a Member",
+			body: handlers.CloneListRequest{CharacterID: characterID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Character of Another User",
+			body: handlers.CloneListRequest{CharacterID: characterID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Name: "Guild Hunt", World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: uuid.New(), World: "Antica"}, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Character does not belong to user",
+		},
+		{
+			name: "Database Error - CloneListSoulcores",
+			body: handlers.CloneListRequest{CharacterID: characterID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Name: "Guild Hunt", World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					CreateList(gomock.Any(), gomock.Any()).
+					Return(db.List{ID: uuid.New()}, nil)
+
+				store.EXPECT().
+					AddListCharacter(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					CloneListSoulcores(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to copy soul cores",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s/clone", listID)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/clone")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.CloneList(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, 0, tx.Committed)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+
+			var response handlers.CloneListResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestMergeList(t *testing.T) {
+	sourceID := uuid.New()
+	shareCode := uuid.New()
+	demonID, dragonID, ratID := uuid.New(), uuid.New(), uuid.New()
+
+	expectOwnerOfBoth := func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+		store.EXPECT().
+			GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{ListID: listID, UserID: userID}).
+			Return(db.ListRoleOwner, nil)
+
+		store.EXPECT().
+			GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{ListID: sourceID, UserID: userID}).
+			Return(db.ListRoleOwner, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		body          handlers.MergeListRequest
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		checkResponse func(t *testing.T, response handlers.MergeListResponse)
+	}{
+		{
+			name: "Success",
+			body: handlers.MergeListRequest{SourceListID: sourceID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				memberID := uuid.New()
+				expectOwnerOfBoth(store, listID, userID)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), sourceID).
+					Return(db.List{ID: sourceID, World: "Antica", ShareCode: shareCode, ShareCodeEnabled: true}, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return([]db.GetListSoulcoresRow{
+						{CreatureID: demonID, Status: db.SoulcoreStatusObtained, AddedByUserID: userID},
+						{CreatureID: dragonID, Status: db.SoulcoreStatusUnlocked, AddedByUserID: userID},
+					}, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: sourceID}).
+					Return([]db.GetListSoulcoresRow{
+						{CreatureID: demonID, Status: db.SoulcoreStatusUnlocked, AddedByUserID: memberID},
+						{CreatureID: dragonID, Status: db.SoulcoreStatusObtained, AddedByUserID: memberID},
+						{CreatureID: ratID, Status: db.SoulcoreStatusReserved, AddedByUserID: memberID, ReservedForUserID: memberID},
+					}, nil)
+
+				// The dragon is already unlocked in the target, so only two cores change
+				store.EXPECT().
+					AddSoulcoreToList(gomock.Any(), db.AddSoulcoreToListParams{
+						ListID:        listID,
+						CreatureID:    demonID,
+						Status:        db.SoulcoreStatusUnlocked,
+						AddedByUserID: memberID,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					AddSoulcoreToList(gomock.Any(), db.AddSoulcoreToListParams{
+						ListID:            listID,
+						CreatureID:        ratID,
+						Status:            db.SoulcoreStatusReserved,
+						AddedByUserID:     memberID,
+						ReservedForUserID: memberID,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					MergeListMembers(gomock.Any(), db.MergeListMembersParams{TargetListID: listID, SourceListID: sourceID}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					ReleaseListReservations(gomock.Any(), listID).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					MoveListChatMessages(gomock.Any(), db.MoveListChatMessagesParams{TargetListID: listID, SourceListID: sourceID}).
+					Return(int64(12), nil)
+
+				store.EXPECT().
+					MoveListSoulcoreSuggestions(gomock.Any(), db.MoveListSoulcoreSuggestionsParams{TargetListID: listID, SourceListID: sourceID}).
+					Return(nil)
+
+				store.EXPECT().
+					MoveListShareRedirects(gomock.Any(), db.MoveListShareRedirectsParams{TargetListID: listID, SourceListID: sourceID}).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListShareRedirect(gomock.Any(), db.CreateListShareRedirectParams{
+						ShareCode:    shareCode,
+						SourceListID: sourceID,
+						ListID:       listID,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					SoftDeleteList(gomock.Any(), sourceID).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response handlers.MergeListResponse) {
+				require.Equal(t, 2, response.SoulcoresMerged)
+				require.Equal(t, int64(1), response.MembersMoved)
+				require.Equal(t, int64(12), response.ChatMessagesMoved)
+				require.Equal(t, int64(1), response.ReservationsReleased)
+			},
+		},
+		{
+			name: "Success - Disabled Share Code Is Not Redirected",
+			body: handlers.MergeListRequest{SourceListID: sourceID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				expectOwnerOfBoth(store, listID, userID)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), sourceID).
+					Return(db.List{ID: sourceID, World: "Antica", ShareCode: shareCode}, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(2)
+
+				store.EXPECT().
+					MergeListMembers(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					ReleaseListReservations(gomock.Any(), listID).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					MoveListChatMessages(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					MoveListSoulcoreSuggestions(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					MoveListShareRedirects(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					SoftDeleteList(gomock.Any(), sourceID).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response handlers.MergeListResponse) {
+				require.Zero(t, response.SoulcoresMerged)
+			},
+		},
+		{
+			name:          "Missing Source List",
+			body:          handlers.MergeListRequest{},
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Source list ID is required",
+		},
+		{
+			name: "Moderator of Source Cannot Merge",
+			body: handlers.MergeListRequest{SourceListID: sourceID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{ListID: listID, UserID: userID}).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{ListID: sourceID, UserID: userID}).
+					Return(db.ListRoleModerator, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only the owner of both lists can merge them",
+		},
+		{
+			name: "Not a Member of Target",
+			body: handlers.MergeListRequest{SourceListID: sourceID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Different Worlds",
+			body: handlers.MergeListRequest{SourceListID: sourceID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				expectOwnerOfBoth(store, listID, userID)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), sourceID).
+					Return(db.List{ID: sourceID, World: "Secura"}, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Cannot merge lists on different worlds",
+		},
+		{
+			name: "Source List Deleted",
+			body: handlers.MergeListRequest{SourceListID: sourceID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				expectOwnerOfBoth(store, listID, userID)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), sourceID).
+					Return(db.List{}, sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "List not found",
+		},
+		{
+			name: "Database Error - MergeListMembers",
+			body: handlers.MergeListRequest{SourceListID: sourceID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				expectOwnerOfBoth(store, listID, userID)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), sourceID).
+					Return(db.List{ID: sourceID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(2)
+
+				store.EXPECT().
+					MergeListMembers(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to move list members",
+		},
+		{
+			name: "Database Error - ReleaseListReservations",
+			body: handlers.MergeListRequest{SourceListID: sourceID},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				expectOwnerOfBoth(store, listID, userID)
+
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), sourceID).
+					Return(db.List{ID: sourceID, World: "Antica"}, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(2)
+
+				store.EXPECT().
+					MergeListMembers(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					ReleaseListReservations(gomock.Any(), listID).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to release reservations",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s/merge", listID)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/merge")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.MergeList(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, 0, tx.Committed)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+
+			var response handlers.MergeListResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, listID, response.ListID)
+			tc.checkResponse(t, response)
+		})
+	}
+}
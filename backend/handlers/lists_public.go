@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+)
+
+// PublicListResponse is the read-only view of an unlisted or public list.
+// It leaves out user IDs, share codes and chat.
+type PublicListResponse struct {
+	ID               uuid.UUID           `json:"id"`
+	Name             string              `json:"name"`
+	World            string              `json:"world"`
+	Visibility       db.ListVisibility   `json:"visibility"`
+	ApprovalRequired bool                `json:"approval_required"`
+	CreatedAt        time.Time           `json:"created_at"`
+	Members          []PublicMemberStats `json:"members"`
+	SoulCores        []PublicSoulcore    `json:"soul_cores"`
+}
+
+// PublicMemberStats is the progress of an active member shown on public lists
+type PublicMemberStats struct {
+	CharacterName          string `json:"character_name"`
+	ObtainedCount          int64  `json:"obtained_count"`
+	UnlockedCount          int64  `json:"unlocked_count"`
+	CharacterUnlockedCount int64  `json:"character_unlocked_count"`
+}
+
+// PublicSoulcore is a soulcore shown on public lists
+type PublicSoulcore struct {
+	CreatureID    uuid.UUID         `json:"creature_id"`
+	CreatureName  string            `json:"creature_name"`
+	LocalizedName string            `json:"localized_name"`
+	Status        db.SoulcoreStatus `json:"status"`
+	AddedBy       pgtype.Text       `json:"added_by"`
+}
+
+// GetPublicList returns the read-only view of a list to anyone, as long as it is not private
+func (h *ListsHandler) GetPublicList(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	lang, err := requestLanguage(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	list, err := h.store.GetList(ctx, listID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return apperror.DatabaseError("Failed to get list", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetList",
+				Table:     "lists",
+			})
+	}
+
+	// Private lists look the same as missing ones
+	if err != nil || list.Visibility == db.ListVisibilityPrivate {
+		return apperror.NotFoundError("List not found", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  listID.String(),
+				Reason: "List does not exist or is private",
+			})
+	}
+
+	members, err := h.store.GetListMembers(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get list members", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListMembers",
+				Table:     "lists_users",
+			})
+	}
+
+	memberStats := []PublicMemberStats{}
+	for _, m := range members {
+		if !m.IsActive {
+			continue
+		}
+		memberStats = append(memberStats, PublicMemberStats{
+			CharacterName:          m.CharacterName,
+			ObtainedCount:          m.ObtainedCount,
+			UnlockedCount:          m.UnlockedCount,
+			CharacterUnlockedCount: m.CharacterUnlockedCount,
+		})
+	}
+
+	soulcores, err := h.store.GetListSoulcores(ctx, db.GetListSoulcoresParams{Lang: lang, ListID: listID})
+	if err != nil {
+		return apperror.DatabaseError("Failed to get soul cores", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListSoulcores",
+				Table:     "lists_soulcores",
+			})
+	}
+
+	publicSoulcores := make([]PublicSoulcore, len(soulcores))
+	for i, s := range soulcores {
+		publicSoulcores[i] = PublicSoulcore{
+			CreatureID:    s.CreatureID,
+			CreatureName:  s.CreatureName,
+			LocalizedName: s.LocalizedName,
+			Status:        s.Status,
+			AddedBy:       s.AddedBy,
+		}
+	}
+
+	return c.JSON(http.StatusOK, PublicListResponse{
+		ID:               list.ID,
+		Name:             list.Name,
+		World:            list.World,
+		Visibility:       list.Visibility,
+		ApprovalRequired: list.ApprovalRequired,
+		CreatedAt:        list.CreatedAt.Time,
+		Members:          memberStats,
+		SoulCores:        publicSoulcores,
+	})
+}
+
+// ListDirectoryResponse is a page of public lists on a world
+type ListDirectoryResponse struct {
+	Lists         []ListDirectoryEntry `json:"lists"`
+	CreatureCount int64                `json:"creature_count"`
+	Pagination    PaginationInfo       `json:"pagination"`
+}
+
+// ListDirectoryEntry is a public list with its completion stats. Completion is the
+// share of the creatures in the list's scope unlocked in the list in percent. The share
+// code lets players join straight from the directory and is left out while disabled.
+type ListDirectoryEntry struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	World            string     `json:"world"`
+	ShareCode        *uuid.UUID `json:"share_code,omitempty"`
+	ApprovalRequired bool       `json:"approval_required"`
+	MemberCount      int64      `json:"member_count"`
+	ObtainedCount    int64      `json:"obtained_count"`
+	UnlockedCount    int64      `json:"unlocked_count"`
+	Completion       float64    `json:"completion"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// GetListDirectory returns the public lists of a world, most complete first
+func (h *ListsHandler) GetListDirectory(c echo.Context) error {
+	world := strings.TrimSpace(c.QueryParam("world"))
+	if world == "" {
+		return apperror.ValidationError("World is required", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "world",
+				Reason: "World query parameter cannot be empty",
+			})
+	}
+
+	// Get page number from query parameters, default to 1
+	pageStr := c.QueryParam("page")
+	page := 1
+	if pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			return apperror.ValidationError("Invalid page number", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "page",
+					Value:  pageStr,
+					Reason: "Page must be a positive integer",
+				})
+		}
+	}
+
+	const pageSize = 20
+	offset := (page - 1) * pageSize
+
+	ctx := c.Request().Context()
+
+	lists, err := h.store.GetPublicLists(ctx, db.GetPublicListsParams{
+		World:  world,
+		Limit:  int32(pageSize),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to get public lists", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetPublicLists",
+				Table:     "lists",
+			})
+	}
+
+	creatureCount, err := h.store.CountCreatures(ctx)
+	if err != nil {
+		return apperror.DatabaseError("Failed to count creatures", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "CountCreatures",
+				Table:     "creatures",
+			})
+	}
+
+	var totalRecords int64
+	if len(lists) > 0 {
+		totalRecords = lists[0].TotalCount
+	}
+	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	entries := make([]ListDirectoryEntry, len(lists))
+	for i, l := range lists {
+		var shareCode *uuid.UUID
+		if l.ShareCodeEnabled {
+			shareCode = &l.ShareCode
+		}
+		entries[i] = ListDirectoryEntry{
+			ID:               l.ID,
+			Name:             l.Name,
+			World:            l.World,
+			ShareCode:        shareCode,
+			ApprovalRequired: l.ApprovalRequired,
+			MemberCount:      l.MemberCount,
+			ObtainedCount:    l.ObtainedCount,
+			UnlockedCount:    l.UnlockedCount,
+			Completion:       completionPercent(l.UnlockedCount, l.ScopeSize),
+			CreatedAt:        l.CreatedAt.Time,
+		}
+	}
+
+	return c.JSON(http.StatusOK, ListDirectoryResponse{
+		Lists:         entries,
+		CreatureCount: creatureCount,
+		Pagination: PaginationInfo{
+			TotalPages:   totalPages,
+			CurrentPage:  page,
+			TotalRecords: int(totalRecords),
+			PageSize:     pageSize,
+		},
+	})
+}
@@ -0,0 +1,263 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPublicList(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success - Public",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Name: "Open List", World: "Antica", Visibility: db.ListVisibilityPublic}, nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), listID).
+					Return([]db.GetListMembersRow{
+						{UserID: uuid.New(), CharacterName: "Active", ObtainedCount: 3, UnlockedCount: 1, IsActive: true},
+						{UserID: uuid.New(), CharacterName: "Claimed", IsActive: false},
+					}, nil)
+
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{Lang: "en", ListID: listID}).
+					Return([]db.GetListSoulcoresRow{
+						{
+							ListID:        listID,
+							CreatureID:    uuid.New(),
+							CreatureName:  "Dragon",
+							Status:        db.SoulcoreStatusObtained,
+							AddedBy:       pgtype.Text{String: "Active", Valid: true},
+							AddedByUserID: uuid.New(),
+						},
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Private List",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{ID: listID, Visibility: db.ListVisibilityPrivate}, nil)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "List not found",
+		},
+		{
+			name: "List Not Found",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{}, sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "List not found",
+		},
+		{
+			name: "Database Error - GetList",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetList(gomock.Any(), listID).
+					Return(db.List{}, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+
+			url := fmt.Sprintf("/api/public/lists/%s", listID)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/public/lists/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+
+			tc.setupMocks(store, listID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.GetPublicList(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			// Only active members are shown and no user IDs leak
+			var response handlers.PublicListResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Members, 1)
+			require.Equal(t, "Active", response.Members[0].CharacterName)
+			require.Len(t, response.SoulCores, 1)
+			require.NotContains(t, rec.Body.String(), "user_id")
+			require.NotContains(t, rec.Body.String(), "share_code")
+		})
+	}
+}
+
+func TestGetListDirectory(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		setupMocks    func(store *mockdb.MockStore)
+		expectedCode  int
+		expectedError string
+		checkResponse func(t *testing.T, response handlers.ListDirectoryResponse)
+	}{
+		{
+			name:  "Success",
+			query: "?world=Antica&page=2",
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPublicLists(gomock.Any(), db.GetPublicListsParams{
+						World:  "Antica",
+						Limit:  20,
+						Offset: 20,
+					}).
+					Return([]db.GetPublicListsRow{
+						{ID: uuid.New(), Name: "Open", World: "Antica", ShareCode: uuid.New(), ShareCodeEnabled: true, UnlockedCount: 50, ScopeSize: 200, TotalCount: 21},
+						{ID: uuid.New(), Name: "Invite Only", World: "Antica", ShareCode: uuid.New(), UnlockedCount: 10, ScopeSize: 40, TotalCount: 21},
+					}, nil)
+
+				store.EXPECT().
+					CountCreatures(gomock.Any()).
+					Return(int64(200), nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response handlers.ListDirectoryResponse) {
+				require.Len(t, response.Lists, 2)
+				require.Equal(t, 25.0, response.Lists[0].Completion)
+				// Completion is measured against the scope of each list
+				require.Equal(t, 25.0, response.Lists[1].Completion)
+				require.NotNil(t, response.Lists[0].ShareCode)
+				require.Nil(t, response.Lists[1].ShareCode)
+				require.Equal(t, int64(200), response.CreatureCount)
+				require.Equal(t, 2, response.Pagination.TotalPages)
+				require.Equal(t, 2, response.Pagination.CurrentPage)
+			},
+		},
+		{
+			name:  "Success - Empty World",
+			query: "?world=Secura",
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPublicLists(gomock.Any(), gomock.Any()).
+					Return([]db.GetPublicListsRow{}, nil)
+
+				store.EXPECT().
+					CountCreatures(gomock.Any()).
+					Return(int64(200), nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response handlers.ListDirectoryResponse) {
+				require.Empty(t, response.Lists)
+				require.Equal(t, 1, response.Pagination.TotalPages)
+			},
+		},
+		{
+			name:  "Missing World",
+			query: "",
+			setupMocks: func(store *mockdb.MockStore) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "World is required",
+		},
+		{
+			name:  "Invalid Page",
+			query: "?world=Antica&page=0",
+			setupMocks: func(store *mockdb.MockStore) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid page number",
+		},
+		{
+			name:  "Database Error - GetPublicLists",
+			query: "?world=Antica",
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPublicLists(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get public lists",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/public/lists"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			tc.setupMocks(store)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.GetListDirectory(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response handlers.ListDirectoryResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			tc.checkResponse(t, response)
+		})
+	}
+}
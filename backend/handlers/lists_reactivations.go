@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// GetReactivations returns the list memberships of claimed characters waiting for the user,
+// as their new owner, to accept or decline them
+func (h *ListsHandler) GetReactivations(c echo.Context) error {
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	reactivations, err := h.store.GetUserListReactivations(c.Request().Context(), userID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get list reactivations", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetUserListReactivations",
+				Table:     "list_reactivations",
+			})
+	}
+
+	return c.JSON(http.StatusOK, reactivations)
+}
+
+// GetListReactivations shows list moderators which characters of the list were claimed by
+// another player and are waiting for the new owner to take the membership over
+func (h *ListsHandler) GetListReactivations(c echo.Context) error {
+	listID, userID, err := listCharacterParams(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanViewReactivations() {
+		return apperror.AuthorizationError("Only list moderators can view reactivations", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to view reactivations",
+			})
+	}
+
+	reactivations, err := h.store.GetListReactivations(ctx, listID)
+	if err != nil {
+		return apperror.DatabaseError("Failed to get list reactivations", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListReactivations",
+				Table:     "list_reactivations",
+			})
+	}
+
+	return c.JSON(http.StatusOK, reactivations)
+}
+
+// AcceptReactivation lets the new owner of a claimed character take over the membership the
+// character had in a list. They join with their role if they are in the list already, as a
+// member otherwise.
+func (h *ListsHandler) AcceptReactivation(c echo.Context) error {
+	listID, userID, err := listCharacterParams(c)
+	if err != nil {
+		return err
+	}
+
+	characterID, err := uuid.Parse(c.Param("character_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid character ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Value:  c.Param("character_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Removed members need to be re-invited, a claimed character does not get them back in
+	isRemoved, err := h.store.IsUserRemovedFromList(ctx, db.IsUserRemovedFromListParams{
+		ListID: listID,
+		UserID: userID,
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to check list removals", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "IsUserRemovedFromList",
+				Table:     "list_removed_members",
+			})
+	}
+	if isRemoved {
+		return apperror.AuthorizationError("You were removed from this list and need to be re-invited", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  listID.String(),
+				Reason: "User was removed from the list",
+			})
+	}
+
+	var role db.ListRole
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		var err error
+		role, err = q.AcceptListReactivation(ctx, db.AcceptListReactivationParams{
+			ListID:      listID,
+			CharacterID: characterID,
+			UserID:      userID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.NotFoundError("Reactivation not found", err).
+					WithDetails(&apperror.ValidationErrorDetails{
+						Field:  "character_id",
+						Value:  characterID.String(),
+						Reason: "No pending reactivation for this character",
+					})
+			}
+			return apperror.DatabaseError("Failed to accept reactivation", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "AcceptListReactivation",
+					Table:     "list_reactivations",
+				})
+		}
+
+		return recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:  listID,
+			ActorID: userID,
+			Action:  db.ListActivityActionMemberJoined,
+		})
+	})
+	if err != nil {
+		return txError(err, "Failed to accept reactivation")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberJoined, listID, map[string]any{
+		"user_id":      userID,
+		"character_id": characterID,
+		"role":         role,
+	})
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeclineReactivation discards the membership a claimed character had in a list. The
+// previous owner's membership stays inactive.
+func (h *ListsHandler) DeclineReactivation(c echo.Context) error {
+	listID, userID, err := listCharacterParams(c)
+	if err != nil {
+		return err
+	}
+
+	characterID, err := uuid.Parse(c.Param("character_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid character ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Value:  c.Param("character_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	affected, err := h.store.DeleteListReactivation(c.Request().Context(), db.DeleteListReactivationParams{
+		ListID:      listID,
+		CharacterID: characterID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to decline reactivation", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "DeleteListReactivation",
+				Table:     "list_reactivations",
+			})
+	}
+
+	if affected == 0 {
+		return apperror.NotFoundError("Reactivation not found", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Value:  characterID.String(),
+				Reason: "No pending reactivation for this character",
+			})
+	}
+
+	return c.NoContent(http.StatusOK)
+}
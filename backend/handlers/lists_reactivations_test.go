@@ -0,0 +1,360 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetReactivations(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		expectedCount int
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, userID uuid.UUID) {
+				store.EXPECT().
+					GetUserListReactivations(gomock.Any(), userID).
+					Return([]db.GetUserListReactivationsRow{
+						{ListID: uuid.New(), ListName: "Guild Hunt", World: "Antica", CharacterID: uuid.New(), CharacterName: "Knight"},
+						{ListID: uuid.New(), ListName: "Duo", World: "Antica", CharacterID: uuid.New(), CharacterName: "Knight"},
+					}, nil)
+			},
+			expectedCode:  http.StatusOK,
+			expectedCount: 2,
+		},
+		{
+			name: "Database Error",
+			setupMocks: func(store *mockdb.MockStore, userID uuid.UUID) {
+				store.EXPECT().
+					GetUserListReactivations(gomock.Any(), userID).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get list reactivations",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			userID := uuid.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/reactivations", nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/reactivations")
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.GetReactivations(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response []db.GetUserListReactivationsRow
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response, tc.expectedCount)
+		})
+	}
+}
+
+func TestGetListReactivations(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListReactivations(gomock.Any(), listID).
+					Return([]db.GetListReactivationsRow{
+						{CharacterID: uuid.New(), CharacterName: "Knight", PreviousUserID: uuid.New(), UserID: uuid.New()},
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Member Cannot View",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can view reactivations",
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/reactivations", listID)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/reactivations")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.GetListReactivations(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response []db.GetListReactivationsRow
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response, 1)
+		})
+	}
+}
+
+func TestAcceptReactivation(t *testing.T) {
+	characterID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), db.IsUserRemovedFromListParams{ListID: listID, UserID: userID}).
+					Return(false, nil)
+
+				store.EXPECT().
+					AcceptListReactivation(gomock.Any(), db.AcceptListReactivationParams{
+						ListID:      listID,
+						CharacterID: characterID,
+						UserID:      userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:  listID,
+						ActorID: userID,
+						Action:  db.ListActivityActionMemberJoined,
+					}).
+					Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Removed From List",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(true, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "You were removed from this list",
+		},
+		{
+			name: "Reactivation Not Found",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				// Gone, declined, or the character changed hands again
+				store.EXPECT().
+					AcceptListReactivation(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Reactivation not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/reactivations/%s/accept", listID, characterID)
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/reactivations/:character_id/accept")
+			c.SetParamNames("id", "character_id")
+			c.SetParamValues(listID.String(), characterID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.AcceptReactivation(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, 0, tx.Committed)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+		})
+	}
+}
+
+func TestDeclineReactivation(t *testing.T) {
+	characterID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					DeleteListReactivation(gomock.Any(), db.DeleteListReactivationParams{
+						ListID:      listID,
+						CharacterID: characterID,
+						UserID:      userID,
+					}).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Reactivation Not Found",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					DeleteListReactivation(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Reactivation not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/reactivations/%s", listID, characterID)
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/reactivations/:character_id")
+			c.SetParamNames("id", "character_id")
+			c.SetParamValues(listID.String(), characterID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.DeclineReactivation(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// listMembership resolves the caller's role in a list through the policy
+func (h *ListsHandler) listMembership(ctx context.Context, listID, userID uuid.UUID) (services.ListMembership, error) {
+	membership, err := h.policy.ListMembership(ctx, listID, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrNotListMember) {
+			return membership, apperror.AuthorizationError("User is not a member of this list", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "list_id",
+					Value:  listID.String(),
+					Reason: "User is not a member of this list",
+				})
+		}
+		return membership, apperror.DatabaseError("Failed to check list membership", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListMemberRole",
+				Table:     "lists_users",
+			})
+	}
+
+	return membership, nil
+}
+
+// UpdateMemberRoleRequest represents the request body for changing a member's role
+type UpdateMemberRoleRequest struct {
+	Role db.ListRole `json:"role"`
+}
+
+// UpdateMemberRole changes the role of a list member
+func (h *ListsHandler) UpdateMemberRole(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	targetUserID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid user ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  c.Param("user_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req UpdateMemberRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	if !services.ValidListRole(req.Role) {
+		return apperror.ValidationError("Invalid role", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(req.Role),
+				Reason: "Role must be one of owner, moderator, member or viewer",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "User ID not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	if targetUserID == userID {
+		return apperror.ValidationError("You cannot change your own role", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  targetUserID.String(),
+				Reason: "Target is the current user",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanManageMembers() {
+		return apperror.AuthorizationError("Only list moderators can manage members", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Not authorized to manage members",
+			})
+	}
+
+	target, err := h.policy.ListMembership(ctx, listID, targetUserID)
+	if err != nil {
+		if errors.Is(err, services.ErrNotListMember) {
+			return apperror.NotFoundError("Member not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "user_id",
+					Value:  targetUserID.String(),
+					Reason: "User is not a member of this list",
+				})
+		}
+		return apperror.DatabaseError("Failed to get member role", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListMemberRole",
+				Table:     "lists_users",
+			})
+	}
+
+	if !membership.CanAssignRole(target.Role, req.Role) {
+		return apperror.AuthorizationError("Not allowed to assign this role", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(req.Role),
+				Reason: "Role change is not permitted for the current user",
+			})
+	}
+
+	_, err = h.store.UpdateListMemberRole(ctx, db.UpdateListMemberRoleParams{
+		ListID: listID,
+		UserID: targetUserID,
+		Role:   req.Role,
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to update member role", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "UpdateListMemberRole",
+				Table:     "lists_users",
+			})
+	}
+
+	publishListEvent(ctx, h.hub, services.EventMemberRoleChanged, listID, map[string]any{
+		"user_id":       targetUserID,
+		"role":          req.Role,
+		"previous_role": target.Role,
+	})
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"user_id": targetUserID,
+		"role":    req.Role,
+	})
+}
@@ -0,0 +1,258 @@
+package handlers_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateMemberRole(t *testing.T) {
+	testCases := []struct {
+		name          string
+		role          db.ListRole
+		setupRequest  func(c echo.Context)
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success - Owner Promotes Moderator",
+			role: db.ListRoleModerator,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					UpdateListMemberRole(gomock.Any(), db.UpdateListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+						Role:   db.ListRoleModerator,
+					}).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Success - Moderator Restricts Member",
+			role: db.ListRoleViewer,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					UpdateListMemberRole(gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Invalid Role",
+			role: db.ListRole("admin"),
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid role",
+		},
+		{
+			name: "Own Role",
+			role: db.ListRoleMember,
+			setupRequest: func(c echo.Context) {
+				c.SetParamValues(c.Param("id"), c.Get("user_id").(string))
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "You cannot change your own role",
+		},
+		{
+			name: "Member Cannot Manage Members",
+			role: db.ListRoleViewer,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can manage members",
+		},
+		{
+			name: "Moderator Cannot Promote to Moderator",
+			role: db.ListRoleModerator,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Not allowed to assign this role",
+		},
+		{
+			name: "Owner Role Cannot Be Assigned",
+			role: db.ListRoleOwner,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Not allowed to assign this role",
+		},
+		{
+			name: "Target Not a Member",
+			role: db.ListRoleViewer,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Member not found",
+		},
+		{
+			name: "Database Error - UpdateListMemberRole",
+			role: db.ListRoleViewer,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, targetID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: targetID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					UpdateListMemberRole(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to update member role",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+			targetID := uuid.New()
+
+			body, err := json.Marshal(handlers.UpdateMemberRoleRequest{Role: tc.role})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s/members/%s/role", listID, targetID)
+			req := httptest.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/members/:user_id/role")
+			c.SetParamNames("id", "user_id")
+			c.SetParamValues(listID.String(), targetID.String())
+			c.Set("user_id", userID.String())
+
+			if tc.setupRequest != nil {
+				tc.setupRequest(c)
+			}
+
+			tc.setupMocks(store, listID, userID, targetID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.UpdateMemberRole(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, string(tc.role), response["role"])
+		})
+	}
+}
@@ -0,0 +1,400 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// dateLayout is how target dates are written in requests and responses
+const dateLayout = "2006-01-02"
+
+// ListScope is the set of creatures a list works towards. Creature IDs narrow the scope
+// down to those creatures, excluded ones are always left out and the difficulty range
+// drops creatures outside of it. An empty scope covers every creature.
+type ListScope struct {
+	MinDifficulty       *int32      `json:"min_difficulty"`
+	MaxDifficulty       *int32      `json:"max_difficulty"`
+	CreatureIDs         []uuid.UUID `json:"creature_ids"`
+	ExcludedCreatureIDs []uuid.UUID `json:"excluded_creature_ids"`
+	TargetDate          *string     `json:"target_date"`
+}
+
+// ListProgress is how far a list got with the creatures in its scope. Percentages are
+// rounded to one decimal, days left is only set when the scope has a target date.
+type ListProgress struct {
+	ScopeSize       int64   `json:"scope_size"`
+	ObtainedCount   int64   `json:"obtained_count"`
+	UnlockedCount   int64   `json:"unlocked_count"`
+	ObtainedPercent float64 `json:"obtained_percent"`
+	UnlockedPercent float64 `json:"unlocked_percent"`
+	DaysLeft        *int    `json:"days_left,omitempty"`
+}
+
+// ListProgressGroup is how far a list got with the creatures of one bestiary class or race
+// in its scope. Name is null for creatures without bestiary data.
+type ListProgressGroup struct {
+	Name *string `json:"name"`
+	ListProgress
+}
+
+// completionPercent returns count out of total in percent, rounded to one decimal
+func completionPercent(count, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(count)/float64(total)*1000) / 10
+}
+
+// listScope loads the scope of a list. It returns nil for lists that track every creature.
+func (h *ListsHandler) listScope(ctx context.Context, listID uuid.UUID) (*ListScope, error) {
+	row, err := h.store.GetListScope(ctx, listID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperror.DatabaseError("Failed to get list scope", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListScope",
+				Table:     "list_scopes",
+			})
+	}
+
+	creatures, err := h.store.GetListScopeCreatures(ctx, listID)
+	if err != nil {
+		return nil, apperror.DatabaseError("Failed to get list scope creatures", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListScopeCreatures",
+				Table:     "list_scope_creatures",
+			})
+	}
+
+	scope := &ListScope{
+		CreatureIDs:         []uuid.UUID{},
+		ExcludedCreatureIDs: []uuid.UUID{},
+	}
+	if row.MinDifficulty.Valid {
+		scope.MinDifficulty = &row.MinDifficulty.Int32
+	}
+	if row.MaxDifficulty.Valid {
+		scope.MaxDifficulty = &row.MaxDifficulty.Int32
+	}
+	if row.TargetDate.Valid {
+		date := row.TargetDate.Time.Format(dateLayout)
+		scope.TargetDate = &date
+	}
+	for _, sc := range creatures {
+		if sc.Excluded {
+			scope.ExcludedCreatureIDs = append(scope.ExcludedCreatureIDs, sc.CreatureID)
+		} else {
+			scope.CreatureIDs = append(scope.CreatureIDs, sc.CreatureID)
+		}
+	}
+
+	return scope, nil
+}
+
+// listProgress counts how far a list got with the creatures in its scope
+func (h *ListsHandler) listProgress(ctx context.Context, listID uuid.UUID, scope *ListScope, now time.Time) (ListProgress, error) {
+	row, err := h.store.GetListProgress(ctx, listID)
+	if err != nil {
+		return ListProgress{}, apperror.DatabaseError("Failed to get list progress", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListProgress",
+				Table:     "lists_soulcores",
+			})
+	}
+
+	progress := ListProgress{
+		ScopeSize:       row.ScopeSize,
+		ObtainedCount:   row.ObtainedCount,
+		UnlockedCount:   row.UnlockedCount,
+		ObtainedPercent: completionPercent(row.ObtainedCount, row.ScopeSize),
+		UnlockedPercent: completionPercent(row.UnlockedCount, row.ScopeSize),
+	}
+	if scope != nil && scope.TargetDate != nil {
+		if target, err := time.Parse(dateLayout, *scope.TargetDate); err == nil {
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+			daysLeft := int(target.Sub(today).Hours() / 24)
+			progress.DaysLeft = &daysLeft
+		}
+	}
+
+	return progress, nil
+}
+
+// GetListProgressGroups breaks the progress of a list down by the bestiary class of the
+// creatures in its scope, or by their race with by=race
+func (h *ListsHandler) GetListProgressGroups(c echo.Context) error {
+	listID, userID, err := listCharacterParams(c)
+	if err != nil {
+		return err
+	}
+
+	groupBy := c.QueryParam("by")
+	if groupBy == "" {
+		groupBy = "class"
+	}
+	if groupBy != "class" && groupBy != "race" {
+		return apperror.ValidationError("Invalid grouping", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "by",
+				Value:  groupBy,
+				Reason: "Progress can be grouped by class or race",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	if _, err := h.listMembership(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	rows, err := h.store.GetListProgressGroups(ctx, db.GetListProgressGroupsParams{
+		GroupBy: groupBy,
+		ListID:  listID,
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to get list progress", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListProgressGroups",
+				Table:     "lists_soulcores",
+			})
+	}
+
+	groups := make([]ListProgressGroup, len(rows))
+	for i, row := range rows {
+		groups[i] = ListProgressGroup{
+			ListProgress: ListProgress{
+				ScopeSize:       row.ScopeSize,
+				ObtainedCount:   row.ObtainedCount,
+				UnlockedCount:   row.UnlockedCount,
+				ObtainedPercent: completionPercent(row.ObtainedCount, row.ScopeSize),
+				UnlockedPercent: completionPercent(row.UnlockedCount, row.ScopeSize),
+			},
+		}
+		if row.GroupName.Valid {
+			groups[i].Name = &row.GroupName.String
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"by":     groupBy,
+		"groups": groups,
+	})
+}
+
+// UpdateListScope replaces the scope of a list. Progress, member stats and hunt
+// recommendations of the list are computed against the creatures in scope.
+func (h *ListsHandler) UpdateListScope(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req ListScope
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	for _, d := range []struct {
+		field string
+		value *int32
+	}{{"min_difficulty", req.MinDifficulty}, {"max_difficulty", req.MaxDifficulty}} {
+		if d.value != nil && (*d.value < 0 || *d.value > services.MaxCreatureDifficulty) {
+			return apperror.ValidationError("Invalid difficulty", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  d.field,
+					Reason: "Difficulty must be between 0 and 5",
+				})
+		}
+	}
+	if req.MinDifficulty != nil && req.MaxDifficulty != nil && *req.MinDifficulty > *req.MaxDifficulty {
+		return apperror.ValidationError("Invalid difficulty range", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "min_difficulty",
+				Reason: "Minimum difficulty cannot be above the maximum",
+			})
+	}
+
+	var targetDate pgtype.Date
+	if req.TargetDate != nil {
+		date, err := time.Parse(dateLayout, *req.TargetDate)
+		if err != nil {
+			return apperror.ValidationError("Invalid target date", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "target_date",
+					Value:  *req.TargetDate,
+					Reason: "Target date must be formatted as YYYY-MM-DD",
+				})
+		}
+		targetDate = pgtype.Date{Time: date, Valid: true}
+	}
+
+	included := make(map[uuid.UUID]bool, len(req.CreatureIDs))
+	for _, id := range req.CreatureIDs {
+		included[id] = true
+	}
+	for _, id := range req.ExcludedCreatureIDs {
+		if included[id] {
+			return apperror.ValidationError("Creature cannot be both included and excluded", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "excluded_creature_ids",
+					Value:  id.String(),
+					Reason: "Creature is also listed in creature_ids",
+				})
+		}
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanEditList() {
+		return apperror.AuthorizationError("Only list moderators can change the list scope", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userID.String(),
+				Reason: "Not authorized to change list scope",
+			})
+	}
+
+	creatureIDs := append(append([]uuid.UUID{}, req.CreatureIDs...), req.ExcludedCreatureIDs...)
+	if len(creatureIDs) > 0 {
+		known, err := h.store.GetCreatureIDs(ctx, creatureIDs)
+		if err != nil {
+			return apperror.DatabaseError("Failed to get creatures", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetCreatureIDs",
+					Table:     "creatures",
+				})
+		}
+		isKnown := make(map[uuid.UUID]bool, len(known))
+		for _, id := range known {
+			isKnown[id] = true
+		}
+		for _, id := range creatureIDs {
+			if !isKnown[id] {
+				return apperror.ValidationError("Unknown creature in scope", nil).
+					WithDetails(&apperror.ValidationErrorDetails{
+						Field:  "creature_ids",
+						Value:  id.String(),
+						Reason: "Creature does not exist",
+					})
+			}
+		}
+	}
+
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		params := db.UpsertListScopeParams{
+			ListID:     listID,
+			TargetDate: targetDate,
+		}
+		if req.MinDifficulty != nil {
+			params.MinDifficulty = pgtype.Int4{Int32: *req.MinDifficulty, Valid: true}
+		}
+		if req.MaxDifficulty != nil {
+			params.MaxDifficulty = pgtype.Int4{Int32: *req.MaxDifficulty, Valid: true}
+		}
+		if _, err := q.UpsertListScope(ctx, params); err != nil {
+			return apperror.DatabaseError("Failed to update list scope", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "UpsertListScope",
+					Table:     "list_scopes",
+				})
+		}
+
+		if err := q.DeleteListScopeCreatures(ctx, listID); err != nil {
+			return apperror.DatabaseError("Failed to update list scope creatures", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "DeleteListScopeCreatures",
+					Table:     "list_scope_creatures",
+				})
+		}
+
+		for _, set := range []db.AddListScopeCreaturesParams{
+			{ListID: listID, CreatureIds: req.CreatureIDs, Excluded: false},
+			{ListID: listID, CreatureIds: req.ExcludedCreatureIDs, Excluded: true},
+		} {
+			if len(set.CreatureIds) == 0 {
+				continue
+			}
+			err := q.AddListScopeCreatures(ctx, set)
+			if err != nil {
+				return apperror.DatabaseError("Failed to update list scope creatures", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "AddListScopeCreatures",
+						Table:     "list_scope_creatures",
+					})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to update list scope")
+	}
+
+	scope, err := h.listScope(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	progress, err := h.listProgress(ctx, listID, scope, time.Now())
+	if err != nil {
+		return err
+	}
+
+	publishListEvent(ctx, h.hub, services.EventListUpdated, listID, map[string]any{
+		"scope":      scope,
+		"progress":   progress,
+		"updated_by": userID,
+	})
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"scope":    scope,
+		"progress": progress,
+	})
+}
@@ -0,0 +1,327 @@
+package handlers_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateListScope(t *testing.T) {
+	dragonID := uuid.New()
+	ratID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		body          string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			body: fmt.Sprintf(`{"max_difficulty":2,"excluded_creature_ids":["%s"],"target_date":"2026-12-24"}`, ratID),
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetCreatureIDs(gomock.Any(), []uuid.UUID{ratID}).
+					Return([]uuid.UUID{ratID}, nil)
+
+				store.EXPECT().
+					UpsertListScope(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.UpsertListScopeParams) (db.ListScope, error) {
+						require.False(t, arg.MinDifficulty.Valid)
+						require.Equal(t, pgtype.Int4{Int32: 2, Valid: true}, arg.MaxDifficulty)
+						require.Equal(t, "2026-12-24", arg.TargetDate.Time.Format("2006-01-02"))
+						return db.ListScope{ListID: listID}, nil
+					})
+
+				store.EXPECT().
+					DeleteListScopeCreatures(gomock.Any(), listID).
+					Return(nil)
+
+				store.EXPECT().
+					AddListScopeCreatures(gomock.Any(), db.AddListScopeCreaturesParams{
+						ListID:      listID,
+						CreatureIds: []uuid.UUID{ratID},
+						Excluded:    true,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					GetListScope(gomock.Any(), listID).
+					Return(db.ListScope{ListID: listID, MaxDifficulty: pgtype.Int4{Int32: 2, Valid: true}}, nil)
+
+				store.EXPECT().
+					GetListScopeCreatures(gomock.Any(), listID).
+					Return([]db.GetListScopeCreaturesRow{{CreatureID: ratID, Excluded: true}}, nil)
+
+				store.EXPECT().
+					GetListProgress(gomock.Any(), listID).
+					Return(db.GetListProgressRow{ScopeSize: 166}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Invalid Difficulty Range",
+			body:          `{"min_difficulty":3,"max_difficulty":1}`,
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid difficulty range",
+		},
+		{
+			name:          "Invalid Target Date",
+			body:          `{"target_date":"24.12.2026"}`,
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid target date",
+		},
+		{
+			name:          "Creature Included and Excluded",
+			body:          fmt.Sprintf(`{"creature_ids":["%s"],"excluded_creature_ids":["%s"]}`, dragonID, dragonID),
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Creature cannot be both included and excluded",
+		},
+		{
+			name: "Member Cannot Change Scope",
+			body: `{"max_difficulty":2}`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Only list moderators can change the list scope",
+		},
+		{
+			name: "Unknown Creature",
+			body: fmt.Sprintf(`{"creature_ids":["%s"]}`, dragonID),
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetCreatureIDs(gomock.Any(), gomock.Any()).
+					Return([]uuid.UUID{}, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Unknown creature in scope",
+		},
+		{
+			name: "Database Error - UpsertListScope",
+			body: `{"min_difficulty":1}`,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					UpsertListScope(gomock.Any(), gomock.Any()).
+					Return(db.ListScope{}, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to update list scope",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/scope", listID)
+			req := httptest.NewRequest(http.MethodPut, url, strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/scope")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.UpdateListScope(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response struct {
+				Scope    handlers.ListScope    `json:"scope"`
+				Progress handlers.ListProgress `json:"progress"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, []uuid.UUID{ratID}, response.Scope.ExcludedCreatureIDs)
+			require.Equal(t, int64(166), response.Progress.ScopeSize)
+		})
+	}
+}
+
+func TestGetListProgressGroups(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		setupMocks    func(store *mockdb.MockStore, listID uuid.UUID)
+		expectedCode  int
+		expectedError string
+		checkResponse func(t *testing.T, groups []handlers.ListProgressGroup)
+	}{
+		{
+			name: "Success - By Class",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListProgressGroups(gomock.Any(), db.GetListProgressGroupsParams{GroupBy: "class", ListID: listID}).
+					Return([]db.GetListProgressGroupsRow{
+						{GroupName: pgtype.Text{String: "Dragons", Valid: true}, ScopeSize: 8, ObtainedCount: 3, UnlockedCount: 1},
+						{ScopeSize: 3},
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, groups []handlers.ListProgressGroup) {
+				require.Len(t, groups, 2)
+				require.Equal(t, "Dragons", *groups[0].Name)
+				require.Equal(t, int64(3), groups[0].ObtainedCount)
+				require.Equal(t, 37.5, groups[0].ObtainedPercent)
+				require.Equal(t, 12.5, groups[0].UnlockedPercent)
+				// Creatures without bestiary data
+				require.Nil(t, groups[1].Name)
+				require.Equal(t, float64(0), groups[1].ObtainedPercent)
+			},
+		},
+		{
+			name:  "Success - By Race",
+			query: "?by=race",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListProgressGroups(gomock.Any(), db.GetListProgressGroupsParams{GroupBy: "race", ListID: listID}).
+					Return([]db.GetListProgressGroupsRow{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, groups []handlers.ListProgressGroup) {
+				require.Empty(t, groups)
+			},
+		},
+		{
+			name:          "Invalid Grouping",
+			query:         "?by=location",
+			setupMocks:    func(store *mockdb.MockStore, listID uuid.UUID) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid grouping",
+		},
+		{
+			name: "Not a Member",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRole(""), sql.ErrNoRows)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "User is not a member of this list",
+		},
+		{
+			name: "Database Error",
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListProgressGroups(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to get list progress",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			listID := uuid.New()
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/progress/groups%s", listID, tc.query)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/progress/groups")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.GetListProgressGroups(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response struct {
+				By     string                       `json:"by"`
+				Groups []handlers.ListProgressGroup `json:"groups"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			tc.checkResponse(t, response.Groups)
+		})
+	}
+}
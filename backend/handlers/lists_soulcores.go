@@ -1,18 +1,27 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 )
 
+// SoulcoreUndoWindow is how long a removed soulcore can be restored before it is purged.
+// It defaults to a day and can be changed at startup.
+var SoulcoreUndoWindow = 24 * time.Hour
+
 // UpdateSoulcoreStatus updates the status of a soul core in a list
 func (h *ListsHandler) UpdateSoulcoreStatus(c echo.Context) error {
 	listID, err := uuid.Parse(c.Param("id"))
@@ -26,8 +35,9 @@ func (h *ListsHandler) UpdateSoulcoreStatus(c echo.Context) error {
 	}
 
 	var req struct {
-		CreatureID uuid.UUID         `json:"creature_id"`
-		Status     db.SoulcoreStatus `json:"status"`
+		CreatureID        uuid.UUID         `json:"creature_id"`
+		Status            db.SoulcoreStatus `json:"status"`
+		ReservedForUserID uuid.UUID         `json:"reserved_for_user_id"`
 	}
 	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
 		return apperror.ValidationError("Invalid request body", err).
@@ -37,6 +47,10 @@ func (h *ListsHandler) UpdateSoulcoreStatus(c echo.Context) error {
 			})
 	}
 
+	if err := validateSoulcoreStatus(req.Status, req.ReservedForUserID); err != nil {
+		return err
+	}
+
 	// Get authenticated user ID from context
 	userIDStr, ok := c.Get("user_id").(string)
 	if !ok {
@@ -59,26 +73,10 @@ func (h *ListsHandler) UpdateSoulcoreStatus(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Check if user is a member of the list
-	isMember, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
-		ListID: listID,
-		UserID: userID,
-	})
+	// Check the user's role in the list
+	membership, err := h.listMembership(ctx, listID, userID)
 	if err != nil {
-		return apperror.DatabaseError("Failed to check list membership", err).
-			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "IsUserListMember",
-				Table:     "list_members",
-			})
-	}
-
-	if !isMember {
-		return apperror.AuthorizationError("User is not a member of this list", nil).
-			WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "list_id",
-				Value:  listID.String(),
-				Reason: "User is not a member",
-			})
+		return err
 	}
 
 	// Get the soulcore to check ownership
@@ -101,19 +99,9 @@ func (h *ListsHandler) UpdateSoulcoreStatus(c echo.Context) error {
 			})
 	}
 
-	// Get list details to check if user is the owner
-	list, err := h.store.GetList(ctx, listID)
-	if err != nil {
-		return apperror.DatabaseError("Failed to get list details", err).
-			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "GetList",
-				Table:     "lists",
-			})
-	}
-
-	// Allow both the soulcore adder and list owner to modify it
-	if soulcore.AddedByUserID != userID && list.AuthorID != userID {
-		return apperror.AuthorizationError("Only the list owner or the user who added the soulcore can modify it", nil).
+	// Allow both the soulcore adder and list moderators to modify it
+	if !membership.CanModifySoulcore(soulcore.AddedByUserID) {
+		return apperror.AuthorizationError("Only list moderators or the user who added the soulcore can modify it", nil).
 			WithDetails(&apperror.ValidationErrorDetails{
 				Field:  "user_id",
 				Value:  userID.String(),
@@ -121,75 +109,71 @@ func (h *ListsHandler) UpdateSoulcoreStatus(c echo.Context) error {
 			})
 	}
 
-	// Update soul core status
-	err = h.store.UpdateSoulcoreStatus(ctx, db.UpdateSoulcoreStatusParams{
-		ListID:     listID,
-		CreatureID: req.CreatureID,
-		Status:     req.Status,
-	})
-	if err != nil {
-		return apperror.DatabaseError("Failed to update soul core status", err).
-			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "UpdateSoulcoreStatus",
-				Table:     "list_soulcores",
+	if !membership.CanChangeSoulcoreStatus(soulcore.Status, req.Status) {
+		return apperror.ValidationError("Invalid soulcore status change", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "status",
+				Value:  string(req.Status),
+				Reason: "A soulcore cannot go from " + string(soulcore.Status) + " to " + string(req.Status),
 			})
 	}
 
-	if req.Status == "unlocked" {
-		members, err := h.store.GetListMembersWithUnlocks(ctx, listID)
+	if err := h.checkReservation(ctx, listID, req.ReservedForUserID); err != nil {
+		return err
+	}
+
+	// Update soul core status
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		updated, err := q.UpdateSoulcoreStatus(ctx, db.UpdateSoulcoreStatusParams{
+			ListID:            listID,
+			CreatureID:        req.CreatureID,
+			Status:            req.Status,
+			ReservedForUserID: req.ReservedForUserID,
+			FromStatus:        soulcore.Status,
+		})
 		if err != nil {
-			appErr := apperror.DatabaseError("Failed to get list members", err).
+			return apperror.DatabaseError("Failed to update soul core status", err).
 				WithDetails(&apperror.DatabaseErrorDetails{
-					Operation: "GetListMembersWithUnlocks",
-					Table:     "lists_users",
+					Operation: "UpdateSoulcoreStatus",
+					Table:     "list_soulcores",
 				})
-			appErr.LogError()
-		} else {
-			for _, member := range members {
-				if !member.IsActive {
-					continue
-				}
-
-				if member.UserID == soulcore.AddedByUserID {
-					err = h.store.AddCharacterSoulcore(ctx, db.AddCharacterSoulcoreParams{
-						CharacterID: member.CharacterID,
-						CreatureID:  req.CreatureID,
-					})
-					if err != nil {
-						if !isUniqueConstraintViolation(err) {
-							appErr := apperror.DatabaseError("Failed to add soulcore to character", err).
-								WithDetails(&apperror.DatabaseErrorDetails{
-									Operation: "AddCharacterSoulcore",
-									Table:     "characters_soulcores",
-								})
-							appErr.LogError()
-						}
-					}
-				} else {
-					err = h.store.CreateSoulcoreSuggestion(ctx, db.CreateSoulcoreSuggestionParams{
-						CharacterID: member.CharacterID,
-						CreatureID:  req.CreatureID,
-						ListID:      listID,
-					})
-					if err != nil {
-						if !isUniqueConstraintViolation(err) {
-							appErr := apperror.DatabaseError("Failed to create soulcore suggestion", err).
-								WithDetails(&apperror.DatabaseErrorDetails{
-									Operation: "CreateSoulcoreSuggestion",
-									Table:     "character_soulcore_suggestions",
-								})
-							appErr.LogError()
-						}
-					}
-				}
-			}
 		}
+		if updated == 0 {
+			return soulcoreStatusConflict(req.CreatureID, soulcore.Status)
+		}
+
+		return recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:       listID,
+			ActorID:      userID,
+			Action:       db.ListActivityActionSoulcoreStatusChanged,
+			CreatureID:   req.CreatureID,
+			TargetUserID: req.ReservedForUserID,
+			OldValue:     activityValue(string(soulcore.Status)),
+			NewValue:     activityValue(string(req.Status)),
+		})
+	})
+	if err != nil {
+		return txError(err, "Failed to update soul core status")
+	}
+
+	publishListEvent(ctx, h.hub, services.EventSoulcoreStatusChanged, listID, map[string]any{
+		"creature_id":          req.CreatureID,
+		"creature_name":        soulcore.CreatureName,
+		"status":               req.Status,
+		"previous_status":      soulcore.Status,
+		"reserved_for_user_id": req.ReservedForUserID,
+		"user_id":              userID,
+	})
+
+	if req.Status == db.SoulcoreStatusUnlocked {
+		h.shareUnlock(ctx, listID, req.CreatureID, soulcore.AddedByUserID)
 	}
 
 	return c.NoContent(http.StatusOK)
 }
 
-// AddSoulcore adds a new soul core to a list
+// AddSoulcore adds a new soul core to a list. Re-adding a removed soul core restores it,
+// adding one the list already has is a conflict.
 func (h *ListsHandler) AddSoulcore(c echo.Context) error {
 	listID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -202,8 +186,9 @@ func (h *ListsHandler) AddSoulcore(c echo.Context) error {
 	}
 
 	var req struct {
-		CreatureID uuid.UUID         `json:"creature_id"`
-		Status     db.SoulcoreStatus `json:"status"`
+		CreatureID        uuid.UUID         `json:"creature_id"`
+		Status            db.SoulcoreStatus `json:"status"`
+		ReservedForUserID uuid.UUID         `json:"reserved_for_user_id"`
 	}
 	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
 		return apperror.ValidationError("Invalid request body", err).
@@ -213,6 +198,10 @@ func (h *ListsHandler) AddSoulcore(c echo.Context) error {
 			})
 	}
 
+	if err := validateSoulcoreStatus(req.Status, req.ReservedForUserID); err != nil {
+		return err
+	}
+
 	// Get authenticated user ID from context
 	userIDStr, ok := c.Get("user_id").(string)
 	if !ok {
@@ -235,41 +224,62 @@ func (h *ListsHandler) AddSoulcore(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Check if user is a member of the list
-	isMember, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
-		ListID: listID,
-		UserID: userID,
-	})
+	// Check the user's role in the list
+	membership, err := h.listMembership(ctx, listID, userID)
 	if err != nil {
-		return apperror.DatabaseError("Failed to check list membership", err).
-			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "IsUserListMember",
-				Table:     "list_members",
-			})
+		return err
 	}
 
-	if !isMember {
-		return apperror.AuthorizationError("User is not a member of this list", nil).
+	if !membership.CanContribute() {
+		return apperror.AuthorizationError("Viewers cannot add soulcores", nil).
 			WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "list_id",
-				Value:  listID.String(),
-				Reason: "User is not a member",
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Role does not allow adding soulcores",
 			})
 	}
 
-	// Add soul core with the user ID who added it
-	err = h.store.AddSoulcoreToList(ctx, db.AddSoulcoreToListParams{
-		ListID:        listID,
-		CreatureID:    req.CreatureID,
-		Status:        req.Status,
-		AddedByUserID: userID,
+	if err := h.checkReservation(ctx, listID, req.ReservedForUserID); err != nil {
+		return err
+	}
+
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		// Only removed entries are replaced, changing a soulcore the list has goes through
+		// UpdateSoulcoreStatus and its permission and lifecycle checks
+		added, err := q.AddSoulcoreToListIfMissing(ctx, db.AddSoulcoreToListIfMissingParams{
+			ListID:            listID,
+			CreatureID:        req.CreatureID,
+			Status:            req.Status,
+			AddedByUserID:     userID,
+			ReservedForUserID: req.ReservedForUserID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to add soul core", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "AddSoulcoreToListIfMissing",
+					Table:     "list_soulcores",
+				})
+		}
+		if added == 0 {
+			return apperror.ConflictError("Soul core is already in the list", nil).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "creature_id",
+					Value:  req.CreatureID.String(),
+					Reason: "Update the soul core's status instead",
+				})
+		}
+
+		return recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:       listID,
+			ActorID:      userID,
+			Action:       db.ListActivityActionSoulcoreAdded,
+			CreatureID:   req.CreatureID,
+			TargetUserID: req.ReservedForUserID,
+			NewValue:     activityValue(string(req.Status)),
+		})
 	})
 	if err != nil {
-		return apperror.DatabaseError("Failed to add soul core", err).
-			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "AddSoulcoreToList",
-				Table:     "list_soulcores",
-			})
+		return txError(err, "Failed to add soul core")
 	}
 
 	return c.NoContent(http.StatusOK)
@@ -319,26 +329,10 @@ func (h *ListsHandler) RemoveSoulcore(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Check if user is a member of the list
-	isMember, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
-		ListID: listID,
-		UserID: userID,
-	})
+	// Check the user's role in the list
+	membership, err := h.listMembership(ctx, listID, userID)
 	if err != nil {
-		return apperror.DatabaseError("Failed to check list membership", err).
-			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "IsUserListMember",
-				Table:     "list_members",
-			})
-	}
-
-	if !isMember {
-		return apperror.AuthorizationError("User is not a member of this list", nil).
-			WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "list_id",
-				Value:  listID.String(),
-				Reason: "User is not a member",
-			})
+		return err
 	}
 
 	// Get the soulcore to check ownership
@@ -361,40 +355,304 @@ func (h *ListsHandler) RemoveSoulcore(c echo.Context) error {
 			})
 	}
 
-	// Get list details to check if user is the owner
-	list, err := h.store.GetList(ctx, listID)
+	// Allow both the soulcore adder and list moderators to remove it
+	if !membership.CanModifySoulcore(soulcore.AddedByUserID) {
+		return apperror.AuthorizationError("Only list moderators or the user who added the soulcore can remove it", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userID.String(),
+				Reason: "Not authorized to remove soulcore",
+			})
+	}
+
+	// Mark the soulcore as removed, it stays restorable for SoulcoreUndoWindow
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		err := q.RemoveListSoulcore(ctx, db.RemoveListSoulcoreParams{
+			ListID:     listID,
+			CreatureID: creatureID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to remove soul core", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "RemoveListSoulcore",
+					Table:     "list_soulcores",
+				})
+		}
+
+		return recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:     listID,
+			ActorID:    userID,
+			Action:     db.ListActivityActionSoulcoreRemoved,
+			CreatureID: creatureID,
+			OldValue:   activityValue(string(soulcore.Status)),
+		})
+	})
+	if err != nil {
+		return txError(err, "Failed to remove soul core")
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"restorable_until": time.Now().Add(SoulcoreUndoWindow),
+	})
+}
+
+// RestoreSoulcore undoes the removal of a soulcore while it is still within SoulcoreUndoWindow
+func (h *ListsHandler) RestoreSoulcore(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	creatureID, err := uuid.Parse(c.Param("creature_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid creature ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "creature_id",
+				Value:  c.Param("creature_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Check the user's role in the list
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	soulcore, err := h.store.GetRemovedListSoulcore(ctx, db.GetRemovedListSoulcoreParams{
+		ListID:          listID,
+		CreatureID:      creatureID,
+		RestorableSince: pgtype.Timestamptz{Time: time.Now().Add(-SoulcoreUndoWindow), Valid: true},
+	})
 	if err != nil {
-		return apperror.DatabaseError("Failed to get list details", err).
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Removed soulcore not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "creature_id",
+					Value:  creatureID.String(),
+					Reason: "Soulcore is not removed or past its undo window",
+				})
+		}
+		return apperror.DatabaseError("Failed to get removed soulcore", err).
 			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "GetList",
-				Table:     "lists",
+				Operation: "GetRemovedListSoulcore",
+				Table:     "list_soulcores",
 			})
 	}
 
-	// Allow both the soulcore adder and list owner to remove it
-	if soulcore.AddedByUserID != userID && list.AuthorID != userID {
-		return apperror.AuthorizationError("Only the list owner or the user who added the soulcore can remove it", nil).
+	// Whoever could remove the soulcore can bring it back
+	if !membership.CanModifySoulcore(soulcore.AddedByUserID) {
+		return apperror.AuthorizationError("Only list moderators or the user who added the soulcore can restore it", nil).
 			WithDetails(&apperror.ValidationErrorDetails{
 				Field:  "user_id",
 				Value:  userID.String(),
-				Reason: "Not authorized to remove soulcore",
+				Reason: "Not authorized to restore soulcore",
 			})
 	}
 
-	// Delete the soulcore from the list
-	err = h.store.RemoveListSoulcore(ctx, db.RemoveListSoulcoreParams{
-		ListID:     listID,
-		CreatureID: creatureID,
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		err := q.RestoreListSoulcore(ctx, db.RestoreListSoulcoreParams{
+			ListID:     listID,
+			CreatureID: creatureID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to restore soul core", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "RestoreListSoulcore",
+					Table:     "list_soulcores",
+				})
+		}
+
+		return recordActivity(ctx, q, db.CreateListActivityParams{
+			ListID:     listID,
+			ActorID:    userID,
+			Action:     db.ListActivityActionSoulcoreRestored,
+			CreatureID: creatureID,
+			NewValue:   activityValue(string(soulcore.Status)),
+		})
 	})
 	if err != nil {
-		return apperror.DatabaseError("Failed to remove soul core", err).
+		return txError(err, "Failed to restore soul core")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// PurgeRemovedSoulcores permanently deletes list and character soulcores whose undo window has passed
+func (h *ListsHandler) PurgeRemovedSoulcores() error {
+	ctx := context.Background()
+	deletedBefore := pgtype.Timestamptz{Time: time.Now().Add(-SoulcoreUndoWindow), Valid: true}
+
+	listCount, err := h.store.PurgeRemovedListSoulcores(ctx, deletedBefore)
+	if err != nil {
+		return apperror.DatabaseError("Failed to purge removed list soulcores", err).
 			WithDetails(&apperror.DatabaseErrorDetails{
-				Operation: "RemoveListSoulcore",
+				Operation: "PurgeRemovedListSoulcores",
 				Table:     "list_soulcores",
+			}).
+			Wrap(err)
+	}
+
+	characterCount, err := h.store.PurgeRemovedCharacterSoulcores(ctx, deletedBefore)
+	if err != nil {
+		return apperror.DatabaseError("Failed to purge removed character soulcores", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "PurgeRemovedCharacterSoulcores",
+				Table:     "character_soulcores",
+			}).
+			Wrap(err)
+	}
+
+	if listCount > 0 || characterCount > 0 {
+		slog.Info("purged removed soulcores", "list_count", listCount, "character_count", characterCount)
+	}
+
+	return nil
+}
+
+// validateSoulcoreStatus checks that status is known and that a member to earmark the
+// core for is given exactly when the status is reserved
+func validateSoulcoreStatus(status db.SoulcoreStatus, reservedFor uuid.UUID) error {
+	if !services.ValidSoulcoreStatus(status) {
+		return apperror.ValidationError("Invalid soulcore status", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "status",
+				Value:  string(status),
+				Reason: "Status must be wanted, reserved, obtained, traded or unlocked",
 			})
 	}
 
-	return c.NoContent(http.StatusOK)
+	reserved := reservedFor != uuid.Nil
+	if services.SoulcoreStatusNeedsReservation(status) != reserved {
+		return apperror.ValidationError("Invalid soulcore reservation", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "reserved_for_user_id",
+				Value:  reservedFor.String(),
+				Reason: "Reserved soulcores, and only those, need the member they are reserved for",
+			})
+	}
+	return nil
+}
+
+// soulcoreStatusConflict reports a soulcore whose status changed since it was checked
+func soulcoreStatusConflict(creatureID uuid.UUID, checked db.SoulcoreStatus) error {
+	return apperror.ConflictError("Soulcore status changed in the meantime", nil).
+		WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "creature_id",
+			Value:  creatureID.String(),
+			Reason: "The soulcore is no longer " + string(checked),
+		})
+}
+
+// checkReservation makes sure a soulcore is only earmarked for an active member of the list
+func (h *ListsHandler) checkReservation(ctx context.Context, listID, reservedFor uuid.UUID) error {
+	if reservedFor == uuid.Nil {
+		return nil
+	}
+
+	isMember, err := h.store.IsUserListMember(ctx, db.IsUserListMemberParams{
+		ListID: listID,
+		UserID: reservedFor,
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to check list membership", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "IsUserListMember",
+				Table:     "lists_users",
+			})
+	}
+
+	if !isMember {
+		return apperror.ValidationError("Soulcores can only be reserved for list members", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "reserved_for_user_id",
+				Value:  reservedFor.String(),
+				Reason: "User is not an active member of the list",
+			})
+	}
+	return nil
+}
+
+// shareUnlock hands an unlocked soulcore to the active members of a list. The member who
+// added it gets it on their character right away, everyone else gets a suggestion.
+// Failures are only logged, as the unlock itself already succeeded.
+func (h *ListsHandler) shareUnlock(ctx context.Context, listID, creatureID, addedByUserID uuid.UUID) {
+	members, err := h.store.GetListMembersWithUnlocks(ctx, listID)
+	if err != nil {
+		appErr := apperror.DatabaseError("Failed to get list members", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetListMembersWithUnlocks",
+				Table:     "lists_users",
+			})
+		appErr.LogError()
+		return
+	}
+
+	for _, member := range members {
+		if !member.IsActive {
+			continue
+		}
+
+		if member.UserID == addedByUserID {
+			_, err = h.store.AddCharacterSoulcore(ctx, db.AddCharacterSoulcoreParams{
+				CharacterID: member.CharacterID,
+				CreatureID:  creatureID,
+			})
+			if err != nil {
+				if !isUniqueConstraintViolation(err) {
+					appErr := apperror.DatabaseError("Failed to add soulcore to character", err).
+						WithDetails(&apperror.DatabaseErrorDetails{
+							Operation: "AddCharacterSoulcore",
+							Table:     "characters_soulcores",
+						})
+					appErr.LogError()
+				}
+			}
+		} else {
+			err = h.store.CreateSoulcoreSuggestion(ctx, db.CreateSoulcoreSuggestionParams{
+				CharacterID: member.CharacterID,
+				CreatureID:  creatureID,
+				ListID:      listID,
+			})
+			if err != nil {
+				if !isUniqueConstraintViolation(err) {
+					appErr := apperror.DatabaseError("Failed to create soulcore suggestion", err).
+						WithDetails(&apperror.DatabaseErrorDetails{
+							Operation: "CreateSoulcoreSuggestion",
+							Table:     "character_soulcore_suggestions",
+						})
+					appErr.LogError()
+				}
+			}
+		}
+	}
 }
 
 // isUniqueConstraintViolation checks if an error is from a database unique constraint violation
@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+)
+
+// BatchSoulcore is a single soulcore of a batch request
+type BatchSoulcore struct {
+	CreatureID        uuid.UUID         `json:"creature_id"`
+	Status            db.SoulcoreStatus `json:"status"`
+	ReservedForUserID uuid.UUID         `json:"reserved_for_user_id"`
+}
+
+// BatchSoulcoresRequest represents the request body for adding or updating many soulcores at once
+type BatchSoulcoresRequest struct {
+	Soulcores []BatchSoulcore `json:"soulcores"`
+}
+
+// validate checks the whole batch up front, so no item is applied when any of them is malformed
+func (r BatchSoulcoresRequest) validate() error {
+	for _, s := range r.Soulcores {
+		if err := validateSoulcoreStatus(s.Status, s.ReservedForUserID); err != nil {
+			return err
+		}
+	}
+	return validateBatchCreatureIDs("soulcores", r.creatureIDs())
+}
+
+// checkReservations makes sure every member the batch reserves soulcores for belongs to the list
+func (h *ListsHandler) checkReservations(ctx context.Context, listID uuid.UUID, soulcores []BatchSoulcore) error {
+	checked := make(map[uuid.UUID]bool)
+	for _, s := range soulcores {
+		if checked[s.ReservedForUserID] {
+			continue
+		}
+		if err := h.checkReservation(ctx, listID, s.ReservedForUserID); err != nil {
+			return err
+		}
+		checked[s.ReservedForUserID] = true
+	}
+	return nil
+}
+
+func (r BatchSoulcoresRequest) creatureIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(r.Soulcores))
+	for i, s := range r.Soulcores {
+		ids[i] = s.CreatureID
+	}
+	return ids
+}
+
+// AddSoulcores adds many soulcores to a list in one transaction. Soulcores the list
+// already has are left untouched and unknown creatures are skipped.
+func (h *ListsHandler) AddSoulcores(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req BatchSoulcoresRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	if err := req.validate(); err != nil {
+		return err
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Check the user's role in the list
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !membership.CanContribute() {
+		return apperror.AuthorizationError("Viewers cannot add soulcores", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "role",
+				Value:  string(membership.Role),
+				Reason: "Role does not allow adding soulcores",
+			})
+	}
+
+	if err := h.checkReservations(ctx, listID, req.Soulcores); err != nil {
+		return err
+	}
+
+	var results []BatchItemResult
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		results = make([]BatchItemResult, len(req.Soulcores))
+
+		known, err := q.GetCreatureIDs(ctx, req.creatureIDs())
+		if err != nil {
+			return apperror.DatabaseError("Failed to get creatures", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetCreatureIDs",
+					Table:     "creatures",
+				})
+		}
+		isKnown := make(map[uuid.UUID]bool, len(known))
+		for _, id := range known {
+			isKnown[id] = true
+		}
+
+		for i, s := range req.Soulcores {
+			results[i] = BatchItemResult{CreatureID: s.CreatureID, Result: BatchResultNotFound}
+			if !isKnown[s.CreatureID] {
+				continue
+			}
+
+			added, err := q.AddSoulcoreToListIfMissing(ctx, db.AddSoulcoreToListIfMissingParams{
+				ListID:            listID,
+				CreatureID:        s.CreatureID,
+				Status:            s.Status,
+				AddedByUserID:     userID,
+				ReservedForUserID: s.ReservedForUserID,
+			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to add soul core", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "AddSoulcoreToListIfMissing",
+						Table:     "list_soulcores",
+					})
+			}
+
+			if added == 0 {
+				results[i].Result = BatchResultAlreadyPresent
+				continue
+			}
+
+			err = recordActivity(ctx, q, db.CreateListActivityParams{
+				ListID:       listID,
+				ActorID:      userID,
+				Action:       db.ListActivityActionSoulcoreAdded,
+				CreatureID:   s.CreatureID,
+				TargetUserID: s.ReservedForUserID,
+				NewValue:     activityValue(string(s.Status)),
+			})
+			if err != nil {
+				return err
+			}
+			results[i].Result = BatchResultAdded
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to add soul cores")
+	}
+
+	return c.JSON(http.StatusOK, newBatchResponse(results))
+}
+
+// UpdateSoulcoreStatuses changes the status of many soulcores of a list in one transaction.
+// Soulcores the user may not modify are reported as forbidden and changes the lifecycle
+// does not allow as invalid_transition, both are left as they are.
+func (h *ListsHandler) UpdateSoulcoreStatuses(c echo.Context) error {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid list ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "list_id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req BatchSoulcoresRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	if err := req.validate(); err != nil {
+		return err
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Check the user's role in the list
+	membership, err := h.listMembership(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.checkReservations(ctx, listID, req.Soulcores); err != nil {
+		return err
+	}
+
+	var results []BatchItemResult
+	var updated []db.GetListSoulcoresRow
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		results = make([]BatchItemResult, len(req.Soulcores))
+		updated = nil
+
+		soulcores, err := q.GetListSoulcores(ctx, db.GetListSoulcoresParams{ListID: listID})
+		if err != nil {
+			return apperror.DatabaseError("Failed to get soul cores", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetListSoulcores",
+					Table:     "lists_soulcores",
+				})
+		}
+		byCreature := make(map[uuid.UUID]db.GetListSoulcoresRow, len(soulcores))
+		for _, s := range soulcores {
+			byCreature[s.CreatureID] = s
+		}
+
+		for i, s := range req.Soulcores {
+			results[i] = BatchItemResult{CreatureID: s.CreatureID}
+
+			soulcore, ok := byCreature[s.CreatureID]
+			switch {
+			case !ok:
+				results[i].Result = BatchResultNotFound
+				continue
+			case !membership.CanModifySoulcore(soulcore.AddedByUserID):
+				results[i].Result = BatchResultForbidden
+				continue
+			case soulcore.Status == s.Status && soulcore.ReservedForUserID == s.ReservedForUserID:
+				results[i].Result = BatchResultUnchanged
+				continue
+			case !membership.CanChangeSoulcoreStatus(soulcore.Status, s.Status):
+				results[i].Result = BatchResultInvalidTransition
+				continue
+			}
+
+			changed, err := q.UpdateSoulcoreStatus(ctx, db.UpdateSoulcoreStatusParams{
+				ListID:            listID,
+				CreatureID:        s.CreatureID,
+				Status:            s.Status,
+				ReservedForUserID: s.ReservedForUserID,
+				FromStatus:        soulcore.Status,
+			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to update soul core status", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "UpdateSoulcoreStatus",
+						Table:     "list_soulcores",
+					})
+			}
+			if changed == 0 {
+				return soulcoreStatusConflict(s.CreatureID, soulcore.Status)
+			}
+
+			err = recordActivity(ctx, q, db.CreateListActivityParams{
+				ListID:       listID,
+				ActorID:      userID,
+				Action:       db.ListActivityActionSoulcoreStatusChanged,
+				CreatureID:   s.CreatureID,
+				TargetUserID: s.ReservedForUserID,
+				OldValue:     activityValue(string(soulcore.Status)),
+				NewValue:     activityValue(string(s.Status)),
+			})
+			if err != nil {
+				return err
+			}
+
+			results[i].Result = BatchResultUpdated
+			updated = append(updated, soulcore)
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to update soul core statuses")
+	}
+
+	changes := make(map[uuid.UUID]BatchSoulcore, len(req.Soulcores))
+	for _, s := range req.Soulcores {
+		changes[s.CreatureID] = s
+	}
+
+	for _, soulcore := range updated {
+		change := changes[soulcore.CreatureID]
+		publishListEvent(ctx, h.hub, services.EventSoulcoreStatusChanged, listID, map[string]any{
+			"creature_id":          soulcore.CreatureID,
+			"creature_name":        soulcore.CreatureName,
+			"status":               change.Status,
+			"previous_status":      soulcore.Status,
+			"reserved_for_user_id": change.ReservedForUserID,
+			"user_id":              userID,
+		})
+
+		if change.Status == db.SoulcoreStatusUnlocked {
+			h.shareUnlock(ctx, listID, soulcore.CreatureID, soulcore.AddedByUserID)
+		}
+	}
+
+	return c.JSON(http.StatusOK, newBatchResponse(results))
+}
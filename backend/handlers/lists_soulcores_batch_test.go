@@ -0,0 +1,513 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/sergot/tibiacores/backend/handlers"
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddSoulcores(t *testing.T) {
+	testCases := []struct {
+		name            string
+		buildBody       func(creatureIDs []uuid.UUID) handlers.BatchSoulcoresRequest
+		setupMocks      func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID)
+		expectedCode    int
+		expectedError   string
+		expectedResults []handlers.BatchResult
+		rolledBack      bool
+	}{
+		{
+			name: "Success",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchSoulcoresRequest {
+				return handlers.BatchSoulcoresRequest{Soulcores: []handlers.BatchSoulcore{
+					{CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained},
+					{CreatureID: creatureIDs[1], Status: db.SoulcoreStatusUnlocked},
+					{CreatureID: creatureIDs[2], Status: db.SoulcoreStatusObtained},
+				}}
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreatureIDs(gomock.Any(), creatureIDs[:3]).
+					Return(creatureIDs[:2], nil)
+
+				store.EXPECT().
+					AddSoulcoreToListIfMissing(gomock.Any(), db.AddSoulcoreToListIfMissingParams{
+						ListID:        listID,
+						CreatureID:    creatureIDs[0],
+						Status:        db.SoulcoreStatusObtained,
+						AddedByUserID: userID,
+					}).
+					Return(int64(1), nil)
+
+				// Only soulcores that were actually added show up in the activity log
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:     listID,
+						ActorID:    userID,
+						Action:     db.ListActivityActionSoulcoreAdded,
+						CreatureID: creatureIDs[0],
+						NewValue:   pgtype.Text{String: string(db.SoulcoreStatusObtained), Valid: true},
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					AddSoulcoreToListIfMissing(gomock.Any(), db.AddSoulcoreToListIfMissingParams{
+						ListID:        listID,
+						CreatureID:    creatureIDs[1],
+						Status:        db.SoulcoreStatusUnlocked,
+						AddedByUserID: userID,
+					}).
+					Return(int64(0), nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultAdded,
+				handlers.BatchResultAlreadyPresent,
+				handlers.BatchResultNotFound,
+			},
+		},
+		{
+			name: "Empty Batch",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchSoulcoresRequest {
+				return handlers.BatchSoulcoresRequest{}
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Batch is empty",
+		},
+		{
+			name: "Duplicate Creature",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchSoulcoresRequest {
+				return handlers.BatchSoulcoresRequest{Soulcores: []handlers.BatchSoulcore{
+					{CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained},
+					{CreatureID: creatureIDs[0], Status: db.SoulcoreStatusUnlocked},
+				}}
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Duplicate creature in batch",
+		},
+		{
+			name: "Invalid Status",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchSoulcoresRequest {
+				return handlers.BatchSoulcoresRequest{Soulcores: []handlers.BatchSoulcore{
+					{CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained},
+					{CreatureID: creatureIDs[1], Status: "lost"},
+				}}
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid soulcore status",
+		},
+		{
+			name: "Viewer Cannot Add",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchSoulcoresRequest {
+				return handlers.BatchSoulcoresRequest{Soulcores: []handlers.BatchSoulcore{
+					{CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained},
+				}}
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleViewer, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Viewers cannot add soulcores",
+		},
+		{
+			name: "Database Error - AddSoulcoreToListIfMissing",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchSoulcoresRequest {
+				return handlers.BatchSoulcoresRequest{Soulcores: []handlers.BatchSoulcore{
+					{CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained},
+					{CreatureID: creatureIDs[1], Status: db.SoulcoreStatusObtained},
+				}}
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetCreatureIDs(gomock.Any(), gomock.Any()).
+					Return(creatureIDs[:2], nil)
+
+				store.EXPECT().
+					AddSoulcoreToListIfMissing(gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					AddSoulcoreToListIfMissing(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to add soul core",
+			rolledBack:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+			creatureIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+			body, err := json.Marshal(tc.buildBody(creatureIDs))
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s/soulcores/batch", listID)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/soulcores/batch")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, listID, userID, creatureIDs)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err = h.AddSoulcores(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, tc.rolledBack, tx.RolledBack > 0)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+
+			var response handlers.BatchResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Results, len(tc.expectedResults))
+			for i, result := range response.Results {
+				require.Equal(t, creatureIDs[i], result.CreatureID)
+				require.Equal(t, tc.expectedResults[i], result.Result)
+			}
+		})
+	}
+}
+
+func TestUpdateSoulcoreStatuses(t *testing.T) {
+	testCases := []struct {
+		name            string
+		role            db.ListRole
+		setupMocks      func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID)
+		expectedCode    int
+		expectedError   string
+		expectedResults []handlers.BatchResult
+		expectedEvents  int
+		rolledBack      bool
+	}{
+		{
+			name: "Success - Member",
+			role: db.ListRoleMember,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return([]db.GetListSoulcoresRow{
+						{ListID: listID, CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained, AddedByUserID: userID},
+						{ListID: listID, CreatureID: creatureIDs[1], Status: db.SoulcoreStatusObtained, AddedByUserID: uuid.New()},
+						{ListID: listID, CreatureID: creatureIDs[2], Status: db.SoulcoreStatusUnlocked, AddedByUserID: userID},
+					}, nil)
+
+				store.EXPECT().
+					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
+						ListID:     listID,
+						CreatureID: creatureIDs[0],
+						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
+					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:     listID,
+						ActorID:    userID,
+						Action:     db.ListActivityActionSoulcoreStatusChanged,
+						CreatureID: creatureIDs[0],
+						OldValue:   pgtype.Text{String: string(db.SoulcoreStatusObtained), Valid: true},
+						NewValue:   pgtype.Text{String: string(db.SoulcoreStatusUnlocked), Valid: true},
+					}).
+					Return(nil)
+
+				// The unlock is shared with the list after the commit
+				store.EXPECT().
+					GetListMembersWithUnlocks(gomock.Any(), listID).
+					Return([]db.GetListMembersWithUnlocksRow{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultUpdated,
+				handlers.BatchResultForbidden,
+				handlers.BatchResultUnchanged,
+				handlers.BatchResultNotFound,
+			},
+			expectedEvents: 1,
+		},
+		{
+			name: "Success - Moderator",
+			role: db.ListRoleModerator,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return([]db.GetListSoulcoresRow{
+						{ListID: listID, CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained, AddedByUserID: uuid.New()},
+						{ListID: listID, CreatureID: creatureIDs[1], Status: db.SoulcoreStatusObtained, AddedByUserID: uuid.New()},
+						{ListID: listID, CreatureID: creatureIDs[2], Status: db.SoulcoreStatusUnlocked, AddedByUserID: uuid.New()},
+					}, nil)
+
+				store.EXPECT().
+					UpdateSoulcoreStatus(gomock.Any(), gomock.Any()).
+					Return(int64(1), nil).
+					Times(2)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+
+				store.EXPECT().
+					GetListMembersWithUnlocks(gomock.Any(), listID).
+					Return([]db.GetListMembersWithUnlocksRow{}, nil).
+					Times(2)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultUpdated,
+				handlers.BatchResultUpdated,
+				handlers.BatchResultUnchanged,
+				handlers.BatchResultNotFound,
+			},
+			expectedEvents: 2,
+		},
+		{
+			name: "Invalid Transition",
+			role: db.ListRoleMember,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return([]db.GetListSoulcoresRow{
+						{ListID: listID, CreatureID: creatureIDs[0], Status: db.SoulcoreStatusWanted, AddedByUserID: userID},
+						{ListID: listID, CreatureID: creatureIDs[1], Status: db.SoulcoreStatusTraded, AddedByUserID: userID},
+						{ListID: listID, CreatureID: creatureIDs[2], Status: db.SoulcoreStatusUnlocked, AddedByUserID: userID},
+					}, nil)
+
+				// Only the traded core is unlocked, a wanted one has to be obtained first
+				store.EXPECT().
+					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
+						ListID:     listID,
+						CreatureID: creatureIDs[1],
+						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusTraded,
+					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					GetListMembersWithUnlocks(gomock.Any(), listID).
+					Return([]db.GetListMembersWithUnlocksRow{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultInvalidTransition,
+				handlers.BatchResultUpdated,
+				handlers.BatchResultUnchanged,
+				handlers.BatchResultNotFound,
+			},
+			expectedEvents: 1,
+		},
+		{
+			name: "Database Error - UpdateSoulcoreStatus",
+			role: db.ListRoleModerator,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return([]db.GetListSoulcoresRow{
+						{ListID: listID, CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained, AddedByUserID: userID},
+						{ListID: listID, CreatureID: creatureIDs[1], Status: db.SoulcoreStatusObtained, AddedByUserID: userID},
+					}, nil)
+
+				store.EXPECT().
+					UpdateSoulcoreStatus(gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					UpdateSoulcoreStatus(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to update soul core status",
+			rolledBack:    true,
+		},
+		{
+			name: "Soulcore Status Changed",
+			role: db.ListRoleModerator,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return([]db.GetListSoulcoresRow{
+						{ListID: listID, CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained, AddedByUserID: userID},
+					}, nil)
+
+				// Another member changed the status after it was read
+				store.EXPECT().
+					UpdateSoulcoreStatus(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "Soulcore status changed in the meantime",
+			rolledBack:    true,
+		},
+		{
+			name: "Database Error - CreateListActivity",
+			role: db.ListRoleModerator,
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetListSoulcores(gomock.Any(), db.GetListSoulcoresParams{ListID: listID}).
+					Return([]db.GetListSoulcoresRow{
+						{ListID: listID, CreatureID: creatureIDs[0], Status: db.SoulcoreStatusObtained, AddedByUserID: userID},
+					}, nil)
+
+				store.EXPECT().
+					UpdateSoulcoreStatus(gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to record list activity",
+			rolledBack:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			listID := uuid.New()
+			userID := uuid.New()
+			creatureIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+
+			// Every creature is unlocked, the third one already was
+			soulcores := make([]handlers.BatchSoulcore, len(creatureIDs))
+			for i, id := range creatureIDs {
+				soulcores[i] = handlers.BatchSoulcore{CreatureID: id, Status: db.SoulcoreStatusUnlocked}
+			}
+			body, err := json.Marshal(handlers.BatchSoulcoresRequest{Soulcores: soulcores})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/lists/%s/soulcores/batch", listID)
+			req := httptest.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/soulcores/batch")
+			c.SetParamNames("id")
+			c.SetParamValues(listID.String())
+			c.Set("user_id", userID.String())
+
+			store.EXPECT().
+				GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+					ListID: listID,
+					UserID: userID,
+				}).
+				Return(tc.role, nil)
+
+			tc.setupMocks(store, listID, userID, creatureIDs)
+
+			// Record the status changes published to the list
+			bus := services.NewMemoryEventBus()
+			var events []services.ListEvent
+			bus.Subscribe(func(ctx context.Context, event services.ListEvent) {
+				events = append(events, event)
+			})
+
+			h := handlers.NewListsHandler(store, services.NewHub(bus))
+			err = h.UpdateSoulcoreStatuses(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, tc.rolledBack, tx.RolledBack > 0)
+				require.Empty(t, events)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Len(t, events, tc.expectedEvents)
+
+			var response handlers.BatchResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Results, len(tc.expectedResults))
+			for i, result := range response.Results {
+				require.Equal(t, creatureIDs[i], result.CreatureID)
+				require.Equal(t, tc.expectedResults[i], result.Result)
+			}
+		})
+	}
+}
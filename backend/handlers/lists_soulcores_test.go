@@ -9,13 +9,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 	mockdb "github.com/sergot/tibiacores/backend/db/mock"
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/handlers"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -36,24 +39,55 @@ func TestAddSoulcore(t *testing.T) {
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				// Check if user is a member
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				// Add soulcore to list
 				store.EXPECT().
-					AddSoulcoreToList(gomock.Any(), db.AddSoulcoreToListParams{
+					AddSoulcoreToListIfMissing(gomock.Any(), db.AddSoulcoreToListIfMissingParams{
 						ListID:        listID,
 						CreatureID:    creatureID,
 						Status:        db.SoulcoreStatusObtained,
 						AddedByUserID: userID,
 					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:     listID,
+						ActorID:    userID,
+						Action:     db.ListActivityActionSoulcoreAdded,
+						CreatureID: creatureID,
+						NewValue:   pgtype.Text{String: string(db.SoulcoreStatusObtained), Valid: true},
+					}).
 					Return(nil)
 			},
 			expectedCode: "success",
 		},
+		{
+			name: "Soulcore Already in List",
+			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				// Another member's live soulcore is left alone
+				store.EXPECT().
+					AddSoulcoreToListIfMissing(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+			},
+			expectedCode:  "conflict_error",
+			expectedError: "Soul core is already in the list",
+		},
 		{
 			name: "Invalid List ID",
 			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
@@ -84,15 +118,31 @@ func TestAddSoulcore(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(false, nil)
+					Return(db.ListRole(""), sql.ErrNoRows)
 			},
 			expectedCode:  "authorization_error",
 			expectedError: "User is not a member of this list",
 		},
+		{
+			name: "Viewer Cannot Add Soulcore",
+			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleViewer, nil)
+			},
+			expectedCode:  "authorization_error",
+			expectedError: "Viewers cannot add soulcores",
+		},
 		{
 			name: "Error Adding Soulcore",
 			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
@@ -100,20 +150,20 @@ func TestAddSoulcore(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
-					AddSoulcoreToList(gomock.Any(), db.AddSoulcoreToListParams{
+					AddSoulcoreToListIfMissing(gomock.Any(), db.AddSoulcoreToListIfMissingParams{
 						ListID:        listID,
 						CreatureID:    creatureID,
 						Status:        db.SoulcoreStatusObtained,
 						AddedByUserID: userID,
 					}).
-					Return(errors.New("database error"))
+					Return(int64(0), errors.New("database error"))
 			},
 			expectedCode:  "database_error",
 			expectedError: "Failed to add soul core",
@@ -143,6 +193,7 @@ func TestAddSoulcore(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
 			listID := uuid.New()
 			creatureID := uuid.New()
 			userID := uuid.New()
@@ -178,7 +229,7 @@ func TestAddSoulcore(t *testing.T) {
 			tc.setupMocks(store, listID, creatureID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err = h.AddSoulcore(c)
 
 			// Check for expected error response
@@ -216,11 +267,11 @@ func TestRemoveSoulcore(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -235,16 +286,19 @@ func TestRemoveSoulcore(t *testing.T) {
 					}, nil)
 
 				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: uuid.New(), // Different user is the owner
-					}, nil)
+					RemoveListSoulcore(gomock.Any(), db.RemoveListSoulcoreParams{
+						ListID:     listID,
+						CreatureID: creatureID,
+					}).
+					Return(nil)
 
 				store.EXPECT().
-					RemoveListSoulcore(gomock.Any(), db.RemoveListSoulcoreParams{
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
 						ListID:     listID,
+						ActorID:    userID,
+						Action:     db.ListActivityActionSoulcoreRemoved,
 						CreatureID: creatureID,
+						OldValue:   pgtype.Text{String: string(db.SoulcoreStatusObtained), Valid: true},
 					}).
 					Return(nil)
 			},
@@ -257,11 +311,11 @@ func TestRemoveSoulcore(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleOwner, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -275,19 +329,16 @@ func TestRemoveSoulcore(t *testing.T) {
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: userID, // Current user is the owner
-					}, nil)
-
 				store.EXPECT().
 					RemoveListSoulcore(gomock.Any(), db.RemoveListSoulcoreParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 					}).
 					Return(nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
 			},
 			expectedCode: "success",
 		},
@@ -320,11 +371,11 @@ func TestRemoveSoulcore(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(false, nil)
+					Return(db.ListRole(""), sql.ErrNoRows)
 			},
 			expectedCode:  "authorization_error",
 			expectedError: "User is not a member of this list",
@@ -336,11 +387,11 @@ func TestRemoveSoulcore(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -359,11 +410,11 @@ func TestRemoveSoulcore(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -376,48 +427,9 @@ func TestRemoveSoulcore(t *testing.T) {
 						AddedByUserID: uuid.New(), // Different user added the soulcore
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
-
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: uuid.New(), // Different user is the owner
-					}, nil)
 			},
 			expectedCode:  "authorization_error",
-			expectedError: "Only the list owner or the user who added the soulcore can remove it",
-		},
-		{
-			name: "Error Getting List",
-			setupRequest: func(c echo.Context) {
-				// Default setup is fine
-			},
-			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
-				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
-						ListID: listID,
-						UserID: userID,
-					}).
-					Return(true, nil)
-
-				store.EXPECT().
-					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
-						ListID:     listID,
-						CreatureID: creatureID,
-					}).
-					Return(db.GetListSoulcoreRow{
-						ListID:        listID,
-						CreatureID:    creatureID,
-						AddedByUserID: uuid.New(),
-						Status:        db.SoulcoreStatusObtained,
-					}, nil)
-
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{}, errors.New("database error"))
-			},
-			expectedCode:  "database_error",
-			expectedError: "Failed to get list details",
+			expectedError: "Only list moderators or the user who added the soulcore can remove it",
 		},
 		{
 			name: "Database Error",
@@ -426,11 +438,11 @@ func TestRemoveSoulcore(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -444,13 +456,6 @@ func TestRemoveSoulcore(t *testing.T) {
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: uuid.New(),
-					}, nil)
-
 				store.EXPECT().
 					RemoveListSoulcore(gomock.Any(), db.RemoveListSoulcoreParams{
 						ListID:     listID,
@@ -470,6 +475,7 @@ func TestRemoveSoulcore(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
 			userID := uuid.New()
 
 			// Create HTTP request
@@ -494,7 +500,7 @@ func TestRemoveSoulcore(t *testing.T) {
 			tc.setupMocks(store, listID, creatureID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err := h.RemoveSoulcore(c)
 
 			// Check for expected error response
@@ -514,9 +520,161 @@ func TestRemoveSoulcore(t *testing.T) {
 	}
 }
 
+func TestRestoreSoulcore(t *testing.T) {
+	listID := uuid.New()
+	creatureID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, userID uuid.UUID)
+		expectedCode  string
+		expectedError string
+	}{
+		{
+			name: "Success - Soulcore Adder",
+			setupMocks: func(store *mockdb.MockStore, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetRemovedListSoulcore(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.GetRemovedListSoulcoreParams) (db.GetRemovedListSoulcoreRow, error) {
+						require.Equal(t, listID, arg.ListID)
+						require.Equal(t, creatureID, arg.CreatureID)
+						require.WithinDuration(t, time.Now().Add(-handlers.SoulcoreUndoWindow), arg.RestorableSince.Time, time.Minute)
+						return db.GetRemovedListSoulcoreRow{
+							ListID:        listID,
+							CreatureID:    creatureID,
+							AddedByUserID: userID,
+							Status:        db.SoulcoreStatusObtained,
+						}, nil
+					})
+
+				store.EXPECT().
+					RestoreListSoulcore(gomock.Any(), db.RestoreListSoulcoreParams{
+						ListID:     listID,
+						CreatureID: creatureID,
+					}).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:     listID,
+						ActorID:    userID,
+						Action:     db.ListActivityActionSoulcoreRestored,
+						CreatureID: creatureID,
+						NewValue:   pgtype.Text{String: string(db.SoulcoreStatusObtained), Valid: true},
+					}).
+					Return(nil)
+			},
+			expectedCode: "success",
+		},
+		{
+			name: "Not Restorable",
+			setupMocks: func(store *mockdb.MockStore, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetRemovedListSoulcore(gomock.Any(), gomock.Any()).
+					Return(db.GetRemovedListSoulcoreRow{}, sql.ErrNoRows)
+			},
+			expectedCode:  "not_found_error",
+			expectedError: "Removed soulcore not found",
+		},
+		{
+			name: "Unauthorized - Other Member",
+			setupMocks: func(store *mockdb.MockStore, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetRemovedListSoulcore(gomock.Any(), gomock.Any()).
+					Return(db.GetRemovedListSoulcoreRow{
+						ListID:        listID,
+						CreatureID:    creatureID,
+						AddedByUserID: uuid.New(),
+						Status:        db.SoulcoreStatusObtained,
+					}, nil)
+			},
+			expectedCode:  "authorization_error",
+			expectedError: "Only list moderators or the user who added the soulcore can restore it",
+		},
+		{
+			name: "Database Error - RestoreListSoulcore",
+			setupMocks: func(store *mockdb.MockStore, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleOwner, nil)
+
+				store.EXPECT().
+					GetRemovedListSoulcore(gomock.Any(), gomock.Any()).
+					Return(db.GetRemovedListSoulcoreRow{
+						ListID:        listID,
+						CreatureID:    creatureID,
+						AddedByUserID: userID,
+						Status:        db.SoulcoreStatusObtained,
+					}, nil)
+
+				store.EXPECT().
+					RestoreListSoulcore(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectedCode:  "database_error",
+			expectedError: "Failed to restore soul core",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
+			userID := uuid.New()
+
+			url := fmt.Sprintf("/api/lists/%s/soulcores/%s/restore", listID.String(), creatureID.String())
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/lists/:id/soulcores/:creature_id/restore")
+			c.Set("user_id", userID.String())
+			c.SetParamNames("id", "creature_id")
+			c.SetParamValues(listID.String(), creatureID.String())
+
+			tc.setupMocks(store, userID)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			err := h.RestoreSoulcore(c)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				appErr, ok := err.(*apperror.AppError)
+				require.True(t, ok)
+				require.Equal(t, tc.expectedCode, appErr.Code)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
 func TestUpdateSoulcoreStatus(t *testing.T) {
 	listID := uuid.New()
 	creatureID := uuid.New()
+	reservedFor := uuid.New()
 
 	testCases := []struct {
 		name          string
@@ -532,11 +690,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -550,18 +708,23 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: uuid.New(), // Different user is the owner
-					}, nil)
-
 				store.EXPECT().
 					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
+					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:     listID,
+						ActorID:    userID,
+						Action:     db.ListActivityActionSoulcoreStatusChanged,
+						CreatureID: creatureID,
+						OldValue:   pgtype.Text{String: string(db.SoulcoreStatusObtained), Valid: true},
+						NewValue:   pgtype.Text{String: string(db.SoulcoreStatusUnlocked), Valid: true},
 					}).
 					Return(nil)
 
@@ -586,7 +749,7 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 				// Expect adding directly to the adder's character
 				store.EXPECT().
 					AddCharacterSoulcore(gomock.Any(), gomock.Any()).
-					Return(nil)
+					Return(int64(1), nil)
 
 				// Expect creating a suggestion for the other character
 				store.EXPECT().
@@ -605,11 +768,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 				adderID := uuid.New()
 
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleOwner, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -623,19 +786,17 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: userID, // Current user is the owner
-					}, nil)
-
 				store.EXPECT().
 					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
 					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				// Expect call to get list members
@@ -659,7 +820,7 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 				// Expect attempting to add to both characters
 				store.EXPECT().
 					AddCharacterSoulcore(gomock.Any(), gomock.Any()).
-					Return(nil).
+					Return(int64(1), nil).
 					Times(1)
 
 				store.EXPECT().
@@ -670,7 +831,7 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			expectedCode: "success",
 		},
 		{
-			name: "Success - Update to Obtained Status",
+			name: "Success - Owner Reverts Unlock",
 			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
 				reqBody.Reset()
 				err := json.NewEncoder(reqBody).Encode(map[string]any{
@@ -681,11 +842,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleOwner, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -699,23 +860,168 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 						Status:        db.SoulcoreStatusUnlocked,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: uuid.New(),
-					}, nil)
-
 				store.EXPECT().
 					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 						Status:     db.SoulcoreStatusObtained,
+						FromStatus: db.SoulcoreStatusUnlocked,
 					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 			expectedCode: "success",
 		},
+		{
+			name: "Invalid Transition - Member Reverts Unlock",
+			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
+				reqBody.Reset()
+				err := json.NewEncoder(reqBody).Encode(map[string]any{
+					"creature_id": creatureID,
+					"status":      db.SoulcoreStatusObtained,
+				})
+				require.NoError(t, err)
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleModerator, nil)
+
+				store.EXPECT().
+					GetListSoulcore(gomock.Any(), gomock.Any()).
+					Return(db.GetListSoulcoreRow{
+						ListID:        listID,
+						CreatureID:    creatureID,
+						AddedByUserID: userID,
+						Status:        db.SoulcoreStatusUnlocked,
+					}, nil)
+			},
+			expectedCode:  "validation_error",
+			expectedError: "Invalid soulcore status change",
+		},
+		{
+			name: "Success - Reserve For Member",
+			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
+				reqBody.Reset()
+				err := json.NewEncoder(reqBody).Encode(map[string]any{
+					"creature_id":          creatureID,
+					"status":               db.SoulcoreStatusReserved,
+					"reserved_for_user_id": reservedFor,
+				})
+				require.NoError(t, err)
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListSoulcore(gomock.Any(), gomock.Any()).
+					Return(db.GetListSoulcoreRow{
+						ListID:        listID,
+						CreatureID:    creatureID,
+						AddedByUserID: userID,
+						Status:        db.SoulcoreStatusObtained,
+					}, nil)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+						ListID: listID,
+						UserID: reservedFor,
+					}).
+					Return(true, nil)
+
+				store.EXPECT().
+					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
+						ListID:            listID,
+						CreatureID:        creatureID,
+						Status:            db.SoulcoreStatusReserved,
+						ReservedForUserID: reservedFor,
+						FromStatus:        db.SoulcoreStatusObtained,
+					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), db.CreateListActivityParams{
+						ListID:       listID,
+						ActorID:      userID,
+						Action:       db.ListActivityActionSoulcoreStatusChanged,
+						CreatureID:   creatureID,
+						TargetUserID: reservedFor,
+						OldValue:     pgtype.Text{String: string(db.SoulcoreStatusObtained), Valid: true},
+						NewValue:     pgtype.Text{String: string(db.SoulcoreStatusReserved), Valid: true},
+					}).
+					Return(nil)
+			},
+			expectedCode: "success",
+		},
+		{
+			name: "Reserved For Non-Member",
+			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
+				reqBody.Reset()
+				err := json.NewEncoder(reqBody).Encode(map[string]any{
+					"creature_id":          creatureID,
+					"status":               db.SoulcoreStatusReserved,
+					"reserved_for_user_id": reservedFor,
+				})
+				require.NoError(t, err)
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), gomock.Any()).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListSoulcore(gomock.Any(), gomock.Any()).
+					Return(db.GetListSoulcoreRow{
+						ListID:        listID,
+						CreatureID:    creatureID,
+						AddedByUserID: userID,
+						Status:        db.SoulcoreStatusObtained,
+					}, nil)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+			},
+			expectedCode:  "validation_error",
+			expectedError: "Soulcores can only be reserved for list members",
+		},
+		{
+			name: "Reserved Without Member",
+			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
+				reqBody.Reset()
+				err := json.NewEncoder(reqBody).Encode(map[string]any{
+					"creature_id": creatureID,
+					"status":      db.SoulcoreStatusReserved,
+				})
+				require.NoError(t, err)
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  "validation_error",
+			expectedError: "Invalid soulcore reservation",
+		},
+		{
+			name: "Invalid Status",
+			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
+				reqBody.Reset()
+				err := json.NewEncoder(reqBody).Encode(map[string]any{
+					"creature_id": creatureID,
+					"status":      "lost",
+				})
+				require.NoError(t, err)
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  "validation_error",
+			expectedError: "Invalid soulcore status",
+		},
 		{
 			name: "Invalid List ID",
 			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
@@ -746,11 +1052,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(false, nil)
+					Return(db.ListRole(""), sql.ErrNoRows)
 			},
 			expectedCode:  "authorization_error",
 			expectedError: "User is not a member of this list",
@@ -762,11 +1068,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -785,11 +1091,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -802,16 +1108,9 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 						AddedByUserID: uuid.New(), // Different user added the soulcore
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
-
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: uuid.New(), // Different user is the owner
-					}, nil)
 			},
 			expectedCode:  "authorization_error",
-			expectedError: "Only the list owner or the user who added the soulcore can modify it",
+			expectedError: "Only list moderators or the user who added the soulcore can modify it",
 		},
 		{
 			name: "Database Error",
@@ -820,11 +1119,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			},
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -839,22 +1138,54 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 					}, nil)
 
 				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: uuid.New(),
+					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
+						ListID:     listID,
+						CreatureID: creatureID,
+						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
+					}).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  "database_error",
+			expectedError: "Failed to update soul core status",
+		},
+		{
+			name: "Soulcore Status Changed",
+			setupRequest: func(c echo.Context, reqBody *bytes.Buffer) {
+				// Default setup is fine
+			},
+			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
+						ListID: listID,
+						UserID: userID,
+					}).
+					Return(db.ListRoleMember, nil)
+
+				store.EXPECT().
+					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
+						ListID:     listID,
+						CreatureID: creatureID,
+					}).
+					Return(db.GetListSoulcoreRow{
+						ListID:        listID,
+						CreatureID:    creatureID,
+						AddedByUserID: userID,
+						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
+				// Another member changed the status after it was checked
 				store.EXPECT().
 					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
 					}).
-					Return(errors.New("database error"))
+					Return(int64(0), nil)
 			},
-			expectedCode:  "database_error",
-			expectedError: "Failed to update soul core status",
+			expectedCode:  "conflict_error",
+			expectedError: "Soulcore status changed in the meantime",
 		},
 		{
 			name: "Error Creating Suggestions",
@@ -867,11 +1198,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 				otherCharacterID := uuid.New()
 
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -885,19 +1216,17 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: uuid.New(),
-					}, nil)
-
 				store.EXPECT().
 					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
 					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				// Expect call to get list members
@@ -921,7 +1250,7 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 				// Expect adding soulcore to current user's character
 				store.EXPECT().
 					AddCharacterSoulcore(gomock.Any(), gomock.Any()).
-					Return(nil)
+					Return(int64(1), nil)
 
 				// Expect creating a suggestion for the other user's character that returns an error
 				store.EXPECT().
@@ -941,11 +1270,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 				otherCharacterID := uuid.New()
 
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -959,19 +1288,17 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{
-						ID:       listID,
-						AuthorID: uuid.New(),
-					}, nil)
-
 				store.EXPECT().
 					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
 					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				// Expect call to get list members
@@ -996,7 +1323,7 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 				duplicateError := errors.New("pq: duplicate key value violates unique constraint")
 				store.EXPECT().
 					AddCharacterSoulcore(gomock.Any(), gomock.Any()).
-					Return(duplicateError)
+					Return(int64(0), duplicateError)
 
 				// Simulate duplicate key error for other user's character
 				store.EXPECT().
@@ -1013,11 +1340,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				// Basic setup for authentication and authorization
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -1031,16 +1358,17 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{ID: listID}, nil)
-
 				store.EXPECT().
 					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
 					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				// Return mix of active and inactive members
@@ -1064,7 +1392,7 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 				// Expect only one call for the active member
 				store.EXPECT().
 					AddCharacterSoulcore(gomock.Any(), gomock.Any()).
-					Return(nil).
+					Return(int64(1), nil).
 					Times(1)
 				// No calls expected for suggestion creation
 			},
@@ -1078,11 +1406,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				// Basic setup for authentication and authorization
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -1096,16 +1424,17 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{ID: listID}, nil)
-
 				store.EXPECT().
 					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
 					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				// Simulate database error when getting list members
@@ -1123,11 +1452,11 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			setupMocks: func(store *mockdb.MockStore, listID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
 				// Basic setup
 				store.EXPECT().
-					IsUserListMember(gomock.Any(), db.IsUserListMemberParams{
+					GetListMemberRole(gomock.Any(), db.GetListMemberRoleParams{
 						ListID: listID,
 						UserID: userID,
 					}).
-					Return(true, nil)
+					Return(db.ListRoleMember, nil)
 
 				store.EXPECT().
 					GetListSoulcore(gomock.Any(), db.GetListSoulcoreParams{
@@ -1141,16 +1470,17 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 						Status:        db.SoulcoreStatusObtained,
 					}, nil)
 
-				store.EXPECT().
-					GetList(gomock.Any(), listID).
-					Return(db.List{ID: listID}, nil)
-
 				store.EXPECT().
 					UpdateSoulcoreStatus(gomock.Any(), db.UpdateSoulcoreStatusParams{
 						ListID:     listID,
 						CreatureID: creatureID,
 						Status:     db.SoulcoreStatusUnlocked,
+						FromStatus: db.SoulcoreStatusObtained,
 					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				// Return empty list of members
@@ -1171,6 +1501,7 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
 			userID := uuid.New()
 
 			// Create request body
@@ -1204,7 +1535,7 @@ func TestUpdateSoulcoreStatus(t *testing.T) {
 			tc.setupMocks(store, listID, creatureID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err = h.UpdateSoulcoreStatus(c)
 
 			// Check for expected error response
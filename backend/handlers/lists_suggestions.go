@@ -41,6 +41,11 @@ func (h *ListsHandler) GetCharacterSuggestions(c echo.Context) error {
 		})
 	}
 
+	lang, err := requestLanguage(c)
+	if err != nil {
+		return err
+	}
+
 	ctx := c.Request().Context()
 
 	// Verify that the character belongs to the user
@@ -59,7 +64,7 @@ func (h *ListsHandler) GetCharacterSuggestions(c echo.Context) error {
 		})
 	}
 
-	if char.UserID != userID {
+	if !h.policy.CanManageCharacter(userID, char) {
 		return apperror.AuthorizationError("Character does not belong to user", nil).WithDetails(&apperror.ValidationErrorDetails{
 			Field:  "character_id",
 			Value:  characterID.String(),
@@ -67,7 +72,7 @@ func (h *ListsHandler) GetCharacterSuggestions(c echo.Context) error {
 		})
 	}
 
-	suggestions, err := h.store.GetCharacterSuggestions(ctx, characterID)
+	suggestions, err := h.store.GetCharacterSuggestions(ctx, db.GetCharacterSuggestionsParams{Lang: lang, CharacterID: characterID})
 	if err != nil {
 		return apperror.DatabaseError("Failed to get suggestions", err).WithDetails(&apperror.DatabaseErrorDetails{
 			Operation: "GetCharacterSuggestions",
@@ -135,7 +140,7 @@ func (h *ListsHandler) AcceptSoulcoreSuggestion(c echo.Context) error {
 		})
 	}
 
-	if char.UserID != userID {
+	if !h.policy.CanManageCharacter(userID, char) {
 		return apperror.AuthorizationError("Character does not belong to user", nil).WithDetails(&apperror.ValidationErrorDetails{
 			Field:  "character_id",
 			Value:  characterID.String(),
@@ -143,28 +148,33 @@ func (h *ListsHandler) AcceptSoulcoreSuggestion(c echo.Context) error {
 		})
 	}
 
-	// Add the soulcore to the character
-	err = h.store.AddCharacterSoulcore(ctx, db.AddCharacterSoulcoreParams{
-		CharacterID: characterID,
-		CreatureID:  req.CreatureID,
-	})
-	if err != nil {
-		return apperror.DatabaseError("Failed to add soulcore to character", err).WithDetails(&apperror.DatabaseErrorDetails{
-			Operation: "AddCharacterSoulcore",
-			Table:     "character_soulcores",
+	// Add the soulcore to the character and remove the suggestion in one go
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		_, err := q.AddCharacterSoulcore(ctx, db.AddCharacterSoulcoreParams{
+			CharacterID: characterID,
+			CreatureID:  req.CreatureID,
 		})
-	}
+		if err != nil {
+			return apperror.DatabaseError("Failed to add soulcore to character", err).WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "AddCharacterSoulcore",
+				Table:     "character_soulcores",
+			})
+		}
 
-	// Remove the suggestion
-	err = h.store.DeleteSoulcoreSuggestion(ctx, db.DeleteSoulcoreSuggestionParams{
-		CharacterID: characterID,
-		CreatureID:  req.CreatureID,
+		err = q.DeleteSoulcoreSuggestion(ctx, db.DeleteSoulcoreSuggestionParams{
+			CharacterID: characterID,
+			CreatureID:  req.CreatureID,
+		})
+		if err != nil {
+			return apperror.DatabaseError("Failed to delete suggestion", err).WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "DeleteSoulcoreSuggestion",
+				Table:     "soulcore_suggestions",
+			})
+		}
+		return nil
 	})
 	if err != nil {
-		return apperror.DatabaseError("Failed to delete suggestion", err).WithDetails(&apperror.DatabaseErrorDetails{
-			Operation: "DeleteSoulcoreSuggestion",
-			Table:     "soulcore_suggestions",
-		})
+		return txError(err, "Failed to accept suggestion")
 	}
 
 	return c.NoContent(http.StatusOK)
@@ -227,7 +237,7 @@ func (h *ListsHandler) DismissSoulcoreSuggestion(c echo.Context) error {
 		})
 	}
 
-	if char.UserID != userID {
+	if !h.policy.CanManageCharacter(userID, char) {
 		return apperror.AuthorizationError("Character does not belong to user", nil).WithDetails(&apperror.ValidationErrorDetails{
 			Field:  "character_id",
 			Value:  characterID.String(),
@@ -249,3 +259,143 @@ func (h *ListsHandler) DismissSoulcoreSuggestion(c echo.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+// AcceptSoulcoreSuggestions accepts many suggestions of a character in one transaction
+func (h *ListsHandler) AcceptSoulcoreSuggestions(c echo.Context) error {
+	return h.resolveSoulcoreSuggestions(c, true)
+}
+
+// DismissSoulcoreSuggestions dismisses many suggestions of a character in one transaction
+func (h *ListsHandler) DismissSoulcoreSuggestions(c echo.Context) error {
+	return h.resolveSoulcoreSuggestions(c, false)
+}
+
+// resolveSoulcoreSuggestions removes the suggestions named in the request and, when accept
+// is set, adds their soulcores to the character. Creatures without a pending suggestion
+// are reported as not found.
+func (h *ListsHandler) resolveSoulcoreSuggestions(c echo.Context, accept bool) error {
+	characterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid character ID", err).WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "id",
+			Value:  c.Param("id"),
+			Reason: "Invalid UUID format",
+		})
+	}
+
+	var req BatchCreaturesRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "request_body",
+			Reason: "Failed to decode JSON request",
+		})
+	}
+
+	if err := validateBatchCreatureIDs("creature_ids", req.CreatureIDs); err != nil {
+		return err
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "user_id",
+			Reason: "Missing or invalid user ID in context",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "user_id",
+			Value:  userIDStr,
+			Reason: "Invalid UUID format",
+		})
+	}
+
+	ctx := c.Request().Context()
+
+	// Verify that the character belongs to the user
+	char, err := h.store.GetCharacter(ctx, characterID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Character not found", err).WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "character_id",
+				Value:  characterID.String(),
+				Reason: "Character does not exist",
+			})
+		}
+		return apperror.DatabaseError("Failed to get character", err).WithDetails(&apperror.DatabaseErrorDetails{
+			Operation: "GetCharacter",
+			Table:     "characters",
+		})
+	}
+
+	if !h.policy.CanManageCharacter(userID, char) {
+		return apperror.AuthorizationError("Character does not belong to user", nil).WithDetails(&apperror.ValidationErrorDetails{
+			Field:  "character_id",
+			Value:  characterID.String(),
+			Reason: "Character belongs to a different user",
+		})
+	}
+
+	var results []BatchItemResult
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		results = make([]BatchItemResult, len(req.CreatureIDs))
+
+		suggestions, err := q.GetCharacterSuggestions(ctx, db.GetCharacterSuggestionsParams{CharacterID: characterID})
+		if err != nil {
+			return apperror.DatabaseError("Failed to get suggestions", err).WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetCharacterSuggestions",
+				Table:     "soulcore_suggestions",
+			})
+		}
+		suggested := make(map[uuid.UUID]bool, len(suggestions))
+		for _, s := range suggestions {
+			suggested[s.CreatureID] = true
+		}
+
+		for i, creatureID := range req.CreatureIDs {
+			results[i] = BatchItemResult{CreatureID: creatureID, Result: BatchResultNotFound}
+			if !suggested[creatureID] {
+				continue
+			}
+
+			results[i].Result = BatchResultDismissed
+			if accept {
+				added, err := q.AddCharacterSoulcore(ctx, db.AddCharacterSoulcoreParams{
+					CharacterID: characterID,
+					CreatureID:  creatureID,
+				})
+				if err != nil {
+					return apperror.DatabaseError("Failed to add soulcore to character", err).WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "AddCharacterSoulcore",
+						Table:     "character_soulcores",
+					})
+				}
+
+				results[i].Result = BatchResultAlreadyPresent
+				if added > 0 {
+					results[i].Result = BatchResultAdded
+				}
+			}
+
+			err = q.DeleteSoulcoreSuggestion(ctx, db.DeleteSoulcoreSuggestionParams{
+				CharacterID: characterID,
+				CreatureID:  creatureID,
+			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to delete suggestion", err).WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "DeleteSoulcoreSuggestion",
+					Table:     "soulcore_suggestions",
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to resolve suggestions")
+	}
+
+	return c.JSON(http.StatusOK, newBatchResponse(results))
+}
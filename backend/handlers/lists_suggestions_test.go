@@ -16,6 +16,7 @@ import (
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/handlers"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -49,7 +50,7 @@ func TestGetCharacterSuggestions(t *testing.T) {
 
 				// Get character suggestions - expect to be called exactly once
 				store.EXPECT().
-					GetCharacterSuggestions(gomock.Any(), characterID).
+					GetCharacterSuggestions(gomock.Any(), db.GetCharacterSuggestionsParams{Lang: "en", CharacterID: characterID}).
 					Return([]db.GetCharacterSuggestionsRow{
 						{
 							CharacterID:  characterID,
@@ -135,7 +136,7 @@ func TestGetCharacterSuggestions(t *testing.T) {
 
 				// Database error getting suggestions
 				store.EXPECT().
-					GetCharacterSuggestions(gomock.Any(), characterID).
+					GetCharacterSuggestions(gomock.Any(), db.GetCharacterSuggestionsParams{Lang: "en", CharacterID: characterID}).
 					Return(nil, errors.New("database error"))
 			},
 			expectedCode:  http.StatusInternalServerError,
@@ -159,7 +160,7 @@ func TestGetCharacterSuggestions(t *testing.T) {
 
 				// Empty suggestions list
 				store.EXPECT().
-					GetCharacterSuggestions(gomock.Any(), characterID).
+					GetCharacterSuggestions(gomock.Any(), db.GetCharacterSuggestionsParams{Lang: "en", CharacterID: characterID}).
 					Return([]db.GetCharacterSuggestionsRow{}, nil)
 			},
 			expectedCode: http.StatusOK,
@@ -201,7 +202,7 @@ func TestGetCharacterSuggestions(t *testing.T) {
 			tc.setupMocks(store, characterID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err := h.GetCharacterSuggestions(c)
 
 			// Check for expected error response
@@ -235,6 +236,7 @@ func TestAcceptSoulcoreSuggestion(t *testing.T) {
 		setupMocks    func(store *mockdb.MockStore, characterID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID)
 		expectedCode  int
 		expectedError string
+		rolledBack    bool
 	}{
 		{
 			name: "Success",
@@ -259,7 +261,7 @@ func TestAcceptSoulcoreSuggestion(t *testing.T) {
 						CharacterID: characterID,
 						CreatureID:  creatureID,
 					}).
-					Return(nil).
+					Return(int64(1), nil).
 					Times(1)
 
 				// Delete the suggestion - expect to be called exactly once
@@ -351,10 +353,11 @@ func TestAcceptSoulcoreSuggestion(t *testing.T) {
 						CharacterID: characterID,
 						CreatureID:  creatureID,
 					}).
-					Return(errors.New("database error"))
+					Return(int64(0), errors.New("database error"))
 			},
 			expectedCode:  http.StatusInternalServerError,
 			expectedError: "Failed to add soulcore to character",
+			rolledBack:    true,
 		},
 		{
 			name: "Error Deleting Suggestion",
@@ -378,7 +381,7 @@ func TestAcceptSoulcoreSuggestion(t *testing.T) {
 						CharacterID: characterID,
 						CreatureID:  creatureID,
 					}).
-					Return(nil)
+					Return(int64(1), nil)
 
 				// Error deleting suggestion
 				store.EXPECT().
@@ -390,6 +393,7 @@ func TestAcceptSoulcoreSuggestion(t *testing.T) {
 			},
 			expectedCode:  http.StatusInternalServerError,
 			expectedError: "Failed to delete suggestion",
+			rolledBack:    true,
 		},
 	}
 
@@ -400,6 +404,7 @@ func TestAcceptSoulcoreSuggestion(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
 			characterID := uuid.New()
 			creatureID := uuid.New()
 			userID := uuid.New()
@@ -434,7 +439,7 @@ func TestAcceptSoulcoreSuggestion(t *testing.T) {
 			tc.setupMocks(store, characterID, creatureID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err = h.AcceptSoulcoreSuggestion(c)
 
 			// Check for expected error response
@@ -443,6 +448,7 @@ func TestAcceptSoulcoreSuggestion(t *testing.T) {
 				require.True(t, ok)
 				require.Equal(t, tc.expectedCode, appErr.StatusCode)
 				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, tc.rolledBack, tx.RolledBack > 0)
 				return
 			}
 
@@ -616,7 +622,7 @@ func TestDismissSoulcoreSuggestion(t *testing.T) {
 			tc.setupMocks(store, characterID, creatureID, userID)
 
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 			err = h.DismissSoulcoreSuggestion(c)
 
 			// Check for expected error response
@@ -634,3 +640,201 @@ func TestDismissSoulcoreSuggestion(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveSoulcoreSuggestions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		accept          bool
+		creatureIDs     func(creatureIDs []uuid.UUID) []uuid.UUID
+		setupMocks      func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID)
+		expectedCode    int
+		expectedError   string
+		expectedResults []handlers.BatchResult
+		rolledBack      bool
+	}{
+		{
+			name:   "Success - Accept",
+			accept: true,
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID}, nil)
+
+				store.EXPECT().
+					GetCharacterSuggestions(gomock.Any(), db.GetCharacterSuggestionsParams{CharacterID: characterID}).
+					Return([]db.GetCharacterSuggestionsRow{
+						{CharacterID: characterID, CreatureID: creatureIDs[0]},
+						{CharacterID: characterID, CreatureID: creatureIDs[1]},
+					}, nil)
+
+				store.EXPECT().
+					AddCharacterSoulcore(gomock.Any(), db.AddCharacterSoulcoreParams{
+						CharacterID: characterID,
+						CreatureID:  creatureIDs[0],
+					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					AddCharacterSoulcore(gomock.Any(), db.AddCharacterSoulcoreParams{
+						CharacterID: characterID,
+						CreatureID:  creatureIDs[1],
+					}).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					DeleteSoulcoreSuggestion(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultAdded,
+				handlers.BatchResultAlreadyPresent,
+				handlers.BatchResultNotFound,
+			},
+		},
+		{
+			name:   "Success - Dismiss",
+			accept: false,
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID}, nil)
+
+				store.EXPECT().
+					GetCharacterSuggestions(gomock.Any(), db.GetCharacterSuggestionsParams{CharacterID: characterID}).
+					Return([]db.GetCharacterSuggestionsRow{
+						{CharacterID: characterID, CreatureID: creatureIDs[1]},
+					}, nil)
+
+				store.EXPECT().
+					DeleteSoulcoreSuggestion(gomock.Any(), db.DeleteSoulcoreSuggestionParams{
+						CharacterID: characterID,
+						CreatureID:  creatureIDs[1],
+					}).
+					Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultNotFound,
+				handlers.BatchResultDismissed,
+				handlers.BatchResultNotFound,
+			},
+		},
+		{
+			name:   "Duplicate Creature",
+			accept: true,
+			creatureIDs: func(creatureIDs []uuid.UUID) []uuid.UUID {
+				return []uuid.UUID{creatureIDs[0], creatureIDs[0]}
+			},
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Duplicate creature in batch",
+		},
+		{
+			name:   "Character Belongs To Different User",
+			accept: false,
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: uuid.New()}, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Character does not belong to user",
+		},
+		{
+			name:   "Error Deleting Suggestion",
+			accept: true,
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID}, nil)
+
+				store.EXPECT().
+					GetCharacterSuggestions(gomock.Any(), db.GetCharacterSuggestionsParams{CharacterID: characterID}).
+					Return([]db.GetCharacterSuggestionsRow{
+						{CharacterID: characterID, CreatureID: creatureIDs[0]},
+					}, nil)
+
+				store.EXPECT().
+					AddCharacterSoulcore(gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					DeleteSoulcoreSuggestion(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to delete suggestion",
+			rolledBack:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			characterID := uuid.New()
+			userID := uuid.New()
+			creatureIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+			requested := creatureIDs
+			if tc.creatureIDs != nil {
+				requested = tc.creatureIDs(creatureIDs)
+			}
+			body, err := json.Marshal(handlers.BatchCreaturesRequest{CreatureIDs: requested})
+			require.NoError(t, err)
+
+			action := "dismiss"
+			if tc.accept {
+				action = "accept"
+			}
+			url := fmt.Sprintf("/api/characters/%s/suggestions/%s/batch", characterID, action)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/characters/:id/suggestions/" + action + "/batch")
+			c.Set("user_id", userID.String())
+			c.SetParamNames("id")
+			c.SetParamValues(characterID.String())
+
+			tc.setupMocks(store, characterID, userID, creatureIDs)
+
+			h := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
+			if tc.accept {
+				err = h.AcceptSoulcoreSuggestions(c)
+			} else {
+				err = h.DismissSoulcoreSuggestions(c)
+			}
+
+			if tc.expectedError != "" {
+				var appErr *apperror.AppError
+				require.ErrorAs(t, err, &appErr)
+				require.Equal(t, tc.expectedCode, appErr.StatusCode)
+				require.Contains(t, appErr.Message, tc.expectedError)
+				require.Equal(t, tc.rolledBack, tx.RolledBack > 0)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.Equal(t, 1, tx.Committed)
+
+			var response handlers.BatchResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Results, len(tc.expectedResults))
+			for i, result := range response.Results {
+				require.Equal(t, creatureIDs[i], result.CreatureID)
+				require.Equal(t, tc.expectedResults[i], result.Result)
+			}
+		})
+	}
+}
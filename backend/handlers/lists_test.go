@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -18,6 +19,7 @@ import (
 	db "github.com/sergot/tibiacores/backend/db/sqlc"
 	"github.com/sergot/tibiacores/backend/handlers"
 	"github.com/sergot/tibiacores/backend/pkg/apperror"
+	"github.com/sergot/tibiacores/backend/services"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -29,6 +31,7 @@ func TestCreateList(t *testing.T) {
 		setupMocks    func(store *mockdb.MockStore, userID uuid.UUID)
 		expectedCode  int
 		expectedError string
+		rolledBack    bool
 		checkResponse func(t *testing.T, response *handlers.CreateListResponse)
 	}{
 		{
@@ -225,13 +228,7 @@ func TestCreateList(t *testing.T) {
 				c.Set("user_id", nil)
 			},
 			setupMocks: func(store *mockdb.MockStore, userID uuid.UUID) {
-				// Create a new anonymous user
-				newUserID := uuid.New()
-				store.EXPECT().
-					CreateAnonymousUser(gomock.Any(), gomock.Any()).
-					Return(db.User{
-						ID: newUserID,
-					}, nil)
+				// No anonymous user is created for invalid requests
 			},
 			expectedCode:  http.StatusBadRequest,
 			expectedError: "Character name and world are required for first list",
@@ -257,6 +254,7 @@ func TestCreateList(t *testing.T) {
 			},
 			expectedCode:  http.StatusInternalServerError,
 			expectedError: "failed to create user",
+			rolledBack:    true,
 		},
 		{
 			name: "Invalid Request Body",
@@ -313,6 +311,7 @@ func TestCreateList(t *testing.T) {
 			},
 			expectedCode:  http.StatusBadRequest,
 			expectedError: "Character name is already registered",
+			rolledBack:    true,
 		},
 		{
 			name: "Character Not Found",
@@ -334,6 +333,7 @@ func TestCreateList(t *testing.T) {
 			},
 			expectedCode:  http.StatusNotFound,
 			expectedError: "Character not found",
+			rolledBack:    true,
 		},
 		{
 			name: "Character Belongs to Different User",
@@ -360,6 +360,7 @@ func TestCreateList(t *testing.T) {
 			},
 			expectedCode:  http.StatusUnauthorized,
 			expectedError: "Character does not belong to user",
+			rolledBack:    true,
 		},
 		{
 			name: "Error Creating List",
@@ -396,6 +397,7 @@ func TestCreateList(t *testing.T) {
 			},
 			expectedCode:  http.StatusInternalServerError,
 			expectedError: "Failed to create list",
+			rolledBack:    true,
 		},
 		{
 			name: "Error Adding Character to List",
@@ -445,6 +447,7 @@ func TestCreateList(t *testing.T) {
 			},
 			expectedCode:  http.StatusInternalServerError,
 			expectedError: "Failed to add character to list",
+			rolledBack:    true,
 		},
 		{
 			name: "Error Creating Character",
@@ -471,6 +474,7 @@ func TestCreateList(t *testing.T) {
 			},
 			expectedCode:  http.StatusInternalServerError,
 			expectedError: "Failed to create character",
+			rolledBack:    true,
 		},
 	}
 
@@ -480,7 +484,8 @@ func TestCreateList(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
-			handler := handlers.NewListsHandler(store)
+			tx := mockdb.ExpectTx(store)
+			handler := handlers.NewListsHandler(store, services.NewHub(services.NewMemoryEventBus()))
 
 			// Create a new Echo instance
 			e := echo.New()
@@ -520,6 +525,7 @@ func TestCreateList(t *testing.T) {
 				require.ErrorAs(t, err, &appErr)
 				require.Equal(t, tc.expectedCode, appErr.StatusCode)
 				require.Equal(t, tc.expectedError, appErr.Message)
+				require.Equal(t, tc.rolledBack, tx.RolledBack > 0)
 				return
 			}
 
@@ -545,6 +551,7 @@ func TestJoinList(t *testing.T) {
 		setupMocks    func(store *mockdb.MockStore, shareCode uuid.UUID, userID uuid.UUID)
 		expectedCode  int
 		expectedError string
+		rolledBack    bool
 		checkResponse func(t *testing.T, response *handlers.ListDetailResponse)
 	}{
 		{
@@ -573,6 +580,11 @@ func TestJoinList(t *testing.T) {
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(list, nil)
 
+				// User was not removed from the list
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
 				// Check if user is already a member
 				store.EXPECT().
 					IsUserListMember(gomock.Any(), gomock.Any()).
@@ -593,6 +605,15 @@ func TestJoinList(t *testing.T) {
 					AddListCharacter(gomock.Any(), gomock.Any()).
 					Return(nil)
 
+				// The join shows up in the activity log of the list
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, arg db.CreateListActivityParams) error {
+						require.Equal(t, db.ListActivityActionMemberJoined, arg.Action)
+						require.Equal(t, uuid.Nil, arg.TargetUserID)
+						return nil
+					})
+
 				// Get members for response
 				store.EXPECT().
 					GetListMembers(gomock.Any(), list.ID).
@@ -636,6 +657,11 @@ func TestJoinList(t *testing.T) {
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(list, nil)
 
+				// User was not removed from the list
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
 				// Check if user is already a member
 				store.EXPECT().
 					IsUserListMember(gomock.Any(), gomock.Any()).
@@ -661,6 +687,10 @@ func TestJoinList(t *testing.T) {
 					AddListCharacter(gomock.Any(), gomock.Any()).
 					Return(nil)
 
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
+
 				// Get members for response
 				store.EXPECT().
 					GetListMembers(gomock.Any(), list.ID).
@@ -717,10 +747,136 @@ func TestJoinList(t *testing.T) {
 				store.EXPECT().
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListByRedirectCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				// Not an invite code either
+				store.EXPECT().
+					GetListInviteByCode(gomock.Any(), shareCode).
+					Return(db.ListInvite{}, sql.ErrNoRows)
 			},
 			expectedCode:  http.StatusNotFound,
 			expectedError: "list not found",
 		},
+		{
+			name: "Success - Invite Code",
+			setupRequest: func(c echo.Context, body *bytes.Buffer) {
+				body.Reset()
+				err := json.NewEncoder(body).Encode(map[string]any{
+					"character_name": "NewCharacter",
+					"world":          "Antica",
+				})
+				require.NoError(t, err)
+			},
+			setupMocks: func(store *mockdb.MockStore, shareCode uuid.UUID, userID uuid.UUID) {
+				list := db.List{
+					ID:        uuid.New(),
+					AuthorID:  uuid.New(),
+					Name:      "Test List",
+					World:     "Antica",
+					ShareCode: uuid.New(),
+				}
+				invite := db.ListInvite{
+					ID:       uuid.New(),
+					ListID:   list.ID,
+					Code:     shareCode,
+					MaxUses:  pgtype.Int4{Int32: 5, Valid: true},
+					UseCount: 1,
+				}
+
+				// Code is not the list's share code
+				store.EXPECT().
+					GetListByShareCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListByRedirectCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				// Code belongs to a usable invite
+				store.EXPECT().
+					GetListInviteByCode(gomock.Any(), shareCode).
+					Return(invite, nil)
+
+				store.EXPECT().
+					GetList(gomock.Any(), list.ID).
+					Return(list, nil)
+
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					IsUserListMember(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
+				store.EXPECT().
+					GetCharacterByName(gomock.Any(), "NewCharacter").
+					Return(db.Character{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateCharacter(gomock.Any(), gomock.Any()).
+					Return(db.Character{ID: uuid.New(), UserID: userID, Name: "NewCharacter", World: "Antica"}, nil)
+
+				// The use is counted right before joining
+				store.EXPECT().
+					UseListInvite(gomock.Any(), invite.ID).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					AddListCharacter(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					GetListMembers(gomock.Any(), list.ID).
+					Return([]db.GetListMembersRow{
+						{UserID: userID, CharacterName: "NewCharacter", IsActive: true},
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkResponse: func(t *testing.T, response *handlers.ListDetailResponse) {
+				require.Equal(t, "Test List", response.Name)
+				require.Equal(t, 1, len(response.Members))
+			},
+		},
+		{
+			name: "Exhausted Invite",
+			setupRequest: func(c echo.Context, body *bytes.Buffer) {
+				body.Reset()
+				err := json.NewEncoder(body).Encode(map[string]any{
+					"character_name": "NewCharacter",
+					"world":          "Antica",
+				})
+				require.NoError(t, err)
+			},
+			setupMocks: func(store *mockdb.MockStore, shareCode uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetListByShareCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListByRedirectCode(gomock.Any(), shareCode).
+					Return(db.List{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetListInviteByCode(gomock.Any(), shareCode).
+					Return(db.ListInvite{
+						ID:       uuid.New(),
+						ListID:   uuid.New(),
+						Code:     shareCode,
+						MaxUses:  pgtype.Int4{Int32: 2, Valid: true},
+						UseCount: 2,
+					}, nil)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invite has reached its maximum number of uses",
+		},
 		{
 			name: "User Already a Member",
 			setupRequest: func(c echo.Context, body *bytes.Buffer) {
@@ -745,6 +901,11 @@ func TestJoinList(t *testing.T) {
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(list, nil)
 
+				// User was not removed from the list
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
 				// User is already a member
 				store.EXPECT().
 					IsUserListMember(gomock.Any(), gomock.Any()).
@@ -752,6 +913,42 @@ func TestJoinList(t *testing.T) {
 			},
 			expectedCode:  http.StatusBadRequest,
 			expectedError: "user is already a member of this list",
+			rolledBack:    true,
+		},
+		{
+			name: "Removed Member Needs Re-invite",
+			setupRequest: func(c echo.Context, body *bytes.Buffer) {
+				body.Reset()
+				err := json.NewEncoder(body).Encode(map[string]any{
+					"character_name": "TestCharacter",
+					"world":          "Antica",
+				})
+				require.NoError(t, err)
+			},
+			setupMocks: func(store *mockdb.MockStore, shareCode uuid.UUID, userID uuid.UUID) {
+				list := db.List{
+					ID:        uuid.New(),
+					AuthorID:  uuid.New(),
+					Name:      "Test List",
+					World:     "Antica",
+					ShareCode: shareCode,
+				}
+
+				// Get list by share code
+				store.EXPECT().
+					GetListByShareCode(gomock.Any(), shareCode).
+					Return(list, nil)
+
+				// User was removed by a moderator
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), db.IsUserRemovedFromListParams{
+						ListID: list.ID,
+						UserID: userID,
+					}).
+					Return(true, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "you were removed from this list and need to be re-invited",
 		},
 		{
 			name: "Character Not Found",
@@ -775,6 +972,11 @@ func TestJoinList(t *testing.T) {
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(list, nil)
 
+				// User was not removed from the list
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
 				// Check if user is already a member
 				store.EXPECT().
 					IsUserListMember(gomock.Any(), gomock.Any()).
@@ -787,6 +989,7 @@ func TestJoinList(t *testing.T) {
 			},
 			expectedCode:  http.StatusNotFound,
 			expectedError: "character not found",
+			rolledBack:    true,
 		},
 		{
 			name: "Character Belongs to Different User",
@@ -810,6 +1013,11 @@ func TestJoinList(t *testing.T) {
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(list, nil)
 
+				// User was not removed from the list
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
 				// Check if user is already a member
 				store.EXPECT().
 					IsUserListMember(gomock.Any(), gomock.Any()).
@@ -827,6 +1035,7 @@ func TestJoinList(t *testing.T) {
 			},
 			expectedCode:  http.StatusUnauthorized,
 			expectedError: "character does not belong to user",
+			rolledBack:    true,
 		},
 		{
 			name: "World Mismatch",
@@ -850,6 +1059,11 @@ func TestJoinList(t *testing.T) {
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(list, nil)
 
+				// User was not removed from the list
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
 				// Check if user is already a member
 				store.EXPECT().
 					IsUserListMember(gomock.Any(), gomock.Any()).
@@ -867,6 +1081,7 @@ func TestJoinList(t *testing.T) {
 			},
 			expectedCode:  http.StatusBadRequest,
 			expectedError: "character world does not match list world",
+			rolledBack:    true,
 		},
 		{
 			name: "Error Adding Character to List",
@@ -890,6 +1105,11 @@ func TestJoinList(t *testing.T) {
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(list, nil)
 
+				// User was not removed from the list
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
 				// Check if user is already a member
 				store.EXPECT().
 					IsUserListMember(gomock.Any(), gomock.Any()).
@@ -912,6 +1132,7 @@ func TestJoinList(t *testing.T) {
 			},
 			expectedCode:  http.StatusInternalServerError,
 			expectedError: "failed to add character to list",
+			rolledBack:    true,
 		},
 		{
 			name: "Error Getting List Members",
@@ -935,6 +1156,11 @@ func TestJoinList(t *testing.T) {
 					GetListByShareCode(gomock.Any(), shareCode).
 					Return(list, nil)
 
+				// User was not removed from the list
+				store.EXPECT().
+					IsUserRemovedFromList(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+
 				// Check if user is already a member
 				store.EXPECT().
 					IsUserListMember(gomock.Any(), gomock.Any()).
@@ -955,6 +1181,10 @@ func TestJoinList(t *testing.T) {
 					AddListCharacter(gomock.Any(), gomock.Any()).
 					Return(nil)
 
+				store.EXPECT().
+					CreateListActivity(gomock.Any(), gomock.Any()).
+					Return(nil)
+
 				// Error getting members
 				store.EXPECT().
 					GetListMembers(gomock.Any(), list.ID).
@@ -972,6 +1202,7 @@ func TestJoinList(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
 			userID := uuid.New()
 			shareCode := uuid.New()
 
@@ -998,8 +1229,15 @@ func TestJoinList(t *testing.T) {
 			// Setup mock expectations
 			tc.setupMocks(store, shareCode, userID)
 
+			// Record the events published to the list
+			bus := services.NewMemoryEventBus()
+			var events []services.ListEvent
+			bus.Subscribe(func(ctx context.Context, event services.ListEvent) {
+				events = append(events, event)
+			})
+
 			// Execute handler
-			h := handlers.NewListsHandler(store)
+			h := handlers.NewListsHandler(store, services.NewHub(bus))
 			err := h.JoinList(c)
 
 			// Check for expected error response
@@ -1009,6 +1247,10 @@ func TestJoinList(t *testing.T) {
 				require.ErrorAs(t, err, &appErr)
 				require.Equal(t, tc.expectedCode, appErr.StatusCode)
 				require.Equal(t, tc.expectedError, appErr.Message)
+				require.Equal(t, tc.rolledBack, tx.RolledBack > 0)
+				if tx.Committed == 0 {
+					require.Empty(t, events)
+				}
 				return
 			}
 
@@ -1016,6 +1258,10 @@ func TestJoinList(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, tc.expectedCode, rec.Code)
 
+			// Other members learn about the join
+			require.Len(t, events, 1)
+			require.Equal(t, services.EventMemberJoined, events[0].Type)
+
 			// Check response body
 			if tc.checkResponse != nil {
 				var response handlers.ListDetailResponse
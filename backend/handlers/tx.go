@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/sergot/tibiacores/backend/pkg/apperror"
+)
+
+// txError turns an error returned by ExecTx into an AppError. Errors returned from
+// inside the transaction already are AppErrors and are kept as they are, failures to
+// begin or commit the transaction are reported as database errors with message.
+func txError(err error, message string) *apperror.AppError {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperror.DatabaseError(message, err)
+}
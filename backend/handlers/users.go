@@ -21,6 +21,7 @@ import (
 type UsersHandler struct {
 	store        db.Store
 	emailService services.EmailServiceInterface
+	policy       *services.Policy
 }
 
 type SignupRequest struct {
@@ -41,7 +42,11 @@ type CharacterPreview struct {
 }
 
 func NewUsersHandler(store db.Store, emailService services.EmailServiceInterface) *UsersHandler {
-	return &UsersHandler{store: store, emailService: emailService}
+	return &UsersHandler{
+		store:        store,
+		emailService: emailService,
+		policy:       services.NewPolicy(store),
+	}
 }
 
 // Login authenticates a user with email and password
@@ -130,20 +135,10 @@ func (h *UsersHandler) Signup(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// Check if user exists with this email
 	var email pgtype.Text
 	email.String = req.Email
 	email.Valid = true
 
-	existingUser, getUserErr := h.store.GetUserByEmail(ctx, email)
-	if getUserErr == nil && !existingUser.IsAnonymous {
-		return apperror.ValidationError("Email already in use", nil).
-			WithDetails(&apperror.ValidationErrorDetails{
-				Field:  "email",
-				Reason: "Email already registered",
-			})
-	}
-
 	// Hash password
 	hashedPassword, err := auth.HashPassword(req.Password)
 	if err != nil {
@@ -165,80 +160,97 @@ func (h *UsersHandler) Signup(c echo.Context) error {
 		Valid: true,
 	}
 
-	var user db.User
-
 	// Check for existing session token
 	authHeader := c.Request().Header.Get("Authorization")
-	var existingUserID *string
+	var existingUserID *uuid.UUID
 	if authHeader != "" {
 		if claims, err := auth.ValidateToken(strings.TrimPrefix(authHeader, "Bearer ")); err == nil {
-			existingUserID = &claims.UserID
+			userID, parseErr := uuid.Parse(claims.UserID)
+			if parseErr != nil {
+				return apperror.ValidationError("Invalid user ID format", parseErr).
+					WithDetails(&apperror.ValidationErrorDetails{
+						Field:  "user_id",
+						Value:  claims.UserID,
+						Reason: "Invalid UUID format",
+					})
+			}
+			existingUserID = &userID
 		}
 	}
 
-	if existingUserID != nil {
-		// Migrate existing anonymous user
-		userID, parseErr := uuid.Parse(*existingUserID)
-		if parseErr != nil {
-			return apperror.ValidationError("Invalid user ID format", parseErr).
+	// The email check and the account change run in one transaction, so two
+	// signups with the same email can't both succeed
+	var user db.User
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		// Check if user exists with this email
+		existingUser, getUserErr := q.GetUserByEmail(ctx, email)
+		if getUserErr == nil && !existingUser.IsAnonymous {
+			return apperror.ValidationError("Email already in use", nil).
 				WithDetails(&apperror.ValidationErrorDetails{
-					Field:  "user_id",
-					Value:  *existingUserID,
-					Reason: "Invalid UUID format",
+					Field:  "email",
+					Reason: "Email already registered",
 				})
 		}
 
-		user, err = h.store.MigrateAnonymousUser(ctx, db.MigrateAnonymousUserParams{
-			Email:                      email,
-			Password:                   password,
-			EmailVerificationToken:     verificationToken,
-			EmailVerificationExpiresAt: expiresAt,
-			ID:                         userID,
-		})
-		if err != nil {
-			slog.Error("Failed to migrate anonymous user", "error", err)
-			return apperror.DatabaseError("Failed to migrate user", err).
-				WithDetails(&apperror.DatabaseErrorDetails{
-					Operation: "MigrateAnonymousUser",
-					Table:     "users",
-				}).
-				Wrap(err)
-		}
-	} else if getUserErr == nil && existingUser.IsAnonymous {
-		// Update existing anonymous user found by email
-		user, err = h.store.MigrateAnonymousUser(ctx, db.MigrateAnonymousUserParams{
-			Email:                      email,
-			Password:                   password,
-			EmailVerificationToken:     verificationToken,
-			EmailVerificationExpiresAt: expiresAt,
-			ID:                         existingUser.ID,
-		})
-		if err != nil {
-			slog.Error("Failed to migrate existing user", "error", err)
-			return apperror.DatabaseError("Failed to update user", err).
-				WithDetails(&apperror.DatabaseErrorDetails{
-					Operation: "MigrateAnonymousUser",
-					Table:     "users",
-				}).
-				Wrap(err)
-		}
-	} else {
-		// Create new user
-		user, err = h.store.CreateUser(ctx, db.CreateUserParams{
-			Email:                      email,
-			Password:                   password,
-			EmailVerificationToken:     verificationToken,
-			EmailVerificationExpiresAt: expiresAt,
-		})
-		if err != nil {
-			slog.Error("Failed to create user", "error", err)
-			return apperror.DatabaseError("Failed to create user", err).
-				WithDetails(&apperror.DatabaseErrorDetails{
-					Operation: "CreateUser",
-					Table:     "users",
-				}).
-				Wrap(err)
+		var err error
+		if existingUserID != nil {
+			// Migrate existing anonymous user
+			user, err = q.MigrateAnonymousUser(ctx, db.MigrateAnonymousUserParams{
+				Email:                      email,
+				Password:                   password,
+				EmailVerificationToken:     verificationToken,
+				EmailVerificationExpiresAt: expiresAt,
+				ID:                         *existingUserID,
+			})
+			if err != nil {
+				slog.Error("Failed to migrate anonymous user", "error", err)
+				return apperror.DatabaseError("Failed to migrate user", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "MigrateAnonymousUser",
+						Table:     "users",
+					}).
+					Wrap(err)
+			}
+		} else if getUserErr == nil && existingUser.IsAnonymous {
+			// Update existing anonymous user found by email
+			user, err = q.MigrateAnonymousUser(ctx, db.MigrateAnonymousUserParams{
+				Email:                      email,
+				Password:                   password,
+				EmailVerificationToken:     verificationToken,
+				EmailVerificationExpiresAt: expiresAt,
+				ID:                         existingUser.ID,
+			})
+			if err != nil {
+				slog.Error("Failed to migrate existing user", "error", err)
+				return apperror.DatabaseError("Failed to update user", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "MigrateAnonymousUser",
+						Table:     "users",
+					}).
+					Wrap(err)
+			}
+		} else {
+			// Create new user
+			user, err = q.CreateUser(ctx, db.CreateUserParams{
+				Email:                      email,
+				Password:                   password,
+				EmailVerificationToken:     verificationToken,
+				EmailVerificationExpiresAt: expiresAt,
+			})
+			if err != nil {
+				slog.Error("Failed to create user", "error", err)
+				return apperror.DatabaseError("Failed to create user", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "CreateUser",
+						Table:     "users",
+					}).
+					Wrap(err)
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to sign up")
 	}
 
 	// Generate new token
@@ -434,7 +446,7 @@ func (h *UsersHandler) GetCharacter(c echo.Context) error {
 	}
 
 	// Verify character belongs to user
-	if character.UserID != userID {
+	if !h.policy.CanManageCharacter(userID, character) {
 		return apperror.AuthorizationError("Character does not belong to user", nil).
 			WithDetails(&apperror.ValidationErrorDetails{
 				Field:  "user_id",
@@ -478,6 +490,11 @@ func (h *UsersHandler) GetCharacterSoulcores(c echo.Context) error {
 			})
 	}
 
+	lang, err := requestLanguage(c)
+	if err != nil {
+		return err
+	}
+
 	ctx := c.Request().Context()
 
 	// Verify character belongs to user
@@ -499,7 +516,7 @@ func (h *UsersHandler) GetCharacterSoulcores(c echo.Context) error {
 			Wrap(err)
 	}
 
-	if character.UserID != userID {
+	if !h.policy.CanManageCharacter(userID, character) {
 		return apperror.AuthorizationError("Character does not belong to user", nil).
 			WithDetails(&apperror.ValidationErrorDetails{
 				Field:  "user_id",
@@ -509,7 +526,7 @@ func (h *UsersHandler) GetCharacterSoulcores(c echo.Context) error {
 	}
 
 	// Get unlocked soulcores
-	soulcores, err := h.store.GetCharacterSoulcores(ctx, characterID)
+	soulcores, err := h.store.GetCharacterSoulcores(ctx, db.GetCharacterSoulcoresParams{Lang: lang, CharacterID: characterID})
 	if err != nil {
 		return apperror.DatabaseError("Failed to get character soulcores", err).
 			WithDetails(&apperror.DatabaseErrorDetails{
@@ -585,7 +602,7 @@ func (h *UsersHandler) RemoveCharacterSoulcore(c echo.Context) error {
 			Wrap(err)
 	}
 
-	if character.UserID != userID {
+	if !h.policy.CanManageCharacter(userID, character) {
 		return apperror.AuthorizationError("Character does not belong to user", nil).
 			WithDetails(&apperror.ValidationErrorDetails{
 				Field:  "user_id",
@@ -594,7 +611,7 @@ func (h *UsersHandler) RemoveCharacterSoulcore(c echo.Context) error {
 			})
 	}
 
-	// Remove the soulcore
+	// Remove the soulcore, it stays restorable for SoulcoreUndoWindow
 	err = h.store.RemoveCharacterSoulcore(ctx, db.RemoveCharacterSoulcoreParams{
 		CharacterID: characterID,
 		CreatureID:  creatureID,
@@ -608,6 +625,106 @@ func (h *UsersHandler) RemoveCharacterSoulcore(c echo.Context) error {
 			Wrap(err)
 	}
 
+	return c.JSON(http.StatusOK, map[string]any{
+		"restorable_until": time.Now().Add(SoulcoreUndoWindow),
+	})
+}
+
+// RestoreCharacterSoulcore undoes the removal of a character soulcore within SoulcoreUndoWindow
+func (h *UsersHandler) RestoreCharacterSoulcore(c echo.Context) error {
+	characterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid character ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	creatureID, err := uuid.Parse(c.Param("creature_id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid creature ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "creature_id",
+				Value:  c.Param("creature_id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Verify character belongs to user
+	character, err := h.store.GetCharacter(ctx, characterID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Character not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "id",
+					Value:  characterID.String(),
+					Reason: "Character does not exist",
+				})
+		}
+		return apperror.DatabaseError("Failed to get character", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetCharacter",
+				Table:     "characters",
+			}).
+			Wrap(err)
+	}
+
+	if !h.policy.CanManageCharacter(userID, character) {
+		return apperror.AuthorizationError("Character does not belong to user", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userID.String(),
+				Reason: "Access denied to other user's character",
+			})
+	}
+
+	restored, err := h.store.RestoreCharacterSoulcore(ctx, db.RestoreCharacterSoulcoreParams{
+		CharacterID:     characterID,
+		CreatureID:      creatureID,
+		RestorableSince: pgtype.Timestamptz{Time: time.Now().Add(-SoulcoreUndoWindow), Valid: true},
+	})
+	if err != nil {
+		return apperror.DatabaseError("Failed to restore soul core", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "RestoreCharacterSoulcore",
+				Table:     "character_soulcores",
+			}).
+			Wrap(err)
+	}
+
+	if restored == 0 {
+		return apperror.NotFoundError("Removed soulcore not found", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "creature_id",
+				Value:  creatureID.String(),
+				Reason: "Soulcore is not removed or past its undo window",
+			})
+	}
+
 	return c.NoContent(http.StatusOK)
 }
 
@@ -675,7 +792,7 @@ func (h *UsersHandler) AddCharacterSoulcore(c echo.Context) error {
 			Wrap(err)
 	}
 
-	if character.UserID != userID {
+	if !h.policy.CanManageCharacter(userID, character) {
 		return apperror.AuthorizationError("Character does not belong to user", nil).
 			WithDetails(&apperror.ValidationErrorDetails{
 				Field:  "user_id",
@@ -685,7 +802,7 @@ func (h *UsersHandler) AddCharacterSoulcore(c echo.Context) error {
 	}
 
 	// Add the soulcore to the character
-	err = h.store.AddCharacterSoulcore(ctx, db.AddCharacterSoulcoreParams{
+	_, err = h.store.AddCharacterSoulcore(ctx, db.AddCharacterSoulcoreParams{
 		CharacterID: characterID,
 		CreatureID:  req.CreatureID,
 	})
@@ -701,6 +818,132 @@ func (h *UsersHandler) AddCharacterSoulcore(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// AddCharacterSoulcores adds many soulcores to a character in one transaction. Soulcores
+// the character already has and unknown creatures are reported instead of failing the batch.
+func (h *UsersHandler) AddCharacterSoulcores(c echo.Context) error {
+	characterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.ValidationError("Invalid character ID", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "id",
+				Value:  c.Param("id"),
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	var req BatchCreaturesRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return apperror.ValidationError("Invalid request body", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "body",
+				Reason: "Invalid JSON format",
+			})
+	}
+
+	if err := validateBatchCreatureIDs("creature_ids", req.CreatureIDs); err != nil {
+		return err
+	}
+
+	// Get authenticated user ID from context
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return apperror.AuthorizationError("Invalid user authentication", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Reason: "Not found in context",
+			})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return apperror.AuthorizationError("Invalid user ID format", err).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userIDStr,
+				Reason: "Invalid UUID format",
+			})
+	}
+
+	ctx := c.Request().Context()
+
+	// Verify character belongs to user
+	character, err := h.store.GetCharacter(ctx, characterID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFoundError("Character not found", err).
+				WithDetails(&apperror.ValidationErrorDetails{
+					Field:  "id",
+					Value:  characterID.String(),
+					Reason: "Character does not exist",
+				})
+		}
+		return apperror.DatabaseError("Failed to get character", err).
+			WithDetails(&apperror.DatabaseErrorDetails{
+				Operation: "GetCharacter",
+				Table:     "characters",
+			}).
+			Wrap(err)
+	}
+
+	if !h.policy.CanManageCharacter(userID, character) {
+		return apperror.AuthorizationError("Character does not belong to user", nil).
+			WithDetails(&apperror.ValidationErrorDetails{
+				Field:  "user_id",
+				Value:  userID.String(),
+				Reason: "Access denied to other user's character",
+			})
+	}
+
+	var results []BatchItemResult
+	err = h.store.ExecTx(ctx, func(q db.Querier) error {
+		results = make([]BatchItemResult, len(req.CreatureIDs))
+
+		known, err := q.GetCreatureIDs(ctx, req.CreatureIDs)
+		if err != nil {
+			return apperror.DatabaseError("Failed to get creatures", err).
+				WithDetails(&apperror.DatabaseErrorDetails{
+					Operation: "GetCreatureIDs",
+					Table:     "creatures",
+				})
+		}
+		isKnown := make(map[uuid.UUID]bool, len(known))
+		for _, id := range known {
+			isKnown[id] = true
+		}
+
+		for i, creatureID := range req.CreatureIDs {
+			results[i] = BatchItemResult{CreatureID: creatureID, Result: BatchResultNotFound}
+			if !isKnown[creatureID] {
+				continue
+			}
+
+			added, err := q.AddCharacterSoulcore(ctx, db.AddCharacterSoulcoreParams{
+				CharacterID: characterID,
+				CreatureID:  creatureID,
+			})
+			if err != nil {
+				return apperror.DatabaseError("Failed to add soul core", err).
+					WithDetails(&apperror.DatabaseErrorDetails{
+						Operation: "AddCharacterSoulcore",
+						Table:     "character_soulcores",
+					}).
+					Wrap(err)
+			}
+
+			results[i].Result = BatchResultAlreadyPresent
+			if added > 0 {
+				results[i].Result = BatchResultAdded
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "Failed to add soul cores")
+	}
+
+	return c.JSON(http.StatusOK, newBatchResponse(results))
+}
+
 // GetPendingSuggestions returns all characters with pending soulcore suggestions
 func (h *UsersHandler) GetPendingSuggestions(c echo.Context) error {
 	// Get authenticated user ID from context
@@ -860,6 +1103,11 @@ func (h *UsersHandler) GetCharacterPublic(c echo.Context) error {
 			})
 	}
 
+	lang, err := requestLanguage(c)
+	if err != nil {
+		return err
+	}
+
 	ctx := c.Request().Context()
 
 	// Get character details by name
@@ -882,7 +1130,7 @@ func (h *UsersHandler) GetCharacterPublic(c echo.Context) error {
 	}
 
 	// Get unlocked soulcores
-	soulcores, err := h.store.GetCharacterSoulcores(ctx, character.ID)
+	soulcores, err := h.store.GetCharacterSoulcores(ctx, db.GetCharacterSoulcoresParams{Lang: lang, CharacterID: character.ID})
 	if err != nil {
 		return apperror.DatabaseError("Failed to get character soulcores", err).
 			WithDetails(&apperror.DatabaseErrorDetails{
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -205,7 +206,7 @@ func TestAddCharacterSoulcore(t *testing.T) {
 						CharacterID: characterID,
 						CreatureID:  creatureID,
 					}).
-					Return(nil)
+					Return(int64(1), nil)
 			},
 			expectedCode: http.StatusOK,
 		},
@@ -324,6 +325,156 @@ func TestAddCharacterSoulcore(t *testing.T) {
 	}
 }
 
+func TestAddCharacterSoulcores(t *testing.T) {
+	testCases := []struct {
+		name            string
+		buildBody       func(creatureIDs []uuid.UUID) handlers.BatchCreaturesRequest
+		setupMocks      func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID)
+		expectedCode    int
+		expectedError   string
+		expectedResults []handlers.BatchResult
+		rolledBack      bool
+	}{
+		{
+			name: "Success",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchCreaturesRequest {
+				return handlers.BatchCreaturesRequest{CreatureIDs: creatureIDs}
+			},
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID}, nil)
+
+				store.EXPECT().
+					GetCreatureIDs(gomock.Any(), creatureIDs).
+					Return(creatureIDs[:2], nil)
+
+				store.EXPECT().
+					AddCharacterSoulcore(gomock.Any(), db.AddCharacterSoulcoreParams{
+						CharacterID: characterID,
+						CreatureID:  creatureIDs[0],
+					}).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					AddCharacterSoulcore(gomock.Any(), db.AddCharacterSoulcoreParams{
+						CharacterID: characterID,
+						CreatureID:  creatureIDs[1],
+					}).
+					Return(int64(0), nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResults: []handlers.BatchResult{
+				handlers.BatchResultAdded,
+				handlers.BatchResultAlreadyPresent,
+				handlers.BatchResultNotFound,
+			},
+		},
+		{
+			name: "Empty Batch",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchCreaturesRequest {
+				return handlers.BatchCreaturesRequest{}
+			},
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				// No mocks needed for this case
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Batch is empty",
+		},
+		{
+			name: "Character Belongs To Different User",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchCreaturesRequest {
+				return handlers.BatchCreaturesRequest{CreatureIDs: creatureIDs}
+			},
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: uuid.New()}, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Character does not belong to user",
+		},
+		{
+			name: "Database Error - AddCharacterSoulcore",
+			buildBody: func(creatureIDs []uuid.UUID) handlers.BatchCreaturesRequest {
+				return handlers.BatchCreaturesRequest{CreatureIDs: creatureIDs}
+			},
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, userID uuid.UUID, creatureIDs []uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID}, nil)
+
+				store.EXPECT().
+					GetCreatureIDs(gomock.Any(), creatureIDs).
+					Return(creatureIDs, nil)
+
+				store.EXPECT().
+					AddCharacterSoulcore(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to add soul core",
+			rolledBack:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tx := mockdb.ExpectTx(store)
+			emailService := newMockEmailService(ctrl)
+			characterID := uuid.New()
+			userID := uuid.New()
+			creatureIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+			body, err := json.Marshal(tc.buildBody(creatureIDs))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/characters/"+characterID.String()+"/soulcores/batch", bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/characters/:id/soulcores/batch")
+			c.SetParamNames("id")
+			c.SetParamValues(characterID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, characterID, userID, creatureIDs)
+
+			h := handlers.NewUsersHandler(store, emailService)
+			err = h.AddCharacterSoulcores(c)
+
+			if tc.expectedError != "" {
+				middleware.ErrorHandler(err, c)
+				require.Equal(t, tc.expectedCode, rec.Code)
+
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Contains(t, errorResponse["message"].(string), tc.expectedError)
+				require.Equal(t, tc.rolledBack, tx.RolledBack > 0)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+
+			var response handlers.BatchResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Results, len(tc.expectedResults))
+			for i, result := range response.Results {
+				require.Equal(t, creatureIDs[i], result.CreatureID)
+				require.Equal(t, tc.expectedResults[i], result.Result)
+			}
+			require.Equal(t, 1, response.Counts[handlers.BatchResultAdded])
+		})
+	}
+}
+
 func TestGetUser(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -1229,7 +1380,7 @@ func TestGetCharacterSoulcores(t *testing.T) {
 
 				// Then get the soulcores for the character
 				store.EXPECT().
-					GetCharacterSoulcores(gomock.Any(), characterID).
+					GetCharacterSoulcores(gomock.Any(), db.GetCharacterSoulcoresParams{Lang: "en", CharacterID: characterID}).
 					Return([]db.GetCharacterSoulcoresRow{
 						{
 							CharacterID:  characterID,
@@ -1346,7 +1497,7 @@ func TestGetCharacterSoulcores(t *testing.T) {
 
 				// Then fail to get the soulcores
 				store.EXPECT().
-					GetCharacterSoulcores(gomock.Any(), characterID).
+					GetCharacterSoulcores(gomock.Any(), db.GetCharacterSoulcoresParams{Lang: "en", CharacterID: characterID}).
 					Return(nil, errors.New("database error"))
 			},
 			expectedCode:  http.StatusInternalServerError,
@@ -1370,7 +1521,7 @@ func TestGetCharacterSoulcores(t *testing.T) {
 
 				// Then return empty list of soulcores
 				store.EXPECT().
-					GetCharacterSoulcores(gomock.Any(), characterID).
+					GetCharacterSoulcores(gomock.Any(), db.GetCharacterSoulcoresParams{Lang: "en", CharacterID: characterID}).
 					Return([]db.GetCharacterSoulcoresRow{}, nil)
 			},
 			expectedCode: http.StatusOK,
@@ -1656,6 +1807,115 @@ func TestRemoveCharacterSoulcore(t *testing.T) {
 	}
 }
 
+func TestRestoreCharacterSoulcore(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(store *mockdb.MockStore, characterID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID}, nil)
+
+				store.EXPECT().
+					RestoreCharacterSoulcore(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.RestoreCharacterSoulcoreParams) (int64, error) {
+						require.Equal(t, characterID, arg.CharacterID)
+						require.Equal(t, creatureID, arg.CreatureID)
+						require.WithinDuration(t, time.Now().Add(-handlers.SoulcoreUndoWindow), arg.RestorableSince.Time, time.Minute)
+						return 1, nil
+					})
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Character Belongs To Different User",
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: uuid.New()}, nil)
+			},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "Character does not belong to user",
+		},
+		{
+			name: "Not Restorable",
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID}, nil)
+
+				store.EXPECT().
+					RestoreCharacterSoulcore(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "Removed soulcore not found",
+		},
+		{
+			name: "Database Error - RestoreCharacterSoulcore",
+			setupMocks: func(store *mockdb.MockStore, characterID uuid.UUID, creatureID uuid.UUID, userID uuid.UUID) {
+				store.EXPECT().
+					GetCharacter(gomock.Any(), characterID).
+					Return(db.Character{ID: characterID, UserID: userID}, nil)
+
+				store.EXPECT().
+					RestoreCharacterSoulcore(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Failed to restore soul core",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			emailService := newMockEmailService(ctrl)
+
+			characterID := uuid.New()
+			creatureID := uuid.New()
+			userID := uuid.New()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/characters/"+characterID.String()+"/soulcores/"+creatureID.String()+"/restore", nil)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			c.SetPath("/api/characters/:id/soulcores/:creature_id/restore")
+			c.SetParamNames("id", "creature_id")
+			c.SetParamValues(characterID.String(), creatureID.String())
+			c.Set("user_id", userID.String())
+
+			tc.setupMocks(store, characterID, creatureID, userID)
+
+			h := handlers.NewUsersHandler(store, emailService)
+			err := h.RestoreCharacterSoulcore(c)
+
+			if tc.expectedError != "" {
+				middleware.ErrorHandler(err, c)
+
+				require.Equal(t, tc.expectedCode, rec.Code)
+
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Contains(t, errorResponse["message"].(string), tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
 func TestLogin(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -2059,6 +2319,7 @@ func TestSignup(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			mockdb.ExpectTx(store)
 			emailService := newMockEmailService(ctrl)
 
 			// Create HTTP request
@@ -2131,7 +2392,7 @@ func TestGetCharacterPublic(t *testing.T) {
 
 				// Get character soulcores
 				store.EXPECT().
-					GetCharacterSoulcores(gomock.Any(), character.ID).
+					GetCharacterSoulcores(gomock.Any(), db.GetCharacterSoulcoresParams{Lang: "en", CharacterID: character.ID}).
 					Return([]db.GetCharacterSoulcoresRow{
 						{
 							CharacterID:  character.ID,
@@ -2202,7 +2463,7 @@ func TestGetCharacterPublic(t *testing.T) {
 					Return(character, nil)
 
 				store.EXPECT().
-					GetCharacterSoulcores(gomock.Any(), character.ID).
+					GetCharacterSoulcores(gomock.Any(), db.GetCharacterSoulcoresParams{Lang: "en", CharacterID: character.ID}).
 					Return(nil, errors.New("database error"))
 			},
 			expectedCode:  http.StatusInternalServerError,
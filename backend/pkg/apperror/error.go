@@ -18,6 +18,7 @@ const (
 	ErrorTypeValidation    ErrorType = "validation"
 	ErrorTypeAuthorization ErrorType = "authorization"
 	ErrorTypeNotFound      ErrorType = "not_found"
+	ErrorTypeConflict      ErrorType = "conflict"
 	ErrorTypeDatabase      ErrorType = "database"
 	ErrorTypeInternal      ErrorType = "internal"
 	ErrorTypeExternal      ErrorType = "external"
@@ -267,6 +268,10 @@ func NotFoundError(message string, err error) *AppError {
 	return NewError(ErrorTypeNotFound, "not_found_error", message, http.StatusNotFound, err)
 }
 
+func ConflictError(message string, err error) *AppError {
+	return NewError(ErrorTypeConflict, "conflict_error", message, http.StatusConflict, err)
+}
+
 func DatabaseError(message string, err error) *AppError {
 	return NewError(ErrorTypeDatabase, "database_error", message, http.StatusInternalServerError, err)
 }
@@ -0,0 +1,702 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+)
+
+// DefaultRenameThreshold is the name similarity from which a creature missing from the
+// catalog and a new one are taken for a rename
+const DefaultRenameThreshold = 0.8
+
+// CatalogCreature is a creature as the catalog files describe it. Difficulty is nil when
+// the difficulty source does not know it, Bestiary when the bestiary source does not list it.
+type CatalogCreature struct {
+	Name       string
+	Difficulty *int32
+	Bestiary   *CatalogBestiary
+}
+
+// CatalogBestiary is a creature's bestiary entry. Fields the source leaves empty are nil
+// and keep whatever the creature has.
+type CatalogBestiary struct {
+	Class       *string
+	Race        *string
+	CharmPoints *int32
+	Locations   []string
+	Sprite      *string
+}
+
+// Empty reports whether no field is set
+func (b CatalogBestiary) Empty() bool {
+	return b.Class == nil && b.Race == nil && b.CharmPoints == nil && b.Locations == nil && b.Sprite == nil
+}
+
+// CatalogRename is a creature whose name changed. Similarity is 1 for renames found
+// through an alias.
+type CatalogRename struct {
+	From       string
+	To         string
+	Similarity float64
+	ByAlias    bool
+}
+
+// CatalogDifficultyChange is a creature whose difficulty differs from the catalog. Name is
+// the creature's name after the sync.
+type CatalogDifficultyChange struct {
+	Name string
+	From *int32
+	To   *int32
+}
+
+// CatalogBestiaryChange is a creature whose bestiary entry differs from the catalog. From
+// and To are complete entries, nil fields are NULL. Name is the creature's name after the sync.
+type CatalogBestiaryChange struct {
+	Name string
+	From CatalogBestiary
+	To   CatalogBestiary
+}
+
+// CatalogTranslationChange is a translated creature name that is added, changed or removed.
+// From is nil for new translations, To for removed ones. Name is the creature's name after
+// the sync.
+type CatalogTranslationChange struct {
+	Language string
+	Name     string
+	From     *string
+	To       *string
+}
+
+// CatalogDiff holds what it takes to bring the creatures table in line with the catalog
+type CatalogDiff struct {
+	Added        []CatalogCreature
+	Removed      []CatalogCreature
+	Renamed      []CatalogRename
+	Difficulties []CatalogDifficultyChange
+	Bestiary     []CatalogBestiaryChange
+	Translations []CatalogTranslationChange
+}
+
+// Empty reports whether the creatures table already matches the catalog
+func (d CatalogDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Renamed) == 0 && len(d.Difficulties) == 0 &&
+		len(d.Bestiary) == 0 && len(d.Translations) == 0
+}
+
+// ParseCatalogNames reads creature names, one per line. Blank lines are skipped, duplicate
+// names are an error since they would clash on the unique name.
+func ParseCatalogNames(r io.Reader) ([]string, error) {
+	var names []string
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" {
+			continue
+		}
+		if first, ok := seen[strings.ToLower(name)]; ok {
+			return nil, fmt.Errorf("line %d: %q is already listed on line %d", line, name, first)
+		}
+		seen[strings.ToLower(name)] = line
+		names = append(names, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// ParseCatalogDifficulties reads a CSV file with a name,difficulty header
+func ParseCatalogDifficulties(r io.Reader) (map[string]int32, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("difficulty file is empty")
+		}
+		return nil, err
+	}
+	if strings.TrimSpace(header[0]) != "name" || strings.TrimSpace(header[1]) != "difficulty" {
+		return nil, fmt.Errorf("expected a name,difficulty header, got %s", strings.Join(header, ","))
+	}
+
+	difficulties := make(map[string]int32)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		difficulty, err := strconv.ParseInt(strings.TrimSpace(record[1]), 10, 32)
+		if err != nil || difficulty < 0 || difficulty > MaxCreatureDifficulty {
+			return nil, fmt.Errorf("line %d: difficulty must be between 0 and %d, got %q", line, MaxCreatureDifficulty, record[1])
+		}
+		difficulties[strings.TrimSpace(record[0])] = int32(difficulty)
+	}
+	return difficulties, nil
+}
+
+// bestiaryColumns are the columns a bestiary file can have besides name
+var bestiaryColumns = []string{"class", "race", "charm_points", "locations", "sprite"}
+
+// ParseCatalogBestiary reads a CSV file whose header is name followed by any of
+// bestiaryColumns, in any order. Columns the file leaves out and empty fields are left
+// unknown. Locations are separated by semicolons.
+func ParseCatalogBestiary(r io.Reader) (map[string]CatalogBestiary, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("bestiary file is empty")
+		}
+		return nil, err
+	}
+	if strings.TrimSpace(header[0]) != "name" {
+		return nil, fmt.Errorf("expected the header to start with name, got %s", strings.Join(header, ","))
+	}
+
+	columns := make(map[string]int, len(header)-1)
+	for i, column := range header[1:] {
+		column = strings.TrimSpace(column)
+		if !slices.Contains(bestiaryColumns, column) {
+			return nil, fmt.Errorf("unknown bestiary column %q, expected any of %s", column, strings.Join(bestiaryColumns, ","))
+		}
+		if _, ok := columns[column]; ok {
+			return nil, fmt.Errorf("bestiary column %q appears twice", column)
+		}
+		columns[column] = i + 1
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	text := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+
+	bestiary := make(map[string]CatalogBestiary)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		entry := CatalogBestiary{
+			Class:  text(field(record, "class")),
+			Race:   text(field(record, "race")),
+			Sprite: text(field(record, "sprite")),
+		}
+
+		if s := field(record, "charm_points"); s != "" {
+			points, err := strconv.ParseInt(s, 10, 32)
+			if err != nil || points < 0 {
+				return nil, fmt.Errorf("line %d: charm points must be a whole number of at least 0, got %q", line, s)
+			}
+			entry.CharmPoints = int4Ptr(int32(points), true)
+		}
+
+		for _, location := range strings.Split(field(record, "locations"), ";") {
+			if location = strings.TrimSpace(location); location != "" {
+				entry.Locations = append(entry.Locations, location)
+			}
+		}
+
+		bestiary[strings.TrimSpace(record[0])] = entry
+	}
+	return bestiary, nil
+}
+
+// ParseCatalogTranslations reads a CSV file with a name,translation header, translating
+// the canonical English creature names into one language. Rows without a translation are
+// skipped.
+func ParseCatalogTranslations(r io.Reader) (map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("translation file is empty")
+		}
+		return nil, err
+	}
+	if strings.TrimSpace(header[0]) != "name" || strings.TrimSpace(header[1]) != "translation" {
+		return nil, fmt.Errorf("expected a name,translation header, got %s", strings.Join(header, ","))
+	}
+
+	translations := make(map[string]string)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		name, translation := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if translation == "" {
+			continue
+		}
+		if _, ok := translations[name]; ok {
+			return nil, fmt.Errorf("line %d: %q is translated twice", line, name)
+		}
+		translations[name] = translation
+	}
+	return translations, nil
+}
+
+// DiffTranslations compares translation files, by language and creature name, with the
+// translations in the database. Languages without a file are left alone. Translations of
+// renamed creatures are compared under the new name, those of removed creatures go away
+// with the creature.
+func DiffTranslations(translations map[string]map[string]string, existing []db.GetAllCreatureTranslationsRow, diff CatalogDiff) []CatalogTranslationChange {
+	renamed := make(map[string]string, len(diff.Renamed))
+	for _, r := range diff.Renamed {
+		renamed[r.From] = r.To
+	}
+	removed := make(map[string]bool, len(diff.Removed))
+	for _, c := range diff.Removed {
+		removed[c.Name] = true
+	}
+
+	current := make(map[string]map[string]string)
+	for _, t := range existing {
+		if _, ok := translations[t.Language]; !ok || removed[t.CreatureName] {
+			continue
+		}
+		name := t.CreatureName
+		if to, ok := renamed[name]; ok {
+			name = to
+		}
+		if current[t.Language] == nil {
+			current[t.Language] = make(map[string]string)
+		}
+		current[t.Language][name] = t.Name
+	}
+
+	var changes []CatalogTranslationChange
+	for lang, names := range translations {
+		for name, to := range names {
+			if from, ok := current[lang][name]; !ok || from != to {
+				changes = append(changes, CatalogTranslationChange{Language: lang, Name: name, From: stringPtr(from, ok), To: &to})
+			}
+		}
+		for name, from := range current[lang] {
+			if _, ok := names[name]; !ok {
+				changes = append(changes, CatalogTranslationChange{Language: lang, Name: name, From: &from})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Language != changes[j].Language {
+			return changes[i].Language < changes[j].Language
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// DiffCatalog compares the catalog with the creatures table. aliases maps lower case
+// aliases to the current name of their creature. A creature missing from the catalog is
+// taken as renamed when a new catalog name is one of its aliases, or failing that, when
+// the names are at least threshold similar; the most similar pairs are matched first.
+// Catalog creatures without a difficulty keep the one they have, bestiary fields the
+// catalog leaves empty are kept the same way.
+func DiffCatalog(catalog []CatalogCreature, creatures []db.Creature, aliases map[string]string, threshold float64) CatalogDiff {
+	var diff CatalogDiff
+
+	current := make(map[string]db.Creature, len(creatures))
+	for _, c := range creatures {
+		current[c.Name] = c
+	}
+	listed := make(map[string]bool, len(catalog))
+	for _, c := range catalog {
+		listed[c.Name] = true
+	}
+
+	var added []CatalogCreature
+	for _, c := range catalog {
+		if existing, ok := current[c.Name]; ok {
+			diff.addDifficultyChange(c, existing)
+			diff.addBestiaryChange(c, existing)
+			continue
+		}
+		added = append(added, c)
+	}
+
+	removed := make(map[string]db.Creature)
+	for _, c := range creatures {
+		if !listed[c.Name] {
+			removed[c.Name] = c
+		}
+	}
+
+	renamedTo := make(map[string]bool)
+	renameTo := func(from db.Creature, to CatalogCreature, similarity float64, byAlias bool) {
+		diff.Renamed = append(diff.Renamed, CatalogRename{
+			From:       from.Name,
+			To:         to.Name,
+			Similarity: similarity,
+			ByAlias:    byAlias,
+		})
+		diff.addDifficultyChange(to, from)
+		diff.addBestiaryChange(to, from)
+		delete(removed, from.Name)
+		renamedTo[to.Name] = true
+	}
+
+	// Former names coming back
+	for _, c := range added {
+		if name, ok := aliases[strings.ToLower(c.Name)]; ok {
+			if from, ok := removed[name]; ok {
+				renameTo(from, c, 1, true)
+			}
+		}
+	}
+
+	type candidate struct {
+		from       db.Creature
+		to         CatalogCreature
+		similarity float64
+	}
+	var candidates []candidate
+	for _, c := range added {
+		if renamedTo[c.Name] {
+			continue
+		}
+		for _, r := range removed {
+			if s := NameSimilarity(r.Name, c.Name); s >= threshold {
+				candidates = append(candidates, candidate{from: r, to: c, similarity: s})
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].similarity != candidates[j].similarity {
+			return candidates[i].similarity > candidates[j].similarity
+		}
+		if candidates[i].from.Name != candidates[j].from.Name {
+			return candidates[i].from.Name < candidates[j].from.Name
+		}
+		return candidates[i].to.Name < candidates[j].to.Name
+	})
+	for _, cand := range candidates {
+		if _, ok := removed[cand.from.Name]; !ok || renamedTo[cand.to.Name] {
+			continue
+		}
+		renameTo(cand.from, cand.to, cand.similarity, false)
+	}
+
+	for _, c := range added {
+		if !renamedTo[c.Name] {
+			// Inserted without a bestiary entry, which is then set like for any other creature
+			diff.Added = append(diff.Added, CatalogCreature{Name: c.Name, Difficulty: c.Difficulty})
+			diff.addBestiaryChange(c, db.Creature{Name: c.Name})
+		}
+	}
+	for _, c := range removed {
+		removed := CatalogCreature{Name: c.Name, Difficulty: int4Ptr(c.Difficulty.Int32, c.Difficulty.Valid)}
+		if b := bestiaryOf(c); !b.Empty() {
+			removed.Bestiary = &b
+		}
+		diff.Removed = append(diff.Removed, removed)
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Renamed, func(i, j int) bool { return diff.Renamed[i].To < diff.Renamed[j].To })
+	sort.Slice(diff.Difficulties, func(i, j int) bool { return diff.Difficulties[i].Name < diff.Difficulties[j].Name })
+	sort.Slice(diff.Bestiary, func(i, j int) bool { return diff.Bestiary[i].Name < diff.Bestiary[j].Name })
+
+	return diff
+}
+
+func (d *CatalogDiff) addDifficultyChange(c CatalogCreature, existing db.Creature) {
+	if c.Difficulty == nil {
+		return
+	}
+	if existing.Difficulty.Valid && existing.Difficulty.Int32 == *c.Difficulty {
+		return
+	}
+	d.Difficulties = append(d.Difficulties, CatalogDifficultyChange{
+		Name: c.Name,
+		From: int4Ptr(existing.Difficulty.Int32, existing.Difficulty.Valid),
+		To:   c.Difficulty,
+	})
+}
+
+func (d *CatalogDiff) addBestiaryChange(c CatalogCreature, existing db.Creature) {
+	if c.Bestiary == nil {
+		return
+	}
+
+	from := bestiaryOf(existing)
+	to := from
+	if c.Bestiary.Class != nil {
+		to.Class = c.Bestiary.Class
+	}
+	if c.Bestiary.Race != nil {
+		to.Race = c.Bestiary.Race
+	}
+	if c.Bestiary.CharmPoints != nil {
+		to.CharmPoints = c.Bestiary.CharmPoints
+	}
+	if c.Bestiary.Locations != nil {
+		to.Locations = c.Bestiary.Locations
+	}
+	if c.Bestiary.Sprite != nil {
+		to.Sprite = c.Bestiary.Sprite
+	}
+
+	if equalBestiary(from, to) {
+		return
+	}
+	d.Bestiary = append(d.Bestiary, CatalogBestiaryChange{Name: c.Name, From: from, To: to})
+}
+
+// bestiaryOf returns the bestiary entry a creature has in the database
+func bestiaryOf(c db.Creature) CatalogBestiary {
+	b := CatalogBestiary{
+		Class:       textPtr(c.BestiaryClass),
+		Race:        textPtr(c.Race),
+		CharmPoints: int4Ptr(c.CharmPoints.Int32, c.CharmPoints.Valid),
+		Sprite:      textPtr(c.Sprite),
+	}
+	if len(c.Locations) > 0 {
+		b.Locations = c.Locations
+	}
+	return b
+}
+
+func equalBestiary(a, b CatalogBestiary) bool {
+	return equalPtr(a.Class, b.Class) && equalPtr(a.Race, b.Race) && equalPtr(a.CharmPoints, b.CharmPoints) &&
+		equalPtr(a.Sprite, b.Sprite) && slices.Equal(a.Locations, b.Locations)
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func stringPtr(s string, valid bool) *string {
+	if !valid {
+		return nil
+	}
+	return &s
+}
+
+func textPtr(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
+func int4Ptr(v int32, valid bool) *int32 {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// NameSimilarity is one minus the edit distance between two creature names relative to
+// the longer one. Case and repeated spaces are ignored.
+func NameSimilarity(a, b string) float64 {
+	ra := []rune(strings.Join(strings.Fields(strings.ToLower(a)), " "))
+	rb := []rune(strings.Join(strings.Fields(strings.ToLower(b)), " "))
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	// Levenshtein distance keeping only the previous row
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+// CatalogMigration renders the diff as a goose migration. Renames go through
+// rename_creature so the old names stay as aliases. Removals are plain deletes, which fail
+// for creatures that are still referenced instead of dropping soulcores with them.
+func CatalogMigration(d CatalogDiff) string {
+	var up, down strings.Builder
+
+	if len(d.Renamed) > 0 {
+		fmt.Fprintf(&up, "-- Rename %d creature(s)\n", len(d.Renamed))
+		fmt.Fprintf(&down, "-- Undo %d rename(s)\n", len(d.Renamed))
+		for _, r := range d.Renamed {
+			fmt.Fprintf(&up, "SELECT rename_creature(%s, %s);\n", sqlString(r.From), sqlString(r.To))
+			fmt.Fprintf(&down, "SELECT rename_creature(%s, %s);\n", sqlString(r.To), sqlString(r.From))
+		}
+	}
+
+	if len(d.Added) > 0 {
+		separate(&up, &down)
+		fmt.Fprintf(&up, "-- Add %d new creature(s)\n", len(d.Added))
+		fmt.Fprintf(&down, "-- Remove %d added creature(s)\n", len(d.Added))
+		for _, c := range d.Added {
+			fmt.Fprintf(&up, "INSERT INTO creatures (name, difficulty) VALUES (%s, %s) ON CONFLICT (name) DO NOTHING;\n", sqlString(c.Name), sqlInt(c.Difficulty))
+			fmt.Fprintf(&down, "DELETE FROM creatures WHERE name = %s;\n", sqlString(c.Name))
+		}
+	}
+
+	if len(d.Removed) > 0 {
+		separate(&up, &down)
+		fmt.Fprintf(&up, "-- Remove %d creature(s) no longer in the catalog\n", len(d.Removed))
+		fmt.Fprintf(&down, "-- Restore %d removed creature(s)\n", len(d.Removed))
+		for _, c := range d.Removed {
+			fmt.Fprintf(&up, "DELETE FROM creatures WHERE name = %s;\n", sqlString(c.Name))
+			fmt.Fprintf(&down, "INSERT INTO creatures (name, difficulty) VALUES (%s, %s) ON CONFLICT (name) DO NOTHING;\n", sqlString(c.Name), sqlInt(c.Difficulty))
+			if c.Bestiary != nil {
+				fmt.Fprintf(&down, "%s\n", sqlBestiaryUpdate(c.Name, *c.Bestiary))
+			}
+		}
+	}
+
+	if len(d.Difficulties) > 0 {
+		separate(&up, &down)
+		fmt.Fprintf(&up, "-- Update %d difficulty rating(s)\n", len(d.Difficulties))
+		fmt.Fprintf(&down, "-- Restore %d difficulty rating(s)\n", len(d.Difficulties))
+		for _, c := range d.Difficulties {
+			fmt.Fprintf(&up, "UPDATE creatures SET difficulty = %s WHERE name = %s;\n", sqlInt(c.To), sqlString(c.Name))
+			fmt.Fprintf(&down, "UPDATE creatures SET difficulty = %s WHERE name = %s;\n", sqlInt(c.From), sqlString(c.Name))
+		}
+	}
+
+	if len(d.Bestiary) > 0 {
+		separate(&up, &down)
+		fmt.Fprintf(&up, "-- Update the bestiary entries of %d creature(s)\n", len(d.Bestiary))
+		fmt.Fprintf(&down, "-- Restore the bestiary entries of %d creature(s)\n", len(d.Bestiary))
+		for _, c := range d.Bestiary {
+			fmt.Fprintf(&up, "%s\n", sqlBestiaryUpdate(c.Name, c.To))
+			fmt.Fprintf(&down, "%s\n", sqlBestiaryUpdate(c.Name, c.From))
+		}
+	}
+
+	if len(d.Translations) > 0 {
+		separate(&up, &down)
+		fmt.Fprintf(&up, "-- Update %d creature name translation(s)\n", len(d.Translations))
+		fmt.Fprintf(&down, "-- Restore %d creature name translation(s)\n", len(d.Translations))
+		for _, t := range d.Translations {
+			fmt.Fprintf(&up, "%s\n", sqlTranslation(t.Language, t.Name, t.To))
+			fmt.Fprintf(&down, "%s\n", sqlTranslation(t.Language, t.Name, t.From))
+		}
+	}
+
+	// Undo in reverse: translations, bestiary entries, difficulties, removals, additions,
+	// then renames
+	return "-- +goose Up\n-- +goose StatementBegin\n" + up.String() +
+		"-- +goose StatementEnd\n\n-- +goose Down\n-- +goose StatementBegin\n" + reverseSections(down.String()) +
+		"-- +goose StatementEnd\n"
+}
+
+func separate(builders ...*strings.Builder) {
+	for _, b := range builders {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+	}
+}
+
+func reverseSections(s string) string {
+	sections := strings.Split(strings.TrimSuffix(s, "\n"), "\n\n")
+	for i, j := 0, len(sections)-1; i < j; i, j = i+1, j-1 {
+		sections[i], sections[j] = sections[j], sections[i]
+	}
+	if s == "" {
+		return ""
+	}
+	return strings.Join(sections, "\n\n") + "\n"
+}
+
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func sqlInt(d *int32) string {
+	if d == nil {
+		return "NULL"
+	}
+	return strconv.Itoa(int(*d))
+}
+
+func sqlText(s *string) string {
+	if s == nil {
+		return "NULL"
+	}
+	return sqlString(*s)
+}
+
+func sqlTextArray(values []string) string {
+	if len(values) == 0 {
+		return "'{}'"
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = sqlString(v)
+	}
+	return "ARRAY[" + strings.Join(quoted, ", ") + "]"
+}
+
+func sqlBestiaryUpdate(name string, b CatalogBestiary) string {
+	return fmt.Sprintf("UPDATE creatures SET bestiary_class = %s, race = %s, charm_points = %s, locations = %s, sprite = %s WHERE name = %s;",
+		sqlText(b.Class), sqlText(b.Race), sqlInt(b.CharmPoints), sqlTextArray(b.Locations), sqlText(b.Sprite), sqlString(name))
+}
+
+// sqlTranslation sets the translation of a creature's name, or removes it when translation is nil
+func sqlTranslation(lang, name string, translation *string) string {
+	if translation == nil {
+		return fmt.Sprintf("DELETE FROM creature_translations WHERE language = %s AND creature_id = (SELECT id FROM creatures WHERE name = %s);",
+			sqlString(lang), sqlString(name))
+	}
+	return fmt.Sprintf("INSERT INTO creature_translations (creature_id, language, name) SELECT id, %s, %s FROM creatures WHERE name = %s "+
+		"ON CONFLICT (creature_id, language) DO UPDATE SET name = EXCLUDED.name;",
+		sqlString(lang), sqlString(*translation), sqlString(name))
+}
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func catalogCreature(name string, difficulty int32) CatalogCreature {
+	if difficulty == 0 {
+		return CatalogCreature{Name: name}
+	}
+	return CatalogCreature{Name: name, Difficulty: &difficulty}
+}
+
+func dbCreature(name string, difficulty int32) db.Creature {
+	return db.Creature{ID: uuid.New(), Name: name, Difficulty: pgtype.Int4{Int32: difficulty, Valid: difficulty != 0}}
+}
+
+func TestDiffCatalog(t *testing.T) {
+	creatures := []db.Creature{
+		dbCreature("Rat", 1),
+		dbCreature("Dragon", 2),
+		dbCreature("Horse (Dark Brown)", 1),
+		dbCreature("Nomad  (Blue)", 2),
+		dbCreature("Monk (Creature)", 3),
+		dbCreature("Shell Drake", 0),
+	}
+
+	t.Run("matching catalog has no changes", func(t *testing.T) {
+		catalog := []CatalogCreature{catalogCreature("Rat", 1), catalogCreature("Dragon", 0)}
+		diff := DiffCatalog(catalog, creatures[:2], nil, DefaultRenameThreshold)
+		assert.True(t, diff.Empty())
+	})
+
+	t.Run("detects additions, removals, renames and difficulties", func(t *testing.T) {
+		catalog := []CatalogCreature{
+			catalogCreature("Rat", 2),
+			catalogCreature("Dragon", 0),
+			catalogCreature("Horse (Taupe)", 1),
+			catalogCreature("Nomad (Blue)", 3),
+			catalogCreature("Monk", 3),
+			catalogCreature("Bluebeak", 4),
+		}
+		aliases := map[string]string{"monk": "Monk (Creature)"}
+
+		diff := DiffCatalog(catalog, creatures, aliases, DefaultRenameThreshold)
+
+		require.Len(t, diff.Renamed, 2)
+		assert.Equal(t, CatalogRename{From: "Monk (Creature)", To: "Monk", Similarity: 1, ByAlias: true}, diff.Renamed[0])
+		assert.Equal(t, "Nomad  (Blue)", diff.Renamed[1].From)
+		assert.Equal(t, "Nomad (Blue)", diff.Renamed[1].To)
+		assert.InDelta(t, 1, diff.Renamed[1].Similarity, 0.001)
+
+		// Too different to be taken for a rename
+		require.Len(t, diff.Added, 2)
+		assert.Equal(t, "Bluebeak", diff.Added[0].Name)
+		assert.Equal(t, "Horse (Taupe)", diff.Added[1].Name)
+
+		require.Len(t, diff.Removed, 2)
+		assert.Equal(t, "Horse (Dark Brown)", diff.Removed[0].Name)
+		assert.Equal(t, "Shell Drake", diff.Removed[1].Name)
+		assert.Nil(t, diff.Removed[1].Difficulty)
+
+		// Renamed creatures are compared under their new name
+		require.Len(t, diff.Difficulties, 2)
+		assert.Equal(t, "Nomad (Blue)", diff.Difficulties[0].Name)
+		assert.Equal(t, int32(2), *diff.Difficulties[0].From)
+		assert.Equal(t, int32(3), *diff.Difficulties[0].To)
+		assert.Equal(t, "Rat", diff.Difficulties[1].Name)
+	})
+
+	t.Run("most similar names are paired first", func(t *testing.T) {
+		catalog := []CatalogCreature{catalogCreature("Lizard Magician", 0), catalogCreature("Lizard Magicians", 0)}
+		diff := DiffCatalog(catalog, []db.Creature{dbCreature("Lizard Magican", 2)}, nil, DefaultRenameThreshold)
+
+		require.Len(t, diff.Renamed, 1)
+		assert.Equal(t, "Lizard Magician", diff.Renamed[0].To)
+		require.Len(t, diff.Added, 1)
+		assert.Equal(t, "Lizard Magicians", diff.Added[0].Name)
+		assert.Empty(t, diff.Removed)
+	})
+}
+
+func TestDiffCatalogBestiary(t *testing.T) {
+	dragon := dbCreature("Dragon", 2)
+	dragon.BestiaryClass = pgtype.Text{String: "Dragons", Valid: true}
+	dragon.Locations = []string{"Darashia"}
+	rat := dbCreature("Rat", 1)
+	rat.Race = pgtype.Text{String: "Rat", Valid: true}
+
+	class, sprite, points := "Dragons", "/assets/soulcores/Dragon_Soul_Core.gif", int32(25)
+	catalog := []CatalogCreature{
+		// Only the fields the bestiary file knows change, the locations are kept
+		{Name: "Dragon", Bestiary: &CatalogBestiary{Class: &class, CharmPoints: &points, Sprite: &sprite}},
+		// Nothing new
+		{Name: "Rat", Bestiary: &CatalogBestiary{}},
+		{Name: "Dragon Lord", Bestiary: &CatalogBestiary{Class: &class}},
+	}
+
+	diff := DiffCatalog(catalog, []db.Creature{dragon, rat}, nil, DefaultRenameThreshold)
+
+	require.Len(t, diff.Added, 1)
+	assert.Nil(t, diff.Added[0].Bestiary)
+
+	require.Len(t, diff.Bestiary, 2)
+	assert.Equal(t, "Dragon", diff.Bestiary[0].Name)
+	assert.Nil(t, diff.Bestiary[0].From.CharmPoints)
+	assert.Equal(t, CatalogBestiary{Class: &class, CharmPoints: &points, Locations: []string{"Darashia"}, Sprite: &sprite}, diff.Bestiary[0].To)
+	// New creatures get their entry set after being added
+	assert.Equal(t, "Dragon Lord", diff.Bestiary[1].Name)
+	assert.True(t, diff.Bestiary[1].From.Empty())
+}
+
+func TestNameSimilarity(t *testing.T) {
+	assert.InDelta(t, 1, NameSimilarity("Nomad  (Blue)", "nomad (blue)"), 0.001)
+	assert.InDelta(t, 1-1.0/15, NameSimilarity("Lizard Magican", "Lizard Magician"), 0.001)
+	assert.Less(t, NameSimilarity("Horse (Dark Brown)", "Horse (Taupe)"), DefaultRenameThreshold)
+	assert.InDelta(t, 1, NameSimilarity("", ""), 0.001)
+}
+
+func TestParseCatalogNames(t *testing.T) {
+	names, err := ParseCatalogNames(strings.NewReader("Rat\n\n  Dragon \nKnight's Apparition\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Rat", "Dragon", "Knight's Apparition"}, names)
+
+	_, err = ParseCatalogNames(strings.NewReader("Rat\nDragon\nrat\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func TestParseCatalogDifficulties(t *testing.T) {
+	difficulties, err := ParseCatalogDifficulties(strings.NewReader("name,difficulty\nRat,1\nDragon,3\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int32{"Rat": 1, "Dragon": 3}, difficulties)
+
+	_, err = ParseCatalogDifficulties(strings.NewReader("creature,stars\nRat,1\n"))
+	require.Error(t, err)
+
+	_, err = ParseCatalogDifficulties(strings.NewReader("name,difficulty\nRat,1\nDragon,9\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func TestParseCatalogBestiary(t *testing.T) {
+	bestiary, err := ParseCatalogBestiary(strings.NewReader(
+		"name,class,race,charm_points,locations,sprite\n" +
+			"Dragon,Dragons,Dragon,25,Darashia Dragon Lair; Fenrock;,/assets/soulcores/Dragon_Soul_Core.gif\n" +
+			"Rat,,,,,\n"))
+	require.NoError(t, err)
+	require.Len(t, bestiary, 2)
+	assert.Equal(t, "Dragons", *bestiary["Dragon"].Class)
+	assert.Equal(t, int32(25), *bestiary["Dragon"].CharmPoints)
+	assert.Equal(t, []string{"Darashia Dragon Lair", "Fenrock"}, bestiary["Dragon"].Locations)
+	assert.True(t, bestiary["Rat"].Empty())
+
+	// Columns the file leaves out stay unknown
+	bestiary, err = ParseCatalogBestiary(strings.NewReader("name,sprite,race\nRat,/assets/soulcores/Rat_Soul_Core.gif,Rat\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "/assets/soulcores/Rat_Soul_Core.gif", *bestiary["Rat"].Sprite)
+	assert.Equal(t, "Rat", *bestiary["Rat"].Race)
+	assert.Nil(t, bestiary["Rat"].Class)
+
+	_, err = ParseCatalogBestiary(strings.NewReader("name,stars\nRat,1\n"))
+	require.Error(t, err)
+
+	_, err = ParseCatalogBestiary(strings.NewReader("name,race,race\nRat,Rat,Rat\n"))
+	require.Error(t, err)
+
+	_, err = ParseCatalogBestiary(strings.NewReader("name,charm_points\nRat,5\nCave Rat,-5\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+// The bestiary file only ships columns that are filled in for every creature it lists, a
+// blank field would hide the creature from anything that filters or groups by the column
+func TestCatalogBestiaryData(t *testing.T) {
+	f, err := os.Open("../../data/creature_bestiary.csv")
+	require.NoError(t, err)
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+
+	header := records[0]
+	for _, record := range records[1:] {
+		for i, field := range record[1:] {
+			assert.NotEmpty(t, strings.TrimSpace(field), "%s has no %s", record[0], header[i+1])
+		}
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	bestiary, err := ParseCatalogBestiary(f)
+	require.NoError(t, err)
+
+	names, err := os.ReadFile("../../data/creatures.txt")
+	require.NoError(t, err)
+	catalog, err := ParseCatalogNames(bytes.NewReader(names))
+	require.NoError(t, err)
+	for name := range bestiary {
+		assert.Contains(t, catalog, name)
+	}
+}
+
+func TestParseCatalogTranslations(t *testing.T) {
+	translations, err := ParseCatalogTranslations(strings.NewReader("name,translation\nDragon,Smok\nRat,\n\"Knight's Apparition\",\" Zjawa Rycerza \"\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Dragon": "Smok", "Knight's Apparition": "Zjawa Rycerza"}, translations)
+
+	_, err = ParseCatalogTranslations(strings.NewReader("name,pl\nDragon,Smok\n"))
+	require.Error(t, err)
+
+	_, err = ParseCatalogTranslations(strings.NewReader("name,translation\nDragon,Smok\nDragon,Wielki Smok\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func TestDiffTranslations(t *testing.T) {
+	existing := []db.GetAllCreatureTranslationsRow{
+		{Language: "de", CreatureName: "Dragon", Name: "Drache"},
+		{Language: "pl", CreatureName: "Dragon", Name: "Smok"},
+		{Language: "pl", CreatureName: "Rat", Name: "Szczur"},
+		{Language: "pl", CreatureName: "Nomad  (Blue)", Name: "Nomada (Niebieski)"},
+		{Language: "pl", CreatureName: "Shell Drake", Name: "Muszlowy Smok"},
+	}
+	diff := CatalogDiff{
+		Renamed: []CatalogRename{{From: "Nomad  (Blue)", To: "Nomad (Blue)", Similarity: 1}},
+		Removed: []CatalogCreature{{Name: "Shell Drake"}},
+	}
+	translations := map[string]map[string]string{
+		"pl": {
+			"Dragon":       "Wielki Smok",
+			"Nomad (Blue)": "Nomada (Niebieski)",
+			"Bluebeak":     "Niebieskodziób",
+		},
+	}
+
+	changes := DiffTranslations(translations, existing, diff)
+
+	// German has no file and stays as it is, renamed creatures keep their translation
+	require.Len(t, changes, 3)
+	assert.Equal(t, "Bluebeak", changes[0].Name)
+	assert.Nil(t, changes[0].From)
+	assert.Equal(t, "Niebieskodziób", *changes[0].To)
+	assert.Equal(t, "Dragon", changes[1].Name)
+	assert.Equal(t, "Smok", *changes[1].From)
+	assert.Equal(t, "Wielki Smok", *changes[1].To)
+	assert.Equal(t, "Rat", changes[2].Name)
+	assert.Equal(t, "Szczur", *changes[2].From)
+	assert.Nil(t, changes[2].To)
+}
+
+func TestCatalogMigration(t *testing.T) {
+	three, two := int32(3), int32(2)
+	diff := CatalogDiff{
+		Renamed:      []CatalogRename{{From: "Nomad  (Blue)", To: "Nomad (Blue)", Similarity: 1}},
+		Added:        []CatalogCreature{{Name: "Knight's Apparition", Difficulty: &three}},
+		Removed:      []CatalogCreature{{Name: "Shell Drake"}},
+		Difficulties: []CatalogDifficultyChange{{Name: "Rat", From: nil, To: &two}},
+	}
+
+	migration := CatalogMigration(diff)
+
+	up, down, ok := strings.Cut(migration, "-- +goose Down")
+	require.True(t, ok)
+
+	assert.Contains(t, up, "SELECT rename_creature('Nomad  (Blue)', 'Nomad (Blue)');")
+	assert.Contains(t, up, "INSERT INTO creatures (name, difficulty) VALUES ('Knight''s Apparition', 3) ON CONFLICT (name) DO NOTHING;")
+	assert.Contains(t, up, "DELETE FROM creatures WHERE name = 'Shell Drake';")
+	assert.Contains(t, up, "UPDATE creatures SET difficulty = 2 WHERE name = 'Rat';")
+	assert.Less(t, strings.Index(up, "rename_creature"), strings.Index(up, "INSERT"))
+
+	assert.Contains(t, down, "SELECT rename_creature('Nomad (Blue)', 'Nomad  (Blue)');")
+	assert.Contains(t, down, "DELETE FROM creatures WHERE name = 'Knight''s Apparition';")
+	assert.Contains(t, down, "INSERT INTO creatures (name, difficulty) VALUES ('Shell Drake', NULL) ON CONFLICT (name) DO NOTHING;")
+	assert.Contains(t, down, "UPDATE creatures SET difficulty = NULL WHERE name = 'Rat';")
+	// Undone in reverse, renames last
+	assert.Less(t, strings.Index(down, "UPDATE"), strings.Index(down, "rename_creature"))
+}
+
+func TestCatalogMigrationBestiary(t *testing.T) {
+	class := "Dragons"
+	diff := CatalogDiff{
+		Removed: []CatalogCreature{{Name: "Shell Drake", Bestiary: &CatalogBestiary{Class: &class}}},
+		Bestiary: []CatalogBestiaryChange{{
+			Name: "Dragon",
+			From: CatalogBestiary{},
+			To:   CatalogBestiary{Class: &class, Locations: []string{"Darashia", "Goroma's Dragon Lair"}},
+		}},
+	}
+
+	up, down, ok := strings.Cut(CatalogMigration(diff), "-- +goose Down")
+	require.True(t, ok)
+
+	assert.Contains(t, up, "UPDATE creatures SET bestiary_class = 'Dragons', race = NULL, charm_points = NULL, "+
+		"locations = ARRAY['Darashia', 'Goroma''s Dragon Lair'], sprite = NULL WHERE name = 'Dragon';")
+	assert.Contains(t, down, "UPDATE creatures SET bestiary_class = NULL, race = NULL, charm_points = NULL, "+
+		"locations = '{}', sprite = NULL WHERE name = 'Dragon';")
+
+	// Removed creatures get their entry back along with them
+	assert.Less(t, strings.Index(down, "INSERT INTO creatures (name, difficulty) VALUES ('Shell Drake'"),
+		strings.Index(down, "UPDATE creatures SET bestiary_class = 'Dragons'"))
+}
+
+func TestCatalogMigrationTranslations(t *testing.T) {
+	smok, szczur := "Smok", "Szczur"
+	diff := CatalogDiff{
+		Translations: []CatalogTranslationChange{
+			{Language: "pl", Name: "Dragon", To: &smok},
+			{Language: "pl", Name: "Rat", From: &szczur},
+		},
+	}
+
+	up, down, ok := strings.Cut(CatalogMigration(diff), "-- +goose Down")
+	require.True(t, ok)
+
+	assert.Contains(t, up, "INSERT INTO creature_translations (creature_id, language, name) SELECT id, 'pl', 'Smok' FROM creatures WHERE name = 'Dragon' "+
+		"ON CONFLICT (creature_id, language) DO UPDATE SET name = EXCLUDED.name;")
+	assert.Contains(t, up, "DELETE FROM creature_translations WHERE language = 'pl' AND creature_id = (SELECT id FROM creatures WHERE name = 'Rat');")
+	assert.Contains(t, down, "DELETE FROM creature_translations WHERE language = 'pl' AND creature_id = (SELECT id FROM creatures WHERE name = 'Dragon');")
+	assert.Contains(t, down, "SELECT id, 'pl', 'Szczur' FROM creatures WHERE name = 'Rat'")
+}
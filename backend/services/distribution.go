@@ -0,0 +1,156 @@
+package services
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/google/uuid"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+)
+
+// DistributionCore is an obtained soulcore waiting for a recipient
+type DistributionCore struct {
+	CreatureID   uuid.UUID `json:"creature_id"`
+	CreatureName string    `json:"creature_name"`
+}
+
+// DistributionMember is an active list member who may receive soulcores
+type DistributionMember struct {
+	UserID        uuid.UUID
+	CharacterID   uuid.UUID
+	CharacterName string
+	// Unlocked holds the creatures the member's character has already unlocked
+	Unlocked map[uuid.UUID]bool
+}
+
+// DistributionAssignment gives a soulcore to a member
+type DistributionAssignment struct {
+	CreatureID    uuid.UUID `json:"creature_id"`
+	CreatureName  string    `json:"creature_name"`
+	UserID        uuid.UUID `json:"user_id"`
+	CharacterID   uuid.UUID `json:"character_id"`
+	CharacterName string    `json:"character_name"`
+}
+
+// DistributionResult is the outcome of a distribution. Unassigned holds the cores every
+// active member already has.
+type DistributionResult struct {
+	Assignments []DistributionAssignment `json:"assignments"`
+	Unassigned  []DistributionCore       `json:"unassigned"`
+}
+
+// ValidDistributionMode reports whether mode is one of the known distribution modes
+func ValidDistributionMode(mode db.DistributionMode) bool {
+	switch mode {
+	case db.DistributionModeNeed, db.DistributionModeRoundRobin,
+		db.DistributionModeFewestCores, db.DistributionModeRandom:
+		return true
+	}
+	return false
+}
+
+// Distribute decides which member receives each core. Only members who have not unlocked
+// a creature are candidates for its core. The seed drives every tie-break and roll, so
+// the same seed, cores and members always give the same result.
+//
+//   - need gives the scarcest cores out first, each to the candidate who received the
+//     fewest so far, preferring whoever lacks most of the batch
+//   - round_robin walks the members in turn, giving each core to the next one lacking it
+//   - fewest_cores gives each core to the candidate with the fewest unlocked soulcores
+//   - random rolls among the candidates of each core
+func Distribute(mode db.DistributionMode, seed int64, cores []DistributionCore, members []DistributionMember) DistributionResult {
+	rng := rand.New(rand.NewSource(seed))
+
+	// Sort the input first so the order the rows came in does not change the outcome
+	cores = append([]DistributionCore(nil), cores...)
+	sort.Slice(cores, func(i, j int) bool {
+		if cores[i].CreatureName != cores[j].CreatureName {
+			return cores[i].CreatureName < cores[j].CreatureName
+		}
+		return cores[i].CreatureID.String() < cores[j].CreatureID.String()
+	})
+	members = append([]DistributionMember(nil), members...)
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CharacterID.String() < members[j].CharacterID.String()
+	})
+	rng.Shuffle(len(members), func(i, j int) {
+		members[i], members[j] = members[j], members[i]
+	})
+
+	candidates := func(core DistributionCore) []int {
+		var idx []int
+		for i, m := range members {
+			if !m.Unlocked[core.CreatureID] {
+				idx = append(idx, i)
+			}
+		}
+		return idx
+	}
+
+	received := make([]int, len(members))
+	missing := make([]int, len(members))
+	for _, core := range cores {
+		for _, i := range candidates(core) {
+			missing[i]++
+		}
+	}
+
+	if mode == db.DistributionModeNeed {
+		sort.SliceStable(cores, func(i, j int) bool {
+			return len(candidates(cores[i])) < len(candidates(cores[j]))
+		})
+	}
+
+	result := DistributionResult{
+		Assignments: []DistributionAssignment{},
+		Unassigned:  []DistributionCore{},
+	}
+	next := 0
+	for _, core := range cores {
+		idx := candidates(core)
+		if len(idx) == 0 {
+			result.Unassigned = append(result.Unassigned, core)
+			continue
+		}
+
+		// Candidates are in shuffled member order, so the first best one wins ties
+		pick := idx[0]
+		switch mode {
+		case db.DistributionModeNeed:
+			for _, i := range idx[1:] {
+				if received[i] < received[pick] || (received[i] == received[pick] && missing[i] > missing[pick]) {
+					pick = i
+				}
+			}
+		case db.DistributionModeRoundRobin:
+			for _, i := range idx {
+				if i >= next {
+					pick = i
+					break
+				}
+			}
+			next = pick + 1
+		case db.DistributionModeFewestCores:
+			unlocked := func(i int) int { return len(members[i].Unlocked) + received[i] }
+			for _, i := range idx[1:] {
+				if unlocked(i) < unlocked(pick) {
+					pick = i
+				}
+			}
+		case db.DistributionModeRandom:
+			pick = idx[rng.Intn(len(idx))]
+		}
+
+		received[pick]++
+		m := members[pick]
+		result.Assignments = append(result.Assignments, DistributionAssignment{
+			CreatureID:    core.CreatureID,
+			CreatureName:  core.CreatureName,
+			UserID:        m.UserID,
+			CharacterID:   m.CharacterID,
+			CharacterName: m.CharacterName,
+		})
+	}
+
+	return result
+}
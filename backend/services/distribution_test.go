@@ -0,0 +1,107 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func distributionMember(name string, unlocked ...DistributionCore) DistributionMember {
+	m := DistributionMember{
+		UserID:        uuid.New(),
+		CharacterID:   uuid.New(),
+		CharacterName: name,
+		Unlocked:      make(map[uuid.UUID]bool),
+	}
+	for _, core := range unlocked {
+		m.Unlocked[core.CreatureID] = true
+	}
+	return m
+}
+
+func recipients(result DistributionResult) map[string]string {
+	byCreature := make(map[string]string)
+	for _, a := range result.Assignments {
+		byCreature[a.CreatureName] = a.CharacterName
+	}
+	return byCreature
+}
+
+func TestDistribute(t *testing.T) {
+	dragon := DistributionCore{CreatureID: uuid.New(), CreatureName: "Dragon"}
+	demon := DistributionCore{CreatureID: uuid.New(), CreatureName: "Demon"}
+	hydra := DistributionCore{CreatureID: uuid.New(), CreatureName: "Hydra"}
+
+	t.Run("only members lacking a creature receive its core", func(t *testing.T) {
+		knight := distributionMember("Knight", dragon)
+		druid := distributionMember("Druid")
+		members := []DistributionMember{knight, druid}
+
+		for _, mode := range []db.DistributionMode{
+			db.DistributionModeNeed, db.DistributionModeRoundRobin,
+			db.DistributionModeFewestCores, db.DistributionModeRandom,
+		} {
+			result := Distribute(mode, 42, []DistributionCore{dragon}, members)
+			require.Len(t, result.Assignments, 1, mode)
+			assert.Equal(t, druid.UserID, result.Assignments[0].UserID, mode)
+			assert.Empty(t, result.Unassigned, mode)
+		}
+	})
+
+	t.Run("core everyone has is left unassigned", func(t *testing.T) {
+		members := []DistributionMember{distributionMember("Knight", dragon), distributionMember("Druid", dragon)}
+
+		result := Distribute(db.DistributionModeNeed, 1, []DistributionCore{dragon}, members)
+		assert.Empty(t, result.Assignments)
+		assert.Equal(t, []DistributionCore{dragon}, result.Unassigned)
+	})
+
+	t.Run("need serves the scarcest core first and spreads the rest", func(t *testing.T) {
+		// Only the druid lacks the hydra, so the knight has to get the dragon
+		knight := distributionMember("Knight", hydra)
+		druid := distributionMember("Druid")
+		members := []DistributionMember{knight, druid}
+
+		for seed := int64(0); seed < 10; seed++ {
+			result := Distribute(db.DistributionModeNeed, seed, []DistributionCore{dragon, hydra}, members)
+			assert.Equal(t, map[string]string{"Dragon": "Knight", "Hydra": "Druid"}, recipients(result))
+		}
+	})
+
+	t.Run("round robin takes turns", func(t *testing.T) {
+		members := []DistributionMember{distributionMember("Knight"), distributionMember("Druid")}
+
+		result := Distribute(db.DistributionModeRoundRobin, 7, []DistributionCore{dragon, demon, hydra}, members)
+		require.Len(t, result.Assignments, 3)
+		assert.NotEqual(t, result.Assignments[0].UserID, result.Assignments[1].UserID)
+		assert.Equal(t, result.Assignments[0].UserID, result.Assignments[2].UserID)
+	})
+
+	t.Run("fewest cores favours the member furthest behind", func(t *testing.T) {
+		veteran := distributionMember("Veteran", demon, hydra)
+		rookie := distributionMember("Rookie")
+		members := []DistributionMember{veteran, rookie}
+
+		result := Distribute(db.DistributionModeFewestCores, 3, []DistributionCore{dragon}, members)
+		assert.Equal(t, map[string]string{"Dragon": "Rookie"}, recipients(result))
+	})
+
+	t.Run("same seed gives the same result", func(t *testing.T) {
+		members := []DistributionMember{distributionMember("Knight"), distributionMember("Druid"), distributionMember("Sorcerer")}
+		cores := []DistributionCore{dragon, demon, hydra}
+
+		first := Distribute(db.DistributionModeRandom, 1234, cores, members)
+		reversed := []DistributionMember{members[2], members[1], members[0]}
+		second := Distribute(db.DistributionModeRandom, 1234, []DistributionCore{hydra, demon, dragon}, reversed)
+		assert.Equal(t, first, second)
+	})
+}
+
+func TestValidDistributionMode(t *testing.T) {
+	assert.True(t, ValidDistributionMode(db.DistributionModeNeed))
+	assert.True(t, ValidDistributionMode(db.DistributionModeRandom))
+	assert.False(t, ValidDistributionMode(db.DistributionMode("dice")))
+}
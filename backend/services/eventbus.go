@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrEventBusClosed is returned when publishing to an event bus that has been shut down
+var ErrEventBusClosed = errors.New("event bus is closed")
+
+// EventHandler receives every event delivered by an EventBus
+type EventHandler func(ctx context.Context, event ListEvent)
+
+// EventBus distributes list events to every backend instance
+type EventBus interface {
+	EventPublisher
+	Subscribe(handler EventHandler)
+	Close() error
+}
+
+// Ensure MemoryEventBus implements EventBus
+var _ EventBus = (*MemoryEventBus)(nil)
+
+// MemoryEventBus delivers events within a single process, used for tests and
+// single-node development
+type MemoryEventBus struct {
+	mu       sync.RWMutex
+	handlers []EventHandler
+	closed   bool
+}
+
+func NewMemoryEventBus() *MemoryEventBus {
+	return &MemoryEventBus{}
+}
+
+// Subscribe registers a handler that is called for every published event
+func (b *MemoryEventBus) Subscribe(handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish synchronously delivers the event to all subscribed handlers
+func (b *MemoryEventBus) Publish(ctx context.Context, event ListEvent) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrEventBusClosed
+	}
+	handlers := make([]EventHandler, len(b.handlers))
+	copy(handlers, b.handlers)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+
+	return nil
+}
+
+// Close stops delivering events
+func (b *MemoryEventBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	b.handlers = nil
+
+	return nil
+}
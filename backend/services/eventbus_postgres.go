@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// eventBusChannel is the Postgres notification channel shared by all instances
+	eventBusChannel = "tibiacores_list_events"
+
+	// eventBusMaxPayload is the NOTIFY payload limit of the default Postgres build
+	eventBusMaxPayload = 8000
+
+	// eventBusReconnectDelay is the wait before re-establishing a lost listener connection
+	eventBusReconnectDelay = 5 * time.Second
+)
+
+// Ensure PostgresEventBus implements EventBus
+var _ EventBus = (*PostgresEventBus)(nil)
+
+// PostgresEventBus fans out events to every backend instance using Postgres
+// LISTEN/NOTIFY. Each instance holds one dedicated listener connection taken
+// from the pool, events published by an instance are delivered back to it too.
+type PostgresEventBus struct {
+	pool     *pgxpool.Pool
+	mu       sync.RWMutex
+	handlers []EventHandler
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewPostgresEventBus starts listening for events in the background until Close is called
+func NewPostgresEventBus(pool *pgxpool.Pool) *PostgresEventBus {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &PostgresEventBus{
+		pool:   pool,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go b.run(ctx)
+
+	return b
+}
+
+// Subscribe registers a handler that is called for every event received from Postgres
+func (b *PostgresEventBus) Subscribe(handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish sends the event to all instances through pg_notify
+func (b *PostgresEventBus) Publish(ctx context.Context, event ListEvent) error {
+	select {
+	case <-b.done:
+		return ErrEventBusClosed
+	default:
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if len(data) >= eventBusMaxPayload {
+		return fmt.Errorf("event %s payload is %d bytes, exceeds notification limit", event.Type, len(data))
+	}
+
+	_, err = b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", eventBusChannel, string(data))
+	return err
+}
+
+// Close stops the listener and releases its connection
+func (b *PostgresEventBus) Close() error {
+	b.cancel()
+	<-b.done
+
+	return nil
+}
+
+// run keeps a listener connection open, reconnecting after failures
+func (b *PostgresEventBus) run(ctx context.Context) {
+	defer close(b.done)
+
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Events sent while disconnected are lost, clients catch up on reconnect
+		slog.Error("event bus listener disconnected",
+			"error", err,
+			"retry_in", eventBusReconnectDelay.String(),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventBusReconnectDelay):
+		}
+	}
+}
+
+// listen subscribes to the channel and dispatches notifications until the connection fails
+func (b *PostgresEventBus) listen(ctx context.Context) error {
+	poolConn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The listener connection never goes back to the pool
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{eventBusChannel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event ListEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			slog.Warn("discarding malformed event bus notification", "error", err)
+			continue
+		}
+
+		b.dispatch(ctx, event)
+	}
+}
+
+func (b *PostgresEventBus) dispatch(ctx context.Context, event ListEvent) {
+	b.mu.RLock()
+	handlers := make([]EventHandler, len(b.handlers))
+	copy(handlers, b.handlers)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryEventBus_Publish(t *testing.T) {
+	bus := NewMemoryEventBus()
+
+	var first, second []ListEvent
+	bus.Subscribe(func(ctx context.Context, event ListEvent) {
+		first = append(first, event)
+	})
+	bus.Subscribe(func(ctx context.Context, event ListEvent) {
+		second = append(second, event)
+	})
+
+	event := ListEvent{Type: EventSoulcoreStatusChanged, ListID: uuid.New()}
+	require.NoError(t, bus.Publish(context.Background(), event))
+
+	assert.Equal(t, []ListEvent{event}, first)
+	assert.Equal(t, []ListEvent{event}, second)
+}
+
+func TestMemoryEventBus_Close(t *testing.T) {
+	bus := NewMemoryEventBus()
+
+	delivered := 0
+	bus.Subscribe(func(ctx context.Context, event ListEvent) {
+		delivered++
+	})
+
+	require.NoError(t, bus.Close())
+
+	err := bus.Publish(context.Background(), ListEvent{ListID: uuid.New()})
+	assert.ErrorIs(t, err, ErrEventBusClosed)
+	assert.Equal(t, 0, delivered)
+}
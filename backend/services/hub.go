@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// List event types pushed to connected list members
+const (
+	EventChatMessageCreated = "chat.message_created"
+	EventChatMessageDeleted = "chat.message_deleted"
+	EventChatMessagesRead   = "chat.messages_read"
+
+	EventSoulcoreStatusChanged  = "soulcore.status_changed"
+	EventSoulcoresDistributed   = "soulcore.distributed"
+	EventCharacterClaimed       = "character.claimed"
+	EventMemberRoleChanged      = "member.role_changed"
+	EventMemberCharacterChanged = "member.character_changed"
+	EventMemberLeft             = "member.left"
+	EventMemberRemoved          = "member.removed"
+	EventMemberJoinRequested    = "member.join_requested"
+	EventMemberJoined           = "member.joined"
+	EventOwnershipTransferred   = "list.ownership_transferred"
+	EventListUpdated            = "list.updated"
+	EventListDeleted            = "list.deleted"
+	EventListMerged             = "list.merged"
+)
+
+// departureEvents name a member who no longer belongs to the list, in the given payload
+// field. Their connections to it are closed once the event is delivered. A claimed character
+// only names its previous owner when the claim took their last character in the list.
+var departureEvents = map[string]string{
+	EventMemberLeft:       "user_id",
+	EventMemberRemoved:    "user_id",
+	EventCharacterClaimed: "previous_user_id",
+}
+
+// listClosedEvents end a list for everyone, all connections to it are closed once the event
+// is delivered. A merge only ends the source list, the target list lives on.
+var listClosedEvents = map[string]bool{
+	EventListDeleted: true,
+	EventListMerged:  true,
+}
+
+// hubClientBufferSize is the number of events buffered per connection before
+// the client is considered too slow and gets disconnected
+const hubClientBufferSize = 32
+
+// ErrHubClosed is returned when using a hub that has been shut down
+var ErrHubClosed = errors.New("hub is closed")
+
+// ListEvent is a real-time update delivered to every connected member of a list
+type ListEvent struct {
+	Type    string          `json:"type"`
+	ListID  uuid.UUID       `json:"list_id"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// NewListEvent builds a ListEvent with a JSON encoded payload
+func NewListEvent(eventType string, listID uuid.UUID, payload any) (ListEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ListEvent{}, err
+	}
+
+	return ListEvent{
+		Type:    eventType,
+		ListID:  listID,
+		Payload: data,
+	}, nil
+}
+
+// EventPublisher publishes list events to connected clients
+type EventPublisher interface {
+	Publish(ctx context.Context, event ListEvent) error
+}
+
+// Ensure Hub implements EventPublisher
+var _ EventPublisher = (*Hub)(nil)
+
+// HubClient is a single connection subscribed to the events of one list
+type HubClient struct {
+	ListID uuid.UUID
+	UserID uuid.UUID
+	events chan ListEvent
+}
+
+// Events returns the channel of events for this client. The channel is closed
+// when the client is unsubscribed, dropped for being too slow or the hub shuts down.
+func (c *HubClient) Events() <-chan ListEvent {
+	return c.events
+}
+
+// Hub keeps track of the connections to this instance per list. Events are
+// published through the event bus so members connected to any instance get them.
+type Hub struct {
+	bus     EventBus
+	mu      sync.RWMutex
+	clients map[uuid.UUID]map[*HubClient]struct{}
+	closed  bool
+}
+
+func NewHub(bus EventBus) *Hub {
+	h := &Hub{
+		bus:     bus,
+		clients: make(map[uuid.UUID]map[*HubClient]struct{}),
+	}
+	bus.Subscribe(h.dispatch)
+
+	return h
+}
+
+// Subscribe registers a new connection for the given list and user
+func (h *Hub) Subscribe(listID, userID uuid.UUID) (*HubClient, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+
+	client := &HubClient{
+		ListID: listID,
+		UserID: userID,
+		events: make(chan ListEvent, hubClientBufferSize),
+	}
+
+	if h.clients[listID] == nil {
+		h.clients[listID] = make(map[*HubClient]struct{})
+	}
+	h.clients[listID][client] = struct{}{}
+
+	return client, nil
+}
+
+// Unsubscribe removes a connection from the hub and closes its event channel
+func (h *Hub) Unsubscribe(client *HubClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(client)
+}
+
+// Publish sends an event to the members of the event's list on every instance
+func (h *Hub) Publish(ctx context.Context, event ListEvent) error {
+	h.mu.RLock()
+	closed := h.closed
+	h.mu.RUnlock()
+
+	if closed {
+		return ErrHubClosed
+	}
+
+	return h.bus.Publish(ctx, event)
+}
+
+// dispatch delivers an event from the bus to the local connections of its list.
+// Clients whose buffer is full are disconnected instead of blocking the bus, and so are
+// the connections of a member who no longer belongs to the list or of a list that ended,
+// after they got the event.
+func (h *Hub) dispatch(ctx context.Context, event ListEvent) {
+	var slow []*HubClient
+	departed := departedUser(event)
+	closed := closesList(event)
+
+	h.mu.RLock()
+	if h.closed {
+		h.mu.RUnlock()
+		return
+	}
+	for client := range h.clients[event.ListID] {
+		select {
+		case client.events <- event:
+		default:
+			slow = append(slow, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(slow) > 0 || departed != uuid.Nil || closed {
+		h.mu.Lock()
+		for _, client := range slow {
+			slog.Warn("dropping slow list event client",
+				"list_id", client.ListID,
+				"user_id", client.UserID,
+			)
+			h.removeLocked(client)
+		}
+		for client := range h.clients[event.ListID] {
+			if closed || client.UserID == departed {
+				h.removeLocked(client)
+			}
+		}
+		h.mu.Unlock()
+	}
+}
+
+// departedUser returns the member a departure event names, or uuid.Nil for other events
+func departedUser(event ListEvent) uuid.UUID {
+	field, ok := departureEvents[event.Type]
+	if !ok {
+		return uuid.Nil
+	}
+
+	var payload map[string]json.RawMessage
+	var userID uuid.UUID
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		logUnreadableEvent(event, err)
+		return uuid.Nil
+	}
+	if raw, ok := payload[field]; ok {
+		if err := json.Unmarshal(raw, &userID); err != nil {
+			logUnreadableEvent(event, err)
+			return uuid.Nil
+		}
+	}
+	return userID
+}
+
+// closesList reports whether an event ends its list
+func closesList(event ListEvent) bool {
+	if !listClosedEvents[event.Type] {
+		return false
+	}
+	if event.Type != EventListMerged {
+		return true
+	}
+
+	var payload struct {
+		SourceListID uuid.UUID `json:"source_list_id"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		logUnreadableEvent(event, err)
+		return false
+	}
+	return payload.SourceListID == event.ListID
+}
+
+func logUnreadableEvent(event ListEvent, err error) {
+	slog.Warn("unreadable list event",
+		"list_id", event.ListID,
+		"type", event.Type,
+		"error", err,
+	)
+}
+
+// ClientCount returns the number of active connections for a list
+func (h *Hub) ClientCount(listID uuid.UUID) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.clients[listID])
+}
+
+// Close disconnects all clients and rejects new subscriptions
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+
+	for _, clients := range h.clients {
+		for client := range clients {
+			close(client.events)
+		}
+	}
+	h.clients = make(map[uuid.UUID]map[*HubClient]struct{})
+}
+
+// removeLocked removes a client, the caller must hold the write lock
+func (h *Hub) removeLocked(client *HubClient) {
+	clients, ok := h.clients[client.ListID]
+	if !ok {
+		return
+	}
+	if _, ok := clients[client]; !ok {
+		return
+	}
+
+	delete(clients, client)
+	close(client.events)
+
+	if len(clients) == 0 {
+		delete(h.clients, client.ListID)
+	}
+}
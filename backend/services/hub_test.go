@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_PublishDeliversToListMembers(t *testing.T) {
+	hub := NewHub(NewMemoryEventBus())
+	defer hub.Close()
+
+	listID := uuid.New()
+	otherListID := uuid.New()
+
+	first, err := hub.Subscribe(listID, uuid.New())
+	require.NoError(t, err)
+	second, err := hub.Subscribe(listID, uuid.New())
+	require.NoError(t, err)
+	other, err := hub.Subscribe(otherListID, uuid.New())
+	require.NoError(t, err)
+
+	event, err := NewListEvent(EventChatMessageCreated, listID, map[string]string{"message": "hi"})
+	require.NoError(t, err)
+	require.NoError(t, hub.Publish(context.Background(), event))
+
+	for _, client := range []*HubClient{first, second} {
+		select {
+		case received := <-client.Events():
+			assert.Equal(t, EventChatMessageCreated, received.Type)
+			assert.Equal(t, listID, received.ListID)
+
+			var payload map[string]string
+			require.NoError(t, json.Unmarshal(received.Payload, &payload))
+			assert.Equal(t, "hi", payload["message"])
+		default:
+			t.Fatal("expected event to be delivered")
+		}
+	}
+
+	select {
+	case <-other.Events():
+		t.Fatal("event leaked to another list")
+	default:
+	}
+}
+
+func TestHub_DropsSlowClients(t *testing.T) {
+	hub := NewHub(NewMemoryEventBus())
+	defer hub.Close()
+
+	listID := uuid.New()
+	client, err := hub.Subscribe(listID, uuid.New())
+	require.NoError(t, err)
+
+	event := ListEvent{Type: EventChatMessagesRead, ListID: listID}
+	for i := 0; i <= hubClientBufferSize; i++ {
+		require.NoError(t, hub.Publish(context.Background(), event))
+	}
+
+	assert.Equal(t, 0, hub.ClientCount(listID))
+
+	received := 0
+	for range client.Events() {
+		received++
+	}
+	assert.Equal(t, hubClientBufferSize, received)
+}
+
+func TestHub_DisconnectsDepartedMembers(t *testing.T) {
+	for eventType, field := range departureEvents {
+		t.Run(eventType, func(t *testing.T) {
+			hub := NewHub(NewMemoryEventBus())
+			defer hub.Close()
+
+			listID := uuid.New()
+			removedID := uuid.New()
+
+			removed, err := hub.Subscribe(listID, removedID)
+			require.NoError(t, err)
+			// A second tab of the same member
+			removedTab, err := hub.Subscribe(listID, removedID)
+			require.NoError(t, err)
+			// The member keeps following their other lists
+			otherList, err := hub.Subscribe(uuid.New(), removedID)
+			require.NoError(t, err)
+			remaining, err := hub.Subscribe(listID, uuid.New())
+			require.NoError(t, err)
+
+			event, err := NewListEvent(eventType, listID, map[string]any{field: removedID})
+			require.NoError(t, err)
+			require.NoError(t, hub.Publish(context.Background(), event))
+
+			// The departed member still learns why the stream ends
+			for _, client := range []*HubClient{removed, removedTab} {
+				received, ok := <-client.Events()
+				require.True(t, ok)
+				assert.Equal(t, eventType, received.Type)
+				_, ok = <-client.Events()
+				assert.False(t, ok)
+			}
+
+			assert.Equal(t, 1, hub.ClientCount(listID))
+			assert.Equal(t, eventType, (<-remaining.Events()).Type)
+
+			later := ListEvent{Type: EventChatMessageCreated, ListID: listID}
+			require.NoError(t, hub.Publish(context.Background(), later))
+			assert.Equal(t, EventChatMessageCreated, (<-remaining.Events()).Type)
+
+			select {
+			case <-otherList.Events():
+				t.Fatal("event leaked to another list")
+			default:
+			}
+			assert.Equal(t, 1, hub.ClientCount(otherList.ListID))
+		})
+	}
+}
+
+func TestHub_KeepsClaimedCharacterOwnersWithOtherCharacters(t *testing.T) {
+	hub := NewHub(NewMemoryEventBus())
+	defer hub.Close()
+
+	listID := uuid.New()
+	client, err := hub.Subscribe(listID, uuid.New())
+	require.NoError(t, err)
+
+	// Without previous_user_id the previous owner still has a character in the list
+	event, err := NewListEvent(EventCharacterClaimed, listID, map[string]any{"user_id": uuid.New()})
+	require.NoError(t, err)
+	require.NoError(t, hub.Publish(context.Background(), event))
+
+	assert.Equal(t, EventCharacterClaimed, (<-client.Events()).Type)
+	assert.Equal(t, 1, hub.ClientCount(listID))
+}
+
+func TestHub_DisconnectsClosedLists(t *testing.T) {
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	merged := map[string]any{"source_list_id": sourceID, "target_list_id": targetID}
+
+	testCases := []struct {
+		name    string
+		listID  uuid.UUID
+		event   string
+		payload map[string]any
+		closed  bool
+	}{
+		{name: "Deleted", listID: sourceID, event: EventListDeleted, payload: map[string]any{"deleted_by": uuid.New()}, closed: true},
+		{name: "Merged Source", listID: sourceID, event: EventListMerged, payload: merged, closed: true},
+		{name: "Merged Target", listID: targetID, event: EventListMerged, payload: merged, closed: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := NewHub(NewMemoryEventBus())
+			defer hub.Close()
+
+			first, err := hub.Subscribe(tc.listID, uuid.New())
+			require.NoError(t, err)
+			second, err := hub.Subscribe(tc.listID, uuid.New())
+			require.NoError(t, err)
+			otherList, err := hub.Subscribe(uuid.New(), first.UserID)
+			require.NoError(t, err)
+
+			event, err := NewListEvent(tc.event, tc.listID, tc.payload)
+			require.NoError(t, err)
+			require.NoError(t, hub.Publish(context.Background(), event))
+
+			// Everyone still learns why the stream ends
+			for _, client := range []*HubClient{first, second} {
+				received, ok := <-client.Events()
+				require.True(t, ok)
+				assert.Equal(t, tc.event, received.Type)
+			}
+
+			if tc.closed {
+				assert.Equal(t, 0, hub.ClientCount(tc.listID))
+				for _, client := range []*HubClient{first, second} {
+					_, ok := <-client.Events()
+					assert.False(t, ok)
+				}
+			} else {
+				assert.Equal(t, 2, hub.ClientCount(tc.listID))
+			}
+			assert.Equal(t, 1, hub.ClientCount(otherList.ListID))
+		})
+	}
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	hub := NewHub(NewMemoryEventBus())
+	defer hub.Close()
+
+	listID := uuid.New()
+	client, err := hub.Subscribe(listID, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, 1, hub.ClientCount(listID))
+
+	hub.Unsubscribe(client)
+	hub.Unsubscribe(client)
+
+	assert.Equal(t, 0, hub.ClientCount(listID))
+	_, ok := <-client.Events()
+	assert.False(t, ok)
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub(NewMemoryEventBus())
+
+	listID := uuid.New()
+	client, err := hub.Subscribe(listID, uuid.New())
+	require.NoError(t, err)
+
+	hub.Close()
+
+	_, ok := <-client.Events()
+	assert.False(t, ok)
+
+	_, err = hub.Subscribe(listID, uuid.New())
+	assert.ErrorIs(t, err, ErrHubClosed)
+	assert.ErrorIs(t, hub.Publish(context.Background(), ListEvent{ListID: listID}), ErrHubClosed)
+
+	// Closing twice is a no-op
+	hub.Close()
+}
+
+func TestHub_PublishReachesOtherInstances(t *testing.T) {
+	bus := NewMemoryEventBus()
+	defer bus.Close()
+
+	// Two hubs sharing a bus behave like two backend replicas
+	local := NewHub(bus)
+	defer local.Close()
+	remote := NewHub(bus)
+	defer remote.Close()
+
+	listID := uuid.New()
+	client, err := remote.Subscribe(listID, uuid.New())
+	require.NoError(t, err)
+
+	event := ListEvent{Type: EventChatMessageCreated, ListID: listID}
+	require.NoError(t, local.Publish(context.Background(), event))
+
+	select {
+	case received := <-client.Events():
+		assert.Equal(t, event, received)
+	default:
+		t.Fatal("expected event to reach the other instance")
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+)
+
+// MaxCreatureDifficulty is the highest bestiary difficulty. Creatures without a known
+// difficulty are ranked as if they were this hard.
+const MaxCreatureDifficulty = 5
+
+// HuntMember is a list member whose character still needs a creature's soulcore
+type HuntMember struct {
+	UserID        uuid.UUID `json:"user_id"`
+	CharacterID   uuid.UUID `json:"character_id"`
+	CharacterName string    `json:"character_name"`
+}
+
+// HuntTarget is a creature worth hunting for a list. ListStatus is the status of the
+// list's own soulcore of the creature, nil when the list does not track it.
+type HuntTarget struct {
+	CreatureID   uuid.UUID          `json:"creature_id"`
+	CreatureName string             `json:"creature_name"`
+	Difficulty   *int32             `json:"difficulty"`
+	ListStatus   *db.SoulcoreStatus `json:"list_status"`
+	NeededBy     []HuntMember       `json:"needed_by"`
+	NeedCount    int                `json:"need_count"`
+	Score        float64            `json:"score"`
+}
+
+// HuntFilter narrows down hunt recommendations
+type HuntFilter struct {
+	// MaxDifficulty drops creatures harder than it, and those of unknown difficulty
+	MaxDifficulty *int32
+	// ExcludeReserved drops creatures whose list soulcore is reserved for a member
+	ExcludeReserved bool
+}
+
+// RankHuntTargets scores targets and returns the ones passing filter, best first. A core
+// the list already holds goes to one of the members who need it, so it counts against
+// the need. The score is the remaining need divided by one plus the difficulty, which
+// makes a creature twice as hard worth hunting for about twice as many members.
+func RankHuntTargets(targets []HuntTarget, filter HuntFilter) []HuntTarget {
+	ranked := make([]HuntTarget, 0, len(targets))
+	for _, t := range targets {
+		if filter.MaxDifficulty != nil && (t.Difficulty == nil || *t.Difficulty > *filter.MaxDifficulty) {
+			continue
+		}
+		if filter.ExcludeReserved && t.ListStatus != nil && *t.ListStatus == db.SoulcoreStatusReserved {
+			continue
+		}
+
+		t.NeedCount = len(t.NeededBy)
+		if t.ListStatus != nil && listHoldsCore(*t.ListStatus) {
+			t.NeedCount--
+		}
+		if t.NeedCount <= 0 {
+			continue
+		}
+
+		t.Score = float64(t.NeedCount) / float64(1+huntDifficulty(t))
+		ranked = append(ranked, t)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.NeedCount != b.NeedCount {
+			return a.NeedCount > b.NeedCount
+		}
+		if huntDifficulty(a) != huntDifficulty(b) {
+			return huntDifficulty(a) < huntDifficulty(b)
+		}
+		return a.CreatureName < b.CreatureName
+	})
+
+	return ranked
+}
+
+// listHoldsCore reports whether a list soulcore in status is in hand and not yet used up
+func listHoldsCore(status db.SoulcoreStatus) bool {
+	switch status {
+	case db.SoulcoreStatusObtained, db.SoulcoreStatusReserved, db.SoulcoreStatusTraded:
+		return true
+	}
+	return false
+}
+
+func huntDifficulty(t HuntTarget) int32 {
+	if t.Difficulty == nil {
+		return MaxCreatureDifficulty
+	}
+	return *t.Difficulty
+}
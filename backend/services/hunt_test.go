@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func huntTarget(name string, difficulty *int32, status *db.SoulcoreStatus, needed int) HuntTarget {
+	t := HuntTarget{CreatureID: uuid.New(), CreatureName: name, Difficulty: difficulty, ListStatus: status}
+	for i := 0; i < needed; i++ {
+		t.NeededBy = append(t.NeededBy, HuntMember{UserID: uuid.New(), CharacterID: uuid.New()})
+	}
+	return t
+}
+
+func names(targets []HuntTarget) []string {
+	var n []string
+	for _, t := range targets {
+		n = append(n, t.CreatureName)
+	}
+	return n
+}
+
+func TestRankHuntTargets(t *testing.T) {
+	easy, hard := int32(1), int32(4)
+	reserved, obtained := db.SoulcoreStatusReserved, db.SoulcoreStatusObtained
+
+	targets := []HuntTarget{
+		huntTarget("Demon", &hard, nil, 5),        // 5 / 5 = 1
+		huntTarget("Rotworm", &easy, nil, 3),      // 3 / 2 = 1.5
+		huntTarget("Dragon", &easy, &reserved, 4), // (4 - 1) / 2 = 1.5
+		huntTarget("Ferumbras", nil, nil, 6),      // 6 / 6 = 1
+		huntTarget("Rat", &easy, &obtained, 1),    // nobody left once the core is handed out
+	}
+
+	t.Run("ranks by need weighted by difficulty", func(t *testing.T) {
+		ranked := RankHuntTargets(targets, HuntFilter{})
+		// Equal scores go to the creature more members need, then by name
+		assert.Equal(t, []string{"Dragon", "Rotworm", "Ferumbras", "Demon"}, names(ranked))
+		require.Len(t, ranked, 4)
+		assert.Equal(t, 3, ranked[0].NeedCount)
+		assert.InDelta(t, 1.5, ranked[0].Score, 0.001)
+	})
+
+	t.Run("max difficulty drops harder and unknown creatures", func(t *testing.T) {
+		max := int32(3)
+		ranked := RankHuntTargets(targets, HuntFilter{MaxDifficulty: &max})
+		assert.Equal(t, []string{"Dragon", "Rotworm"}, names(ranked))
+	})
+
+	t.Run("exclude reserved", func(t *testing.T) {
+		ranked := RankHuntTargets(targets, HuntFilter{ExcludeReserved: true})
+		assert.Equal(t, []string{"Rotworm", "Ferumbras", "Demon"}, names(ranked))
+	})
+}
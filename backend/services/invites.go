@@ -0,0 +1,31 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+)
+
+var (
+	// ErrInviteRevoked is returned for invites that were revoked by a list member
+	ErrInviteRevoked = errors.New("invite has been revoked")
+	// ErrInviteExpired is returned for invites past their expiry time
+	ErrInviteExpired = errors.New("invite has expired")
+	// ErrInviteExhausted is returned for invites that reached their maximum number of uses
+	ErrInviteExhausted = errors.New("invite has reached its maximum number of uses")
+)
+
+// ValidateInvite reports whether an invite can still be used to join its list at now
+func ValidateInvite(invite db.ListInvite, now time.Time) error {
+	if invite.RevokedAt.Valid {
+		return ErrInviteRevoked
+	}
+	if invite.ExpiresAt.Valid && !invite.ExpiresAt.Time.After(now) {
+		return ErrInviteExpired
+	}
+	if invite.MaxUses.Valid && invite.UseCount >= invite.MaxUses.Int32 {
+		return ErrInviteExhausted
+	}
+	return nil
+}
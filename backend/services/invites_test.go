@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateInvite(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name        string
+		invite      db.ListInvite
+		expectedErr error
+	}{
+		{
+			name:   "unlimited",
+			invite: db.ListInvite{UseCount: 100},
+		},
+		{
+			name: "within limits",
+			invite: db.ListInvite{
+				ExpiresAt: pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true},
+				MaxUses:   pgtype.Int4{Int32: 5, Valid: true},
+				UseCount:  4,
+			},
+		},
+		{
+			name:        "revoked",
+			invite:      db.ListInvite{RevokedAt: pgtype.Timestamptz{Time: now.Add(-time.Minute), Valid: true}},
+			expectedErr: ErrInviteRevoked,
+		},
+		{
+			name:        "expired",
+			invite:      db.ListInvite{ExpiresAt: pgtype.Timestamptz{Time: now.Add(-time.Minute), Valid: true}},
+			expectedErr: ErrInviteExpired,
+		},
+		{
+			name: "exhausted",
+			invite: db.ListInvite{
+				MaxUses:  pgtype.Int4{Int32: 3, Valid: true},
+				UseCount: 3,
+			},
+			expectedErr: ErrInviteExhausted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateInvite(tc.invite, now)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage is the language creature names are stored in and fall back to
+const DefaultLanguage = "en"
+
+// SupportedLanguages are the languages creature names can be translated into, the same
+// as the frontend's locales
+var SupportedLanguages = []string{"en", "de", "es", "pl", "pt"}
+
+// IsSupportedLanguage reports whether lang is one of SupportedLanguages
+func IsSupportedLanguage(lang string) bool {
+	return slices.Contains(SupportedLanguages, lang)
+}
+
+// PreferredLanguage picks the supported language an Accept-Language header ranks highest.
+// Regional variants count as their language, so pt-BR is pt. It falls back to
+// DefaultLanguage when the header names no supported language.
+func PreferredLanguage(acceptLanguage string) string {
+	type preference struct {
+		lang    string
+		quality float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !IsSupportedLanguage(lang) {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		// q=0 means not acceptable
+		if quality <= 0 {
+			continue
+		}
+
+		preferences = append(preferences, preference{lang: lang, quality: quality})
+	}
+
+	if len(preferences) == 0 {
+		return DefaultLanguage
+	}
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].quality > preferences[j].quality })
+	return preferences[0].lang
+}
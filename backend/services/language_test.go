@@ -0,0 +1,32 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreferredLanguage(t *testing.T) {
+	testCases := []struct {
+		header   string
+		expected string
+	}{
+		{"", "en"},
+		{"pl", "pl"},
+		{"pt-BR,pt;q=0.9,en-US;q=0.8,en;q=0.7", "pt"},
+		{"es-AR;q=0.5, de;q=0.8", "de"},
+		// First listed wins a tie
+		{"es, pl", "es"},
+		// Unsupported languages are skipped
+		{"fr-FR,fr;q=0.9,pl;q=0.5", "pl"},
+		{"fr, *", "en"},
+		{"pl;q=0", "en"},
+		{"pl;q=abc, es;q=0.1", "es"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			assert.Equal(t, tc.expected, PreferredLanguage(tc.header))
+		})
+	}
+}
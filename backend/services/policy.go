@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+)
+
+// ErrNotListMember is returned when a user has no active membership in a list
+var ErrNotListMember = errors.New("user is not a member of this list")
+
+// Policy is the single place that decides what a user may do with lists and characters
+type Policy struct {
+	store db.Querier
+}
+
+func NewPolicy(store db.Querier) *Policy {
+	return &Policy{store: store}
+}
+
+// ListMembership loads the role of a user in a list. It returns ErrNotListMember
+// when the user has no active character in the list.
+func (p *Policy) ListMembership(ctx context.Context, listID, userID uuid.UUID) (ListMembership, error) {
+	role, err := p.store.GetListMemberRole(ctx, db.GetListMemberRoleParams{
+		ListID: listID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ListMembership{}, ErrNotListMember
+		}
+		return ListMembership{}, err
+	}
+
+	return ListMembership{
+		ListID: listID,
+		UserID: userID,
+		Role:   role,
+	}, nil
+}
+
+// CanManageCharacter reports whether a user may act on behalf of a character,
+// e.g. edit its soulcores or handle its suggestions
+func (p *Policy) CanManageCharacter(userID uuid.UUID, character db.Character) bool {
+	return character.UserID == userID
+}
+
+// ListMembership is the role of a user in a list and answers what that user may do
+type ListMembership struct {
+	ListID uuid.UUID
+	UserID uuid.UUID
+	Role   db.ListRole
+}
+
+// IsOwner reports whether the user owns the list
+func (m ListMembership) IsOwner() bool {
+	return m.Role == db.ListRoleOwner
+}
+
+// IsModerator reports whether the user has elevated rights, owners included
+func (m ListMembership) IsModerator() bool {
+	return m.Role == db.ListRoleOwner || m.Role == db.ListRoleModerator
+}
+
+// CanView reports whether the user may read the list, its members and chat
+func (m ListMembership) CanView() bool {
+	return ValidListRole(m.Role)
+}
+
+// CanContribute reports whether the user may add soulcores and post chat messages
+func (m ListMembership) CanContribute() bool {
+	return m.IsModerator() || m.Role == db.ListRoleMember
+}
+
+// CanModifySoulcore reports whether the user may change or remove a soulcore added by addedBy
+func (m ListMembership) CanModifySoulcore(addedBy uuid.UUID) bool {
+	if m.IsModerator() {
+		return true
+	}
+	return m.CanContribute() && addedBy == m.UserID
+}
+
+// CanChangeSoulcoreStatus reports whether the user may move a soulcore from one status to
+// another. The owner may correct any status, everyone else has to follow the lifecycle.
+func (m ListMembership) CanChangeSoulcoreStatus(from, to db.SoulcoreStatus) bool {
+	if m.IsOwner() {
+		return ValidSoulcoreStatus(from) && ValidSoulcoreStatus(to)
+	}
+	return ValidSoulcoreTransition(from, to)
+}
+
+// CanDeleteChatMessage reports whether the user may delete a message written by author
+func (m ListMembership) CanDeleteChatMessage(author uuid.UUID) bool {
+	return m.IsModerator() || author == m.UserID
+}
+
+// CanInvite reports whether the user may share the list's invite code
+func (m ListMembership) CanInvite() bool {
+	return m.CanContribute()
+}
+
+// CanManageMembers reports whether the user may manage other members of the list
+func (m ListMembership) CanManageMembers() bool {
+	return m.IsModerator()
+}
+
+// CanAssignRole reports whether the user may change the role of a member
+// from current to next. Ownership can only change hands through a transfer.
+func (m ListMembership) CanAssignRole(current, next db.ListRole) bool {
+	if current == db.ListRoleOwner || next == db.ListRoleOwner {
+		return false
+	}
+
+	switch m.Role {
+	case db.ListRoleOwner:
+		return true
+	case db.ListRoleModerator:
+		// Moderators can only move people between member and viewer
+		return current != db.ListRoleModerator && next != db.ListRoleModerator
+	}
+	return false
+}
+
+// CanRemoveMember reports whether the user may remove a member holding target from the list
+func (m ListMembership) CanRemoveMember(target db.ListRole) bool {
+	switch m.Role {
+	case db.ListRoleOwner:
+		return target != db.ListRoleOwner
+	case db.ListRoleModerator:
+		return target == db.ListRoleMember || target == db.ListRoleViewer
+	}
+	return false
+}
+
+// CanTransferOwnership reports whether the user may hand the list over to another member
+func (m ListMembership) CanTransferOwnership() bool {
+	return m.IsOwner()
+}
+
+// CanEditList reports whether the user may rename the list or move it to another world
+func (m ListMembership) CanEditList() bool {
+	return m.IsModerator()
+}
+
+// CanDeleteList reports whether the user may delete the list
+func (m ListMembership) CanDeleteList() bool {
+	return m.IsOwner()
+}
+
+// CanRevokeInvite reports whether the user may revoke an invite created by createdBy
+func (m ListMembership) CanRevokeInvite(createdBy uuid.UUID) bool {
+	return m.IsModerator() || (m.CanInvite() && createdBy == m.UserID)
+}
+
+// CanManageShareCode reports whether the user may rotate or disable the list's share code
+func (m ListMembership) CanManageShareCode() bool {
+	return m.IsOwner()
+}
+
+// CanReviewJoinRequests reports whether the user may approve or reject requests to join the list
+func (m ListMembership) CanReviewJoinRequests() bool {
+	return m.IsModerator()
+}
+
+// CanDistributeSoulcores reports whether the user may decide who receives the list's obtained soulcores
+func (m ListMembership) CanDistributeSoulcores() bool {
+	return m.IsModerator()
+}
+
+// CanCloneList reports whether the user may copy the list, optionally with its members, into a new list
+func (m ListMembership) CanCloneList() bool {
+	return m.IsModerator()
+}
+
+// CanMergeList reports whether the user may merge the list into another one or another one into it
+func (m ListMembership) CanMergeList() bool {
+	return m.IsOwner()
+}
+
+// CanViewReactivations reports whether the user may see the memberships of claimed characters
+// that wait for their new owner
+func (m ListMembership) CanViewReactivations() bool {
+	return m.IsModerator()
+}
+
+// ValidListRole reports whether role is one of the known list roles
+func ValidListRole(role db.ListRole) bool {
+	switch role {
+	case db.ListRoleOwner, db.ListRoleModerator, db.ListRoleMember, db.ListRoleViewer:
+		return true
+	}
+	return false
+}
+
+// ValidListVisibility reports whether visibility is one of the known list visibilities
+func ValidListVisibility(visibility db.ListVisibility) bool {
+	switch visibility {
+	case db.ListVisibilityPrivate, db.ListVisibilityUnlisted, db.ListVisibilityPublic:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	mockdb "github.com/sergot/tibiacores/backend/db/mock"
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPolicy_ListMembership(t *testing.T) {
+	listID := uuid.New()
+	userID := uuid.New()
+	params := db.GetListMemberRoleParams{ListID: listID, UserID: userID}
+
+	testCases := []struct {
+		name         string
+		role         db.ListRole
+		storeErr     error
+		expectedErr  error
+		expectedRole db.ListRole
+	}{
+		{
+			name:         "member",
+			role:         db.ListRoleModerator,
+			expectedRole: db.ListRoleModerator,
+		},
+		{
+			name:        "not a member",
+			storeErr:    sql.ErrNoRows,
+			expectedErr: ErrNotListMember,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetListMemberRole(gomock.Any(), params).Return(tc.role, tc.storeErr)
+
+			membership, err := NewPolicy(store).ListMembership(context.Background(), listID, userID)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRole, membership.Role)
+			assert.Equal(t, userID, membership.UserID)
+		})
+	}
+
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		dbErr := errors.New("database error")
+		store.EXPECT().GetListMemberRole(gomock.Any(), params).Return(db.ListRole(""), dbErr)
+
+		_, err := NewPolicy(store).ListMembership(context.Background(), listID, userID)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NotErrorIs(t, err, ErrNotListMember)
+	})
+}
+
+func TestListMembership_Permissions(t *testing.T) {
+	userID := uuid.New()
+	otherID := uuid.New()
+
+	testCases := []struct {
+		role               db.ListRole
+		canContribute      bool
+		canModifyOwn       bool
+		canModifyOthers    bool
+		canDeleteOthersMsg bool
+		canInvite          bool
+		canManageMembers   bool
+		canEditList        bool
+		canDeleteList      bool
+		canRevokeOwn       bool
+		canRevokeOthers    bool
+	}{
+		{role: db.ListRoleOwner, canContribute: true, canModifyOwn: true, canModifyOthers: true, canDeleteOthersMsg: true, canInvite: true, canManageMembers: true, canEditList: true, canDeleteList: true, canRevokeOwn: true, canRevokeOthers: true},
+		{role: db.ListRoleModerator, canContribute: true, canModifyOwn: true, canModifyOthers: true, canDeleteOthersMsg: true, canInvite: true, canManageMembers: true, canEditList: true, canRevokeOwn: true, canRevokeOthers: true},
+		{role: db.ListRoleMember, canContribute: true, canModifyOwn: true, canInvite: true, canRevokeOwn: true},
+		{role: db.ListRoleViewer},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.role), func(t *testing.T) {
+			m := ListMembership{UserID: userID, Role: tc.role}
+
+			assert.True(t, m.CanView())
+			assert.Equal(t, tc.canContribute, m.CanContribute())
+			assert.Equal(t, tc.canModifyOwn, m.CanModifySoulcore(userID))
+			assert.Equal(t, tc.canModifyOthers, m.CanModifySoulcore(otherID))
+			assert.True(t, m.CanDeleteChatMessage(userID))
+			assert.Equal(t, tc.canDeleteOthersMsg, m.CanDeleteChatMessage(otherID))
+			assert.Equal(t, tc.canInvite, m.CanInvite())
+			assert.Equal(t, tc.canManageMembers, m.CanManageMembers())
+			assert.Equal(t, tc.canEditList, m.CanEditList())
+			assert.Equal(t, tc.canDeleteList, m.CanDeleteList())
+			assert.Equal(t, tc.canRevokeOwn, m.CanRevokeInvite(userID))
+			assert.Equal(t, tc.canRevokeOthers, m.CanRevokeInvite(otherID))
+			assert.Equal(t, tc.role == db.ListRoleOwner, m.CanManageShareCode())
+			assert.Equal(t, tc.canManageMembers, m.CanReviewJoinRequests())
+			assert.Equal(t, tc.canManageMembers, m.CanDistributeSoulcores())
+			assert.Equal(t, tc.canEditList, m.CanCloneList())
+			assert.Equal(t, tc.canDeleteList, m.CanMergeList())
+			assert.Equal(t, tc.canManageMembers, m.CanViewReactivations())
+		})
+	}
+}
+
+func TestListMembership_CanAssignRole(t *testing.T) {
+	testCases := []struct {
+		name     string
+		role     db.ListRole
+		current  db.ListRole
+		next     db.ListRole
+		expected bool
+	}{
+		{"owner promotes member", db.ListRoleOwner, db.ListRoleMember, db.ListRoleModerator, true},
+		{"owner demotes moderator", db.ListRoleOwner, db.ListRoleModerator, db.ListRoleViewer, true},
+		{"owner cannot hand out ownership", db.ListRoleOwner, db.ListRoleMember, db.ListRoleOwner, false},
+		{"owner cannot demote an owner", db.ListRoleOwner, db.ListRoleOwner, db.ListRoleMember, false},
+		{"moderator restricts member", db.ListRoleModerator, db.ListRoleMember, db.ListRoleViewer, true},
+		{"moderator cannot promote to moderator", db.ListRoleModerator, db.ListRoleMember, db.ListRoleModerator, false},
+		{"moderator cannot demote moderator", db.ListRoleModerator, db.ListRoleModerator, db.ListRoleMember, false},
+		{"member cannot assign roles", db.ListRoleMember, db.ListRoleViewer, db.ListRoleMember, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := ListMembership{UserID: uuid.New(), Role: tc.role}
+			assert.Equal(t, tc.expected, m.CanAssignRole(tc.current, tc.next))
+		})
+	}
+}
+
+func TestListMembership_CanRemoveMember(t *testing.T) {
+	testCases := []struct {
+		name     string
+		role     db.ListRole
+		target   db.ListRole
+		expected bool
+	}{
+		{"owner removes moderator", db.ListRoleOwner, db.ListRoleModerator, true},
+		{"owner removes viewer", db.ListRoleOwner, db.ListRoleViewer, true},
+		{"owner cannot remove owner", db.ListRoleOwner, db.ListRoleOwner, false},
+		{"moderator removes member", db.ListRoleModerator, db.ListRoleMember, true},
+		{"moderator cannot remove moderator", db.ListRoleModerator, db.ListRoleModerator, false},
+		{"moderator cannot remove owner", db.ListRoleModerator, db.ListRoleOwner, false},
+		{"member cannot remove members", db.ListRoleMember, db.ListRoleViewer, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := ListMembership{UserID: uuid.New(), Role: tc.role}
+			assert.Equal(t, tc.expected, m.CanRemoveMember(tc.target))
+			assert.Equal(t, tc.role == db.ListRoleOwner, m.CanTransferOwnership())
+		})
+	}
+}
+
+func TestListMembership_CanChangeSoulcoreStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		role     db.ListRole
+		from     db.SoulcoreStatus
+		to       db.SoulcoreStatus
+		expected bool
+	}{
+		{"member unlocks obtained core", db.ListRoleMember, db.SoulcoreStatusObtained, db.SoulcoreStatusUnlocked, true},
+		{"member cannot revert unlock", db.ListRoleMember, db.SoulcoreStatusUnlocked, db.SoulcoreStatusObtained, false},
+		{"moderator cannot revert unlock", db.ListRoleModerator, db.SoulcoreStatusUnlocked, db.SoulcoreStatusObtained, false},
+		{"owner reverts unlock", db.ListRoleOwner, db.SoulcoreStatusUnlocked, db.SoulcoreStatusObtained, true},
+		{"owner cannot use unknown status", db.ListRoleOwner, db.SoulcoreStatusUnlocked, db.SoulcoreStatus("lost"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := ListMembership{UserID: uuid.New(), Role: tc.role}
+			assert.Equal(t, tc.expected, m.CanChangeSoulcoreStatus(tc.from, tc.to))
+		})
+	}
+}
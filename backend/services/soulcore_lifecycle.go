@@ -0,0 +1,63 @@
+package services
+
+import (
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+)
+
+// soulcoreTransitions lists where a soulcore may go from each status. A wanted core is
+// hunted for, an obtained one is in hand and may be reserved for a member before it is
+// traded to them or unlocked. Unlocking is final.
+var soulcoreTransitions = map[db.SoulcoreStatus][]db.SoulcoreStatus{
+	db.SoulcoreStatusWanted:   {db.SoulcoreStatusObtained},
+	db.SoulcoreStatusObtained: {db.SoulcoreStatusWanted, db.SoulcoreStatusReserved, db.SoulcoreStatusTraded, db.SoulcoreStatusUnlocked},
+	db.SoulcoreStatusReserved: {db.SoulcoreStatusObtained, db.SoulcoreStatusReserved, db.SoulcoreStatusTraded, db.SoulcoreStatusUnlocked},
+	db.SoulcoreStatusTraded:   {db.SoulcoreStatusUnlocked},
+	db.SoulcoreStatusUnlocked: {},
+}
+
+// ValidSoulcoreStatus reports whether status is one of the known soulcore statuses
+func ValidSoulcoreStatus(status db.SoulcoreStatus) bool {
+	_, ok := soulcoreTransitions[status]
+	return ok
+}
+
+// ValidSoulcoreTransition reports whether the lifecycle lets a soulcore move from one
+// status to another. Keeping the status is always allowed, moving a reservation to
+// another member counts as going from reserved to reserved.
+func ValidSoulcoreTransition(from, to db.SoulcoreStatus) bool {
+	if !ValidSoulcoreStatus(from) || !ValidSoulcoreStatus(to) {
+		return false
+	}
+	if from == to {
+		return true
+	}
+	for _, next := range soulcoreTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// SoulcoreStatusNeedsReservation reports whether a soulcore in status has to be earmarked for a member
+func SoulcoreStatusNeedsReservation(status db.SoulcoreStatus) bool {
+	return status == db.SoulcoreStatusReserved
+}
+
+// soulcoreProgress orders the statuses by how far along the lifecycle a core is
+var soulcoreProgress = map[db.SoulcoreStatus]int{
+	db.SoulcoreStatusWanted:   0,
+	db.SoulcoreStatusObtained: 1,
+	db.SoulcoreStatusReserved: 2,
+	db.SoulcoreStatusTraded:   3,
+	db.SoulcoreStatusUnlocked: 4,
+}
+
+// MergeSoulcoreStatus picks the status a core keeps when two lists that both track it are
+// merged. The one further along the lifecycle wins, ties keep the target's status.
+func MergeSoulcoreStatus(target, source db.SoulcoreStatus) db.SoulcoreStatus {
+	if soulcoreProgress[source] > soulcoreProgress[target] {
+		return source
+	}
+	return target
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	db "github.com/sergot/tibiacores/backend/db/sqlc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidSoulcoreTransition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     db.SoulcoreStatus
+		to       db.SoulcoreStatus
+		expected bool
+	}{
+		{"wanted core is obtained", db.SoulcoreStatusWanted, db.SoulcoreStatusObtained, true},
+		{"wanted core cannot be unlocked", db.SoulcoreStatusWanted, db.SoulcoreStatusUnlocked, false},
+		{"wanted core cannot be reserved", db.SoulcoreStatusWanted, db.SoulcoreStatusReserved, false},
+		{"obtained core is reserved", db.SoulcoreStatusObtained, db.SoulcoreStatusReserved, true},
+		{"obtained core is lost again", db.SoulcoreStatusObtained, db.SoulcoreStatusWanted, true},
+		{"reservation moves to another member", db.SoulcoreStatusReserved, db.SoulcoreStatusReserved, true},
+		{"reservation is released", db.SoulcoreStatusReserved, db.SoulcoreStatusObtained, true},
+		{"reserved core is traded", db.SoulcoreStatusReserved, db.SoulcoreStatusTraded, true},
+		{"traded core is unlocked", db.SoulcoreStatusTraded, db.SoulcoreStatusUnlocked, true},
+		{"traded core cannot be taken back", db.SoulcoreStatusTraded, db.SoulcoreStatusObtained, false},
+		{"unlock is final", db.SoulcoreStatusUnlocked, db.SoulcoreStatusObtained, false},
+		{"unlocked core stays unlocked", db.SoulcoreStatusUnlocked, db.SoulcoreStatusUnlocked, true},
+		{"unknown status", db.SoulcoreStatusObtained, db.SoulcoreStatus("lost"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ValidSoulcoreTransition(tc.from, tc.to))
+		})
+	}
+}
+
+func TestMergeSoulcoreStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		target   db.SoulcoreStatus
+		source   db.SoulcoreStatus
+		expected db.SoulcoreStatus
+	}{
+		{"unlocked beats obtained", db.SoulcoreStatusObtained, db.SoulcoreStatusUnlocked, db.SoulcoreStatusUnlocked},
+		{"target keeps unlocked", db.SoulcoreStatusUnlocked, db.SoulcoreStatusTraded, db.SoulcoreStatusUnlocked},
+		{"obtained beats wanted", db.SoulcoreStatusWanted, db.SoulcoreStatusObtained, db.SoulcoreStatusObtained},
+		{"reservation beats obtained", db.SoulcoreStatusObtained, db.SoulcoreStatusReserved, db.SoulcoreStatusReserved},
+		{"traded beats reserved", db.SoulcoreStatusReserved, db.SoulcoreStatusTraded, db.SoulcoreStatusTraded},
+		{"tie keeps target", db.SoulcoreStatusReserved, db.SoulcoreStatusReserved, db.SoulcoreStatusReserved},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MergeSoulcoreStatus(tc.target, tc.source))
+		})
+	}
+}